@@ -11,11 +11,16 @@ import (
 	"time"
 
 	"kubesphere.io/alert/pkg/config"
+	aldb "kubesphere.io/alert/pkg/db"
+	"kubesphere.io/alert/pkg/db/migrations"
 	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/monitoring"
 	"kubesphere.io/alert/pkg/services/client"
+	"kubesphere.io/alert/pkg/services/controller"
 	"kubesphere.io/alert/pkg/services/executor"
 	"kubesphere.io/alert/pkg/services/manager"
 	"kubesphere.io/alert/pkg/services/watcher"
+	"kubesphere.io/alert/pkg/tracing"
 )
 
 func exitHandler() {
@@ -28,13 +33,39 @@ func exitHandler() {
 			case syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT:
 				ExitFunc()
 			case syscall.SIGUSR1:
+				toggleDebugLevel()
 			case syscall.SIGUSR2:
+				dumpState()
 			default:
 			}
 		}
 	}()
 }
 
+// toggleDebugLevel switches the log level between debug and the configured level.
+func toggleDebugLevel() {
+	level := "debug"
+	if logger.GetLevel() == logger.DebugLevel {
+		level = config.GetInstance().Log.Level
+		if level == "debug" {
+			level = "info"
+		}
+	}
+
+	logger.SetLevelByString(level)
+	logger.Info(nil, "Log level changed to %s by signal", level)
+}
+
+// dumpState writes the runner states of an executor to the log.
+func dumpState() {
+	if e == nil {
+		logger.Info(nil, "No runner state to dump in run mode %s", config.GetInstance().App.RunMode)
+		return
+	}
+
+	e.DumpRunners()
+}
+
 func ExitFunc() {
 	cfg := config.GetInstance()
 	switch cfg.App.RunMode {
@@ -81,10 +112,50 @@ func mainFuncClient() {
 	client.Run()
 }
 
+func mainFuncController() {
+	controller.Run()
+}
+
+func mainFuncMigrate() {
+	cfg := config.GetInstance()
+
+	if !aldb.GetInstance().InitDataPool() {
+		logger.Critical(nil, "%+s", "Init database pool failure...")
+		os.Exit(1)
+	}
+
+	migrator := migrations.NewMigrator(aldb.GetInstance().GetDB(), aldb.GetInstance().Dialect().Name())
+	err := migrator.Run(cfg.Migrate.Command)
+	if err != nil {
+		logger.Critical(nil, "Migrate %s error: %+v", cfg.Migrate.Command, err)
+		os.Exit(1)
+	}
+}
+
+func setupLogger(cfg *config.Config) {
+	logger.SetFormat(cfg.Log.Format)
+	logger.SetGlobalField(logger.RunModeField, cfg.App.RunMode)
+
+	err := logger.SetPackageLevels(cfg.Log.PackageLevels)
+	if err != nil {
+		logger.Error(nil, "Set package log levels error: %+v", err)
+	}
+}
+
 func main() {
 	exitHandler()
 
 	cfg := config.GetInstance().LoadConf()
+
+	setupLogger(cfg)
+
+	err := tracing.Init("alert-" + cfg.App.RunMode)
+	if err != nil {
+		logger.Error(nil, "Init tracing error: %+v", err)
+	}
+
+	go monitoring.Serve()
+
 	switch cfg.App.RunMode {
 	case "executor":
 		mainFuncExecutor()
@@ -94,6 +165,10 @@ func main() {
 		mainFuncManager()
 	case "client":
 		mainFuncClient()
+	case "controller":
+		mainFuncController()
+	case "migrate":
+		mainFuncMigrate()
 	default:
 		logger.Error(nil, "Run mode error, exiting...")
 	}
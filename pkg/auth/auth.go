@@ -0,0 +1,54 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+const AuthorizationKey = "authorization"
+
+var ErrNoCredentials = errors.New("no credentials")
+
+// Authenticator identifies the caller of a request.
+type Authenticator interface {
+	// Authenticate returns the name of the caller, or ErrNoCredentials if the request
+	// carries no credentials the authenticator understands.
+	Authenticate(ctx context.Context) (string, error)
+}
+
+// Authenticators tries each authenticator in turn until one finds credentials in the request.
+type Authenticators []Authenticator
+
+func (as Authenticators) Authenticate(ctx context.Context) (string, error) {
+	for _, a := range as {
+		name, err := a.Authenticate(ctx)
+		if err == ErrNoCredentials {
+			continue
+		}
+		return name, err
+	}
+	return "", ErrNoCredentials
+}
+
+type identityKey struct{}
+
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok
+}
+
+// NewOutgoingContext passes the authorization header of a request on to the grpc calls
+// made with the returned context.
+func NewOutgoingContext(ctx context.Context, authorization string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, AuthorizationKey, authorization)
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// CertAuthenticator identifies the caller of a request by the common name of the verified
+// client certificate it presented over mutual TLS. Proxies, such as the api gateway and the
+// rest client, connect on behalf of their callers, so their certificates identify no one and
+// their requests have to carry the bearer token of the caller.
+type CertAuthenticator struct {
+	Proxies []string
+}
+
+func (a CertAuthenticator) Authenticate(ctx context.Context) (string, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", ErrNoCredentials
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", ErrNoCredentials
+	}
+
+	name := info.State.VerifiedChains[0][0].Subject.CommonName
+	if name == "" {
+		return "", errors.New("client certificate has no common name")
+	}
+	if contains(a.Proxies, name) {
+		return "", ErrNoCredentials
+	}
+	return name, nil
+}
+
+// CertCommonName returns the common name of the certificate in certFile, along with its key
+// in keyFile.
+func CertCommonName(certFile string, keyFile string) (string, error) {
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return "", errors.Wrap(err, "load certificate")
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return "", errors.Wrap(err, "parse certificate")
+	}
+	return cert.Subject.CommonName, nil
+}
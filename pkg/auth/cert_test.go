@@ -0,0 +1,96 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func newPeerContext(ctx context.Context, commonName string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+	state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestCertAuthenticate(t *testing.T) {
+	a := CertAuthenticator{Proxies: []string{"alert-manager"}}
+
+	_, err := a.Authenticate(context.Background())
+	require.Equal(t, ErrNoCredentials, err)
+
+	name, err := a.Authenticate(newPeerContext(context.Background(), "alert-executor"))
+	require.NoError(t, err)
+	require.Equal(t, "alert-executor", name)
+
+	_, err = a.Authenticate(newPeerContext(context.Background(), ""))
+	require.Error(t, err)
+	require.NotEqual(t, ErrNoCredentials, err)
+}
+
+func TestCertAuthenticateProxy(t *testing.T) {
+	secret := []byte("secret")
+	tokenAuthenticator, err := NewTokenAuthenticatorWithKey(secret)
+	require.NoError(t, err)
+	authenticators := Authenticators{tokenAuthenticator, CertAuthenticator{Proxies: []string{"alert-manager"}}}
+
+	// the certificate of a proxy does not stand for its callers
+	ctx := newPeerContext(context.Background(), "alert-manager")
+	_, err = authenticators.Authenticate(ctx)
+	require.Equal(t, ErrNoCredentials, err)
+
+	token := signHS256(t, secret, map[string]interface{}{"sub": "alice"})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(AuthorizationKey, "Bearer "+token))
+	name, err := authenticators.Authenticate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "alice", name)
+}
+
+func TestCertCommonName(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "alert-manager"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "cert")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+
+	name, err := CertCommonName(certFile, keyFile)
+	require.NoError(t, err)
+	require.Equal(t, "alert-manager", name)
+
+	_, err = CertCommonName(filepath.Join(dir, "missing.crt"), keyFile)
+	require.Error(t, err)
+}
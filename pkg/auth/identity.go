@@ -0,0 +1,106 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"encoding/json"
+	"io/ioutil"
+)
+
+// Identity is an authenticated caller with the workspaces and namespaces it may access,
+// admins access every resource.
+type Identity struct {
+	Name       string
+	Admin      bool
+	Workspaces []string
+	Namespaces []string
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether the identity may access the resources of type rsTypeName selected
+// by resource, a resource search or the param of a resource filter keyed by ws_name, ns_name,
+// node_id and pod_name. Cluster and node resources are only accessible to admins, and a
+// resource missing its workspace or namespace selects those of every tenant.
+func (i *Identity) Allows(rsTypeName string, resource map[string]string) bool {
+	if i.Admin {
+		return true
+	}
+
+	switch rsTypeName {
+	case "workspace":
+		wsName := resource["ws_name"]
+		return wsName != "" && contains(i.Workspaces, wsName)
+	case "namespace", "workload", "pod", "container", "external":
+		nsName := resource["ns_name"]
+		return nsName != "" && contains(i.Namespaces, nsName)
+	}
+
+	return false
+}
+
+// AllowsJson is Allows with resource given as a JSON object, malformed resources are denied.
+func (i *Identity) AllowsJson(rsTypeName string, resource string) bool {
+	if i.Admin {
+		return true
+	}
+
+	resourceMap := map[string]string{}
+	if err := json.Unmarshal([]byte(resource), &resourceMap); err != nil {
+		return false
+	}
+	if rsTypeName == "" {
+		rsTypeName = resourceMap["rs_type_name"]
+	}
+
+	return i.Allows(rsTypeName, resourceMap)
+}
+
+type Binding struct {
+	Workspaces []string `json:"workspaces"`
+	Namespaces []string `json:"namespaces"`
+}
+
+// Policy maps the callers to the workspaces and namespaces they may access, eg.
+//
+//	{"admins": ["ks-apiserver"], "users": {"alice": {"workspaces": ["ws1"], "namespaces": ["ns1", "ns2"]}}}
+type Policy struct {
+	Admins []string           `json:"admins"`
+	Users  map[string]Binding `json:"users"`
+}
+
+func LoadPolicy(file string) (*Policy, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &Policy{}
+	if err := json.Unmarshal(content, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Identity returns the identity of the caller named name, callers missing in the policy
+// access no resource.
+func (p *Policy) Identity(name string) *Identity {
+	identity := &Identity{
+		Name:  name,
+		Admin: contains(p.Admins, name),
+	}
+	if binding, ok := p.Users[name]; ok {
+		identity.Workspaces = binding.Workspaces
+		identity.Namespaces = binding.Namespaces
+	}
+	return identity
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowsJson(t *testing.T) {
+	alice := &Identity{Name: "alice", Workspaces: []string{"ws1"}, Namespaces: []string{"ns1"}}
+
+	allowed := []string{
+		`{"rs_type_name": "workspace", "ws_name": "ws1"}`,
+		`{"rs_type_name": "namespace", "ns_name": "ns1"}`,
+		`{"rs_type_name": "container", "ns_name": "ns1", "node_id": "node1", "pod_name": "pod1"}`,
+	}
+	for _, resource := range allowed {
+		require.True(t, alice.AllowsJson("", resource), resource)
+	}
+
+	denied := []string{
+		``,
+		`not json`,
+		`{"rs_type_name": ["namespace"], "ns_name": "ns1"}`,
+		`{"rs_type_name": "cluster"}`,
+		`{"rs_type_name": "node", "node_id": "node1"}`,
+		`{"rs_type_name": "workspace", "ws_name": "ws2"}`,
+		`{"rs_type_name": "namespace"}`,
+		`{"rs_type_name": "namespace", "ns_name": ""}`,
+		`{"rs_type_name": "namespace", "ns_name": "ns2"}`,
+		`{"rs_type_name": "pod", "ws_name": "ws1"}`,
+		`{"rs_type_name": "unknown", "ns_name": "ns1"}`,
+	}
+	for _, resource := range denied {
+		require.False(t, alice.AllowsJson("", resource), resource)
+	}
+
+	require.True(t, alice.AllowsJson("namespace", `{"ns_name": "ns1"}`))
+	require.False(t, alice.AllowsJson("cluster", `{"rs_type_name": "namespace", "ns_name": "ns1"}`))
+
+	admin := &Identity{Name: "admin", Admin: true}
+	require.True(t, admin.AllowsJson("", `{"rs_type_name": "cluster"}`))
+}
+
+func TestLoadPolicy(t *testing.T) {
+	f, err := ioutil.TempFile("", "policy")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(`{"admins": ["ks-apiserver"], "users": {"alice": {"workspaces": ["ws1"], "namespaces": ["ns1", "ns2"]}}}`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	policy, err := LoadPolicy(f.Name())
+	require.NoError(t, err)
+
+	require.Equal(t, &Identity{Name: "ks-apiserver", Admin: true}, policy.Identity("ks-apiserver"))
+	require.Equal(t, &Identity{Name: "alice", Workspaces: []string{"ws1"}, Namespaces: []string{"ns1", "ns2"}}, policy.Identity("alice"))
+	require.Equal(t, &Identity{Name: "mallory"}, policy.Identity("mallory"))
+}
@@ -0,0 +1,156 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+const bearerPrefix = "bearer "
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// TokenAuthenticator verifies the JWT bearer token in the authorization metadata of a
+// request and identifies the caller by its sub claim. Tokens are signed with HS256 by a
+// shared secret, or with RS256 when the key is a PEM encoded RSA public key.
+type TokenAuthenticator struct {
+	secret    []byte
+	publicKey *rsa.PublicKey
+	now       func() time.Time
+}
+
+func NewTokenAuthenticator(keyFile string) (*TokenAuthenticator, error) {
+	key, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenAuthenticatorWithKey(key)
+}
+
+func NewTokenAuthenticatorWithKey(key []byte) (*TokenAuthenticator, error) {
+	a := &TokenAuthenticator{now: time.Now}
+
+	block, _ := pem.Decode(key)
+	if block == nil {
+		if len(key) == 0 {
+			return nil, errors.New("empty token key")
+		}
+		a.secret = key
+		return a, nil
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse token public key")
+	}
+	rsaKey, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("unsupported token public key [%T]", publicKey)
+	}
+	a.publicKey = rsaKey
+	return a, nil
+}
+
+func (a *TokenAuthenticator) Authenticate(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md[AuthorizationKey]) == 0 {
+		return "", ErrNoCredentials
+	}
+
+	authorization := md[AuthorizationKey][0]
+	if !strings.HasPrefix(strings.ToLower(authorization), bearerPrefix) {
+		return "", ErrNoCredentials
+	}
+
+	return a.Verify(strings.TrimSpace(authorization[len(bearerPrefix):]))
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+}
+
+type tokenClaims struct {
+	Sub string   `json:"sub"`
+	Exp *float64 `json:"exp"`
+	Nbf *float64 `json:"nbf"`
+}
+
+// Verify checks the signature and the validity period of a token and returns its subject.
+func (a *TokenAuthenticator) Verify(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.Wrap(ErrInvalidToken, "malformed token")
+	}
+
+	header := tokenHeader{}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return "", errors.Wrap(ErrInvalidToken, "malformed header")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidToken, "malformed signature")
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	switch {
+	case header.Alg == "HS256" && a.secret != nil:
+		mac := hmac.New(sha256.New, a.secret)
+		mac.Write(signed)
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return "", errors.Wrap(ErrInvalidToken, "signature mismatch")
+		}
+	case header.Alg == "RS256" && a.publicKey != nil:
+		hash := sha256.Sum256(signed)
+		if err := rsa.VerifyPKCS1v15(a.publicKey, crypto.SHA256, hash[:], signature); err != nil {
+			return "", errors.Wrap(ErrInvalidToken, "signature mismatch")
+		}
+	default:
+		return "", errors.Wrapf(ErrInvalidToken, "unexpected signing algorithm [%s]", header.Alg)
+	}
+
+	claims := tokenClaims{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return "", errors.Wrap(ErrInvalidToken, "malformed claims")
+	}
+
+	now := float64(a.now().Unix())
+	if claims.Exp != nil && now >= *claims.Exp {
+		return "", errors.Wrap(ErrInvalidToken, "token expired")
+	}
+	if claims.Nbf != nil && now < *claims.Nbf {
+		return "", errors.Wrap(ErrInvalidToken, "token not valid yet")
+	}
+	if claims.Sub == "" {
+		return "", errors.Wrap(ErrInvalidToken, "missing subject")
+	}
+
+	return claims.Sub, nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	content, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		return errors.Wrap(err, "decode token segment")
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func encodeSegment(t *testing.T, v interface{}) string {
+	content, err := json.Marshal(v)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(content)
+}
+
+func signHS256(t *testing.T, secret []byte, claims map[string]interface{}) string {
+	signed := encodeSegment(t, map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, claims map[string]interface{}) string {
+	signed := encodeSegment(t, map[string]string{"alg": "RS256", "typ": "JWT"}) + "." + encodeSegment(t, claims)
+	hash := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	require.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerifyHS256(t *testing.T) {
+	secret := []byte("secret")
+	a, err := NewTokenAuthenticatorWithKey(secret)
+	require.NoError(t, err)
+
+	now := time.Unix(1560000000, 0)
+	a.now = func() time.Time { return now }
+
+	name, err := a.Verify(signHS256(t, secret, map[string]interface{}{"sub": "alice", "exp": now.Unix() + 60}))
+	require.NoError(t, err)
+	require.Equal(t, "alice", name)
+
+	invalid := []string{
+		"",
+		"a.b",
+		signHS256(t, []byte("other"), map[string]interface{}{"sub": "alice"}),
+		signHS256(t, secret, map[string]interface{}{"sub": "alice", "exp": now.Unix()}),
+		signHS256(t, secret, map[string]interface{}{"sub": "alice", "nbf": now.Unix() + 60}),
+		signHS256(t, secret, map[string]interface{}{"exp": now.Unix() + 60}),
+		encodeSegment(t, map[string]string{"alg": "none"}) + "." + encodeSegment(t, map[string]string{"sub": "admin"}) + ".",
+	}
+	for _, token := range invalid {
+		_, err := a.Verify(token)
+		require.Equal(t, ErrInvalidToken, errors.Cause(err), token)
+	}
+}
+
+func TestVerifyRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	a, err := NewTokenAuthenticatorWithKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	require.NoError(t, err)
+
+	name, err := a.Verify(signRS256(t, key, map[string]interface{}{"sub": "bob"}))
+	require.NoError(t, err)
+	require.Equal(t, "bob", name)
+
+	// an HS256 token signed with the public key must not pass for an RS256 one
+	_, err = a.Verify(signHS256(t, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), map[string]interface{}{"sub": "bob"}))
+	require.Equal(t, ErrInvalidToken, errors.Cause(err))
+}
+
+func TestAuthenticate(t *testing.T) {
+	secret := []byte("secret")
+	a, err := NewTokenAuthenticatorWithKey(secret)
+	require.NoError(t, err)
+
+	_, err = a.Authenticate(context.Background())
+	require.Equal(t, ErrNoCredentials, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthorizationKey, "Basic YWxpY2U6"))
+	_, err = Authenticators{a}.Authenticate(ctx)
+	require.Equal(t, ErrNoCredentials, err)
+
+	token := signHS256(t, secret, map[string]interface{}{"sub": "alice"})
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthorizationKey, "Bearer "+token))
+	name, err := Authenticators{CertAuthenticator{}, a}.Authenticate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "alice", name)
+}
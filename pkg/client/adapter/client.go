@@ -1,6 +1,7 @@
 package adapter
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -9,6 +10,8 @@ import (
 
 	"kubesphere.io/alert/pkg/config"
 	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/monitoring"
+	"kubesphere.io/alert/pkg/tracing"
 )
 
 var client = &http.Client{
@@ -30,63 +33,92 @@ const (
 	DefaultScheme = "http"
 )
 
-func SendMetricRequest(metricParam string) string {
+// SendMetricRequest queries the adapter for metrics, the request is aborted
+// when ctx is done.
+func SendMetricRequest(ctx context.Context, metricParam string) (string, error) {
+	ctx, span := tracing.StartSpanWithKind(ctx, "adapter.SendMetricRequest", tracing.SpanKindClient)
+	defer span.End()
+
 	cfg := config.GetInstance()
 	url := fmt.Sprintf("http://127.0.0.1:%s/api/v1/metric", cfg.App.AdapterPort)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		logger.Error(nil, "SendMetricRequest NewRequest error:", err)
-		return ""
+		logger.Error(ctx, "SendMetricRequest NewRequest error: %v", err)
+		return "", err
 	}
+	request = request.WithContext(ctx)
+	tracing.InjectHTTP(ctx, request.Header)
 
 	params := request.URL.Query()
 	params.Add("metric_param", metricParam)
 	request.URL.RawQuery = params.Encode()
 
-	logger.Debug(nil, "SendMetricRequest %s", request.URL.RawQuery)
+	logger.Debug(ctx, "SendMetricRequest %s", request.URL.RawQuery)
+
+	start := time.Now()
+	defer func() {
+		monitoring.AdapterRequestDuration.WithLabelValues("metric").Observe(time.Since(start).Seconds())
+	}()
 
 	response, err := client.Do(request)
 	if err != nil {
-		logger.Error(nil, "SendMetricRequest get error:", err)
-	} else {
-		defer response.Body.Close()
-
-		contents, err := ioutil.ReadAll(response.Body)
-
-		if err != nil {
-			logger.Error(nil, "SendMetricRequest read error:", err.Error())
-		}
+		logger.Error(ctx, "SendMetricRequest get error: %v", err)
+		monitoring.AdapterErrorsTotal.WithLabelValues("metric").Inc()
+		span.SetError(err)
+		return "", err
+	}
+	defer response.Body.Close()
 
-		return string(contents)
+	contents, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		logger.Error(ctx, "SendMetricRequest read error: %v", err)
+		monitoring.AdapterErrorsTotal.WithLabelValues("metric").Inc()
+		span.SetError(err)
+		return "", err
 	}
-	return ""
+
+	return string(contents), nil
 }
 
-func SendEmailRequest(notificationParam string) string {
+func SendEmailRequest(ctx context.Context, notificationParam string) string {
+	ctx, span := tracing.StartSpanWithKind(ctx, "adapter.SendEmailRequest", tracing.SpanKindClient)
+	defer span.End()
+
 	cfg := config.GetInstance()
 	url := fmt.Sprintf("http://127.0.0.1:%s/api/v1/email", cfg.App.AdapterPort)
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		logger.Error(nil, "SendEmailRequest NewRequest error:", err)
+		logger.Error(ctx, "SendEmailRequest NewRequest error: %v", err)
 		return ""
 	}
+	request = request.WithContext(ctx)
+	tracing.InjectHTTP(ctx, request.Header)
 
 	params := request.URL.Query()
 	params.Add("notification_param", notificationParam)
 	request.URL.RawQuery = params.Encode()
 
-	logger.Debug(nil, "SendEmailRequest %s", request.URL.RawQuery)
+	logger.Debug(ctx, "SendEmailRequest %s", request.URL.RawQuery)
+
+	start := time.Now()
+	defer func() {
+		monitoring.AdapterRequestDuration.WithLabelValues("email").Observe(time.Since(start).Seconds())
+	}()
 
 	response, err := client.Do(request)
 	if err != nil {
-		logger.Error(nil, "SendEmailRequest get error:", err)
+		logger.Error(ctx, "SendEmailRequest get error: %v", err)
+		monitoring.AdapterErrorsTotal.WithLabelValues("email").Inc()
+		span.SetError(err)
 	} else {
 		defer response.Body.Close()
 
 		contents, err := ioutil.ReadAll(response.Body)
 
 		if err != nil {
-			logger.Error(nil, "SendEmailRequest read error:", err.Error())
+			logger.Error(ctx, "SendEmailRequest read error: %v", err)
+			monitoring.AdapterErrorsTotal.WithLabelValues("email").Inc()
+			span.SetError(err)
 		}
 
 		return string(contents)
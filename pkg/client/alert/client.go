@@ -6,22 +6,50 @@ package alert
 
 import (
 	"strconv"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"kubesphere.io/alert/pkg/config"
 	"kubesphere.io/alert/pkg/manager"
 	"kubesphere.io/alert/pkg/pb"
+	"kubesphere.io/alert/pkg/util/tlsutil"
 )
 
-type Client struct {
-	pb.AlertManagerClient
-}
+var (
+	credsMutex sync.Mutex
+	creds      credentials.TransportCredentials
+)
 
-func NewClient() (*Client, error) {
+// NewConn returns the connection to the manager, over TLS if it is enabled.
+func NewConn() (*grpc.ClientConn, error) {
 	cfg := config.GetInstance()
 	managerHost := cfg.App.Host
 	managerPort, _ := strconv.Atoi(cfg.App.Port)
 
-	conn, err := manager.NewClient(managerHost, managerPort)
+	if !cfg.Tls.Enable {
+		return manager.NewClient(managerHost, managerPort)
+	}
+
+	credsMutex.Lock()
+	defer credsMutex.Unlock()
+	if creds == nil {
+		var err error
+		creds, err = tlsutil.NewClientCredentials(cfg.Tls.CertFile, cfg.Tls.KeyFile, cfg.Tls.CaFile, cfg.Tls.ServerName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return manager.NewTLSClient(managerHost, managerPort, creds)
+}
+
+type Client struct {
+	pb.AlertManagerClient
+}
+
+func NewClient() (*Client, error) {
+	conn, err := NewConn()
 	if err != nil {
 		return nil, err
 	}
@@ -35,11 +63,7 @@ type CustomClient struct {
 }
 
 func NewCustomClient() (*CustomClient, error) {
-	cfg := config.GetInstance()
-	managerHost := cfg.App.Host
-	managerPort, _ := strconv.Atoi(cfg.App.Port)
-
-	conn, err := manager.NewClient(managerHost, managerPort)
+	conn, err := NewConn()
 	if err != nil {
 		return nil, err
 	}
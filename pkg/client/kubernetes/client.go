@@ -14,6 +14,7 @@ limitations under the License.
 package client
 
 import (
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -41,3 +42,26 @@ func NewK8sClient() *kubernetes.Clientset {
 	}
 	return k8sClient
 }
+
+var dynamicClient dynamic.Interface
+
+// NewDynamicClient returns the client of custom resources, such as AlertRule.
+func NewDynamicClient() dynamic.Interface {
+	if dynamicClient != nil {
+		return dynamicClient
+	}
+
+	// creates the in-cluster config
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		logger.Error(nil, "DynamicClient create InClusterConfig error: %v", err)
+		return dynamicClient
+	}
+
+	dynamicClient, err = dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		logger.Error(nil, "DynamicClient create client error: %v", err)
+		return dynamicClient
+	}
+	return dynamicClient
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
+	"sync"
 	"time"
 
 	wrappers "github.com/golang/protobuf/ptypes/wrappers"
@@ -12,11 +13,22 @@ import (
 	"kubesphere.io/alert/pkg/client/notification/pb"
 	"kubesphere.io/alert/pkg/config"
 	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/monitoring"
+	"kubesphere.io/alert/pkg/tracing"
+	"kubesphere.io/alert/pkg/util/tlsutil"
 )
 
-var nfClient *grpc.ClientConn
+// nfClient is the connection shared by every caller, nfClientMutex guards it as the readiness
+// checks dial it alongside the notifications sent.
+var (
+	nfClient      *grpc.ClientConn
+	nfClientMutex sync.Mutex
+)
 
 func getNotificationConn(svcAddress string) (*grpc.ClientConn, error) {
+	nfClientMutex.Lock()
+	defer nfClientMutex.Unlock()
+
 	if nfClient != nil {
 		return nfClient, nil
 	}
@@ -31,15 +43,37 @@ func getNotificationConn(svcAddress string) (*grpc.ClientConn, error) {
 
 	var err error
 
-	nfClient, err = grpc.DialContext(ctx, svcAddress, grpc.WithInsecure(), grpc.WithKeepaliveParams(keepAlive))
+	transportOption := grpc.WithInsecure()
+	tlsCfg := config.GetInstance().NotificationTls
+	if tlsCfg.Enable {
+		creds, err := tlsutil.NewClientCredentials(tlsCfg.CertFile, tlsCfg.KeyFile, tlsCfg.CaFile, tlsCfg.ServerName)
+		if err != nil {
+			return nil, err
+		}
+		transportOption = grpc.WithTransportCredentials(creds)
+	}
+
+	conn, err := grpc.DialContext(ctx, svcAddress, transportOption, grpc.WithKeepaliveParams(keepAlive), grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor()))
 
 	if err != nil {
 		return nil, err
 	}
 
+	nfClient = conn
 	return nfClient, nil
 }
 
+// CheckConnection returns an error when the notification connection is not usable.
+func CheckConnection(ctx context.Context) error {
+	cfg := config.GetInstance()
+	conn, err := getNotificationConn(cfg.App.NotificationHost)
+	if err != nil {
+		return err
+	}
+
+	return monitoring.CheckGrpcConn(ctx, conn)
+}
+
 func CheckTimeAvailable(availableStartTimeStr string, availableEndTimeStr string) bool {
 	timeFmt := "15:04:05"
 	currentTime := time.Now().Format(timeFmt)
@@ -50,46 +84,52 @@ func CheckTimeAvailable(availableStartTimeStr string, availableEndTimeStr string
 	return availableStartTime.Before(currentTime1) && availableEndTime.After(currentTime1)
 }
 
-func SendNotification(method string, receiver string, title string, content string) (bool, string) {
+// SendNotification creates a notification, the call waits for the connection
+// to become ready and is bounded by the deadline of ctx.
+func SendNotification(ctx context.Context, method string, receiver string, title string, content string) (bool, string) {
+	ctx, span := tracing.StartSpan(ctx, "notification.SendNotification")
+	defer span.End()
+	span.SetAttribute("notification.method", method)
+
 	cfg := config.GetInstance()
 	conn, err := getNotificationConn(cfg.App.NotificationHost)
 	if err != nil {
-		logger.Error(nil, "SendNotification getNotificationConn failed %v", err)
+		logger.Error(ctx, "SendNotification getNotificationConn failed %v", err)
+		monitoring.NotificationsTotal.WithLabelValues(method, "failed").Inc()
+		span.SetError(err)
 		return false, ""
 	}
 
-	// sleep a few millsecond for grpc dial etcd
-	time.Sleep(time.Millisecond * 500)
 	clientX := pb.NewNotificationClient(conn)
 
-	ctx, _ := context.WithTimeout(context.Background(), 10*time.Second)
-
-	resp, err := clientX.CreateNotification(ctx, &pb.CreateNotificationRequest{ContentType: &wrappers.StringValue{Value: method}, Title: &wrappers.StringValue{Value: title}, Content: &wrappers.StringValue{Value: content}, ExpiredDays: &wrappers.UInt32Value{Value: 0}, Owner: &wrappers.StringValue{Value: "KubeSphere"}, AddressInfo: &wrappers.StringValue{Value: receiver}})
+	resp, err := clientX.CreateNotification(ctx, &pb.CreateNotificationRequest{ContentType: &wrappers.StringValue{Value: method}, Title: &wrappers.StringValue{Value: title}, Content: &wrappers.StringValue{Value: content}, ExpiredDays: &wrappers.UInt32Value{Value: 0}, Owner: &wrappers.StringValue{Value: "KubeSphere"}, AddressInfo: &wrappers.StringValue{Value: receiver}}, grpc.WaitForReady(true))
 	if err != nil {
-		logger.Error(nil, "SendNotification CreateNotification failed %v", err)
+		logger.Error(ctx, "SendNotification CreateNotification failed %v", err)
+		monitoring.NotificationsTotal.WithLabelValues(method, "failed").Inc()
+		span.SetError(err)
 		return false, ""
 	}
+	monitoring.NotificationsTotal.WithLabelValues(method, "success").Inc()
 
 	return true, resp.GetNotificationId().GetValue()
 }
 
-func GetNotificationStatus(notificationIds []string) map[string][]string {
+func GetNotificationStatus(ctx context.Context, notificationIds []string) map[string][]string {
 	cfg := config.GetInstance()
 	conn, err := getNotificationConn(cfg.App.NotificationHost)
 	if err != nil {
-		logger.Error(nil, "GetNotificationStatus getNotificationConn failed %v", err)
+		logger.Error(ctx, "GetNotificationStatus getNotificationConn failed %v", err)
 		return nil
 	}
 
-	// sleep a few millsecond for grpc dial etcd
-	time.Sleep(time.Millisecond * 500)
 	clientX := pb.NewNotificationClient(conn)
 
-	ctx, _ := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(cfg.App.NotificationTimeout)*time.Millisecond)
+	defer cancel()
 
-	resp, err := clientX.DescribeTasks(ctx, &pb.DescribeTasksRequest{NotificationId: notificationIds})
+	resp, err := clientX.DescribeTasks(ctx, &pb.DescribeTasksRequest{NotificationId: notificationIds}, grpc.WaitForReady(true))
 	if err != nil {
-		logger.Error(nil, "GetNotificationStatus DescribeTasks failed %v", err)
+		logger.Error(ctx, "GetNotificationStatus DescribeTasks failed %v", err)
 		return nil
 	}
 
@@ -106,3 +146,36 @@ func GetNotificationStatus(notificationIds []string) map[string][]string {
 
 	return notificationStatusMap
 }
+
+// DescribeAddressListNames returns the names of the address lists by id, of those with
+// addressListIds if any, else of those with addressListNames.
+func DescribeAddressListNames(ctx context.Context, addressListIds []string, addressListNames []string) (map[string]string, error) {
+	cfg := config.GetInstance()
+	conn, err := getNotificationConn(cfg.App.NotificationHost)
+	if err != nil {
+		logger.Error(ctx, "DescribeAddressListNames getNotificationConn failed %v", err)
+		return nil, err
+	}
+
+	clientX := pb.NewNotificationClient(conn)
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(cfg.App.NotificationTimeout)*time.Millisecond)
+	defer cancel()
+
+	req := &pb.DescribeAddressListRequest{AddressListId: addressListIds, Limit: uint32(len(addressListIds))}
+	if len(addressListIds) == 0 {
+		req = &pb.DescribeAddressListRequest{AddressListName: addressListNames, Limit: uint32(len(addressListNames))}
+	}
+	resp, err := clientX.DescribeAddressList(ctx, req, grpc.WaitForReady(true))
+	if err != nil {
+		logger.Error(ctx, "DescribeAddressListNames DescribeAddressList failed %v", err)
+		return nil, err
+	}
+
+	addressListNameMap := make(map[string]string)
+	for _, addressList := range resp.AddressListSet {
+		addressListNameMap[addressList.AddressListId.GetValue()] = addressList.AddressListName.GetValue()
+	}
+
+	return addressListNameMap, nil
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/notification"
+	"kubesphere.io/alert/pkg/tracing"
+)
+
+// client sends webhooks to the hosts models.CheckWebhookHost allows, the addresses names resolve
+// to are checked as they are dialed. Redirects are not followed, they could lead anywhere.
+var client = &http.Client{
+	Transport: &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   checkDialAddress,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+func checkDialAddress(network string, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	return models.CheckWebhookHost(host)
+}
+
+// SendAlertmanagerMessage posts message to the Alertmanager webhook receiver at url, the request
+// is aborted when ctx is done.
+func SendAlertmanagerMessage(ctx context.Context, url string, message *notification.AlertmanagerMessage) error {
+	ctx, span := tracing.StartSpanWithKind(ctx, "webhook.SendAlertmanagerMessage", tracing.SpanKindClient)
+	defer span.End()
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		logger.Error(ctx, "SendAlertmanagerMessage marshal error: %v", err)
+		return err
+	}
+
+	request, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		logger.Error(ctx, "SendAlertmanagerMessage NewRequest error: %v", err)
+		return err
+	}
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", "application/json")
+	tracing.InjectHTTP(ctx, request.Header)
+
+	response, err := client.Do(request)
+	if err != nil {
+		logger.Error(ctx, "SendAlertmanagerMessage post error: %v", err)
+		span.SetError(err)
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode/100 != 2 {
+		err = fmt.Errorf("webhook [%s] returned %s", url, response.Status)
+		logger.Error(ctx, "SendAlertmanagerMessage error: %v", err)
+		span.SetError(err)
+		return err
+	}
+
+	return nil
+}
@@ -17,18 +17,16 @@ import (
 )
 
 type Config struct {
-	Log  LogConfig
-	Grpc GrpcConfig
-
-	Mysql struct {
-		Host     string `default:"139.198.121.96"`
-		Port     string `default:"3306"`
-		User     string `default:"root"`
-		Password string `default:"password"`
-		Database string `default:"alert"`
-		Disable  bool   `default:"false"`
-		LogMode  bool   `default:"true"`
-	}
+	Log     LogConfig
+	Grpc    GrpcConfig
+	Tracing TracingConfig
+	Auth    AuthConfig
+
+	Tls             TlsConfig // manager server and the gateway and client connections to it
+	NotificationTls TlsConfig
+
+	Database DatabaseConfig
+	Migrate  MigrateConfig
 
 	Etcd struct {
 		Endpoints string `default:"139.198.121.96:2379"`
@@ -39,6 +37,10 @@ type Config struct {
 		Addr string `default:"redis://redis.kubesphere-system.svc:6379"`
 	}
 
+	Executor ExecutorConfig
+
+	Controller ControllerConfig
+
 	App struct {
 		Host string `default:"localhost"`
 		Port string `default:"9201"`
@@ -46,11 +48,16 @@ type Config struct {
 		ApiHost string `default:"localhost"`
 		ApiPort string `default:"9200"`
 
-		NotificationHost string `default:"notification.kubesphere-notification-system.svc:9201"`
+		NotificationHost    string `default:"notification.kubesphere-notification-system.svc:9201"`
+		NotificationTimeout int    `default:"10000"` // milliseconds
 
 		RunMode string `default:"none"`
 
-		AdapterPort string `default:"8080"`
+		AdapterPort    string `default:"8080"`
+		AdapterTimeout int    `default:"3000"` // milliseconds
+
+		MetricsPort string `default:"9202"`
+		AdminPort   string `default:"9203"` // admin endpoints, served on the loopback interface only
 	}
 }
 
@@ -69,8 +76,63 @@ type GrpcConfig struct {
 	ShowErrorCause bool `default:"false"` // show grpc error cause to frontend
 }
 
+type DatabaseConfig struct {
+	Type     string `default:"mysql"` // mysql, postgres, sqlite3 (needs a binary built with cgo)
+	Host     string `default:"139.198.121.96"`
+	Port     string `default:"3306"`
+	User     string `default:"root"`
+	Password string `default:"password"`
+	Database string `default:"alert"`
+	SslMode  string `default:"disable"`                 // sslmode of postgres connections
+	File     string `default:"/var/lib/alert/alert.db"` // database file of sqlite3
+	Disable  bool   `default:"false"`
+	LogMode  bool   `default:"true"`
+	Timeout  int    `default:"5000"` // milliseconds for executor DB transactions
+}
+
+type MigrateConfig struct {
+	Command          string `default:"up"`   // up, down, status of the migrate run mode
+	AllowNewerSchema bool   `default:"true"` // keep running on a schema a newer version migrated, as in rolling upgrades
+}
+
+type ExecutorConfig struct {
+	MissedTickPolicy  string `default:"skip"` // skip, delay
+	MetricBatchWindow int    `default:"1000"` // milliseconds to batch metric requests, 0 disables batching
+
+	EvaluationFailureThreshold int `default:"0"` // failed periods of a rule before a meta alert is fired, 0 disables meta alerts
+}
+
+type ControllerConfig struct {
+	Namespace    string `default:""`   // namespace of the AlertRule resources reconciled, empty for all of them
+	ResyncPeriod int    `default:"60"` // seconds between reconciling every AlertRule
+}
+
+type TracingConfig struct {
+	Enable     bool    `default:"false"`
+	Exporter   string  `default:"file"` // file
+	File       string  `default:"/tmp/alert-trace.json"`
+	SampleRate float64 `default:"1"` // ratio of new traces to record, from 0 to 1
+}
+
+type AuthConfig struct {
+	Enable     bool   `default:"false"`
+	TokenKey   string `default:""`                            // file of the HMAC secret or PEM encoded RSA public key verifying bearer tokens, empty disables tokens
+	PolicyFile string `default:"/etc/alert/auth/policy.json"` // workspaces and namespaces of every caller
+	Proxies    string `default:""`                            // comma-separated common names of client certificates proxying requests, besides the manager certificate the gateway and rest client use
+}
+
+type TlsConfig struct {
+	Enable     bool   `default:"false"`
+	CertFile   string `default:"/etc/alert/tls/tls.crt"`
+	KeyFile    string `default:"/etc/alert/tls/tls.key"`
+	CaFile     string `default:"/etc/alert/tls/ca.crt"` // verifies peers, servers require client certificates when set
+	ServerName string `default:""`                      // name in the server certificate, empty takes the dialed host
+}
+
 type LogConfig struct {
-	Level string `default:"debug"` // debug, info, warn, error, fatal
+	Level         string `default:"debug"` // debug, info, warn, error, fatal
+	Format        string `default:"text"`  // text, json
+	PackageLevels string `default:""`      // per package overrides, eg. services/executor=debug,client/adapter=warn
 }
 
 func (c *Config) PrintUsage() {
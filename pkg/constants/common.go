@@ -37,3 +37,8 @@ const (
 )
 
 const MIME_MERGEPATCH = "application/merge-patch+json"
+
+const MIME_YAML = "application/yaml"
+
+// ActorHeader is the http header and grpc metadata naming who issued a request.
+const ActorHeader = "x-actor"
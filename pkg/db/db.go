@@ -5,33 +5,32 @@
 package dbutil
 
 import (
-	"fmt"
 	"log"
 	"sync"
 	"time"
 
 	"github.com/jinzhu/gorm"
-	_ "github.com/jinzhu/gorm/dialects/mysql"
 
 	"kubesphere.io/alert/pkg/config"
 )
 
 /*
-* MysqlConnPool
+* ConnPool
 * use gorm
  */
-type MysqlConnPool struct {
+type ConnPool struct {
+	dialect Dialect
 }
 
-var instance *MysqlConnPool
+var instance *ConnPool
 var once sync.Once
 
 var db *gorm.DB
 var err error
 
-func GetInstance() *MysqlConnPool {
+func GetInstance() *ConnPool {
 	once.Do(func() {
-		instance = &MysqlConnPool{}
+		instance = &ConnPool{}
 	})
 	return instance
 }
@@ -39,22 +38,17 @@ func GetInstance() *MysqlConnPool {
 /*
 * @fuc init connection
  */
-func (m *MysqlConnPool) InitDataPool() (isSucc bool) {
+func (m *ConnPool) InitDataPool() (isSucc bool) {
 	cfg := config.GetInstance()
 
-	var (
-		dbCfg            = cfg.Mysql
-		connectionString = fmt.Sprintf(
-			"%v:%v@(%v:%v)/%v?charset=utf8&parseTime=True&loc=Local",
-			dbCfg.User,
-			dbCfg.Password,
-			dbCfg.Host,
-			dbCfg.Port,
-			dbCfg.Database,
-		)
-	)
-
-	db, err = gorm.Open("mysql", connectionString)
+	dialect, err := GetDialect(cfg.Database.Type)
+	if err != nil {
+		log.Print(err)
+		return false
+	}
+	m.dialect = dialect
+
+	db, err = gorm.Open(dialect.Name(), dialect.ConnectionString(cfg.Database))
 	if err != nil {
 		log.Print(err)
 		return false
@@ -69,7 +63,7 @@ func (m *MysqlConnPool) InitDataPool() (isSucc bool) {
 	db.DB().SetMaxIdleConns(100)
 	db.DB().SetMaxOpenConns(100)
 	db.DB().SetConnMaxLifetime(10 * time.Second)
-	db.LogMode(cfg.Mysql.LogMode)
+	db.LogMode(cfg.Database.LogMode)
 
 	// table name should be singular
 	db.SingularTable(true)
@@ -83,6 +77,19 @@ func (m *MysqlConnPool) InitDataPool() (isSucc bool) {
 	return true
 }
 
-func (m *MysqlConnPool) GetMysqlDB() *gorm.DB {
+func (m *ConnPool) GetDB() *gorm.DB {
 	return db
 }
+
+// Dialect is the dialect of the database, mysql until the pool is initialized.
+func (m *ConnPool) Dialect() Dialect {
+	if m.dialect == nil {
+		return mysqlDialect{}
+	}
+	return m.dialect
+}
+
+// JsonExtract is Dialect.JsonExtract of the database.
+func JsonExtract(column, key string) string {
+	return GetInstance().Dialect().JsonExtract(column, key)
+}
@@ -0,0 +1,120 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package dbutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	_ "github.com/jinzhu/gorm/dialects/mysql"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+
+	"kubesphere.io/alert/pkg/config"
+)
+
+const (
+	DialectMysql    = "mysql"
+	DialectPostgres = "postgres"
+	DialectSqlite   = "sqlite3"
+)
+
+// Dialect is the SQL a database speaks where gorm does not abstract it away.
+type Dialect interface {
+	// Name is the name gorm opens the database with.
+	Name() string
+	// ConnectionString is the data source name of the configured database.
+	ConnectionString(cfg config.DatabaseConfig) string
+	// JsonExtract is an expression of the value of key in the JSON object stored in column,
+	// comparable with strings.
+	JsonExtract(column, key string) string
+}
+
+var dialects = map[string]Dialect{
+	DialectMysql:    mysqlDialect{},
+	DialectPostgres: postgresDialect{},
+	DialectSqlite:   sqliteDialect{},
+}
+
+func GetDialect(name string) (Dialect, error) {
+	dialect, ok := dialects[name]
+	if !ok {
+		names := []string{}
+		for name := range dialects {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unsupported database type [%s], supported types are %v", name, names)
+	}
+	return dialect, nil
+}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string {
+	return DialectMysql
+}
+
+func (mysqlDialect) ConnectionString(cfg config.DatabaseConfig) string {
+	return fmt.Sprintf(
+		"%v:%v@(%v:%v)/%v?charset=utf8&parseTime=True&loc=Local",
+		cfg.User,
+		cfg.Password,
+		cfg.Host,
+		cfg.Port,
+		cfg.Database,
+	)
+}
+
+func (mysqlDialect) JsonExtract(column, key string) string {
+	return fmt.Sprintf(`JSON_EXTRACT(%s, '$.%s')`, column, key)
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string {
+	return DialectPostgres
+}
+
+// quotePostgresValue quotes a keyword value of a postgres connection string, which may
+// contain spaces and quotes.
+func quotePostgresValue(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `'`, `\'`, -1)
+	return "'" + value + "'"
+}
+
+func (postgresDialect) ConnectionString(cfg config.DatabaseConfig) string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		quotePostgresValue(cfg.Host),
+		quotePostgresValue(cfg.Port),
+		quotePostgresValue(cfg.User),
+		quotePostgresValue(cfg.Password),
+		quotePostgresValue(cfg.Database),
+		quotePostgresValue(cfg.SslMode),
+	)
+}
+
+func (postgresDialect) JsonExtract(column, key string) string {
+	return fmt.Sprintf(`(%s::jsonb ->> '%s')`, column, key)
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string {
+	return DialectSqlite
+}
+
+// ConnectionString of sqlite3 waits for the locks of other connections rather than failing
+// at once, and lets readers run along with the writer.
+func (sqliteDialect) ConnectionString(cfg config.DatabaseConfig) string {
+	return fmt.Sprintf("file:%s?_busy_timeout=%d&_journal_mode=WAL", cfg.File, cfg.Timeout)
+}
+
+func (sqliteDialect) JsonExtract(column, key string) string {
+	return fmt.Sprintf(`json_extract(%s, '$.%s')`, column, key)
+}
@@ -0,0 +1,65 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package dbutil
+
+import (
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/config"
+)
+
+func TestGetDialect(t *testing.T) {
+	for _, name := range []string{DialectMysql, DialectPostgres, DialectSqlite} {
+		dialect, err := GetDialect(name)
+		require.NoError(t, err)
+		require.Equal(t, name, dialect.Name())
+	}
+
+	_, err := GetDialect("oracle")
+	require.Error(t, err)
+}
+
+func TestConnectionString(t *testing.T) {
+	cfg := config.DatabaseConfig{
+		Host:     "db",
+		Port:     "5432",
+		User:     "alert",
+		Password: `p@ss 'word\`,
+		Database: "alert",
+		SslMode:  "require",
+		File:     "/tmp/alert.db",
+		Timeout:  5000,
+	}
+
+	require.Equal(t, "alert:p@ss 'word\\@(db:5432)/alert?charset=utf8&parseTime=True&loc=Local", mysqlDialect{}.ConnectionString(cfg))
+	require.Equal(t, `host='db' port='5432' user='alert' password='p@ss \'word\\' dbname='alert' sslmode='require'`, postgresDialect{}.ConnectionString(cfg))
+	require.Equal(t, "file:/tmp/alert.db?_busy_timeout=5000&_journal_mode=WAL", sqliteDialect{}.ConnectionString(cfg))
+}
+
+func TestJsonExtract(t *testing.T) {
+	require.Equal(t, `JSON_EXTRACT(t2.rs_filter_param, '$.ns_name')`, mysqlDialect{}.JsonExtract("t2.rs_filter_param", "ns_name"))
+	require.Equal(t, `(t2.rs_filter_param::jsonb ->> 'ns_name')`, postgresDialect{}.JsonExtract("t2.rs_filter_param", "ns_name"))
+
+	db, err := gorm.Open(DialectSqlite, ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.DB().SetMaxOpenConns(1)
+
+	require.NoError(t, db.Exec("CREATE TABLE resource_filter (rs_filter_id varchar(50), rs_filter_param text)").Error)
+	require.NoError(t, db.Exec("INSERT INTO resource_filter VALUES ('rf-1', ?), ('rf-2', ?), ('rf-3', ?)",
+		`{"ns_name":"ns1","pod_name":"pod1"}`,
+		`{"ns_name":"ns2"}`,
+		`{"ws_name":"ns1"}`).Error)
+
+	var ids []string
+	err = db.Table("resource_filter t2").
+		Where(sqliteDialect{}.JsonExtract("t2.rs_filter_param", "ns_name")+" in (?)", []string{"ns1", "ns3"}).
+		Pluck("rs_filter_id", &ids).Error
+	require.NoError(t, err)
+	require.Equal(t, []string{"rf-1"}, ids)
+}
@@ -0,0 +1,315 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+// Package migrations keeps the schema of the alert database in the binary. Every change
+// of the tables comes with a new migration appended to migrations, along with the models.
+// Migrations only add tables, columns and rows, so that the binaries of the previous version
+// keep working with the schema during rolling upgrades.
+package migrations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"kubesphere.io/alert/pkg/logger"
+)
+
+const TableSchemaMigration = "schema_migration"
+
+// Migration changes the schema from the previous version to Version, Up and Down return
+// the statements applying and reverting the change in a dialect.
+type Migration struct {
+	Version uint32
+	Name    string
+	Up      func(dialect string) []string
+	Down    func(dialect string) []string
+}
+
+var migrations = []Migration{
+	v1Init,
+	v2ExternalAlert,
+}
+
+// Latest is the schema version the binary works with.
+func Latest() uint32 {
+	return migrations[len(migrations)-1].Version
+}
+
+// columnTypes replaces the column types of statements with those of a dialect.
+func columnTypes(dialect string, statements []string) []string {
+	var replacer *strings.Replacer
+	switch dialect {
+	case "postgres":
+		replacer = strings.NewReplacer("{datetime}", "timestamp(3)", "{uint}", "integer")
+	case "sqlite3":
+		replacer = strings.NewReplacer("{datetime}", "datetime", "{uint}", "integer")
+	default:
+		replacer = strings.NewReplacer("{datetime}", "datetime(3)", "{uint}", "int unsigned")
+	}
+
+	replaced := []string{}
+	for _, statement := range statements {
+		replaced = append(replaced, replacer.Replace(statement))
+	}
+	return replaced
+}
+
+type record struct {
+	Version   uint32    `gorm:"column:version"`
+	Name      string    `gorm:"column:name"`
+	ApplyTime time.Time `gorm:"column:apply_time"`
+}
+
+func (record) TableName() string {
+	return TableSchemaMigration
+}
+
+type Status struct {
+	Version   uint32
+	Name      string
+	Applied   bool
+	ApplyTime time.Time
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	dialect    string
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB, dialect string) *Migrator {
+	return &Migrator{
+		db:         db,
+		dialect:    dialect,
+		migrations: migrations,
+	}
+}
+
+func (m *Migrator) createTable() error {
+	statement := columnTypes(m.dialect, []string{`CREATE TABLE IF NOT EXISTS schema_migration
+(
+	version integer NOT NULL,
+	name varchar(100) NOT NULL,
+	apply_time {datetime},
+	PRIMARY KEY (version)
+)`})[0]
+	return m.db.Exec(statement).Error
+}
+
+func (m *Migrator) records() (map[uint32]record, error) {
+	records := map[uint32]record{}
+	if !m.db.HasTable(TableSchemaMigration) {
+		return records, nil
+	}
+
+	var rs []record
+	err := m.db.Order("version").Find(&rs).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rs {
+		records[r.Version] = r
+	}
+	return records, nil
+}
+
+// Version is the version of the schema, 0 if no migration has been applied.
+func (m *Migrator) Version() (uint32, error) {
+	records, err := m.records()
+	if err != nil {
+		return 0, err
+	}
+
+	version := uint32(0)
+	for v := range records {
+		if v > version {
+			version = v
+		}
+	}
+	return version, nil
+}
+
+func (m *Migrator) Status() ([]Status, error) {
+	records, err := m.records()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []Status{}
+	for _, migration := range m.migrations {
+		r, applied := records[migration.Version]
+		statuses = append(statuses, Status{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Applied:   applied,
+			ApplyTime: r.ApplyTime,
+		})
+	}
+	return statuses, nil
+}
+
+// NewerSchemaError is the error of Check on a schema a newer binary migrated. The binary can
+// keep working with it, the newer migrations only add to the schema.
+type NewerSchemaError struct {
+	Version uint32
+	Latest  uint32
+}
+
+func (e *NewerSchemaError) Error() string {
+	return fmt.Sprintf("database schema version [%d] is newer than [%d] this binary works with", e.Version, e.Latest)
+}
+
+// Check fails unless the schema is at the version the binary works with, schemas newer than
+// that fail with a NewerSchemaError.
+func (m *Migrator) Check() error {
+	version, err := m.Version()
+	if err != nil {
+		return err
+	}
+
+	latest := m.migrations[len(m.migrations)-1].Version
+	if version < latest {
+		return fmt.Errorf("database schema version [%d] is older than [%d], run the migrate run mode to upgrade it", version, latest)
+	}
+	if version > latest {
+		return &NewerSchemaError{Version: version, Latest: latest}
+	}
+	return nil
+}
+
+// baseline records the first migration as applied to databases the flyway scripts built,
+// which have the tables of the first migration but no migration records.
+func (m *Migrator) baseline() error {
+	if m.db.HasTable(TableSchemaMigration) || !m.db.HasTable("alert") {
+		return nil
+	}
+	if !m.db.HasTable("audit") {
+		return fmt.Errorf("database schema is older than the flyway script V0_9, apply the flyway scripts first")
+	}
+
+	err := m.createTable()
+	if err != nil {
+		return err
+	}
+
+	first := m.migrations[0]
+	err = m.db.Create(&record{Version: first.Version, Name: first.Name, ApplyTime: time.Now()}).Error
+	if err != nil {
+		return err
+	}
+	logger.Info(nil, "Baseline database schema built by flyway at version [%d]", first.Version)
+	return nil
+}
+
+// Up applies the migrations not applied yet in order. Every migration runs in a transaction,
+// though the statements changing tables commit at once in mysql.
+func (m *Migrator) Up() error {
+	err := m.baseline()
+	if err != nil {
+		return err
+	}
+
+	err = m.createTable()
+	if err != nil {
+		return err
+	}
+
+	records, err := m.records()
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range m.migrations {
+		if _, ok := records[migration.Version]; ok {
+			continue
+		}
+
+		tx := m.db.Begin()
+		for _, statement := range migration.Up(m.dialect) {
+			err := tx.Exec(statement).Error
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("apply migration [%d] [%s] failed: %v", migration.Version, migration.Name, err)
+			}
+		}
+		err := tx.Create(&record{Version: migration.Version, Name: migration.Name, ApplyTime: time.Now()}).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		err = tx.Commit().Error
+		if err != nil {
+			return err
+		}
+		logger.Info(nil, "Applied migration [%d] [%s]", migration.Version, migration.Name)
+	}
+	return nil
+}
+
+// Down reverts the latest applied migration.
+func (m *Migrator) Down() error {
+	version, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		logger.Info(nil, "No migration to revert")
+		return nil
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version != version {
+			continue
+		}
+
+		tx := m.db.Begin()
+		for _, statement := range migration.Down(m.dialect) {
+			err := tx.Exec(statement).Error
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("revert migration [%d] [%s] failed: %v", migration.Version, migration.Name, err)
+			}
+		}
+		err := tx.Where("version = ?", migration.Version).Delete(record{}).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		err = tx.Commit().Error
+		if err != nil {
+			return err
+		}
+		logger.Info(nil, "Reverted migration [%d] [%s]", migration.Version, migration.Name)
+		return nil
+	}
+
+	return fmt.Errorf("database schema version [%d] is unknown to this binary", version)
+}
+
+// Run runs a migrate command, up, down or status.
+func (m *Migrator) Run(command string) error {
+	switch command {
+	case "up":
+		return m.Up()
+	case "down":
+		return m.Down()
+	case "status":
+		statuses, err := m.Status()
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			if status.Applied {
+				logger.Info(nil, "Migration [%d] [%s] applied at [%s]", status.Version, status.Name, status.ApplyTime.Format(time.RFC3339))
+			} else {
+				logger.Info(nil, "Migration [%d] [%s] pending", status.Version, status.Name)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown migrate command [%s], expected up, down or status", command)
+}
@@ -0,0 +1,118 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/internal/testutil"
+	"kubesphere.io/alert/pkg/models"
+)
+
+func TestColumnTypes(t *testing.T) {
+	for _, dialect := range []string{"mysql", "postgres", "sqlite3"} {
+		for _, migration := range migrations {
+			for _, statement := range append(migration.Up(dialect), migration.Down(dialect)...) {
+				require.False(t, strings.Contains(statement, "{datetime}") || strings.Contains(statement, "{uint}"), statement)
+			}
+		}
+	}
+}
+
+func TestUpDown(t *testing.T) {
+	db := testutil.OpenDB(t)
+	defer db.Close()
+
+	m := NewMigrator(db, "sqlite3")
+	require.Error(t, m.Check())
+
+	require.NoError(t, m.Run("up"))
+	require.NoError(t, m.Check())
+	version, err := m.Version()
+	require.NoError(t, err)
+	require.Equal(t, Latest(), version)
+
+	// the models match the tables
+	for _, model := range []interface{}{
+		&models.Action{}, &models.Alert{}, &models.Comment{}, &models.History{}, &models.Metric{},
+		&models.Policy{}, &models.ResourceFilter{}, &models.ResourceType{}, &models.Rule{}, &models.Audit{},
+	} {
+		scope := db.NewScope(model)
+		for _, field := range scope.Fields() {
+			require.True(t, scope.Dialect().HasColumn(scope.TableName(), field.DBName), scope.TableName()+"."+field.DBName)
+		}
+	}
+
+	var rsTypes []models.ResourceType
+	require.NoError(t, db.Find(&rsTypes).Error)
+	require.Equal(t, 9, len(rsTypes))
+	var metricCount int
+	require.NoError(t, db.Model(&models.Metric{}).Count(&metricCount).Error)
+	require.Equal(t, 62, metricCount)
+
+	// applied migrations are skipped
+	require.NoError(t, m.Run("up"))
+
+	statuses, err := m.Status()
+	require.NoError(t, err)
+	require.Equal(t, len(migrations), len(statuses))
+	require.True(t, statuses[0].Applied)
+	require.NoError(t, m.Run("status"))
+
+	for range migrations {
+		require.NoError(t, m.Run("down"))
+	}
+	require.Error(t, m.Check())
+	require.False(t, db.HasTable("alert"))
+	require.NoError(t, m.Run("down"))
+
+	require.Error(t, m.Run("sideways"))
+}
+
+func TestBaseline(t *testing.T) {
+	db := testutil.OpenDB(t)
+	defer db.Close()
+
+	// tables built by the flyway scripts before V0_9 can not be baselined
+	require.NoError(t, db.Exec("CREATE TABLE alert (alert_id varchar(50))").Error)
+	m := NewMigrator(db, "sqlite3")
+	require.Error(t, m.Up())
+
+	require.NoError(t, db.Exec("CREATE TABLE audit (audit_id varchar(50))").Error)
+	require.NoError(t, db.Exec("CREATE TABLE resource_type (rs_type_id varchar(50), rs_type_name varchar(50), rs_type_param varchar(255), create_time datetime, update_time datetime)").Error)
+	require.NoError(t, db.Exec("CREATE TABLE metric (metric_id varchar(50), metric_name varchar(50), metric_param varchar(255), status varchar(50), create_time datetime, update_time datetime, rs_type_id varchar(50))").Error)
+	require.NoError(t, m.Up())
+	require.NoError(t, m.Check())
+
+	// the first migration is recorded without running, the later ones run
+	require.False(t, db.HasTable("rule"))
+	var rsTypes []models.ResourceType
+	require.NoError(t, db.Find(&rsTypes).Error)
+	require.Equal(t, 1, len(rsTypes))
+}
+
+func TestCheckNewerSchema(t *testing.T) {
+	db := testutil.OpenDB(t)
+	defer db.Close()
+
+	m := NewMigrator(db, "sqlite3")
+	require.NoError(t, m.Up())
+
+	// a newer binary migrated the schema in a rolling upgrade
+	require.NoError(t, db.Create(&record{Version: Latest() + 1, Name: "newer"}).Error)
+	err := m.Check()
+	require.Error(t, err)
+	newer, ok := err.(*NewerSchemaError)
+	require.True(t, ok)
+	require.Equal(t, &NewerSchemaError{Version: Latest() + 1, Latest: Latest()}, newer)
+
+	// an older schema is no NewerSchemaError
+	m.migrations = append(m.migrations, Migration{Version: Latest() + 2, Name: "pending"})
+	_, ok = m.Check().(*NewerSchemaError)
+	require.False(t, ok)
+}
@@ -0,0 +1,259 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"strings"
+)
+
+// v1Init creates the schema the flyway scripts in pkg/db/schema/alert built up to V0_9.
+var v1Init = Migration{
+	Version: 1,
+	Name:    "init",
+	Up: func(dialect string) []string {
+		statements := columnTypes(dialect, v1Tables)
+		statements = append(statements,
+			"INSERT INTO resource_type (rs_type_id, rs_type_name, rs_type_param, create_time, update_time) VALUES "+strings.Join(v1ResourceTypes, ","),
+			"INSERT INTO metric (metric_id, metric_name, metric_param, status, create_time, update_time, rs_type_id) VALUES "+strings.Join(v1Metrics, ","),
+		)
+		return statements
+	},
+	Down: func(dialect string) []string {
+		return []string{
+			"DROP TABLE IF EXISTS action",
+			"DROP TABLE IF EXISTS alert",
+			"DROP TABLE IF EXISTS comment",
+			"DROP TABLE IF EXISTS history",
+			"DROP TABLE IF EXISTS metric",
+			"DROP TABLE IF EXISTS policy",
+			"DROP TABLE IF EXISTS resource_filter",
+			"DROP TABLE IF EXISTS resource_type",
+			"DROP TABLE IF EXISTS rule",
+			"DROP TABLE IF EXISTS audit",
+		}
+	},
+}
+
+var v1Tables = []string{
+	`CREATE TABLE action
+(
+	action_id varchar(50) NOT NULL,
+	action_name varchar(50) NOT NULL,
+	trigger_status varchar(50) DEFAULT 'Turn2Alarm' NOT NULL,
+	trigger_action varchar(255),
+	create_time {datetime},
+	update_time {datetime},
+	policy_id varchar(50) NOT NULL,
+	nf_address_list_id varchar(50) NOT NULL,
+	PRIMARY KEY (action_id)
+)`,
+	`CREATE INDEX index_action_policy_id ON action(policy_id)`,
+
+	`CREATE TABLE alert
+(
+	alert_id varchar(50) NOT NULL,
+	alert_name varchar(100) NOT NULL,
+	disabled boolean DEFAULT false NOT NULL,
+	running_status varchar(50) NOT NULL,
+	alert_status text NOT NULL,
+	create_time {datetime},
+	update_time {datetime},
+	policy_id varchar(50) NOT NULL,
+	rs_filter_id varchar(50) NOT NULL,
+	executor_id varchar(50) NOT NULL,
+	PRIMARY KEY (alert_id)
+)`,
+
+	`CREATE TABLE comment
+(
+	comment_id varchar(50) NOT NULL,
+	addresser varchar(50) NOT NULL,
+	content varchar(255) NOT NULL,
+	create_time {datetime},
+	update_time {datetime},
+	history_id varchar(50) NOT NULL,
+	PRIMARY KEY (comment_id)
+)`,
+
+	`CREATE TABLE history
+(
+	history_id varchar(50) NOT NULL,
+	history_name varchar(50) NOT NULL,
+	event varchar(50) NOT NULL,
+	content text,
+	notification_id varchar(50),
+	create_time {datetime},
+	update_time {datetime},
+	alert_id varchar(50) NOT NULL,
+	rule_id varchar(50) NOT NULL,
+	resource_name varchar(300),
+	PRIMARY KEY (history_id)
+)`,
+
+	`CREATE TABLE metric
+(
+	metric_id varchar(50) NOT NULL,
+	metric_name varchar(100) NOT NULL,
+	metric_param text NOT NULL,
+	status varchar(20) DEFAULT 'active' NOT NULL,
+	create_time {datetime},
+	update_time {datetime},
+	rs_type_id varchar(50) NOT NULL,
+	PRIMARY KEY (metric_id)
+)`,
+
+	`CREATE TABLE policy
+(
+	policy_id varchar(50) NOT NULL,
+	policy_name varchar(50) NOT NULL,
+	policy_description varchar(255),
+	policy_config text,
+	creator varchar(50),
+	available_start_time varchar(8),
+	available_end_time varchar(8),
+	create_time {datetime},
+	update_time {datetime},
+	rs_type_id varchar(50) NOT NULL,
+	PRIMARY KEY (policy_id)
+)`,
+
+	`CREATE TABLE resource_filter
+(
+	rs_filter_id varchar(50) NOT NULL,
+	rs_filter_name varchar(50) NOT NULL,
+	rs_filter_param text,
+	status varchar(50) DEFAULT 'active' NOT NULL,
+	create_time {datetime},
+	update_time {datetime},
+	rs_type_id varchar(50) NOT NULL,
+	PRIMARY KEY (rs_filter_id)
+)`,
+
+	`CREATE TABLE resource_type
+(
+	rs_type_id varchar(50) NOT NULL,
+	rs_type_name varchar(50) DEFAULT '' NOT NULL,
+	rs_type_param text,
+	create_time {datetime},
+	update_time {datetime},
+	PRIMARY KEY (rs_type_id)
+)`,
+	`CREATE INDEX index_resource_type_rs_type_name ON resource_type(rs_type_name)`,
+
+	`CREATE TABLE rule
+(
+	rule_id varchar(50) NOT NULL,
+	rule_name varchar(50) NOT NULL,
+	disabled boolean DEFAULT false NOT NULL,
+	monitor_periods int DEFAULT 5 NOT NULL,
+	severity varchar(20) DEFAULT 'minor' NOT NULL,
+	metrics_type varchar(10),
+	condition_type varchar(10) NOT NULL,
+	thresholds varchar(50) NOT NULL,
+	unit varchar(50),
+	consecutive_count int DEFAULT 1 NOT NULL,
+	inhibit boolean DEFAULT false NOT NULL,
+	create_time {datetime},
+	update_time {datetime},
+	policy_id varchar(50) NOT NULL,
+	metric_id varchar(50) NOT NULL,
+	evaluation_interval {uint} DEFAULT 0 NOT NULL,
+	PRIMARY KEY (rule_id)
+)`,
+	`CREATE INDEX index_rule_policy_id ON rule(policy_id)`,
+
+	`CREATE TABLE audit
+(
+	audit_id varchar(50) NOT NULL,
+	actor varchar(255) NOT NULL,
+	operation varchar(50) NOT NULL,
+	resource_type varchar(50) NOT NULL,
+	resource_id varchar(50) NOT NULL,
+	request_id varchar(50) NOT NULL,
+	before_value text NOT NULL,
+	after_value text NOT NULL,
+	diff text NOT NULL,
+	create_time {datetime},
+	PRIMARY KEY (audit_id)
+)`,
+	`CREATE INDEX index_audit_resource_id ON audit(resource_id)`,
+	`CREATE INDEX index_audit_actor ON audit(actor)`,
+	`CREATE INDEX index_audit_create_time ON audit(create_time)`,
+}
+
+var v1ResourceTypes = []string{
+	`('rst-2loEnEY6Oyzp','container','/namespaces/{ns_name}/pods/{pod_name}/containers?resources_filter={container_name},/nodes/{node_id}/pods/{pod_name}/containers?resources_filter={container_name}','2019-03-14 00:00:00','2019-03-14 00:00:00')`,
+	`('rst-3m8ZmxVylG90','node','/nodes?resources_filter={node_id}','2019-03-14 00:00:00','2019-03-14 00:00:00')`,
+	`('rst-7ENxQzjLKrpl','pod','/namespaces/{ns_name}/pods?resources_filter={pod_name},/nodes/{node_id}/pods?resources_filter={pod_name}','2019-03-14 00:00:00','2019-03-14 00:00:00')`,
+	`('rst-GjnE66xplG90','cluster','/clusters','2019-03-14 00:00:00','2019-03-14 00:00:00')`,
+	`('rst-pX1mLzBoJ3mA','namespace','/namespaces?resources_filter={ns_name}','2019-03-14 00:00:00','2019-03-14 00:00:00')`,
+	`('rst-rBYPE5KLKrpl','workload','/namespaces/{ns_name}/workloads/{workload_kind}?resources_filter={workload_name}','2019-03-14 00:00:00','2019-03-14 00:00:00')`,
+	`('rst-wgmgmR8o4jPL','component','/components?resources_filter={component_name}','2019-03-14 00:00:00','2019-03-14 00:00:00')`,
+	`('rst-yJO8173xP39G','workspace','/workspaces?resources_filter={ws_name}','2019-03-14 00:00:00','2019-03-14 00:00:00')`,
+}
+
+var v1Metrics = []string{
+	`('mt-021lmDGygjMY','cluster_disk_size_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-GjnE66xplG90')`,
+	`('mt-0WMZpN7MgjMY','namespace_memory_usage_wo_cache','0.000000000931322574615478515625','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-pX1mLzBoJ3mA')`,
+	`('mt-1E7Kv3LA4oA7','namespace_cpu_usage','1.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-pX1mLzBoJ3mA')`,
+	`('mt-29oZ0oV14DnB','container_cpu_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-2loEnEY6Oyzp')`,
+	`('mt-2M70Qwkn4DnB','pod_memory_usage','0.00000095367431640625','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-7ENxQzjLKrpl')`,
+	`('mt-2NV3LVWQ5OPJ','node_disk_size_available','0.000000001','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-3pk7vRx2zAkg','node_load5','1.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-3Q8MMLM2XYlx','cluster_cpu_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-GjnE66xplG90')`,
+	`('mt-3WylnMWkzAkg','workload_statefulset_unavailable_replicas_ratio','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-rBYPE5KLKrpl')`,
+	`('mt-61MWXJX04V8N','node_memory_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-69yNOQgY4V8N','cluster_pod_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-GjnE66xplG90')`,
+	`('mt-7AGJY7X2NO2q','workload_pod_memory_usage_wo_cache','0.00000095367431640625','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-rBYPE5KLKrpl')`,
+	`('mt-7g83mByZNO2q','workspace_memory_usage_wo_cache','0.000000000931322574615478515625','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-yJO8173xP39G')`,
+	`('mt-954yEkAyJKEm','workspace_pod_abnormal_ratio','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-yJO8173xP39G')`,
+	`('mt-9l3YpogEJKEm','workload_deployment_unavailable_replicas_ratio','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-rBYPE5KLKrpl')`,
+	`('mt-9r8wYwOoXYlx','container_memory_utilisation_wo_cache','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-2loEnEY6Oyzp')`,
+	`('mt-9wrDqPOwWk93','node_memory_available','0.000000000931322574615478515625','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-AjJWxqBEJKEm','pod_memory_usage_wo_cache_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-7ENxQzjLKrpl')`,
+	`('mt-AlV2RmR2JKEm','pod_net_bytes_received','0.000125','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-7ENxQzjLKrpl')`,
+	`('mt-AQ2jE8nEJKEm','workload_pod_net_bytes_transmitted','0.000125','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-rBYPE5KLKrpl')`,
+	`('mt-DxqyV8A7zAkg','container_memory_usage','0.00000095367431640625','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-2loEnEY6Oyzp')`,
+	`('mt-DyGW4qPkzAkg','workload_pod_net_bytes_received','0.000125','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-rBYPE5KLKrpl')`,
+	`('mt-G9jvLRjD4oA7','container_memory_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-2loEnEY6Oyzp')`,
+	`('mt-GEqBr1qyJKEm','node_pod_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-GNq5D4l7zAkg','container_cpu_usage','1.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-2loEnEY6Oyzp')`,
+	`('mt-Gqg9EKqX4oA7','node_net_bytes_transmitted','0.000000125','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-j37xz5xkzAkg','namespace_pod_abnormal_ratio','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-pX1mLzBoJ3mA')`,
+	`('mt-jjWGPMlnzAkg','cluster_node_offline_ratio','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-GjnE66xplG90')`,
+	`('mt-JQGwLDvn4DnB','workload_pod_memory_usage','0.00000095367431640625','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-rBYPE5KLKrpl')`,
+	`('mt-k0BrxOP2zAkg','node_disk_write_iops','1.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-k14oD7364DnB','workspace_memory_usage','0.000000000931322574615478515625','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-yJO8173xP39G')`,
+	`('mt-KPxD6YWMgjMY','namespace_memory_usage','0.000000000931322574615478515625','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-pX1mLzBoJ3mA')`,
+	`('mt-kZAM89664DnB','node_cpu_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-lB7l3Z9kzAkg','workload_daemonset_unavailable_replicas_ratio','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-rBYPE5KLKrpl')`,
+	`('mt-lgGwk9n1XYlx','node_pod_abnormal_ratio','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-lM1LD6zkzAkg','pod_cpu_usage','1.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-7ENxQzjLKrpl')`,
+	`('mt-nzLg8vkVgjMY','container_memory_usage_wo_cache','0.00000095367431640625','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-2loEnEY6Oyzp')`,
+	`('mt-o6Q0mv5N5OPJ','cluster_disk_inode_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-GjnE66xplG90')`,
+	`('mt-OrgRJ9WwWk93','workspace_cpu_usage','1.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-yJO8173xP39G')`,
+	`('mt-OyR9nE8W4oA7','container_net_bytes_transmitted','0.000125','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-2loEnEY6Oyzp')`,
+	`('mt-oz3Lx2gR4DnB','container_net_bytes_received','0.000125','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-2loEnEY6Oyzp')`,
+	`('mt-OzOQ477EJKEm','namespace_resourcequota_used_ratio','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-pX1mLzBoJ3mA')`,
+	`('mt-p8Elp7K04V8N','node_disk_size_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-PKmBorl1XYlx','node_load1','1.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-PMLGQr3P5OPJ','pod_cpu_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-7ENxQzjLKrpl')`,
+	`('mt-pQ6xP1mZNO2q','node_disk_read_throughput','0.000001','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-pv9X6BrY4V8N','cluster_pod_abnormal_ratio','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-GjnE66xplG90')`,
+	`('mt-QNWALxDn4DnB','pod_memory_usage_wo_cache','0.00000095367431640625','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-7ENxQzjLKrpl')`,
+	`('mt-QvZ9wwBX4oA7','node_disk_write_throughput','0.000001','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-rE8yox22NO2q','namespace_net_bytes_received','0.000000125','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-pX1mLzBoJ3mA')`,
+	`('mt-rg8x8vplXYlx','pod_memory_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-7ENxQzjLKrpl')`,
+	`('mt-rl5vGLMZNO2q','node_load15','1.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-rQ7Q3KvA4oA7','pod_net_bytes_transmitted','0.000125','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-7ENxQzjLKrpl')`,
+	`('mt-RWXXoJkyJKEm','node_disk_inode_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-vnAjqwNP5OPJ','namespace_net_bytes_transmitted','0.000000125','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-pX1mLzBoJ3mA')`,
+	`('mt-wEV54WQ19ZLR','workspace_net_bytes_transmitted','0.000000125','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-yJO8173xP39G')`,
+	`('mt-WJYqgPYMgjMY','workload_pod_cpu_usage','1.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-rBYPE5KLKrpl')`,
+	`('mt-X818gr764DnB','node_disk_read_iops','1.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+	`('mt-xRA8G6D64DnB','workspace_net_bytes_received','0.000000125','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-yJO8173xP39G')`,
+	`('mt-YPBylZ3nzAkg','cluster_memory_utilisation','100.0','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-GjnE66xplG90')`,
+	`('mt-Z5zNrvq2zAkg','node_net_bytes_received','0.000000125','active','2019-03-14 00:00:00','2019-03-14 00:00:00','rst-3m8ZmxVylG90')`,
+}
@@ -0,0 +1,24 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package migrations
+
+// v2ExternalAlert adds the resource type and metric of the alerts external systems post, which
+// have no monitoring path to evaluate.
+var v2ExternalAlert = Migration{
+	Version: 2,
+	Name:    "external_alert",
+	Up: func(dialect string) []string {
+		return []string{
+			"INSERT INTO resource_type (rs_type_id, rs_type_name, rs_type_param, create_time, update_time) VALUES ('rst-Xq7WzE0tNmLk','external','','2019-07-01 00:00:00','2019-07-01 00:00:00')",
+			"INSERT INTO metric (metric_id, metric_name, metric_param, status, create_time, update_time, rs_type_id) VALUES ('mt-Xq7WzE0tAlRt','external_alert','1.0','active','2019-07-01 00:00:00','2019-07-01 00:00:00','rst-Xq7WzE0tNmLk')",
+		}
+	},
+	Down: func(dialect string) []string {
+		return []string{
+			"DELETE FROM metric WHERE metric_id = 'mt-Xq7WzE0tAlRt'",
+			"DELETE FROM resource_type WHERE rs_type_id = 'rst-Xq7WzE0tNmLk'",
+		}
+	},
+}
@@ -0,0 +1 @@
+ALTER TABLE rule ADD evaluation_interval int unsigned DEFAULT 0 NOT NULL;
\ No newline at end of file
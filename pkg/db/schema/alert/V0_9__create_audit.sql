@@ -0,0 +1,23 @@
+CREATE TABLE audit
+(
+	audit_id varchar(50) NOT NULL,
+	-- actor from request metadata, empty if the caller did not set one
+	actor varchar(255) NOT NULL COMMENT 'actor from request metadata, empty if the caller did not set one',
+	-- rpc name, eg. CreateRule,ModifyAlert,DeleteMetrics
+	operation varchar(50) NOT NULL COMMENT 'rpc name, eg. CreateRule,ModifyAlert,DeleteMetrics',
+	resource_type varchar(50) NOT NULL,
+	resource_id varchar(50) NOT NULL,
+	request_id varchar(50) NOT NULL,
+	-- resource json before the change, empty for created resources
+	before_value text NOT NULL COMMENT 'resource json before the change, empty for created resources',
+	-- resource json after the change, empty for deleted resources
+	after_value text NOT NULL COMMENT 'resource json after the change, empty for deleted resources',
+	-- map[column] {before after} of the changed columns
+	diff text NOT NULL COMMENT 'map[column] {before after} of the changed columns',
+	create_time datetime(3) COMMENT 'datetime(3)',
+	PRIMARY KEY (audit_id)
+);
+
+CREATE INDEX index_audit_resource_id ON audit(resource_id(50));
+CREATE INDEX index_audit_actor ON audit(actor(50));
+CREATE INDEX index_audit_create_time ON audit(create_time);
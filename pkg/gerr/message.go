@@ -83,4 +83,39 @@ var (
 		en:   "delete resource [%s] failed",
 		zhCN: "删除资源[%s]失败",
 	}
+	ErrorResourceNotFound = ErrorMessage{
+		Name: "resource_not_found",
+		en:   "resource [%s] not found",
+		zhCN: "资源[%s]不存在",
+	}
+	ErrorUnsupportedOperation = ErrorMessage{
+		Name: "unsupported_operation",
+		en:   "unsupported operation [%s]",
+		zhCN: "不支持的操作[%s]",
+	}
+	ErrorAuthFailure = ErrorMessage{
+		Name: "auth_failure",
+		en:   "authentication failure",
+		zhCN: "认证失败",
+	}
+	ErrorPermissionDenied = ErrorMessage{
+		Name: "permission_denied",
+		en:   "permission denied",
+		zhCN: "没有权限",
+	}
+	ErrorResourceAlreadyExists = ErrorMessage{
+		Name: "resource_already_exists",
+		en:   "resource [%s] already exists",
+		zhCN: "资源[%s]已存在",
+	}
+	ErrorManagerUnavailable = ErrorMessage{
+		Name: "manager_unavailable",
+		en:   "alert manager unavailable",
+		zhCN: "告警管理服务不可用",
+	}
+	ErrorEvaluationIntervalTooShort = ErrorMessage{
+		Name: "evaluation_interval_too_short",
+		en:   "evaluation interval [%d] shorter than [%d] seconds",
+		zhCN: "检测间隔[%d]小于[%d]秒",
+	}
 )
@@ -5,6 +5,8 @@
 package global
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"strings"
 	"sync"
@@ -15,6 +17,7 @@ import (
 	"kubesphere.io/alert/pkg/config"
 	"kubesphere.io/alert/pkg/constants"
 	aldb "kubesphere.io/alert/pkg/db"
+	"kubesphere.io/alert/pkg/db/migrations"
 	"kubesphere.io/alert/pkg/etcd"
 	"kubesphere.io/alert/pkg/logger"
 )
@@ -52,8 +55,8 @@ func newGlobalCfg() *GlobalCfg {
 }
 
 func (g *GlobalCfg) openDatabase() *GlobalCfg {
-	if g.cfg.Mysql.Disable {
-		logger.Debug(nil, "%+s", "Database setting for Mysql.Disable is true.")
+	if g.cfg.Database.Disable {
+		logger.Debug(nil, "%+s", "Database setting for Database.Disable is true.")
 		return g
 	}
 	isSucc := aldb.GetInstance().InitDataPool()
@@ -62,9 +65,17 @@ func (g *GlobalCfg) openDatabase() *GlobalCfg {
 		logger.Critical(nil, "%+s", "Init database pool failure...")
 		os.Exit(1)
 	}
-	logger.Debug(nil, "%+s", "Init database pool successfully.")
+	logger.Debug(nil, "Init %s database pool successfully.", g.cfg.Database.Type)
+
+	db := aldb.GetInstance().GetDB()
+	err := migrations.NewMigrator(db, aldb.GetInstance().Dialect().Name()).Check()
+	if _, newer := err.(*migrations.NewerSchemaError); newer && g.cfg.Migrate.AllowNewerSchema {
+		logger.Warn(nil, "Check database schema: %+v, keep running with it", err)
+	} else if err != nil {
+		logger.Critical(nil, "Check database schema failure: %+v", err)
+		os.Exit(1)
+	}
 
-	db := aldb.GetInstance().GetMysqlDB()
 	g.database = db
 	logger.Debug(nil, "%+s", "Set globalcfg database value.")
 	return g
@@ -97,3 +108,25 @@ func (g *GlobalCfg) GetEtcd() *etcd.Etcd {
 func (g *GlobalCfg) GetDB() *gorm.DB {
 	return g.database
 }
+
+// CheckDB pings the database, it is a no-op when the database is disabled.
+func (g *GlobalCfg) CheckDB(ctx context.Context) error {
+	if g.cfg.Database.Disable {
+		return nil
+	}
+	if g.database == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	return g.database.DB().PingContext(ctx)
+}
+
+// CheckEtcd reads a key from etcd to make sure the session is usable.
+func (g *GlobalCfg) CheckEtcd(ctx context.Context) error {
+	if g.etcd == nil {
+		return fmt.Errorf("etcd not initialized")
+	}
+
+	_, err := g.etcd.Get(ctx, "alert-health")
+	return err
+}
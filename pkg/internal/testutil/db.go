@@ -0,0 +1,59 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+// Package testutil holds the helpers the tests of the resource_control packages share, it is
+// imported by tests only.
+package testutil
+
+import (
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/models"
+)
+
+// OpenDB opens an empty sqlite database in memory.
+func OpenDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	// every connection opens a database of its own in memory
+	db.DB().SetMaxOpenConns(1)
+	db.SingularTable(true)
+	return db
+}
+
+// OpenAlertDB opens a sqlite database in memory with the tables of alerts and the resource
+// filters, policies, rules and actions they refer to.
+func OpenAlertDB(t *testing.T) *gorm.DB {
+	db := OpenDB(t)
+	require.NoError(t, db.AutoMigrate(&models.Alert{}, &models.ResourceFilter{}, &models.Policy{}, &models.Rule{}, &models.Action{}).Error)
+	return db
+}
+
+// CreateAlert creates an alert running on executor-1, along with a resource filter, policy, rule
+// and action whose ids end with suffix.
+func CreateAlert(t *testing.T, db *gorm.DB, alertId string, suffix string) {
+	require.NoError(t, db.Create(&models.ResourceFilter{RsFilterId: "rf-" + suffix}).Error)
+	require.NoError(t, db.Create(&models.Policy{PolicyId: "pl-" + suffix}).Error)
+	require.NoError(t, db.Create(&models.Rule{RuleId: "rl-" + suffix, PolicyId: "pl-" + suffix}).Error)
+	require.NoError(t, db.Create(&models.Action{ActionId: "ac-" + suffix, PolicyId: "pl-" + suffix}).Error)
+	require.NoError(t, db.Create(&models.Alert{
+		AlertId:       alertId,
+		RunningStatus: "running",
+		AlertStatus:   "{}",
+		ExecutorId:    "executor-1",
+		PolicyId:      "pl-" + suffix,
+		RsFilterId:    "rf-" + suffix,
+	}).Error)
+}
+
+// CountRows counts the rows of table.
+func CountRows(t *testing.T, db *gorm.DB, table string) int {
+	count := 0
+	require.NoError(t, db.Table(table).Count(&count).Error)
+	return count
+}
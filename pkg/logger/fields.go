@@ -0,0 +1,91 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package logger
+
+import (
+	"context"
+	"sync"
+
+	"kubesphere.io/alert/pkg/util/ctxutil"
+)
+
+const (
+	RequestIdField = "request_id"
+	AlertIdField   = "alert_id"
+	RuleIdField    = "rule_id"
+	ExecutorField  = "executor"
+	RunModeField   = "run_mode"
+)
+
+type Field struct {
+	Key   string
+	Value string
+}
+
+type fieldsKey struct{}
+
+var globalFields struct {
+	sync.RWMutex
+	fields []Field
+}
+
+// SetGlobalField adds a field to every log line of the process, such as the run mode.
+func SetGlobalField(key string, value string) {
+	globalFields.Lock()
+	defer globalFields.Unlock()
+
+	for i := range globalFields.fields {
+		if globalFields.fields[i].Key == key {
+			globalFields.fields[i].Value = value
+			return
+		}
+	}
+	globalFields.fields = append(globalFields.fields, Field{Key: key, Value: value})
+}
+
+// WithField returns a copy of ctx whose log lines carry key, a field set again
+// in a child context overrides the parent value.
+func WithField(ctx context.Context, key string, value string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	parent, _ := ctx.Value(fieldsKey{}).([]Field)
+	fields := make([]Field, 0, len(parent)+1)
+	for _, f := range parent {
+		if f.Key != key {
+			fields = append(fields, f)
+		}
+	}
+	fields = append(fields, Field{Key: key, Value: value})
+
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+func contextFields(ctx context.Context) []Field {
+	fields := []Field{}
+	if ctx == nil {
+		return fields
+	}
+
+	if requestId := ctxutil.GetRequestId(ctx); requestId != "" {
+		fields = append(fields, Field{Key: RequestIdField, Value: requestId})
+	}
+	if ctxFields, ok := ctx.Value(fieldsKey{}).([]Field); ok {
+		fields = append(fields, ctxFields...)
+	}
+
+	return fields
+}
+
+// GetFields returns the fields of a log line written with ctx: global fields,
+// the request id and the fields added with WithField.
+func GetFields(ctx context.Context) []Field {
+	globalFields.RLock()
+	fields := append([]Field{}, globalFields.fields...)
+	globalFields.RUnlock()
+
+	return append(fields, contextFields(ctx)...)
+}
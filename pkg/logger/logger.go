@@ -6,6 +6,7 @@ package logger
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -58,6 +59,20 @@ func StringToLevel(level string) Level {
 	return InfoLevel
 }
 
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// ParseLevel is StringToLevel which rejects unknown level names.
+func ParseLevel(level string) (Level, error) {
+	switch level {
+	case "critical", "error", "warn", "warning", "info", "debug":
+		return StringToLevel(level), nil
+	}
+	return InfoLevel, fmt.Errorf("unknown log level [%s]", level)
+}
+
 var logger = NewLogger().WithDepth(4)
 
 func Info(ctx context.Context, format string, v ...interface{}) {
@@ -91,6 +106,94 @@ func SetLevelByString(level string) {
 	globalLogLevel = StringToLevel(level)
 }
 
+func GetLevel() Level {
+	return logger.level()
+}
+
+var jsonFormat int32
+
+// SetFormat switches the output of all loggers between text and json lines.
+func SetFormat(format string) {
+	if format == FormatJSON {
+		atomic.StoreInt32(&jsonFormat, 1)
+	} else {
+		atomic.StoreInt32(&jsonFormat, 0)
+	}
+}
+
+func isJSONFormat() bool {
+	return atomic.LoadInt32(&jsonFormat) == 1
+}
+
+var packageLevels atomic.Value
+
+// SetPackageLevels overrides the log level of packages, levels is a comma-separated
+// list like "services/executor=debug,client/adapter=warn". A package matches
+// when its import path ends with the given path. An empty string clears all overrides.
+func SetPackageLevels(levels string) error {
+	overrides := make(map[string]Level)
+	for _, kv := range strings.Split(levels, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+		parts := strings.Split(kv, "=")
+		if len(parts) != 2 || strings.Trim(parts[0], "/") == "" {
+			return fmt.Errorf("invalid package log level [%s]", kv)
+		}
+		level, err := ParseLevel(parts[1])
+		if err != nil {
+			return err
+		}
+		overrides[strings.Trim(parts[0], "/")] = level
+	}
+
+	packageLevels.Store(overrides)
+	return nil
+}
+
+// GetPackageLevels returns the package level overrides in the format of SetPackageLevels.
+func GetPackageLevels() string {
+	levels := []string{}
+	for pkg, level := range getPackageLevels() {
+		levels = append(levels, pkg+"="+level.String())
+	}
+
+	return strings.Join(levels, ",")
+}
+
+func getPackageLevels() map[string]Level {
+	overrides, _ := packageLevels.Load().(map[string]Level)
+	return overrides
+}
+
+// getPackageLevel returns the level override of the package of pc, the longest
+// matching package path wins.
+func getPackageLevel(overrides map[string]Level, pc uintptr) (Level, bool) {
+	f := runtime.FuncForPC(pc)
+	if f == nil {
+		return 0, false
+	}
+
+	//Function name is like kubesphere.io/alert/pkg/services/executor.(*AlertRunner).Run
+	name := f.Name()
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		name = name[:slash+1+dot]
+	}
+
+	match := ""
+	level := Level(0)
+	for pkg, l := range overrides {
+		if (name == pkg || strings.HasSuffix(name, "/"+pkg)) && len(pkg) > len(match) {
+			match = pkg
+			level = l
+		}
+	}
+
+	return level, match != ""
+}
+
 func NewLogger() *Logger {
 	return &Logger{
 		Level:  globalLogLevel,
@@ -120,46 +223,88 @@ func (logger *Logger) SetLevelByString(level string) {
 
 var replacer = strings.NewReplacer("\r", "\\r", "\n", "\\n")
 
-func (logger *Logger) formatOutput(ctx context.Context, level Level, output string) string {
+func shortFileName(file string) string {
+	for i := len(file) - 1; i > 0; i-- {
+		if file[i] == '/' {
+			return file[i+1:]
+		}
+	}
+	return file
+}
+
+func (logger *Logger) formatOutput(ctx context.Context, level Level, output string, file string, line int) string {
 	now := time.Now().Format("2006-01-02 15:04:05.99999")
-	//messageId := ctxutil.GetMessageId(ctx)
-	//requestId := ctxutil.GetRequestId(ctx)
 
 	output = replacer.Replace(output)
 
 	var suffix string
-	//if len(requestId) > 0 {
-	//	messageId = append(messageId, requestId)
-	//}
-	//if len(messageId) > 0 {
-	//	suffix = fmt.Sprintf("(%s)", strings.Join(messageId, "|"))
-	//}
+	fields := contextFields(ctx)
+	if len(fields) > 0 {
+		kvs := []string{}
+		for _, f := range fields {
+			kvs = append(kvs, f.Key+"="+f.Value)
+		}
+		suffix = fmt.Sprintf(" [%s]", strings.Join(kvs, " "))
+	}
 	if logger.hideCallstack {
 		return fmt.Sprintf("%-25s -%s- %s%s",
 			now, strings.ToUpper(level.String()), output, suffix)
 	} else {
-		_, file, line, ok := runtime.Caller(logger.depth)
-		if !ok {
-			file = "???"
-			line = 0
-		}
-		// short file name
-		for i := len(file) - 1; i > 0; i-- {
-			if file[i] == '/' {
-				file = file[i+1:]
-				break
-			}
-		}
 		return fmt.Sprintf("%-25s -%s- %s (%s:%d)%s",
-			now, strings.ToUpper(level.String()), output, file, line, suffix)
+			now, strings.ToUpper(level.String()), output, shortFileName(file), line, suffix)
 	}
 }
 
+func (logger *Logger) formatJSONOutput(ctx context.Context, level Level, output string, file string, line int) string {
+	entry := map[string]string{
+		"time":  time.Now().Format(time.RFC3339Nano),
+		"level": level.String(),
+		"msg":   output,
+	}
+	if !logger.hideCallstack {
+		entry["caller"] = fmt.Sprintf("%s:%d", shortFileName(file), line)
+	}
+	for _, f := range GetFields(ctx) {
+		if _, ok := entry[f.Key]; !ok {
+			entry[f.Key] = f.Value
+		}
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return logger.formatOutput(ctx, level, output, file, line)
+	}
+	return string(b)
+}
+
 func (logger *Logger) logf(ctx context.Context, level Level, format string, args ...interface{}) {
-	if logger.level() < level {
+	overrides := getPackageLevels()
+	if len(overrides) == 0 && logger.level() < level {
 		return
 	}
-	fmt.Fprintln(logger.output, logger.formatOutput(ctx, level, fmt.Sprintf(format, args...)))
+
+	pc, file, line, ok := runtime.Caller(logger.depth - 1)
+	if !ok {
+		file = "???"
+		line = 0
+	}
+
+	if len(overrides) > 0 {
+		effectiveLevel := logger.level()
+		if l, found := getPackageLevel(overrides, pc); ok && found {
+			effectiveLevel = l
+		}
+		if effectiveLevel < level {
+			return
+		}
+	}
+
+	output := fmt.Sprintf(format, args...)
+	if isJSONFormat() {
+		fmt.Fprintln(logger.output, logger.formatJSONOutput(ctx, level, output, file, line))
+	} else {
+		fmt.Fprintln(logger.output, logger.formatOutput(ctx, level, output, file, line))
+	}
 }
 
 func (logger *Logger) Debug(ctx context.Context, format string, args ...interface{}) {
@@ -0,0 +1,66 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/util/ctxutil"
+)
+
+func TestJSONFormat(t *testing.T) {
+	SetFormat(FormatJSON)
+	defer SetFormat(FormatText)
+
+	buf := &bytes.Buffer{}
+	l := NewLogger().SetOutput(buf)
+	l.SetLevel(DebugLevel)
+
+	SetGlobalField(RunModeField, "executor")
+	ctx := ctxutil.SetRequestId(context.Background(), "req-1")
+	ctx = WithField(ctx, AlertIdField, "al-1")
+	ctx = WithField(ctx, RuleIdField, "rl-1")
+	ctx = WithField(ctx, RuleIdField, "rl-2")
+
+	l.Info(ctx, "alert %s evaluated", "al-1")
+
+	entry := map[string]string{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "info", entry["level"])
+	require.Equal(t, "alert al-1 evaluated", entry["msg"])
+	require.Equal(t, "executor", entry[RunModeField])
+	require.Equal(t, "req-1", entry[RequestIdField])
+	require.Equal(t, "al-1", entry[AlertIdField])
+	require.Equal(t, "rl-2", entry[RuleIdField])
+	require.True(t, strings.HasPrefix(entry["caller"], "logger_test.go:"))
+}
+
+func TestPackageLevels(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := NewLogger().SetOutput(buf)
+	l.SetLevel(ErrorLevel)
+	defer SetPackageLevels("")
+
+	l.Debug(nil, "hidden")
+	require.Equal(t, "", buf.String())
+
+	require.NoError(t, SetPackageLevels("pkg/logger=debug, services/executor=warn"))
+	l.Debug(nil, "shown")
+	require.Contains(t, buf.String(), "shown")
+
+	buf.Reset()
+	require.NoError(t, SetPackageLevels("services/executor=debug"))
+	l.Debug(nil, "hidden")
+	require.Equal(t, "", buf.String())
+
+	require.Error(t, SetPackageLevels("pkg/logger=verbose"))
+	require.Error(t, SetPackageLevels("debug"))
+}
@@ -6,7 +6,6 @@ package manager
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 	"sync"
 	"time"
@@ -14,6 +13,8 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
+
+	"kubesphere.io/alert/pkg/tracing"
 )
 
 var ClientOptions = []grpc.DialOption{
@@ -23,6 +24,7 @@ var ClientOptions = []grpc.DialOption{
 		Timeout:             10 * time.Second,
 		PermitWithoutStream: true,
 	}),
+	grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor()),
 }
 
 var clientCache sync.Map
@@ -41,12 +43,11 @@ func NewClient(host string, port int) (*grpc.ClientConn, error) {
 	return conn, nil
 }
 
-func NewTLSClient(host string, port int, tlsConfig *tls.Config) (*grpc.ClientConn, error) {
+func NewTLSClient(host string, port int, creds credentials.TransportCredentials) (*grpc.ClientConn, error) {
 	endpoint := fmt.Sprintf("%s:%d", host, port)
 	if conn, ok := clientCache.Load(endpoint); ok {
 		return conn.(*grpc.ClientConn), nil
 	}
-	creds := credentials.NewTLS(tlsConfig)
 	tlsClientOptions := []grpc.DialOption{
 		grpc.WithTransportCredentials(creds),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
@@ -54,6 +55,7 @@ func NewTLSClient(host string, port int, tlsConfig *tls.Config) (*grpc.ClientCon
 			Timeout:             10 * time.Second,
 			PermitWithoutStream: true,
 		}),
+		grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor()),
 	}
 	conn, err := grpc.Dial(endpoint, tlsClientOptions...)
 	if err != nil {
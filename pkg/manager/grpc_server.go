@@ -26,7 +26,10 @@ import (
 
 	"kubesphere.io/alert/pkg/gerr"
 	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/monitoring"
+	"kubesphere.io/alert/pkg/tracing"
 	"kubesphere.io/alert/pkg/util/ctxutil"
+	"kubesphere.io/alert/pkg/util/idutil"
 )
 
 type checkerT func(ctx context.Context, req interface{}) error
@@ -43,6 +46,8 @@ type GrpcServer struct {
 	showErrorCause bool
 	checker        checkerT
 	builder        builderT
+	authorizer     grpc.UnaryServerInterceptor
+	interceptors   []grpc.UnaryServerInterceptor
 }
 
 type RegisterCallback func(*grpc.Server)
@@ -72,6 +77,20 @@ func (g *GrpcServer) WithBuilder(b builderT) *GrpcServer {
 	return g
 }
 
+// WithAuthorizer sets an interceptor identifying the caller of every request before the
+// request is checked.
+func (g *GrpcServer) WithAuthorizer(a grpc.UnaryServerInterceptor) *GrpcServer {
+	g.authorizer = a
+	return g
+}
+
+// WithInterceptor adds an interceptor running after the checker and the builder,
+// eg. to audit the requests accepted by the service.
+func (g *GrpcServer) WithInterceptor(i grpc.UnaryServerInterceptor) *GrpcServer {
+	g.interceptors = append(g.interceptors, i)
+	return g
+}
+
 func (g *GrpcServer) Serve(callback RegisterCallback, opt ...grpc.ServerOption) {
 	/*version.PrintVersionInfo(func(s string, i ...interface{}) {
 		logger.Info(nil, s, i)
@@ -83,41 +102,54 @@ func (g *GrpcServer) Serve(callback RegisterCallback, opt ...grpc.ServerOption)
 		logger.Critical(nil, "failed to listen: %+v", err)
 	}
 
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		tracing.UnaryServerInterceptor(),
+		g.unaryServerMetricsInterceptor(),
+		grpc_validator.UnaryServerInterceptor(),
+		g.unaryServerLogInterceptor(),
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+			if g.authorizer != nil {
+				return g.authorizer(ctx, req, info, handler)
+			}
+			return handler(ctx, req)
+		},
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+			if g.checker != nil {
+				err = g.checker(ctx, req)
+				if err != nil {
+					return
+				}
+			}
+
+			return handler(ctx, req)
+		},
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+			if g.builder != nil {
+				req = g.builder(ctx, req)
+			}
+			return handler(ctx, req)
+		},
+	}
+	unaryInterceptors = append(unaryInterceptors, g.interceptors...)
+	unaryInterceptors = append(unaryInterceptors,
+		grpc_recovery.UnaryServerInterceptor(
+			grpc_recovery.WithRecoveryHandler(func(p interface{}) error {
+				logger.Critical(nil, "GRPC server recovery with error: %+v", p)
+				logger.Critical(nil, string(debug.Stack()))
+				if e, ok := p.(error); ok {
+					return gerr.NewWithDetail(nil, gerr.Internal, e, gerr.ErrorInternalError)
+				}
+				return gerr.New(nil, gerr.Internal, gerr.ErrorInternalError)
+			}),
+		),
+	)
+
 	builtinOptions := []grpc.ServerOption{
 		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 			MinTime:             10 * time.Second,
 			PermitWithoutStream: true,
 		}),
-		grpc_middleware.WithUnaryServerChain(
-			grpc_validator.UnaryServerInterceptor(),
-			g.unaryServerLogInterceptor(),
-			func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-				if g.checker != nil {
-					err = g.checker(ctx, req)
-					if err != nil {
-						return
-					}
-				}
-
-				return handler(ctx, req)
-			},
-			func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-				if g.builder != nil {
-					req = g.builder(ctx, req)
-				}
-				return handler(ctx, req)
-			},
-			grpc_recovery.UnaryServerInterceptor(
-				grpc_recovery.WithRecoveryHandler(func(p interface{}) error {
-					logger.Critical(nil, "GRPC server recovery with error: %+v", p)
-					logger.Critical(nil, string(debug.Stack()))
-					if e, ok := p.(error); ok {
-						return gerr.NewWithDetail(nil, gerr.Internal, e, gerr.ErrorInternalError)
-					}
-					return gerr.New(nil, gerr.Internal, gerr.ErrorInternalError)
-				}),
-			),
-		),
+		grpc_middleware.WithUnaryServerChain(unaryInterceptors...),
 		grpc_middleware.WithStreamServerChain(
 			grpc_recovery.StreamServerInterceptor(
 				grpc_recovery.WithRecoveryHandler(func(p interface{}) error {
@@ -148,6 +180,22 @@ var (
 	}
 )
 
+func (g *GrpcServer) unaryServerMetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method := strings.Split(info.FullMethod, "/")
+		action := method[len(method)-1]
+
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		monitoring.GrpcRequestDuration.WithLabelValues(action).Observe(time.Since(start).Seconds())
+		monitoring.GrpcRequestsTotal.WithLabelValues(action, status.Code(err).String()).Inc()
+
+		return resp, err
+	}
+}
+
 func (g *GrpcServer) unaryServerLogInterceptor() grpc.UnaryServerInterceptor {
 	showErrorCause := g.showErrorCause
 
@@ -155,6 +203,9 @@ func (g *GrpcServer) unaryServerLogInterceptor() grpc.UnaryServerInterceptor {
 		var err error
 		//s := senderutil.GetSenderFromContext(ctx)
 		requestId := ctxutil.GetRequestId(ctx)
+		if requestId == "" {
+			requestId = idutil.GetUuid("req-")
+		}
 		ctx = ctxutil.SetRequestId(ctx, requestId)
 		//ctx = senderutil.ContextWithSender(ctx, s)
 
@@ -1,6 +1,11 @@
 package models
 
 import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
 	"time"
 
 	"kubesphere.io/alert/pkg/pb"
@@ -41,6 +46,65 @@ const (
 	AcColNfAddressListId = "nf_address_list_id"
 )
 
+// Action types, set in the trigger action of an action as a json object such as
+// {"type":"alertmanager_webhook","url":"http://receiver:5001/"}. Actions without a type only send
+// notifications to their notification address list.
+const (
+	ActionTypeAlertmanagerWebhook = "alertmanager_webhook"
+)
+
+type ActionConfig struct {
+	Type string `json:"type"`
+	Url  string `json:"url"`
+}
+
+// ParseActionConfig reads the trigger action of an action, trigger actions which are not a json
+// object have no type.
+func ParseActionConfig(triggerAction string) (*ActionConfig, error) {
+	actionConfig := &ActionConfig{}
+	if !strings.HasPrefix(strings.TrimSpace(triggerAction), "{") {
+		return actionConfig, nil
+	}
+
+	err := json.Unmarshal([]byte(triggerAction), actionConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	switch actionConfig.Type {
+	case "":
+	case ActionTypeAlertmanagerWebhook:
+		u, err := url.Parse(actionConfig.Url)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid webhook url [%s]", actionConfig.Url)
+		}
+		err = CheckWebhookHost(u.Hostname())
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported action type [%s]", actionConfig.Type)
+	}
+
+	return actionConfig, nil
+}
+
+// CheckWebhookHost fails for the hosts webhooks must not be sent to, those of the node itself
+// such as the admin server on the loopback interface, and link-local ones such as the metadata
+// services of clouds. Names are checked once more as the webhook client dials their addresses.
+func CheckWebhookHost(host string) error {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("webhook host [%s] is not allowed", host)
+	}
+
+	ip := net.ParseIP(host)
+	if ip != nil && (ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast()) {
+		return fmt.Errorf("webhook host [%s] is not allowed", host)
+	}
+	return nil
+}
+
 func NewActionId() string {
 	return idutil.GetUuid(ActionIdPrefix)
 }
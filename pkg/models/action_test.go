@@ -0,0 +1,42 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseActionConfig(t *testing.T) {
+	actionConfig, err := ParseActionConfig("")
+	require.NoError(t, err)
+	require.Equal(t, &ActionConfig{}, actionConfig)
+
+	actionConfig, err = ParseActionConfig("send email")
+	require.NoError(t, err)
+	require.Equal(t, &ActionConfig{}, actionConfig)
+
+	actionConfig, err = ParseActionConfig(`{"type":"alertmanager_webhook","url":"http://receiver:5001/alerts"}`)
+	require.NoError(t, err)
+	require.Equal(t, &ActionConfig{Type: ActionTypeAlertmanagerWebhook, Url: "http://receiver:5001/alerts"}, actionConfig)
+
+	_, err = ParseActionConfig(`{"type":"alertmanager_webhook","url":"receiver:5001"}`)
+	require.Error(t, err)
+
+	// the node itself and link-local addresses are not webhook receivers
+	for _, host := range []string{"127.0.0.1:9203", "localhost:9203", "[::1]", "0.0.0.0", "169.254.169.254", "[fe80::1]"} {
+		_, err = ParseActionConfig(`{"type":"alertmanager_webhook","url":"http://` + host + `/admin/loglevel"}`)
+		require.Error(t, err, host)
+	}
+	_, err = ParseActionConfig(`{"type":"alertmanager_webhook","url":"http://10.0.0.1:5001/"}`)
+	require.NoError(t, err)
+
+	_, err = ParseActionConfig(`{"type":"sms"}`)
+	require.Error(t, err)
+
+	_, err = ParseActionConfig(`{"type":`)
+	require.Error(t, err)
+}
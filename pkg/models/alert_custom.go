@@ -95,6 +95,16 @@ type AlertStatus struct {
 	CreateTime       time.Time        `gorm:"column:create_time" json:"create_time"`
 	UpdateTime       time.Time        `gorm:"column:update_time" json:"update_time"`
 	AlertStatus      string           `gorm:"column:alert_status"`
+	Evaluation       EvaluationStatus `gorm:"-" json:"evaluation"`
+}
+
+type EvaluationStatus struct {
+	State               string    `json:"state"`
+	Error               string    `json:"error"`
+	LastSuccessTime     time.Time `json:"last_success_time"`
+	LastErrorTime       time.Time `json:"last_error_time"`
+	ConsecutiveFailures uint32    `json:"consecutive_failures"`
+	LastDuration        uint32    `json:"last_duration"`
 }
 
 func AlertStatusToPb(alertStatus AlertStatus) *pb.AlertStatus {
@@ -125,6 +135,16 @@ func AlertStatusToPb(alertStatus AlertStatus) *pb.AlertStatus {
 	}
 	pbAlertStatus.CreateTime = pbutil.ToProtoTimestamp(alertStatus.CreateTime)
 	pbAlertStatus.UpdateTime = pbutil.ToProtoTimestamp(alertStatus.UpdateTime)
+	pbAlertStatus.EvaluationState = alertStatus.Evaluation.State
+	pbAlertStatus.EvaluationError = alertStatus.Evaluation.Error
+	if !alertStatus.Evaluation.LastSuccessTime.IsZero() {
+		pbAlertStatus.LastSuccessTime = pbutil.ToProtoTimestamp(alertStatus.Evaluation.LastSuccessTime)
+	}
+	if !alertStatus.Evaluation.LastErrorTime.IsZero() {
+		pbAlertStatus.LastErrorTime = pbutil.ToProtoTimestamp(alertStatus.Evaluation.LastErrorTime)
+	}
+	pbAlertStatus.ConsecutiveFailures = alertStatus.Evaluation.ConsecutiveFailures
+	pbAlertStatus.LastDuration = alertStatus.Evaluation.LastDuration
 	return &pbAlertStatus
 }
 
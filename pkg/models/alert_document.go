@@ -0,0 +1,193 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+)
+
+// AlertDocumentVersion is the version of the alert document format.
+const AlertDocumentVersion = "v1"
+
+const (
+	AlertDocumentFormatYaml = "yaml"
+	AlertDocumentFormatJson = "json"
+)
+
+// AlertDocument describes alerts as code, to keep them in version control and move them between
+// clusters. Resource types and metrics are referred to by name, alerts and rules are matched by
+// name too, ids are left out.
+type AlertDocument struct {
+	Version string            `json:"version"`
+	Alerts  []AlertDefinition `json:"alerts"`
+}
+
+type AlertDefinition struct {
+	AlertName      string                   `json:"alert_name"`
+	Disabled       bool                     `json:"disabled,omitempty"`
+	ResourceFilter ResourceFilterDefinition `json:"resource_filter"`
+	Policy         PolicyDefinition         `json:"policy"`
+	Action         ActionDefinition         `json:"action"`
+	Rules          []RuleDefinition         `json:"rules"`
+}
+
+type ResourceFilterDefinition struct {
+	RsFilterName  string `json:"rs_filter_name"`
+	RsFilterParam string `json:"rs_filter_param"`
+	RsTypeName    string `json:"rs_type_name"`
+}
+
+type PolicyDefinition struct {
+	PolicyName         string `json:"policy_name"`
+	PolicyDescription  string `json:"policy_description,omitempty"`
+	PolicyConfig       string `json:"policy_config,omitempty"`
+	Creator            string `json:"creator,omitempty"`
+	AvailableStartTime string `json:"available_start_time"`
+	AvailableEndTime   string `json:"available_end_time"`
+}
+
+// ActionDefinition refers to the notification address list by name, as its id differs between
+// clusters.
+type ActionDefinition struct {
+	ActionName        string `json:"action_name"`
+	TriggerStatus     string `json:"trigger_status,omitempty"`
+	TriggerAction     string `json:"trigger_action,omitempty"`
+	NfAddressListName string `json:"nf_address_list_name,omitempty"`
+}
+
+type RuleDefinition struct {
+	RuleName           string `json:"rule_name"`
+	Disabled           bool   `json:"disabled,omitempty"`
+	MetricName         string `json:"metric_name"`
+	MonitorPeriods     uint32 `json:"monitor_periods"`
+	EvaluationInterval uint32 `json:"evaluation_interval,omitempty"`
+	Severity           string `json:"severity"`
+	MetricsType        string `json:"metrics_type,omitempty"`
+	ConditionType      string `json:"condition_type"`
+	Thresholds         string `json:"thresholds"`
+	Unit               string `json:"unit,omitempty"`
+	ConsecutiveCount   uint32 `json:"consecutive_count"`
+	Inhibit            bool   `json:"inhibit,omitempty"`
+}
+
+// ParseAlertDocument reads an alert document in yaml or json.
+func ParseAlertDocument(data []byte) (*AlertDocument, error) {
+	document := &AlertDocument{}
+	err := yaml.Unmarshal(data, document)
+	if err != nil {
+		return nil, err
+	}
+
+	if document.Version != AlertDocumentVersion {
+		return nil, fmt.Errorf("unsupported alert document version [%s]", document.Version)
+	}
+
+	alertNames := make(map[string]bool)
+	for _, alert := range document.Alerts {
+		if alert.AlertName == "" {
+			return nil, fmt.Errorf("alert without alert_name")
+		}
+		if alertNames[alert.AlertName] {
+			return nil, fmt.Errorf("alert [%s] defined twice", alert.AlertName)
+		}
+		alertNames[alert.AlertName] = true
+
+		ruleNames := make(map[string]bool)
+		for _, rule := range alert.Rules {
+			if rule.RuleName == "" {
+				return nil, fmt.Errorf("rule without rule_name in alert [%s]", alert.AlertName)
+			}
+			if ruleNames[rule.RuleName] {
+				return nil, fmt.Errorf("rule [%s] defined twice in alert [%s]", rule.RuleName, alert.AlertName)
+			}
+			ruleNames[rule.RuleName] = true
+		}
+	}
+
+	return document, nil
+}
+
+// MarshalAlertDocument writes an alert document in format, yaml unless json.
+func MarshalAlertDocument(document *AlertDocument, format string) ([]byte, error) {
+	if format == AlertDocumentFormatJson {
+		return json.MarshalIndent(document, "", "  ")
+	}
+	return yaml.Marshal(document)
+}
+
+func diffField(diff []string, path string, oldValue interface{}, newValue interface{}) []string {
+	if oldValue != newValue {
+		diff = append(diff, fmt.Sprintf("%s: %v -> %v", path, oldValue, newValue))
+	}
+	return diff
+}
+
+func diffRuleDefinition(diff []string, oldRule RuleDefinition, newRule RuleDefinition) []string {
+	path := fmt.Sprintf("rules[%s].", newRule.RuleName)
+	diff = diffField(diff, path+"disabled", oldRule.Disabled, newRule.Disabled)
+	diff = diffField(diff, path+"metric_name", oldRule.MetricName, newRule.MetricName)
+	diff = diffField(diff, path+"monitor_periods", oldRule.MonitorPeriods, newRule.MonitorPeriods)
+	diff = diffField(diff, path+"evaluation_interval", oldRule.EvaluationInterval, newRule.EvaluationInterval)
+	diff = diffField(diff, path+"severity", oldRule.Severity, newRule.Severity)
+	diff = diffField(diff, path+"metrics_type", oldRule.MetricsType, newRule.MetricsType)
+	diff = diffField(diff, path+"condition_type", oldRule.ConditionType, newRule.ConditionType)
+	diff = diffField(diff, path+"thresholds", oldRule.Thresholds, newRule.Thresholds)
+	diff = diffField(diff, path+"unit", oldRule.Unit, newRule.Unit)
+	diff = diffField(diff, path+"consecutive_count", oldRule.ConsecutiveCount, newRule.ConsecutiveCount)
+	diff = diffField(diff, path+"inhibit", oldRule.Inhibit, newRule.Inhibit)
+	return diff
+}
+
+// DiffAlertDefinitions lists the fields applying newAlert changes in oldAlert, rules are matched
+// by name.
+func DiffAlertDefinitions(oldAlert *AlertDefinition, newAlert *AlertDefinition) []string {
+	diff := []string{}
+
+	diff = diffField(diff, "disabled", oldAlert.Disabled, newAlert.Disabled)
+
+	diff = diffField(diff, "resource_filter.rs_filter_name", oldAlert.ResourceFilter.RsFilterName, newAlert.ResourceFilter.RsFilterName)
+	diff = diffField(diff, "resource_filter.rs_filter_param", oldAlert.ResourceFilter.RsFilterParam, newAlert.ResourceFilter.RsFilterParam)
+	diff = diffField(diff, "resource_filter.rs_type_name", oldAlert.ResourceFilter.RsTypeName, newAlert.ResourceFilter.RsTypeName)
+
+	diff = diffField(diff, "policy.policy_name", oldAlert.Policy.PolicyName, newAlert.Policy.PolicyName)
+	diff = diffField(diff, "policy.policy_description", oldAlert.Policy.PolicyDescription, newAlert.Policy.PolicyDescription)
+	diff = diffField(diff, "policy.policy_config", oldAlert.Policy.PolicyConfig, newAlert.Policy.PolicyConfig)
+	diff = diffField(diff, "policy.creator", oldAlert.Policy.Creator, newAlert.Policy.Creator)
+	diff = diffField(diff, "policy.available_start_time", oldAlert.Policy.AvailableStartTime, newAlert.Policy.AvailableStartTime)
+	diff = diffField(diff, "policy.available_end_time", oldAlert.Policy.AvailableEndTime, newAlert.Policy.AvailableEndTime)
+
+	diff = diffField(diff, "action.action_name", oldAlert.Action.ActionName, newAlert.Action.ActionName)
+	diff = diffField(diff, "action.trigger_status", oldAlert.Action.TriggerStatus, newAlert.Action.TriggerStatus)
+	diff = diffField(diff, "action.trigger_action", oldAlert.Action.TriggerAction, newAlert.Action.TriggerAction)
+	diff = diffField(diff, "action.nf_address_list_name", oldAlert.Action.NfAddressListName, newAlert.Action.NfAddressListName)
+
+	oldRules := make(map[string]RuleDefinition)
+	for _, rule := range oldAlert.Rules {
+		oldRules[rule.RuleName] = rule
+	}
+	newRuleNames := make(map[string]bool)
+	for _, rule := range newAlert.Rules {
+		newRuleNames[rule.RuleName] = true
+		oldRule, ok := oldRules[rule.RuleName]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("rules[%s]: added", rule.RuleName))
+			continue
+		}
+		diff = diffRuleDefinition(diff, oldRule, rule)
+	}
+
+	removed := []string{}
+	for ruleName := range oldRules {
+		if !newRuleNames[ruleName] {
+			removed = append(removed, ruleName)
+		}
+	}
+	sort.Strings(removed)
+	for _, ruleName := range removed {
+		diff = append(diff, fmt.Sprintf("rules[%s]: removed", ruleName))
+	}
+
+	return diff
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testAlertDocument = `
+version: v1
+alerts:
+- alert_name: pod-cpu
+  resource_filter:
+    rs_filter_name: pods
+    rs_filter_param: '{"ns_name":"ns1"}'
+    rs_type_name: pod
+  policy:
+    policy_name: pod-cpu
+    available_start_time: "00:00:00"
+    available_end_time: "23:59:59"
+  action:
+    action_name: notify
+    nf_address_list_name: ops
+  rules:
+  - rule_name: cpu
+    metric_name: pod_cpu_usage
+    monitor_periods: 1
+    severity: minor
+    condition_type: ">"
+    thresholds: "80"
+    consecutive_count: 1
+`
+
+func TestParseAlertDocument(t *testing.T) {
+	document, err := ParseAlertDocument([]byte(testAlertDocument))
+	require.NoError(t, err)
+	require.Len(t, document.Alerts, 1)
+	require.Equal(t, "pod", document.Alerts[0].ResourceFilter.RsTypeName)
+	require.Equal(t, "pod_cpu_usage", document.Alerts[0].Rules[0].MetricName)
+	require.Equal(t, "ops", document.Alerts[0].Action.NfAddressListName)
+
+	// json documents are yaml documents too
+	data, err := MarshalAlertDocument(document, AlertDocumentFormatJson)
+	require.NoError(t, err)
+	parsed, err := ParseAlertDocument(data)
+	require.NoError(t, err)
+	require.Equal(t, document, parsed)
+
+	data, err = MarshalAlertDocument(document, AlertDocumentFormatYaml)
+	require.NoError(t, err)
+	parsed, err = ParseAlertDocument(data)
+	require.NoError(t, err)
+	require.Equal(t, document, parsed)
+
+	_, err = ParseAlertDocument([]byte("version: v2\nalerts: []\n"))
+	require.Error(t, err)
+	_, err = ParseAlertDocument([]byte("version: v1\nalerts:\n- alert_name: a\n- alert_name: a\n"))
+	require.Error(t, err)
+	_, err = ParseAlertDocument([]byte("version: v1\nalerts:\n- alert_name: a\n  rules:\n  - rule_name: r\n  - rule_name: r\n"))
+	require.Error(t, err)
+}
+
+func TestDiffAlertDefinitions(t *testing.T) {
+	document, err := ParseAlertDocument([]byte(testAlertDocument))
+	require.NoError(t, err)
+	oldAlert := document.Alerts[0]
+
+	require.Empty(t, DiffAlertDefinitions(&oldAlert, &oldAlert))
+
+	newAlert := oldAlert
+	newAlert.Policy.PolicyConfig = `{"repeat_type":"fixed-minute"}`
+	newAlert.Rules = []RuleDefinition{oldAlert.Rules[0], oldAlert.Rules[0]}
+	newAlert.Rules[0].Thresholds = "90"
+	newAlert.Rules[1].RuleName = "cpu-critical"
+	oldAlert.Rules = append(oldAlert.Rules, RuleDefinition{RuleName: "memory"})
+
+	require.Equal(t, []string{
+		`policy.policy_config:  -> {"repeat_type":"fixed-minute"}`,
+		"rules[cpu].thresholds: 80 -> 90",
+		"rules[cpu-critical]: added",
+		"rules[memory]: removed",
+	}, DiffAlertDefinitions(&oldAlert, &newAlert))
+}
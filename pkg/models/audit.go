@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+
+	"kubesphere.io/alert/pkg/pb"
+	"kubesphere.io/alert/pkg/util/idutil"
+	"kubesphere.io/alert/pkg/util/pbutil"
+)
+
+type Audit struct {
+	AuditId      string    `gorm:"column:audit_id" json:"audit_id"`
+	Actor        string    `gorm:"column:actor" json:"actor"`
+	Operation    string    `gorm:"column:operation" json:"operation"`
+	ResourceType string    `gorm:"column:resource_type" json:"resource_type"`
+	ResourceId   string    `gorm:"column:resource_id" json:"resource_id"`
+	RequestId    string    `gorm:"column:request_id" json:"request_id"`
+	BeforeValue  string    `gorm:"column:before_value" json:"before_value"`
+	AfterValue   string    `gorm:"column:after_value" json:"after_value"`
+	Diff         string    `gorm:"column:diff" json:"diff"`
+	CreateTime   time.Time `gorm:"column:create_time" json:"create_time"`
+}
+
+//table name
+const (
+	TableAudit = "audit"
+)
+
+const (
+	AuditIdPrefix = "ad-"
+)
+
+//field name
+//Ad is short for audit.
+const (
+	AdColId           = "audit_id"
+	AdColActor        = "actor"
+	AdColOperation    = "operation"
+	AdColResourceType = "resource_type"
+	AdColResourceId   = "resource_id"
+	AdColRequestId    = "request_id"
+	AdColBeforeValue  = "before_value"
+	AdColAfterValue   = "after_value"
+	AdColDiff         = "diff"
+	AdColCreateTime   = "create_time"
+)
+
+func NewAuditId() string {
+	return idutil.GetUuid(AuditIdPrefix)
+}
+
+func NewAudit(actor string, operation string, resourceType string, resourceId string, requestId string, beforeValue string, afterValue string, diff string) *Audit {
+	audit := &Audit{
+		AuditId:      NewAuditId(),
+		Actor:        actor,
+		Operation:    operation,
+		ResourceType: resourceType,
+		ResourceId:   resourceId,
+		RequestId:    requestId,
+		BeforeValue:  beforeValue,
+		AfterValue:   afterValue,
+		Diff:         diff,
+		CreateTime:   time.Now(),
+	}
+	return audit
+}
+
+func AuditToPb(audit *Audit) *pb.Audit {
+	pbAudit := pb.Audit{}
+	pbAudit.AuditId = audit.AuditId
+	pbAudit.Actor = audit.Actor
+	pbAudit.Operation = audit.Operation
+	pbAudit.ResourceType = audit.ResourceType
+	pbAudit.ResourceId = audit.ResourceId
+	pbAudit.RequestId = audit.RequestId
+	pbAudit.BeforeValue = audit.BeforeValue
+	pbAudit.AfterValue = audit.AfterValue
+	pbAudit.Diff = audit.Diff
+	pbAudit.CreateTime = pbutil.ToProtoTimestamp(audit.CreateTime)
+	return &pbAudit
+}
+
+func ParseAdSet2PbSet(inAds []*Audit) []*pb.Audit {
+	var pbAds []*pb.Audit
+	for _, inAd := range inAds {
+		pbAd := AuditToPb(inAd)
+		pbAds = append(pbAds, pbAd)
+	}
+	return pbAds
+}
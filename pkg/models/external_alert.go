@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Alerts posted by external systems belong to the external resource type. Executors do not
+// evaluate them, their rule uses the external metric and their running status is external.
+const (
+	RsTypeNameExternal    = "external"
+	MetricNameExternal    = "external_alert"
+	RunningStatusExternal = "external"
+)
+
+// ExternalNamespaceLabel is the label of the namespace an external alert belongs to.
+const ExternalNamespaceLabel = "namespace"
+
+// maxResourceNameLength is the length of the resource_name column of history.
+const maxResourceNameLength = 300
+
+// severities maps the usual values of the severity label of Prometheus alerts to the severities
+// of rules.
+var severities = map[string]string{
+	"":         "minor",
+	"info":     "minor",
+	"low":      "minor",
+	"minor":    "minor",
+	"warning":  "minor",
+	"medium":   "major",
+	"major":    "major",
+	"error":    "major",
+	"high":     "major",
+	"critical": "critical",
+	"page":     "critical",
+}
+
+// ParseSeverity returns the rule severity of the value of a severity label, case insensitive.
+func ParseSeverity(label string) (string, bool) {
+	severity, ok := severities[strings.ToLower(label)]
+	return severity, ok
+}
+
+// ExternalResourceName names the resource an external alert of labels fires on by the labels
+// other than alertname and severity, sorted, such as instance=node1,job=node. Names too long for
+// the history are replaced with the fingerprint of the labels.
+func ExternalResourceName(labels map[string]string, fingerprint string) string {
+	pairs := []string{}
+	for name, value := range labels {
+		if name == "alertname" || name == "severity" {
+			continue
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(pairs)
+
+	resourceName := strings.Join(pairs, ",")
+	if len(resourceName) > maxResourceNameLength {
+		return fingerprint
+	}
+	return resourceName
+}
+
+// ExternalRsFilterParam is the resource filter param of the external alerts posted with the
+// namespace label nsName, which belong to no namespace without it.
+func ExternalRsFilterParam(nsName string) string {
+	rsFilterParam := map[string]string{}
+	if nsName != "" {
+		rsFilterParam["ns_name"] = nsName
+	}
+	data, _ := json.Marshal(rsFilterParam)
+	return string(data)
+}
@@ -0,0 +1,49 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeverity(t *testing.T) {
+	severity, ok := ParseSeverity("")
+	require.True(t, ok)
+	require.Equal(t, "minor", severity)
+
+	severity, ok = ParseSeverity("Warning")
+	require.True(t, ok)
+	require.Equal(t, "minor", severity)
+
+	severity, ok = ParseSeverity("critical")
+	require.True(t, ok)
+	require.Equal(t, "critical", severity)
+
+	_, ok = ParseSeverity("fatal")
+	require.False(t, ok)
+}
+
+func TestExternalResourceName(t *testing.T) {
+	labels := map[string]string{
+		"alertname": "HighLatency",
+		"severity":  "major",
+		"job":       "api",
+		"instance":  "node1:9100",
+	}
+	require.Equal(t, "instance=node1:9100,job=api", ExternalResourceName(labels, "3e8a5ea2d0d3fc7c"))
+
+	require.Equal(t, "", ExternalResourceName(map[string]string{"alertname": "Watchdog"}, "3e8a5ea2d0d3fc7c"))
+
+	labels["path"] = strings.Repeat("a", maxResourceNameLength)
+	require.Equal(t, "3e8a5ea2d0d3fc7c", ExternalResourceName(labels, "3e8a5ea2d0d3fc7c"))
+}
+
+func TestExternalRsFilterParam(t *testing.T) {
+	require.Equal(t, `{"ns_name":"ns1"}`, ExternalRsFilterParam("ns1"))
+	require.Equal(t, `{}`, ExternalRsFilterParam(""))
+}
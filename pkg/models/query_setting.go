@@ -13,6 +13,7 @@ var SearchWordColumnTable = []string{
 	TableAlert,
 	TableHistory,
 	TableComment,
+	TableAudit,
 }
 
 // columns that can be search through sql 'like' operator
@@ -30,7 +31,7 @@ var SearchColumns = map[string][]string{
 		PlColId, PlColName, PlColDescription, PlColCreator, PlColTypeId,
 	},
 	TableRule: {
-		RlColId, RlColName, RlColDisabled, RlColMonitorPeriods, RlColSeverity, RlColMetricsType, RlColConditionType, RlColThresholds, RlColUnit, RlColConsecutiveCount, RlColInhibit, RlColPolicyId, RlColMetricId,
+		RlColId, RlColName, RlColDisabled, RlColMonitorPeriods, RlColSeverity, RlColMetricsType, RlColConditionType, RlColThresholds, RlColUnit, RlColConsecutiveCount, RlColInhibit, RlColPolicyId, RlColMetricId, RlColEvaluationInterval,
 	},
 	TableAlert: {
 		AlColId, AlColName, AlColDisabled, AlColRunningStatus, AlColPolicyId, AlColRsFilterId, AlColExecutorId,
@@ -44,6 +45,9 @@ var SearchColumns = map[string][]string{
 	TableAction: {
 		AcColId, AcColName, AcColTriggerStatus, AcColTriggerAction, AcColPolicyId, AcColNfAddressListId,
 	},
+	TableAudit: {
+		AdColId, AdColActor, AdColOperation, AdColResourceType, AdColResourceId, AdColRequestId,
+	},
 }
 
 // columns that can be search through sql '=' operator
@@ -61,7 +65,7 @@ var IndexedColumns = map[string][]string{
 		PlColId, PlColName, PlColDescription, PlColCreator, PlColTypeId,
 	},
 	TableRule: {
-		RlColId, RlColName, RlColDisabled, RlColMonitorPeriods, RlColSeverity, RlColMetricsType, RlColConditionType, RlColThresholds, RlColUnit, RlColConsecutiveCount, RlColInhibit, RlColPolicyId, RlColMetricId,
+		RlColId, RlColName, RlColDisabled, RlColMonitorPeriods, RlColSeverity, RlColMetricsType, RlColConditionType, RlColThresholds, RlColUnit, RlColConsecutiveCount, RlColInhibit, RlColPolicyId, RlColMetricId, RlColEvaluationInterval,
 	},
 	TableAlert: {
 		AlColId, AlColName, AlColDisabled, AlColRunningStatus, AlColPolicyId, AlColRsFilterId, AlColExecutorId,
@@ -75,4 +79,7 @@ var IndexedColumns = map[string][]string{
 	TableAction: {
 		AcColId, AcColName, AcColTriggerStatus, AcColTriggerAction, AcColPolicyId, AcColNfAddressListId,
 	},
+	TableAudit: {
+		AdColId, AdColActor, AdColOperation, AdColResourceType, AdColResourceId, AdColRequestId,
+	},
 }
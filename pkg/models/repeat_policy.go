@@ -0,0 +1,83 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Repeat types of the repeat policies.
+const (
+	RepeatTypeNormal       = "normal"
+	RepeatTypeNotRepeat    = "not-repeat"
+	RepeatTypeFixedMinutes = "fixed-minutes"
+	RepeatTypeExpMinutes   = "exp-minutes"
+)
+
+// SendableTimeSlack is the slack of the next sendable time, notifications are sendable when it
+// falls in the slack.
+const SendableTimeSlack = 3 * time.Second
+
+// RepeatPolicy is how the notifications of a firing resource repeat, the policy config of a
+// policy sets one for every severity.
+type RepeatPolicy struct {
+	RepeatType              string `json:"repeat_type"`
+	RepeatIntervalInitvalue uint32 `json:"repeat_interval_initvalue"`
+	MaxSendCount            uint32 `json:"max_send_count"`
+}
+
+// RepeatStatus is how often the notifications of a firing resource were sent, and when the next
+// one can be sent.
+type RepeatStatus struct {
+	CumulatedSendCount uint32
+	NextResendInterval uint32
+	NextSendableTime   time.Time
+}
+
+// ParsePolicyConfig reads the repeat policies of the severities in policyConfig, policy configs
+// which can not be read fall back to the default policies.
+func ParsePolicyConfig(policyConfig string) map[string]RepeatPolicy {
+	policies := make(map[string]RepeatPolicy)
+	err := json.Unmarshal([]byte(policyConfig), &policies)
+	if err != nil {
+		policies = make(map[string]RepeatPolicy)
+		policies["minor"] = RepeatPolicy{RepeatTypeNotRepeat, 3, 3}
+		policies["major"] = RepeatPolicy{RepeatTypeExpMinutes, 2, 5}
+		policies["critical"] = RepeatPolicy{RepeatTypeFixedMinutes, 1, 8}
+	}
+	return policies
+}
+
+// Sendable reports whether a notification can be sent at now.
+func (p RepeatPolicy) Sendable(status RepeatStatus, now time.Time) bool {
+	switch p.RepeatType {
+	case RepeatTypeNormal:
+		return true
+	case RepeatTypeNotRepeat:
+		return status.CumulatedSendCount == 0
+	case RepeatTypeFixedMinutes, RepeatTypeExpMinutes:
+		if status.CumulatedSendCount >= p.MaxSendCount {
+			return false
+		}
+		return !status.NextSendableTime.After(now.Add(SendableTimeSlack))
+	}
+	return false
+}
+
+// Repeat counts a notification sent against the policy, and returns the status with the time
+// the next one can be sent.
+func (p RepeatPolicy) Repeat(status RepeatStatus) RepeatStatus {
+	status.CumulatedSendCount = status.CumulatedSendCount + 1
+
+	switch p.RepeatType {
+	case RepeatTypeFixedMinutes:
+		status.NextSendableTime = status.NextSendableTime.Add(time.Duration(status.NextResendInterval) * time.Minute)
+	case RepeatTypeExpMinutes:
+		status.NextSendableTime = status.NextSendableTime.Add(time.Duration(status.NextResendInterval) * time.Minute)
+		status.NextResendInterval = status.NextResendInterval * 2
+	}
+	return status
+}
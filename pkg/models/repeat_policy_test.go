@@ -0,0 +1,51 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePolicyConfig(t *testing.T) {
+	policies := ParsePolicyConfig(`{"critical":{"repeat_type":"normal","repeat_interval_initvalue":1,"max_send_count":2}}`)
+	require.Equal(t, map[string]RepeatPolicy{"critical": {RepeatTypeNormal, 1, 2}}, policies)
+
+	// policy configs which can not be read fall back to the default policies
+	policies = ParsePolicyConfig("")
+	require.Equal(t, RepeatPolicy{RepeatTypeNotRepeat, 3, 3}, policies["minor"])
+	require.Equal(t, RepeatPolicy{RepeatTypeExpMinutes, 2, 5}, policies["major"])
+	require.Equal(t, RepeatPolicy{RepeatTypeFixedMinutes, 1, 8}, policies["critical"])
+}
+
+func TestRepeatPolicy(t *testing.T) {
+	now := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	policy := RepeatPolicy{RepeatTypeExpMinutes, 2, 3}
+	status := RepeatStatus{NextResendInterval: 2, NextSendableTime: now}
+	require.True(t, policy.Sendable(status, now))
+
+	status = policy.Repeat(status)
+	require.Equal(t, RepeatStatus{CumulatedSendCount: 1, NextResendInterval: 4, NextSendableTime: now.Add(2 * time.Minute)}, status)
+	require.False(t, policy.Sendable(status, now.Add(time.Minute)))
+	// the next sendable time falls in the slack
+	require.True(t, policy.Sendable(status, now.Add(2*time.Minute-SendableTimeSlack)))
+
+	status.CumulatedSendCount = 3
+	require.False(t, policy.Sendable(status, now.Add(time.Hour)))
+
+	policy = RepeatPolicy{RepeatTypeFixedMinutes, 1, 8}
+	status = policy.Repeat(RepeatStatus{NextResendInterval: 1, NextSendableTime: now})
+	require.Equal(t, RepeatStatus{CumulatedSendCount: 1, NextResendInterval: 1, NextSendableTime: now.Add(time.Minute)}, status)
+
+	policy = RepeatPolicy{RepeatType: RepeatTypeNotRepeat}
+	require.True(t, policy.Sendable(RepeatStatus{}, now))
+	require.False(t, policy.Sendable(policy.Repeat(RepeatStatus{}), now.Add(time.Hour)))
+
+	require.True(t, RepeatPolicy{RepeatType: RepeatTypeNormal}.Sendable(RepeatStatus{CumulatedSendCount: 100}, now))
+	require.False(t, RepeatPolicy{RepeatType: "unknown"}.Sendable(RepeatStatus{}, now))
+}
@@ -0,0 +1,68 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package monitoring
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"kubesphere.io/alert/pkg/logger"
+)
+
+type LogLevel struct {
+	Level         string `json:"level"`
+	PackageLevels string `json:"package_levels"`
+}
+
+type AdminError struct {
+	Error string `json:"error"`
+}
+
+// WriteJSON writes v as the json body of an admin response.
+func WriteJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		logger.Error(nil, "Write admin response failed: %+v", err)
+	}
+}
+
+func getLogLevel() LogLevel {
+	return LogLevel{
+		Level:         logger.GetLevel().String(),
+		PackageLevels: logger.GetPackageLevels(),
+	}
+}
+
+// serveLogLevel shows the log levels on GET, and changes them on PUT or POST, eg.
+// curl -X PUT 'http://127.0.0.1:9203/admin/loglevel?level=debug&package_levels=services/executor=debug'
+func serveLogLevel(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		query := r.URL.Query()
+		if level, ok := query["level"]; ok {
+			if _, err := logger.ParseLevel(level[0]); err != nil {
+				WriteJSON(w, http.StatusBadRequest, AdminError{Error: err.Error()})
+				return
+			}
+			logger.SetLevelByString(level[0])
+		}
+		if packageLevels, ok := query["package_levels"]; ok {
+			if err := logger.SetPackageLevels(packageLevels[0]); err != nil {
+				WriteJSON(w, http.StatusBadRequest, AdminError{Error: err.Error()})
+				return
+			}
+		}
+		logger.Info(nil, "Log level changed to %+v", getLogLevel())
+	default:
+		WriteJSON(w, http.StatusMethodNotAllowed, AdminError{Error: "method not allowed"})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, getLogLevel())
+}
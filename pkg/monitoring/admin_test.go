@@ -0,0 +1,66 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package monitoring
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/logger"
+)
+
+func serveTestLogLevel(t *testing.T, method string, url string) (int, map[string]string) {
+	w := httptest.NewRecorder()
+	serveLogLevel(w, httptest.NewRequest(method, url, nil))
+
+	body := make(map[string]string)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return w.Code, body
+}
+
+func TestServeLogLevel(t *testing.T) {
+	level := logger.GetLevel().String()
+	defer logger.SetLevelByString(level)
+	defer logger.SetPackageLevels("")
+
+	code, body := serveTestLogLevel(t, http.MethodPut, "/admin/loglevel?level=debug&package_levels=services/executor=warn")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "debug", body["level"])
+	require.Equal(t, "services/executor=warning", body["package_levels"])
+
+	code, body = serveTestLogLevel(t, http.MethodGet, "/admin/loglevel")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "debug", body["level"])
+
+	// invalid levels are rejected and change nothing
+	code, body = serveTestLogLevel(t, http.MethodPut, "/admin/loglevel?level=loud")
+	require.Equal(t, http.StatusBadRequest, code)
+	require.NotEmpty(t, body["error"])
+	code, _ = serveTestLogLevel(t, http.MethodPut, "/admin/loglevel?package_levels=services/executor")
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Equal(t, "debug", logger.GetLevel().String())
+	require.Equal(t, "services/executor=warning", logger.GetPackageLevels())
+
+	code, _ = serveTestLogLevel(t, http.MethodDelete, "/admin/loglevel")
+	require.Equal(t, http.StatusMethodNotAllowed, code)
+}
+
+func TestAdminHandlersNotOnMonitoringPort(t *testing.T) {
+	HandleAdmin("/admin/test", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	w := httptest.NewRecorder()
+	adminMux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/test", nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/test", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,184 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"kubesphere.io/alert/pkg/config"
+	"kubesphere.io/alert/pkg/logger"
+)
+
+const (
+	// each check of a probe is bounded by this timeout
+	DefaultCheckTimeout = 2 * time.Second
+
+	CheckStatusOk     = "ok"
+	CheckStatusFailed = "failed"
+)
+
+// CheckFunc returns nil when the dependency it checks is usable.
+type CheckFunc func(ctx context.Context) error
+
+type healthCheck struct {
+	name  string
+	check CheckFunc
+}
+
+type health struct {
+	sync.RWMutex
+	livenessChecks  []healthCheck
+	readinessChecks []healthCheck
+}
+
+type HealthStatus struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+var healthChecks = &health{}
+
+// AddLivenessCheck adds a check to /healthz and /readyz. A failed liveness check
+// means the process can not recover by itself and should be restarted.
+func AddLivenessCheck(name string, check CheckFunc) {
+	healthChecks.Lock()
+	healthChecks.livenessChecks = append(healthChecks.livenessChecks, healthCheck{name: name, check: check})
+	healthChecks.Unlock()
+}
+
+// AddReadinessCheck adds a check to /readyz only.
+func AddReadinessCheck(name string, check CheckFunc) {
+	healthChecks.Lock()
+	healthChecks.readinessChecks = append(healthChecks.readinessChecks, healthCheck{name: name, check: check})
+	healthChecks.Unlock()
+}
+
+func runChecks(checks []healthCheck) (HealthStatus, bool) {
+	status := HealthStatus{
+		Status: CheckStatusOk,
+		Checks: make(map[string]string),
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for _, hc := range checks {
+		wg.Add(1)
+		go func(hc healthCheck) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), DefaultCheckTimeout)
+			err := hc.check(ctx)
+			cancel()
+
+			mutex.Lock()
+			if err != nil {
+				status.Status = CheckStatusFailed
+				status.Checks[hc.name] = err.Error()
+			} else {
+				status.Checks[hc.name] = CheckStatusOk
+			}
+			mutex.Unlock()
+		}(hc)
+	}
+	wg.Wait()
+
+	return status, status.Status == CheckStatusOk
+}
+
+func writeHealthStatus(w http.ResponseWriter, status HealthStatus, ok bool) {
+	w.Header().Set("Content-Type", "application/json")
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	err := json.NewEncoder(w).Encode(status)
+	if err != nil {
+		logger.Error(nil, "Write health status [%+v] failed: %+v", status, err)
+	}
+}
+
+func serveHealthz(w http.ResponseWriter, r *http.Request) {
+	healthChecks.RLock()
+	checks := append([]healthCheck{}, healthChecks.livenessChecks...)
+	healthChecks.RUnlock()
+
+	status, ok := runChecks(checks)
+	if !ok {
+		logger.Error(nil, "Liveness check failed: %+v", status.Checks)
+	}
+	writeHealthStatus(w, status, ok)
+}
+
+func serveReadyz(w http.ResponseWriter, r *http.Request) {
+	healthChecks.RLock()
+	checks := append([]healthCheck{}, healthChecks.livenessChecks...)
+	checks = append(checks, healthChecks.readinessChecks...)
+	healthChecks.RUnlock()
+
+	status, ok := runChecks(checks)
+	if !ok {
+		logger.Debug(nil, "Readiness check failed: %+v", status.Checks)
+	}
+	writeHealthStatus(w, status, ok)
+}
+
+// CheckQueue checks that the configured queue server accepts connections.
+func CheckQueue(ctx context.Context) error {
+	addr := config.GetInstance().Queue.Addr
+
+	//Queue address is either an url like redis://host:port or comma-separated etcd endpoints
+	if strings.Contains(addr, "://") {
+		u, err := url.Parse(addr)
+		if err != nil {
+			return err
+		}
+		addr = u.Host
+	} else {
+		addr = strings.Split(addr, ",")[0]
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// CheckGrpcConn returns an error unless conn gets ready before ctx is done. Connections
+// still connecting are waited for, as they fail no sooner than their first RPC does.
+func CheckGrpcConn(ctx context.Context, conn *grpc.ClientConn) error {
+	if conn == nil {
+		return fmt.Errorf("connection not initialized")
+	}
+
+	for {
+		state := conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.Shutdown:
+			return fmt.Errorf("connection state %s", state)
+		}
+
+		if !conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("connection state %s: %v", state, ctx.Err())
+		}
+	}
+}
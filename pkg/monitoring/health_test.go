@@ -0,0 +1,88 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+func withTestHealthChecks(t *testing.T) func() {
+	saved := healthChecks
+	healthChecks = &health{}
+	return func() { healthChecks = saved }
+}
+
+func serveTestHealth(t *testing.T, serve http.HandlerFunc) (int, HealthStatus) {
+	w := httptest.NewRecorder()
+	serve(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var status HealthStatus
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	return w.Code, status
+}
+
+func TestServeHealth(t *testing.T) {
+	defer withTestHealthChecks(t)()
+
+	ok := func(ctx context.Context) error { return nil }
+	AddLivenessCheck("process", ok)
+	AddReadinessCheck("queue", func(ctx context.Context) error { return fmt.Errorf("queue down") })
+
+	code, status := serveTestHealth(t, serveHealthz)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, HealthStatus{Status: CheckStatusOk, Checks: map[string]string{"process": CheckStatusOk}}, status)
+
+	// readiness runs the liveness checks too
+	code, status = serveTestHealth(t, serveReadyz)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, HealthStatus{Status: CheckStatusFailed, Checks: map[string]string{"process": CheckStatusOk, "queue": "queue down"}}, status)
+}
+
+func TestRunChecksTimeout(t *testing.T) {
+	check := healthCheck{name: "slow", check: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+
+	status, ok := runChecks([]healthCheck{check})
+	require.False(t, ok)
+	require.Equal(t, context.DeadlineExceeded.Error(), status.Checks["slow"])
+}
+
+func TestCheckGrpcConn(t *testing.T) {
+	require.Error(t, CheckGrpcConn(context.Background(), nil))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	go server.Serve(lis)
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultCheckTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, lis.Addr().String(), grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	require.NoError(t, CheckGrpcConn(ctx, conn))
+
+	// a connection to a server gone is not ready, however long it keeps connecting
+	server.Stop()
+	require.True(t, conn.WaitForStateChange(ctx, connectivity.Ready))
+	ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	require.Error(t, CheckGrpcConn(ctx, conn))
+
+	require.NoError(t, conn.Close())
+	require.Error(t, CheckGrpcConn(context.Background(), conn))
+}
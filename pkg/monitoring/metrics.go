@@ -0,0 +1,138 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package monitoring
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	Namespace = "alert"
+)
+
+var (
+	GrpcRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "Number of gRPC requests handled, by method and status code.",
+		},
+		[]string{"method", "code"},
+	)
+
+	GrpcRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of gRPC requests, by method.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"method"},
+	)
+
+	EvaluationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "executor",
+			Name:      "evaluation_duration_seconds",
+			Help:      "Duration of alert rule evaluations, by evaluation state.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"state"},
+	)
+
+	AdapterRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "adapter",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of adapter requests, by api.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"api"},
+	)
+
+	AdapterErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "adapter",
+			Name:      "errors_total",
+			Help:      "Number of failed adapter requests, by api.",
+		},
+		[]string{"api"},
+	)
+
+	NotificationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "notification",
+			Name:      "sent_total",
+			Help:      "Number of notifications sent, by channel and result.",
+		},
+		[]string{"channel", "result"},
+	)
+
+	MigrationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "watcher",
+			Name:      "migrations_total",
+			Help:      "Number of alerts written back to the queue by the watcher, by reason.",
+		},
+		[]string{"reason"},
+	)
+
+	FiringResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: "executor",
+			Name:      "firing_resources",
+			Help:      "Number of resources in alerting state on this executor, by severity.",
+		},
+		[]string{"severity"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		GrpcRequestsTotal,
+		GrpcRequestDuration,
+		EvaluationDuration,
+		AdapterRequestDuration,
+		AdapterErrorsTotal,
+		NotificationsTotal,
+		MigrationsTotal,
+		FiringResources,
+	)
+}
+
+// RegisterGaugeFunc exposes a value owned by a component, such as the runner
+// count of an executor, which is read at scrape time.
+func RegisterGaugeFunc(subsystem string, name string, help string, f func() float64) {
+	prometheus.MustRegister(prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: subsystem,
+			Name:      name,
+			Help:      help,
+		},
+		f,
+	))
+}
+
+// RegisterCounterFunc is RegisterGaugeFunc for values which only increase.
+func RegisterCounterFunc(subsystem string, name string, help string, f func() float64) {
+	prometheus.MustRegister(prometheus.NewCounterFunc(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: subsystem,
+			Name:      name,
+			Help:      help,
+		},
+		f,
+	))
+}
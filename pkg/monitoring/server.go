@@ -0,0 +1,64 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package monitoring
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"kubesphere.io/alert/pkg/config"
+	"kubesphere.io/alert/pkg/logger"
+)
+
+var mux = http.NewServeMux()
+
+// adminMux serves the admin handlers, which change the running process, so they are not
+// exposed on the monitoring port scraped from other pods.
+var adminMux = http.NewServeMux()
+
+// Handle registers an extra handler on the monitoring http server.
+func Handle(pattern string, handler http.Handler) {
+	mux.Handle(pattern, handler)
+}
+
+// HandleAdmin registers an admin handler on the admin http server, which listens on the
+// loopback interface only.
+func HandleAdmin(pattern string, handler http.Handler) {
+	adminMux.Handle(pattern, handler)
+}
+
+// Serve starts the monitoring http server of the current run mode, it serves
+// /metrics, /healthz, /readyz and the handlers registered with Handle. The admin
+// http server serves /admin/loglevel and the handlers registered with HandleAdmin.
+func Serve() {
+	cfg := config.GetInstance()
+
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", serveHealthz)
+	mux.HandleFunc("/readyz", serveReadyz)
+	adminMux.HandleFunc("/admin/loglevel", serveLogLevel)
+
+	go serveAdmin(cfg.App.AdminPort)
+
+	listen := fmt.Sprintf(":%s", cfg.App.MetricsPort)
+	logger.Info(nil, "Monitoring service start http://%s%s/metrics", cfg.App.Host, listen)
+
+	err := http.ListenAndServe(listen, mux)
+	if err != nil {
+		logger.Error(nil, "Monitoring service exit: %+v", err)
+	}
+}
+
+func serveAdmin(port string) {
+	listen := fmt.Sprintf("127.0.0.1:%s", port)
+	logger.Info(nil, "Admin service start http://%s/admin", listen)
+
+	err := http.ListenAndServe(listen, adminMux)
+	if err != nil {
+		logger.Error(nil, "Admin service exit: %+v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package notification
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+)
+
+// AlertmanagerWebhookVersion is the version of the Alertmanager webhook payload.
+const AlertmanagerWebhookVersion = "4"
+
+const (
+	AlertStatusFiring   = "firing"
+	AlertStatusResolved = "resolved"
+)
+
+// AlertmanagerMessage is the payload Alertmanager posts to webhook receivers.
+type AlertmanagerMessage struct {
+	Version           string              `json:"version"`
+	GroupKey          string              `json:"groupKey"`
+	TruncatedAlerts   int                 `json:"truncatedAlerts"`
+	Status            string              `json:"status"`
+	Receiver          string              `json:"receiver"`
+	GroupLabels       map[string]string   `json:"groupLabels"`
+	CommonLabels      map[string]string   `json:"commonLabels"`
+	CommonAnnotations map[string]string   `json:"commonAnnotations"`
+	ExternalURL       string              `json:"externalURL"`
+	Alerts            []AlertmanagerAlert `json:"alerts"`
+}
+
+// AlertmanagerAlert is an alert of an Alertmanager webhook payload. Alerts posted to Alertmanager
+// have the same fields, but status and fingerprint.
+type AlertmanagerAlert struct {
+	Status       string            `json:"status,omitempty"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint,omitempty"`
+}
+
+// AlertmanagerFingerprint identifies the alert of labels as Prometheus does, hashing the sorted
+// label names and values with FNV-1a.
+func AlertmanagerFingerprint(labels map[string]string) string {
+	names := []string{}
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const separator = byte(255)
+	h := fnv.New64a()
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write([]byte{separator})
+		h.Write([]byte(labels[name]))
+		h.Write([]byte{separator})
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
@@ -37,6 +37,8 @@ type Executor struct {
 	Status               string               `protobuf:"bytes,3,opt,name=status,proto3" json:"status"`
 	CreateTime           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time"`
 	UpdateTime           *timestamp.Timestamp `protobuf:"bytes,5,opt,name=update_time,json=updateTime,proto3" json:"update_time"`
+	TaskCount            uint32               `protobuf:"varint,6,opt,name=task_count,json=taskCount,proto3" json:"task_count"`
+	AlertId              []string             `protobuf:"bytes,7,rep,name=alert_id,json=alertId,proto3" json:"alert_id"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -102,6 +104,20 @@ func (m *Executor) GetUpdateTime() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *Executor) GetTaskCount() uint32 {
+	if m != nil {
+		return m.TaskCount
+	}
+	return 0
+}
+
+func (m *Executor) GetAlertId() []string {
+	if m != nil {
+		return m.AlertId
+	}
+	return nil
+}
+
 type CreateExecutorRequest struct {
 	ExecutorName         string   `protobuf:"bytes,1,opt,name=executor_name,json=executorName,proto3" json:"executor_name"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2644,6 +2660,7 @@ type Rule struct {
 	UpdateTime           *timestamp.Timestamp `protobuf:"bytes,13,opt,name=update_time,json=updateTime,proto3" json:"update_time"`
 	PolicyId             string               `protobuf:"bytes,14,opt,name=policy_id,json=policyId,proto3" json:"policy_id"`
 	MetricId             string               `protobuf:"bytes,15,opt,name=metric_id,json=metricId,proto3" json:"metric_id"`
+	EvaluationInterval   uint32               `protobuf:"varint,16,opt,name=evaluation_interval,json=evaluationInterval,proto3" json:"evaluation_interval"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -2779,6 +2796,13 @@ func (m *Rule) GetMetricId() string {
 	return ""
 }
 
+func (m *Rule) GetEvaluationInterval() uint32 {
+	if m != nil {
+		return m.EvaluationInterval
+	}
+	return 0
+}
+
 type CreateRuleRequest struct {
 	RuleName             string   `protobuf:"bytes,1,opt,name=rule_name,json=ruleName,proto3" json:"rule_name"`
 	Disabled             bool     `protobuf:"varint,2,opt,name=disabled,proto3" json:"disabled"`
@@ -2792,6 +2816,7 @@ type CreateRuleRequest struct {
 	Inhibit              bool     `protobuf:"varint,10,opt,name=inhibit,proto3" json:"inhibit"`
 	PolicyId             string   `protobuf:"bytes,11,opt,name=policy_id,json=policyId,proto3" json:"policy_id"`
 	MetricId             string   `protobuf:"bytes,12,opt,name=metric_id,json=metricId,proto3" json:"metric_id"`
+	EvaluationInterval   uint32   `protobuf:"varint,13,opt,name=evaluation_interval,json=evaluationInterval,proto3" json:"evaluation_interval"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2906,6 +2931,13 @@ func (m *CreateRuleRequest) GetMetricId() string {
 	return ""
 }
 
+func (m *CreateRuleRequest) GetEvaluationInterval() uint32 {
+	if m != nil {
+		return m.EvaluationInterval
+	}
+	return 0
+}
+
 type CreateRuleResponse struct {
 	RuleId               string   `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -3179,6 +3211,7 @@ type ModifyRuleRequest struct {
 	Unit                 string   `protobuf:"bytes,9,opt,name=unit,proto3" json:"unit"`
 	ConsecutiveCount     uint32   `protobuf:"varint,10,opt,name=consecutive_count,json=consecutiveCount,proto3" json:"consecutive_count"`
 	Inhibit              bool     `protobuf:"varint,11,opt,name=inhibit,proto3" json:"inhibit"`
+	EvaluationInterval   uint32   `protobuf:"varint,12,opt,name=evaluation_interval,json=evaluationInterval,proto3" json:"evaluation_interval"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3286,6 +3319,13 @@ func (m *ModifyRuleRequest) GetInhibit() bool {
 	return false
 }
 
+func (m *ModifyRuleRequest) GetEvaluationInterval() uint32 {
+	if m != nil {
+		return m.EvaluationInterval
+	}
+	return 0
+}
+
 type ModifyRuleResponse struct {
 	RuleId               string   `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -5687,6 +5727,301 @@ func (m *DeleteActionsResponse) GetActionId() []string {
 	return nil
 }
 
+//10.Audit
+//********************************************************************************************************
+type Audit struct {
+	AuditId              string               `protobuf:"bytes,1,opt,name=audit_id,json=auditId,proto3" json:"audit_id"`
+	Actor                string               `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor"`
+	Operation            string               `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation"`
+	ResourceType         string               `protobuf:"bytes,4,opt,name=resource_type,json=resourceType,proto3" json:"resource_type"`
+	ResourceId           string               `protobuf:"bytes,5,opt,name=resource_id,json=resourceId,proto3" json:"resource_id"`
+	RequestId            string               `protobuf:"bytes,6,opt,name=request_id,json=requestId,proto3" json:"request_id"`
+	BeforeValue          string               `protobuf:"bytes,7,opt,name=before_value,json=beforeValue,proto3" json:"before_value"`
+	AfterValue           string               `protobuf:"bytes,8,opt,name=after_value,json=afterValue,proto3" json:"after_value"`
+	Diff                 string               `protobuf:"bytes,9,opt,name=diff,proto3" json:"diff"`
+	CreateTime           *timestamp.Timestamp `protobuf:"bytes,10,opt,name=create_time,json=createTime,proto3" json:"create_time"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Audit) Reset()         { *m = Audit{} }
+func (m *Audit) String() string { return proto.CompactTextString(m) }
+func (*Audit) ProtoMessage()    {}
+func (*Audit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b11b2fb4e5b6d61, []int{90}
+}
+
+func (m *Audit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Audit.Unmarshal(m, b)
+}
+func (m *Audit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Audit.Marshal(b, m, deterministic)
+}
+func (m *Audit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Audit.Merge(m, src)
+}
+func (m *Audit) XXX_Size() int {
+	return xxx_messageInfo_Audit.Size(m)
+}
+func (m *Audit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Audit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Audit proto.InternalMessageInfo
+
+func (m *Audit) GetAuditId() string {
+	if m != nil {
+		return m.AuditId
+	}
+	return ""
+}
+
+func (m *Audit) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *Audit) GetOperation() string {
+	if m != nil {
+		return m.Operation
+	}
+	return ""
+}
+
+func (m *Audit) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *Audit) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *Audit) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *Audit) GetBeforeValue() string {
+	if m != nil {
+		return m.BeforeValue
+	}
+	return ""
+}
+
+func (m *Audit) GetAfterValue() string {
+	if m != nil {
+		return m.AfterValue
+	}
+	return ""
+}
+
+func (m *Audit) GetDiff() string {
+	if m != nil {
+		return m.Diff
+	}
+	return ""
+}
+
+func (m *Audit) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+type DescribeAuditLogsRequest struct {
+	SearchWord           string               `protobuf:"bytes,1,opt,name=search_word,json=searchWord,proto3" json:"search_word"`
+	SortKey              string               `protobuf:"bytes,2,opt,name=sort_key,json=sortKey,proto3" json:"sort_key"`
+	Reverse              bool                 `protobuf:"varint,3,opt,name=reverse,proto3" json:"reverse"`
+	Offset               uint32               `protobuf:"varint,4,opt,name=offset,proto3" json:"offset"`
+	Limit                uint32               `protobuf:"varint,5,opt,name=limit,proto3" json:"limit"`
+	AuditId              []string             `protobuf:"bytes,6,rep,name=audit_id,json=auditId,proto3" json:"audit_id"`
+	Actor                []string             `protobuf:"bytes,7,rep,name=actor,proto3" json:"actor"`
+	Operation            []string             `protobuf:"bytes,8,rep,name=operation,proto3" json:"operation"`
+	ResourceType         []string             `protobuf:"bytes,9,rep,name=resource_type,json=resourceType,proto3" json:"resource_type"`
+	ResourceId           []string             `protobuf:"bytes,10,rep,name=resource_id,json=resourceId,proto3" json:"resource_id"`
+	RequestId            []string             `protobuf:"bytes,11,rep,name=request_id,json=requestId,proto3" json:"request_id"`
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,12,opt,name=start_time,json=startTime,proto3" json:"start_time"`
+	EndTime              *timestamp.Timestamp `protobuf:"bytes,13,opt,name=end_time,json=endTime,proto3" json:"end_time"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DescribeAuditLogsRequest) Reset()         { *m = DescribeAuditLogsRequest{} }
+func (m *DescribeAuditLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*DescribeAuditLogsRequest) ProtoMessage()    {}
+func (*DescribeAuditLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b11b2fb4e5b6d61, []int{91}
+}
+
+func (m *DescribeAuditLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DescribeAuditLogsRequest.Unmarshal(m, b)
+}
+func (m *DescribeAuditLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DescribeAuditLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *DescribeAuditLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DescribeAuditLogsRequest.Merge(m, src)
+}
+func (m *DescribeAuditLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_DescribeAuditLogsRequest.Size(m)
+}
+func (m *DescribeAuditLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DescribeAuditLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DescribeAuditLogsRequest proto.InternalMessageInfo
+
+func (m *DescribeAuditLogsRequest) GetSearchWord() string {
+	if m != nil {
+		return m.SearchWord
+	}
+	return ""
+}
+
+func (m *DescribeAuditLogsRequest) GetSortKey() string {
+	if m != nil {
+		return m.SortKey
+	}
+	return ""
+}
+
+func (m *DescribeAuditLogsRequest) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
+func (m *DescribeAuditLogsRequest) GetOffset() uint32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *DescribeAuditLogsRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *DescribeAuditLogsRequest) GetAuditId() []string {
+	if m != nil {
+		return m.AuditId
+	}
+	return nil
+}
+
+func (m *DescribeAuditLogsRequest) GetActor() []string {
+	if m != nil {
+		return m.Actor
+	}
+	return nil
+}
+
+func (m *DescribeAuditLogsRequest) GetOperation() []string {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+func (m *DescribeAuditLogsRequest) GetResourceType() []string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return nil
+}
+
+func (m *DescribeAuditLogsRequest) GetResourceId() []string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return nil
+}
+
+func (m *DescribeAuditLogsRequest) GetRequestId() []string {
+	if m != nil {
+		return m.RequestId
+	}
+	return nil
+}
+
+func (m *DescribeAuditLogsRequest) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *DescribeAuditLogsRequest) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+type DescribeAuditLogsResponse struct {
+	Total                uint32   `protobuf:"varint,1,opt,name=total,proto3" json:"total"`
+	AuditSet             []*Audit `protobuf:"bytes,2,rep,name=audit_set,json=auditSet,proto3" json:"audit_set"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DescribeAuditLogsResponse) Reset()         { *m = DescribeAuditLogsResponse{} }
+func (m *DescribeAuditLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*DescribeAuditLogsResponse) ProtoMessage()    {}
+func (*DescribeAuditLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b11b2fb4e5b6d61, []int{92}
+}
+
+func (m *DescribeAuditLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DescribeAuditLogsResponse.Unmarshal(m, b)
+}
+func (m *DescribeAuditLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DescribeAuditLogsResponse.Marshal(b, m, deterministic)
+}
+func (m *DescribeAuditLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DescribeAuditLogsResponse.Merge(m, src)
+}
+func (m *DescribeAuditLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_DescribeAuditLogsResponse.Size(m)
+}
+func (m *DescribeAuditLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DescribeAuditLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DescribeAuditLogsResponse proto.InternalMessageInfo
+
+func (m *DescribeAuditLogsResponse) GetTotal() uint32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *DescribeAuditLogsResponse) GetAuditSet() []*Audit {
+	if m != nil {
+		return m.AuditSet
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Executor)(nil), "kubesphere.alert.Executor")
 	proto.RegisterType((*CreateExecutorRequest)(nil), "kubesphere.alert.CreateExecutorRequest")
@@ -5778,6 +6113,9 @@ func init() {
 	proto.RegisterType((*ModifyActionResponse)(nil), "kubesphere.alert.ModifyActionResponse")
 	proto.RegisterType((*DeleteActionsRequest)(nil), "kubesphere.alert.DeleteActionsRequest")
 	proto.RegisterType((*DeleteActionsResponse)(nil), "kubesphere.alert.DeleteActionsResponse")
+	proto.RegisterType((*Audit)(nil), "kubesphere.alert.Audit")
+	proto.RegisterType((*DescribeAuditLogsRequest)(nil), "kubesphere.alert.DescribeAuditLogsRequest")
+	proto.RegisterType((*DescribeAuditLogsResponse)(nil), "kubesphere.alert.DescribeAuditLogsResponse")
 }
 
 func init() { proto.RegisterFile("alert.proto", fileDescriptor_3b11b2fb4e5b6d61) }
@@ -6098,6 +6436,9 @@ type AlertManagerClient interface {
 	DescribeActions(ctx context.Context, in *DescribeActionsRequest, opts ...grpc.CallOption) (*DescribeActionsResponse, error)
 	ModifyAction(ctx context.Context, in *ModifyActionRequest, opts ...grpc.CallOption) (*ModifyActionResponse, error)
 	DeleteActions(ctx context.Context, in *DeleteActionsRequest, opts ...grpc.CallOption) (*DeleteActionsResponse, error)
+	//10.Audit
+	//********************************************************************************************************
+	DescribeAuditLogs(ctx context.Context, in *DescribeAuditLogsRequest, opts ...grpc.CallOption) (*DescribeAuditLogsResponse, error)
 }
 
 type alertManagerClient struct {
@@ -6468,6 +6809,15 @@ func (c *alertManagerClient) DeleteActions(ctx context.Context, in *DeleteAction
 	return out, nil
 }
 
+func (c *alertManagerClient) DescribeAuditLogs(ctx context.Context, in *DescribeAuditLogsRequest, opts ...grpc.CallOption) (*DescribeAuditLogsResponse, error) {
+	out := new(DescribeAuditLogsResponse)
+	err := c.cc.Invoke(ctx, "/kubesphere.alert.AlertManager/DescribeAuditLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AlertManagerServer is the server API for AlertManager service.
 type AlertManagerServer interface {
 	//0.executor
@@ -6530,6 +6880,9 @@ type AlertManagerServer interface {
 	DescribeActions(context.Context, *DescribeActionsRequest) (*DescribeActionsResponse, error)
 	ModifyAction(context.Context, *ModifyActionRequest) (*ModifyActionResponse, error)
 	DeleteActions(context.Context, *DeleteActionsRequest) (*DeleteActionsResponse, error)
+	//10.Audit
+	//********************************************************************************************************
+	DescribeAuditLogs(context.Context, *DescribeAuditLogsRequest) (*DescribeAuditLogsResponse, error)
 }
 
 // UnimplementedAlertManagerServer can be embedded to have forward compatible implementations.
@@ -6656,6 +7009,9 @@ func (*UnimplementedAlertManagerServer) ModifyAction(ctx context.Context, req *M
 func (*UnimplementedAlertManagerServer) DeleteActions(ctx context.Context, req *DeleteActionsRequest) (*DeleteActionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteActions not implemented")
 }
+func (*UnimplementedAlertManagerServer) DescribeAuditLogs(ctx context.Context, req *DescribeAuditLogsRequest) (*DescribeAuditLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeAuditLogs not implemented")
+}
 
 func RegisterAlertManagerServer(s *grpc.Server, srv AlertManagerServer) {
 	s.RegisterService(&_AlertManager_serviceDesc, srv)
@@ -7381,6 +7737,24 @@ func _AlertManager_DeleteActions_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AlertManager_DescribeAuditLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeAuditLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertManagerServer).DescribeAuditLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubesphere.alert.AlertManager/DescribeAuditLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertManagerServer).DescribeAuditLogs(ctx, req.(*DescribeAuditLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AlertManager_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "kubesphere.alert.AlertManager",
 	HandlerType: (*AlertManagerServer)(nil),
@@ -7545,6 +7919,10 @@ var _AlertManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteActions",
 			Handler:    _AlertManager_DeleteActions_Handler,
 		},
+		{
+			MethodName: "DescribeAuditLogs",
+			Handler:    _AlertManager_DescribeAuditLogs_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "alert.proto",
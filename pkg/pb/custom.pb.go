@@ -713,6 +713,12 @@ type AlertStatus struct {
 	Resources            []*ResourceStatus    `protobuf:"bytes,13,rep,name=resources,proto3" json:"resources"`
 	CreateTime           *timestamp.Timestamp `protobuf:"bytes,14,opt,name=create_time,json=createTime,proto3" json:"create_time"`
 	UpdateTime           *timestamp.Timestamp `protobuf:"bytes,15,opt,name=update_time,json=updateTime,proto3" json:"update_time"`
+	EvaluationState      string               `protobuf:"bytes,16,opt,name=evaluation_state,json=evaluationState,proto3" json:"evaluation_state"`
+	EvaluationError      string               `protobuf:"bytes,17,opt,name=evaluation_error,json=evaluationError,proto3" json:"evaluation_error"`
+	LastSuccessTime      *timestamp.Timestamp `protobuf:"bytes,18,opt,name=last_success_time,json=lastSuccessTime,proto3" json:"last_success_time"`
+	LastErrorTime        *timestamp.Timestamp `protobuf:"bytes,19,opt,name=last_error_time,json=lastErrorTime,proto3" json:"last_error_time"`
+	ConsecutiveFailures  uint32               `protobuf:"varint,20,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures"`
+	LastDuration         uint32               `protobuf:"varint,21,opt,name=last_duration,json=lastDuration,proto3" json:"last_duration"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -848,6 +854,48 @@ func (m *AlertStatus) GetUpdateTime() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *AlertStatus) GetEvaluationState() string {
+	if m != nil {
+		return m.EvaluationState
+	}
+	return ""
+}
+
+func (m *AlertStatus) GetEvaluationError() string {
+	if m != nil {
+		return m.EvaluationError
+	}
+	return ""
+}
+
+func (m *AlertStatus) GetLastSuccessTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSuccessTime
+	}
+	return nil
+}
+
+func (m *AlertStatus) GetLastErrorTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastErrorTime
+	}
+	return nil
+}
+
+func (m *AlertStatus) GetConsecutiveFailures() uint32 {
+	if m != nil {
+		return m.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (m *AlertStatus) GetLastDuration() uint32 {
+	if m != nil {
+		return m.LastDuration
+	}
+	return 0
+}
+
 type DescribeAlertStatusRequest struct {
 	SearchWord           string   `protobuf:"bytes,1,opt,name=search_word,json=searchWord,proto3" json:"search_word"`
 	SortKey              string   `protobuf:"bytes,2,opt,name=sort_key,json=sortKey,proto3" json:"sort_key"`
@@ -1421,238 +1469,1114 @@ func (m *DescribeHistoryDetailResponse) GetHistorydetailSet() []*HistoryDetail {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*DescribeAlertsWithResourceRequest)(nil), "kubesphere.alert.DescribeAlertsWithResourceRequest")
-	proto.RegisterType((*DescribeAlertsWithResourceResponse)(nil), "kubesphere.alert.DescribeAlertsWithResourceResponse")
-	proto.RegisterType((*AlertDetail)(nil), "kubesphere.alert.AlertDetail")
-	proto.RegisterType((*DescribeAlertDetailsRequest)(nil), "kubesphere.alert.DescribeAlertDetailsRequest")
-	proto.RegisterType((*DescribeAlertDetailsResponse)(nil), "kubesphere.alert.DescribeAlertDetailsResponse")
-	proto.RegisterType((*ResourceStatus)(nil), "kubesphere.alert.ResourceStatus")
-	proto.RegisterType((*AlertStatus)(nil), "kubesphere.alert.AlertStatus")
-	proto.RegisterType((*DescribeAlertStatusRequest)(nil), "kubesphere.alert.DescribeAlertStatusRequest")
-	proto.RegisterType((*DescribeAlertStatusResponse)(nil), "kubesphere.alert.DescribeAlertStatusResponse")
-	proto.RegisterType((*HistoryDetail)(nil), "kubesphere.alert.HistoryDetail")
-	proto.RegisterType((*DescribeHistoryDetailRequest)(nil), "kubesphere.alert.DescribeHistoryDetailRequest")
-	proto.RegisterType((*DescribeHistoryDetailResponse)(nil), "kubesphere.alert.DescribeHistoryDetailResponse")
+//2.Alert Bundle
+//********************************************************************************************************
+type CreateAlertBundleRequest struct {
+	ResourceSearch       string                       `protobuf:"bytes,1,opt,name=resource_search,json=resourceSearch,proto3" json:"resource_search"`
+	RsFilter             *CreateResourceFilterRequest `protobuf:"bytes,2,opt,name=rs_filter,json=rsFilter,proto3" json:"rs_filter"`
+	Policy               *CreatePolicyRequest         `protobuf:"bytes,3,opt,name=policy,proto3" json:"policy"`
+	Action               *CreateActionRequest         `protobuf:"bytes,4,opt,name=action,proto3" json:"action"`
+	Rules                []*CreateRuleRequest         `protobuf:"bytes,5,rep,name=rules,proto3" json:"rules"`
+	Alert                *CreateAlertRequest          `protobuf:"bytes,6,opt,name=alert,proto3" json:"alert"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
 }
 
-func init() { proto.RegisterFile("custom.proto", fileDescriptor_0669528d4dffbbe2) }
-
-var fileDescriptor_0669528d4dffbbe2 = []byte{
-	// 1696 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0xcd, 0x6e, 0x23, 0xc7,
-	0x11, 0x06, 0x45, 0xfd, 0x90, 0xc5, 0x5f, 0xb5, 0xb4, 0xd2, 0x2c, 0xed, 0xf5, 0xd2, 0x5c, 0xc7,
-	0x16, 0x90, 0x15, 0xe9, 0x68, 0x9d, 0x93, 0x81, 0x00, 0xca, 0x6e, 0x0c, 0x13, 0xd9, 0x04, 0x06,
-	0x65, 0xc0, 0x40, 0x2e, 0x83, 0xd1, 0x4c, 0x93, 0x6c, 0x78, 0x38, 0x33, 0xe9, 0xee, 0xd1, 0x5a,
-	0x48, 0x4e, 0xc9, 0x1b, 0x6c, 0xde, 0x21, 0x87, 0x00, 0x41, 0x4e, 0x39, 0x06, 0x48, 0x80, 0x5c,
-	0x73, 0x08, 0x72, 0xca, 0x3d, 0x0f, 0x90, 0x47, 0x08, 0xba, 0xaa, 0x87, 0x9c, 0x19, 0x52, 0x3f,
-	0x8b, 0xf8, 0x62, 0x60, 0x4f, 0x52, 0x7f, 0x55, 0xdd, 0x53, 0x5d, 0xf5, 0xd5, 0x57, 0x33, 0x84,
-	0xa6, 0x9f, 0x2a, 0x1d, 0x2f, 0x86, 0x89, 0x8c, 0x75, 0xcc, 0xba, 0x5f, 0xa7, 0x97, 0x5c, 0x25,
-	0x73, 0x2e, 0xf9, 0xd0, 0x0b, 0xb9, 0xd4, 0xbd, 0x77, 0x67, 0x71, 0x3c, 0x0b, 0xf9, 0xc8, 0x4b,
-	0xc4, 0xc8, 0x8b, 0xa2, 0x58, 0x7b, 0x5a, 0xc4, 0x91, 0x22, 0xff, 0xde, 0x7b, 0xd6, 0x8a, 0xab,
-	0xcb, 0x74, 0x3a, 0x7a, 0x25, 0xbd, 0x24, 0xe1, 0x32, 0xb3, 0x3f, 0xc5, 0x3f, 0xfe, 0xe9, 0x8c,
-	0x47, 0xa7, 0xea, 0x95, 0x37, 0x9b, 0x71, 0x39, 0x8a, 0x13, 0x3c, 0x61, 0xc3, 0x69, 0x8f, 0xcb,
-	0xa7, 0x69, 0xb1, 0xe0, 0x4a, 0x7b, 0x8b, 0xc4, 0x3a, 0x34, 0x30, 0x26, 0x5a, 0x0c, 0xfe, 0x58,
-	0x85, 0xf7, 0x5f, 0x70, 0xe5, 0x4b, 0x71, 0xc9, 0xcf, 0x0d, 0xae, 0xbe, 0x12, 0x7a, 0x3e, 0xe1,
-	0x2a, 0x4e, 0xa5, 0xcf, 0x27, 0xfc, 0x97, 0x29, 0x57, 0x9a, 0x3d, 0x86, 0x86, 0xe2, 0x9e, 0xf4,
-	0xe7, 0xee, 0xab, 0x58, 0x06, 0x4e, 0xa5, 0x5f, 0x39, 0xa9, 0x4f, 0x80, 0xa0, 0xaf, 0x62, 0x19,
-	0xb0, 0x87, 0x50, 0x53, 0xb1, 0xd4, 0xee, 0xd7, 0xfc, 0xda, 0xd9, 0x42, 0xeb, 0x9e, 0x59, 0xff,
-	0x94, 0x5f, 0x33, 0x07, 0xf6, 0x24, 0xbf, 0xe2, 0x52, 0x71, 0xa7, 0xda, 0xaf, 0x9c, 0xd4, 0x26,
-	0xd9, 0x92, 0x1d, 0xc1, 0x6e, 0x3c, 0x9d, 0x2a, 0xae, 0x9d, 0xed, 0x7e, 0xe5, 0xa4, 0x35, 0xb1,
-	0x2b, 0x76, 0x08, 0x3b, 0xa1, 0x58, 0x08, 0xed, 0xec, 0x20, 0x4c, 0x0b, 0xf6, 0x11, 0x74, 0xa4,
-	0x0d, 0xcb, 0xa5, 0x27, 0x3b, 0xbb, 0xf8, 0xa4, 0x76, 0x06, 0x5f, 0x20, 0x6a, 0x62, 0xc1, 0x1b,
-	0xba, 0x22, 0x70, 0xf6, 0xfa, 0x55, 0x13, 0x0b, 0xae, 0xc7, 0x01, 0x7b, 0x04, 0x40, 0xa6, 0xc8,
-	0x5b, 0x70, 0xa7, 0x86, 0xc6, 0x3a, 0x22, 0x3f, 0xf7, 0x16, 0x9c, 0xf5, 0xa0, 0x16, 0x08, 0xe5,
-	0x5d, 0x86, 0x3c, 0x70, 0xea, 0xfd, 0xea, 0x49, 0x6d, 0xb2, 0x5c, 0xb3, 0xef, 0x41, 0x5b, 0xa6,
-	0x51, 0x24, 0xa2, 0x99, 0xab, 0xb4, 0xa7, 0x53, 0xe5, 0x00, 0x6e, 0x6f, 0x59, 0xf4, 0x02, 0x41,
-	0xf6, 0x0e, 0xd4, 0x93, 0x38, 0x14, 0xfe, 0xb5, 0x79, 0x7a, 0x03, 0x3d, 0x6a, 0x04, 0x8c, 0x03,
-	0xd6, 0x87, 0xa6, 0x54, 0xee, 0x54, 0x84, 0x9a, 0x4b, 0x63, 0x6f, 0xa2, 0x1d, 0xa4, 0xfa, 0x0c,
-	0xa1, 0x71, 0x60, 0x12, 0xcd, 0xbf, 0xe1, 0x7e, 0xaa, 0x63, 0x74, 0x68, 0x91, 0x43, 0x06, 0x8d,
-	0x83, 0x41, 0x02, 0x83, 0xdb, 0xca, 0xa5, 0x92, 0x38, 0x52, 0xdc, 0x64, 0x50, 0xc7, 0xda, 0x0b,
-	0xb1, 0x52, 0xad, 0x09, 0x2d, 0xd8, 0x27, 0x40, 0x77, 0x75, 0x4d, 0xca, 0xb7, 0xfa, 0xd5, 0x93,
-	0xc6, 0xd9, 0xf1, 0xb0, 0xcc, 0xd5, 0x21, 0x1e, 0x3b, 0xa1, 0x14, 0x5e, 0x70, 0x3d, 0xf8, 0xc7,
-	0x2e, 0x34, 0x10, 0x7b, 0xc1, 0xb5, 0x27, 0xc2, 0x42, 0x7a, 0x89, 0x08, 0x37, 0xa4, 0x97, 0x78,
-	0x70, 0x43, 0x7a, 0x89, 0x0a, 0xab, 0xf4, 0x7e, 0x0a, 0x0d, 0x5f, 0x72, 0x4f, 0x73, 0xd7, 0xd0,
-	0x15, 0x09, 0xd1, 0x38, 0xeb, 0x0d, 0x89, 0xcb, 0xc3, 0x8c, 0xcb, 0xc3, 0x2f, 0x33, 0x2e, 0x4f,
-	0x80, 0xdc, 0x0d, 0xb0, 0xa1, 0x36, 0x3b, 0xf8, 0xec, 0x52, 0x6d, 0xde, 0x87, 0xa6, 0xbd, 0x3f,
-	0x39, 0x11, 0x7d, 0xa8, 0x1d, 0x36, 0x95, 0x6f, 0x0f, 0xed, 0xab, 0xf2, 0x7d, 0x00, 0xed, 0x55,
-	0xf9, 0x2c, 0x83, 0x8c, 0x47, 0x33, 0x2b, 0x20, 0xde, 0xf2, 0x43, 0xe8, 0xac, 0xbc, 0x12, 0x4f,
-	0x7a, 0x0b, 0xa7, 0x6e, 0xa3, 0xb1, 0x6e, 0x5f, 0x18, 0xd0, 0x92, 0x41, 0x5f, 0x27, 0x9c, 0xce,
-	0x02, 0x6a, 0x2a, 0xa9, 0xbe, 0xbc, 0x4e, 0x38, 0x9e, 0x54, 0x22, 0x43, 0x83, 0x1c, 0x56, 0x64,
-	0x30, 0x0e, 0x36, 0x5a, 0x3c, 0xa1, 0x49, 0x0e, 0x04, 0xe1, 0x09, 0xa7, 0xc0, 0xac, 0x43, 0x80,
-	0xa4, 0x41, 0xd1, 0x70, 0x5a, 0xe8, 0xb7, 0x4f, 0x96, 0x17, 0x2b, 0x03, 0x7b, 0x02, 0x2d, 0xeb,
-	0xee, 0xc7, 0xd1, 0x54, 0xcc, 0x9c, 0x36, 0xdd, 0x8f, 0xc0, 0xe7, 0x88, 0x99, 0x7e, 0xc6, 0xd4,
-	0xc7, 0xd2, 0xe9, 0x50, 0xf9, 0xed, 0x92, 0x7d, 0x0c, 0x87, 0xde, 0x95, 0x27, 0x42, 0x53, 0x51,
-	0x93, 0x63, 0xa9, 0xa9, 0x98, 0x5d, 0x74, 0x63, 0x4b, 0xdb, 0x85, 0x31, 0x61, 0xe1, 0x9e, 0xc2,
-	0x0a, 0x75, 0x79, 0x14, 0x90, 0xff, 0x3e, 0xfa, 0x77, 0x97, 0x96, 0x9f, 0x44, 0x01, 0x7a, 0x3b,
-	0xb0, 0xb7, 0xe0, 0x5a, 0x0a, 0x5f, 0x39, 0x8c, 0xfa, 0xda, 0x2e, 0x4d, 0x22, 0x64, 0x1a, 0x72,
-	0xe5, 0xfa, 0x71, 0x1a, 0x69, 0xe7, 0x00, 0x59, 0x0f, 0x08, 0x3d, 0x37, 0x88, 0x11, 0x8f, 0x24,
-	0x56, 0x42, 0x8b, 0xab, 0xa5, 0xd3, 0x21, 0x3a, 0xb5, 0x97, 0x30, 0x39, 0x3e, 0x83, 0xa3, 0x45,
-	0xac, 0xb4, 0x2b, 0xb9, 0xcf, 0x23, 0xed, 0x12, 0x5f, 0x30, 0xaa, 0x07, 0x18, 0xd5, 0x81, 0xb1,
-	0x4e, 0xd0, 0x88, 0x4d, 0x81, 0x81, 0x7d, 0x1f, 0x58, 0x34, 0x75, 0xbd, 0x20, 0x90, 0x5c, 0x29,
-	0x37, 0x14, 0x0a, 0x9b, 0xe3, 0x08, 0x37, 0x74, 0xa2, 0xe9, 0x39, 0x19, 0x5e, 0x0a, 0xa5, 0xc7,
-	0xc1, 0xe0, 0x6f, 0x55, 0x78, 0xa7, 0xd0, 0xc2, 0xd4, 0x57, 0xea, 0xad, 0xd6, 0x7e, 0xab, 0x5a,
-	0x9b, 0xa3, 0x29, 0xc9, 0xec, 0x92, 0xa6, 0x65, 0x15, 0x6e, 0xdd, 0xa5, 0xc2, 0xed, 0x35, 0x15,
-	0xfe, 0x35, 0xbc, 0xbb, 0xb9, 0x84, 0xb7, 0xea, 0xef, 0x67, 0xd0, 0xc1, 0xfb, 0x07, 0xe8, 0x9d,
-	0x53, 0xe1, 0x47, 0x37, 0xa8, 0x30, 0x1d, 0x3b, 0x69, 0xe7, 0x76, 0x19, 0x45, 0xfe, 0xeb, 0x16,
-	0xb4, 0x33, 0xc9, 0xb7, 0xa9, 0x78, 0x02, 0xad, 0x65, 0xc1, 0x30, 0xdf, 0x15, 0xab, 0x4c, 0x16,
-	0xc4, 0x94, 0x3f, 0x81, 0x96, 0x9f, 0x4a, 0x69, 0x78, 0x1d, 0xf2, 0x2b, 0x1e, 0x5a, 0xf6, 0x34,
-	0x2d, 0xf8, 0xd2, 0x60, 0x26, 0xf7, 0x59, 0x4b, 0xd8, 0x46, 0xa9, 0xe2, 0x1d, 0x5a, 0x19, 0x4a,
-	0x7d, 0xf2, 0x31, 0x1c, 0xfa, 0xe9, 0x22, 0x0d, 0x3d, 0xcd, 0x03, 0x57, 0x99, 0xd6, 0x25, 0x67,
-	0x62, 0x17, 0x5b, 0xda, 0x2e, 0x78, 0x14, 0x2c, 0x77, 0x44, 0xfc, 0x1b, 0xd3, 0x59, 0xe8, 0x2e,
-	0x22, 0xcd, 0xe5, 0x95, 0x17, 0x5a, 0xe2, 0x31, 0x63, 0x9b, 0xa0, 0x69, 0x6c, 0x2d, 0x46, 0x1d,
-	0x70, 0x87, 0x01, 0x51, 0x21, 0xb0, 0x0f, 0x89, 0x88, 0x5d, 0x63, 0xb9, 0xb0, 0x06, 0xdb, 0x84,
-	0xfb, 0xde, 0x6c, 0x26, 0xf9, 0x0c, 0x43, 0xc2, 0x94, 0x29, 0x2b, 0xe1, 0xdd, 0x95, 0x81, 0x86,
-	0xe6, 0xe0, 0xef, 0xdb, 0x76, 0xa8, 0xd9, 0xfc, 0x1d, 0xc3, 0x9e, 0x51, 0x8b, 0xd5, 0x4c, 0xdb,
-	0x35, 0xcb, 0x71, 0x60, 0x38, 0x86, 0x86, 0xdc, 0x44, 0xab, 0x19, 0xe0, 0xce, 0x81, 0xf6, 0x11,
-	0x74, 0x16, 0x71, 0x24, 0x0c, 0x85, 0x12, 0x2e, 0x45, 0x1c, 0x28, 0x9b, 0x9b, 0xb6, 0x85, 0xbf,
-	0x20, 0xd4, 0x1c, 0xa2, 0x4c, 0x93, 0x0a, 0x7d, 0x6d, 0xc7, 0xd6, 0x72, 0x6d, 0x26, 0x96, 0x95,
-	0x38, 0x1c, 0x14, 0xd9, 0xc4, 0xb2, 0x98, 0x19, 0x14, 0xa6, 0x5e, 0x7e, 0x1c, 0x05, 0xc2, 0x08,
-	0x38, 0x39, 0xd1, 0x9d, 0x5b, 0x4b, 0x14, 0xdd, 0xde, 0x03, 0xd0, 0x73, 0xc9, 0xd5, 0x3c, 0x0e,
-	0x03, 0x65, 0xe7, 0x56, 0x0e, 0x61, 0x0c, 0xb6, 0xd3, 0x48, 0x68, 0x3b, 0xaa, 0xf0, 0x7f, 0x93,
-	0x51, 0xdf, 0xd0, 0xd9, 0x4f, 0x73, 0x6c, 0x00, 0xbc, 0x44, 0x37, 0x67, 0xa0, 0xf2, 0x3a, 0xb0,
-	0x27, 0xa2, 0xb9, 0xb8, 0x14, 0x1a, 0x07, 0x55, 0x6d, 0x92, 0x2d, 0x4d, 0x37, 0x51, 0xc0, 0x85,
-	0x29, 0x45, 0x10, 0xa6, 0xf1, 0x47, 0x50, 0xcf, 0x78, 0xaa, 0xb0, 0x1b, 0x1b, 0x67, 0xfd, 0xf5,
-	0x8e, 0x28, 0x32, 0x7e, 0xb2, 0xda, 0x52, 0x7e, 0x77, 0x68, 0xbf, 0xd1, 0xbb, 0xc3, 0xa7, 0xd0,
-	0x48, 0x93, 0x60, 0xb9, 0xb9, 0x73, 0xf7, 0x66, 0x72, 0x37, 0xc0, 0xe0, 0xdf, 0x55, 0xe8, 0x15,
-	0x84, 0xc0, 0x06, 0xf7, 0x56, 0xca, 0xbf, 0x2b, 0x52, 0x9e, 0xef, 0xfc, 0x0e, 0x1a, 0x6d, 0xe7,
-	0x0f, 0x7e, 0x55, 0x1a, 0xd3, 0x59, 0x69, 0xef, 0x25, 0xf1, 0x74, 0xd7, 0x7b, 0x48, 0xbc, 0x3d,
-	0xb5, 0x9d, 0xdb, 0x65, 0x24, 0xfe, 0x2f, 0x3b, 0xd0, 0xfa, 0x5c, 0x28, 0x1d, 0xcb, 0x6b, 0xfb,
-	0xda, 0xfd, 0x08, 0x60, 0x4e, 0xc0, 0x4a, 0xa4, 0xea, 0x16, 0x19, 0x07, 0x46, 0x29, 0x32, 0x73,
-	0x4e, 0xaa, 0x1a, 0x16, 0xc3, 0x32, 0xe5, 0x6e, 0x5a, 0xbd, 0x59, 0xe3, 0xb6, 0x4b, 0x1a, 0x77,
-	0x08, 0x3b, 0xfc, 0x8a, 0x47, 0xda, 0x6a, 0x13, 0x2d, 0x0c, 0xab, 0xa2, 0x58, 0x8b, 0xa9, 0xf0,
-	0xf1, 0xdb, 0xd3, 0x9c, 0x69, 0x59, 0x95, 0x87, 0xc7, 0x01, 0x1b, 0xc1, 0x41, 0xc1, 0xd1, 0x92,
-	0x80, 0x34, 0x8a, 0xe5, 0x4d, 0x96, 0x09, 0x79, 0x39, 0xac, 0x95, 0xe4, 0xb0, 0xfc, 0xca, 0x5c,
-	0x5f, 0x7b, 0x65, 0x5e, 0x7f, 0x45, 0x87, 0x0d, 0xaf, 0xe8, 0x25, 0x45, 0x6a, 0xac, 0x29, 0xd2,
-	0xba, 0xa8, 0x36, 0xef, 0x16, 0xd5, 0xd6, 0x8d, 0xa2, 0xda, 0xce, 0x89, 0x6a, 0xb1, 0x97, 0x3a,
-	0xe5, 0x6f, 0xa4, 0x0d, 0x5f, 0x0f, 0xdd, 0x4d, 0x5f, 0x0f, 0x6b, 0x03, 0x7f, 0x7f, 0xc3, 0xc0,
-	0x2f, 0x09, 0x23, 0xfb, 0x7f, 0x84, 0xf1, 0xe0, 0x8d, 0x84, 0xf1, 0xcf, 0xd5, 0xd5, 0x1b, 0x52,
-	0x81, 0xc7, 0xdf, 0x49, 0x69, 0x2c, 0xf6, 0x1e, 0x89, 0xe3, 0x2d, 0xbd, 0x47, 0x02, 0x59, 0xe8,
-	0xbd, 0x62, 0xd5, 0xeb, 0x65, 0x05, 0x2d, 0x74, 0x20, 0x09, 0xe4, 0x86, 0x0e, 0x24, 0x5d, 0xb4,
-	0x1d, 0x98, 0xeb, 0xe6, 0x66, 0x5e, 0xb7, 0xd6, 0x99, 0x41, 0xa2, 0x58, 0x64, 0xc6, 0x11, 0xec,
-	0xd2, 0x17, 0x0e, 0x72, 0xb3, 0x36, 0xb1, 0xab, 0xc1, 0x6f, 0x2b, 0xf0, 0xe8, 0x86, 0xba, 0xdd,
-	0xaa, 0x7b, 0x2f, 0x61, 0xdf, 0x5e, 0x77, 0xed, 0xe5, 0xf6, 0xf1, 0xba, 0xf2, 0x15, 0x4f, 0xee,
-	0x16, 0x76, 0x5e, 0x70, 0x7d, 0xf6, 0xdf, 0x1d, 0x60, 0xa8, 0x8e, 0x3f, 0xf3, 0x22, 0x6f, 0xc6,
-	0xe5, 0x73, 0xfc, 0x75, 0x8d, 0xfd, 0xb3, 0x52, 0x9a, 0xb6, 0x85, 0x1f, 0x3f, 0xd8, 0xb3, 0xf5,
-	0x07, 0xdd, 0xf9, 0xcb, 0x56, 0xef, 0x93, 0x37, 0xdb, 0x44, 0x49, 0x18, 0x7c, 0xfe, 0xfa, 0xfc,
-	0x43, 0xf6, 0x41, 0x60, 0x1d, 0xfb, 0x24, 0xde, 0xfd, 0x57, 0x42, 0xcf, 0xfb, 0x59, 0x9a, 0xfb,
-	0xc4, 0xa8, 0xdf, 0xfc, 0xeb, 0x3f, 0xbf, 0xdb, 0x7a, 0xc8, 0x8e, 0x47, 0x57, 0x3f, 0x18, 0x11,
-	0x03, 0x8c, 0x97, 0x9b, 0x79, 0xb1, 0x3f, 0x54, 0xe0, 0x70, 0xd3, 0xa7, 0x04, 0x3b, 0xbd, 0x23,
-	0xb0, 0xe2, 0x57, 0x63, 0x6f, 0x78, 0x5f, 0x77, 0x7b, 0x83, 0x67, 0xaf, 0xcf, 0x1d, 0x76, 0x54,
-	0xbc, 0x41, 0x9f, 0x2a, 0xa0, 0x30, 0x66, 0xc6, 0xba, 0xab, 0x98, 0xc9, 0xc0, 0x7e, 0x5f, 0x81,
-	0x83, 0x0d, 0x33, 0x91, 0x3d, 0xbd, 0xe3, 0xe1, 0x85, 0xb7, 0xa2, 0xde, 0xe9, 0x3d, 0xbd, 0x6d,
-	0xa4, 0x67, 0xaf, 0xcf, 0x8f, 0xd9, 0x83, 0x52, 0xa4, 0x34, 0x46, 0xd6, 0x03, 0x25, 0x9c, 0xfd,
-	0xa9, 0x02, 0x0f, 0x36, 0xd2, 0x98, 0xdd, 0x92, 0xa7, 0x4d, 0x3a, 0xd5, 0x1b, 0xdd, 0xdb, 0xdf,
-	0x86, 0xfb, 0xc3, 0xd7, 0xe7, 0x0f, 0xd9, 0xf1, 0x32, 0x5c, 0xcb, 0x6d, 0x9b, 0x5a, 0x0c, 0xf8,
-	0x80, 0xed, 0x9b, 0x80, 0x0b, 0xac, 0xff, 0xf1, 0xf6, 0x2f, 0xb6, 0x92, 0xcb, 0xcb, 0x5d, 0x94,
-	0xd5, 0x67, 0xff, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x3f, 0xb4, 0x5c, 0x7a, 0x50, 0x16, 0x00, 0x00,
+func (m *CreateAlertBundleRequest) Reset()         { *m = CreateAlertBundleRequest{} }
+func (m *CreateAlertBundleRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateAlertBundleRequest) ProtoMessage()    {}
+func (*CreateAlertBundleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0669528d4dffbbe2, []int{12}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// AlertManagerCustomClient is the client API for AlertManagerCustom service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type AlertManagerCustomClient interface {
-	//0.Alert
-	//********************************************************************************************************
-	DescribeAlertsWithResource(ctx context.Context, in *DescribeAlertsWithResourceRequest, opts ...grpc.CallOption) (*DescribeAlertsWithResourceResponse, error)
-	DescribeAlertDetails(ctx context.Context, in *DescribeAlertDetailsRequest, opts ...grpc.CallOption) (*DescribeAlertDetailsResponse, error)
-	DescribeAlertStatus(ctx context.Context, in *DescribeAlertStatusRequest, opts ...grpc.CallOption) (*DescribeAlertStatusResponse, error)
-	//1.History
-	//********************************************************************************************************
-	DescribeHistoryDetail(ctx context.Context, in *DescribeHistoryDetailRequest, opts ...grpc.CallOption) (*DescribeHistoryDetailResponse, error)
+func (m *CreateAlertBundleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAlertBundleRequest.Unmarshal(m, b)
+}
+func (m *CreateAlertBundleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAlertBundleRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateAlertBundleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAlertBundleRequest.Merge(m, src)
+}
+func (m *CreateAlertBundleRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateAlertBundleRequest.Size(m)
+}
+func (m *CreateAlertBundleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAlertBundleRequest.DiscardUnknown(m)
 }
 
-type alertManagerCustomClient struct {
-	cc *grpc.ClientConn
+var xxx_messageInfo_CreateAlertBundleRequest proto.InternalMessageInfo
+
+func (m *CreateAlertBundleRequest) GetResourceSearch() string {
+	if m != nil {
+		return m.ResourceSearch
+	}
+	return ""
 }
 
-func NewAlertManagerCustomClient(cc *grpc.ClientConn) AlertManagerCustomClient {
-	return &alertManagerCustomClient{cc}
+func (m *CreateAlertBundleRequest) GetRsFilter() *CreateResourceFilterRequest {
+	if m != nil {
+		return m.RsFilter
+	}
+	return nil
 }
 
-func (c *alertManagerCustomClient) DescribeAlertsWithResource(ctx context.Context, in *DescribeAlertsWithResourceRequest, opts ...grpc.CallOption) (*DescribeAlertsWithResourceResponse, error) {
-	out := new(DescribeAlertsWithResourceResponse)
-	err := c.cc.Invoke(ctx, "/kubesphere.alert.AlertManagerCustom/DescribeAlertsWithResource", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *CreateAlertBundleRequest) GetPolicy() *CreatePolicyRequest {
+	if m != nil {
+		return m.Policy
 	}
-	return out, nil
+	return nil
 }
 
-func (c *alertManagerCustomClient) DescribeAlertDetails(ctx context.Context, in *DescribeAlertDetailsRequest, opts ...grpc.CallOption) (*DescribeAlertDetailsResponse, error) {
-	out := new(DescribeAlertDetailsResponse)
-	err := c.cc.Invoke(ctx, "/kubesphere.alert.AlertManagerCustom/DescribeAlertDetails", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *CreateAlertBundleRequest) GetAction() *CreateActionRequest {
+	if m != nil {
+		return m.Action
 	}
-	return out, nil
+	return nil
 }
 
-func (c *alertManagerCustomClient) DescribeAlertStatus(ctx context.Context, in *DescribeAlertStatusRequest, opts ...grpc.CallOption) (*DescribeAlertStatusResponse, error) {
-	out := new(DescribeAlertStatusResponse)
-	err := c.cc.Invoke(ctx, "/kubesphere.alert.AlertManagerCustom/DescribeAlertStatus", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *CreateAlertBundleRequest) GetRules() []*CreateRuleRequest {
+	if m != nil {
+		return m.Rules
 	}
-	return out, nil
+	return nil
 }
 
-func (c *alertManagerCustomClient) DescribeHistoryDetail(ctx context.Context, in *DescribeHistoryDetailRequest, opts ...grpc.CallOption) (*DescribeHistoryDetailResponse, error) {
-	out := new(DescribeHistoryDetailResponse)
-	err := c.cc.Invoke(ctx, "/kubesphere.alert.AlertManagerCustom/DescribeHistoryDetail", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *CreateAlertBundleRequest) GetAlert() *CreateAlertRequest {
+	if m != nil {
+		return m.Alert
 	}
-	return out, nil
+	return nil
 }
 
-// AlertManagerCustomServer is the server API for AlertManagerCustom service.
-type AlertManagerCustomServer interface {
-	//0.Alert
-	//********************************************************************************************************
-	DescribeAlertsWithResource(context.Context, *DescribeAlertsWithResourceRequest) (*DescribeAlertsWithResourceResponse, error)
-	DescribeAlertDetails(context.Context, *DescribeAlertDetailsRequest) (*DescribeAlertDetailsResponse, error)
-	DescribeAlertStatus(context.Context, *DescribeAlertStatusRequest) (*DescribeAlertStatusResponse, error)
-	//1.History
-	//********************************************************************************************************
-	DescribeHistoryDetail(context.Context, *DescribeHistoryDetailRequest) (*DescribeHistoryDetailResponse, error)
+type CreateAlertBundleResponse struct {
+	AlertId              string   `protobuf:"bytes,1,opt,name=alert_id,json=alertId,proto3" json:"alert_id"`
+	RsFilterId           string   `protobuf:"bytes,2,opt,name=rs_filter_id,json=rsFilterId,proto3" json:"rs_filter_id"`
+	PolicyId             string   `protobuf:"bytes,3,opt,name=policy_id,json=policyId,proto3" json:"policy_id"`
+	ActionId             string   `protobuf:"bytes,4,opt,name=action_id,json=actionId,proto3" json:"action_id"`
+	RuleId               []string `protobuf:"bytes,5,rep,name=rule_id,json=ruleId,proto3" json:"rule_id"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// UnimplementedAlertManagerCustomServer can be embedded to have forward compatible implementations.
-type UnimplementedAlertManagerCustomServer struct {
+func (m *CreateAlertBundleResponse) Reset()         { *m = CreateAlertBundleResponse{} }
+func (m *CreateAlertBundleResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateAlertBundleResponse) ProtoMessage()    {}
+func (*CreateAlertBundleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0669528d4dffbbe2, []int{13}
 }
 
-func (*UnimplementedAlertManagerCustomServer) DescribeAlertsWithResource(ctx context.Context, req *DescribeAlertsWithResourceRequest) (*DescribeAlertsWithResourceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DescribeAlertsWithResource not implemented")
+func (m *CreateAlertBundleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAlertBundleResponse.Unmarshal(m, b)
 }
-func (*UnimplementedAlertManagerCustomServer) DescribeAlertDetails(ctx context.Context, req *DescribeAlertDetailsRequest) (*DescribeAlertDetailsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DescribeAlertDetails not implemented")
+func (m *CreateAlertBundleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAlertBundleResponse.Marshal(b, m, deterministic)
 }
-func (*UnimplementedAlertManagerCustomServer) DescribeAlertStatus(ctx context.Context, req *DescribeAlertStatusRequest) (*DescribeAlertStatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DescribeAlertStatus not implemented")
+func (m *CreateAlertBundleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAlertBundleResponse.Merge(m, src)
 }
-func (*UnimplementedAlertManagerCustomServer) DescribeHistoryDetail(ctx context.Context, req *DescribeHistoryDetailRequest) (*DescribeHistoryDetailResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DescribeHistoryDetail not implemented")
+func (m *CreateAlertBundleResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateAlertBundleResponse.Size(m)
 }
-
-func RegisterAlertManagerCustomServer(s *grpc.Server, srv AlertManagerCustomServer) {
-	s.RegisterService(&_AlertManagerCustom_serviceDesc, srv)
+func (m *CreateAlertBundleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAlertBundleResponse.DiscardUnknown(m)
 }
 
-func _AlertManagerCustom_DescribeAlertsWithResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DescribeAlertsWithResourceRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+var xxx_messageInfo_CreateAlertBundleResponse proto.InternalMessageInfo
+
+func (m *CreateAlertBundleResponse) GetAlertId() string {
+	if m != nil {
+		return m.AlertId
 	}
-	if interceptor == nil {
+	return ""
+}
+
+func (m *CreateAlertBundleResponse) GetRsFilterId() string {
+	if m != nil {
+		return m.RsFilterId
+	}
+	return ""
+}
+
+func (m *CreateAlertBundleResponse) GetPolicyId() string {
+	if m != nil {
+		return m.PolicyId
+	}
+	return ""
+}
+
+func (m *CreateAlertBundleResponse) GetActionId() string {
+	if m != nil {
+		return m.ActionId
+	}
+	return ""
+}
+
+func (m *CreateAlertBundleResponse) GetRuleId() []string {
+	if m != nil {
+		return m.RuleId
+	}
+	return nil
+}
+
+type UpdateAlertBundleRule struct {
+	RuleId               string             `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id"`
+	Rule                 *CreateRuleRequest `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *UpdateAlertBundleRule) Reset()         { *m = UpdateAlertBundleRule{} }
+func (m *UpdateAlertBundleRule) String() string { return proto.CompactTextString(m) }
+func (*UpdateAlertBundleRule) ProtoMessage()    {}
+func (*UpdateAlertBundleRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0669528d4dffbbe2, []int{14}
+}
+
+func (m *UpdateAlertBundleRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateAlertBundleRule.Unmarshal(m, b)
+}
+func (m *UpdateAlertBundleRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateAlertBundleRule.Marshal(b, m, deterministic)
+}
+func (m *UpdateAlertBundleRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateAlertBundleRule.Merge(m, src)
+}
+func (m *UpdateAlertBundleRule) XXX_Size() int {
+	return xxx_messageInfo_UpdateAlertBundleRule.Size(m)
+}
+func (m *UpdateAlertBundleRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateAlertBundleRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateAlertBundleRule proto.InternalMessageInfo
+
+func (m *UpdateAlertBundleRule) GetRuleId() string {
+	if m != nil {
+		return m.RuleId
+	}
+	return ""
+}
+
+func (m *UpdateAlertBundleRule) GetRule() *CreateRuleRequest {
+	if m != nil {
+		return m.Rule
+	}
+	return nil
+}
+
+type UpdateAlertBundleRequest struct {
+	AlertId              string                       `protobuf:"bytes,1,opt,name=alert_id,json=alertId,proto3" json:"alert_id"`
+	ResourceSearch       string                       `protobuf:"bytes,2,opt,name=resource_search,json=resourceSearch,proto3" json:"resource_search"`
+	RsFilter             *CreateResourceFilterRequest `protobuf:"bytes,3,opt,name=rs_filter,json=rsFilter,proto3" json:"rs_filter"`
+	Policy               *CreatePolicyRequest         `protobuf:"bytes,4,opt,name=policy,proto3" json:"policy"`
+	Action               *CreateActionRequest         `protobuf:"bytes,5,opt,name=action,proto3" json:"action"`
+	Rules                []*UpdateAlertBundleRule     `protobuf:"bytes,6,rep,name=rules,proto3" json:"rules"`
+	Alert                *CreateAlertRequest          `protobuf:"bytes,7,opt,name=alert,proto3" json:"alert"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *UpdateAlertBundleRequest) Reset()         { *m = UpdateAlertBundleRequest{} }
+func (m *UpdateAlertBundleRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateAlertBundleRequest) ProtoMessage()    {}
+func (*UpdateAlertBundleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0669528d4dffbbe2, []int{15}
+}
+
+func (m *UpdateAlertBundleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateAlertBundleRequest.Unmarshal(m, b)
+}
+func (m *UpdateAlertBundleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateAlertBundleRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateAlertBundleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateAlertBundleRequest.Merge(m, src)
+}
+func (m *UpdateAlertBundleRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateAlertBundleRequest.Size(m)
+}
+func (m *UpdateAlertBundleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateAlertBundleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateAlertBundleRequest proto.InternalMessageInfo
+
+func (m *UpdateAlertBundleRequest) GetAlertId() string {
+	if m != nil {
+		return m.AlertId
+	}
+	return ""
+}
+
+func (m *UpdateAlertBundleRequest) GetResourceSearch() string {
+	if m != nil {
+		return m.ResourceSearch
+	}
+	return ""
+}
+
+func (m *UpdateAlertBundleRequest) GetRsFilter() *CreateResourceFilterRequest {
+	if m != nil {
+		return m.RsFilter
+	}
+	return nil
+}
+
+func (m *UpdateAlertBundleRequest) GetPolicy() *CreatePolicyRequest {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (m *UpdateAlertBundleRequest) GetAction() *CreateActionRequest {
+	if m != nil {
+		return m.Action
+	}
+	return nil
+}
+
+func (m *UpdateAlertBundleRequest) GetRules() []*UpdateAlertBundleRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *UpdateAlertBundleRequest) GetAlert() *CreateAlertRequest {
+	if m != nil {
+		return m.Alert
+	}
+	return nil
+}
+
+type UpdateAlertBundleResponse struct {
+	AlertId              string   `protobuf:"bytes,1,opt,name=alert_id,json=alertId,proto3" json:"alert_id"`
+	RsFilterId           string   `protobuf:"bytes,2,opt,name=rs_filter_id,json=rsFilterId,proto3" json:"rs_filter_id"`
+	PolicyId             string   `protobuf:"bytes,3,opt,name=policy_id,json=policyId,proto3" json:"policy_id"`
+	ActionId             string   `protobuf:"bytes,4,opt,name=action_id,json=actionId,proto3" json:"action_id"`
+	RuleId               []string `protobuf:"bytes,5,rep,name=rule_id,json=ruleId,proto3" json:"rule_id"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateAlertBundleResponse) Reset()         { *m = UpdateAlertBundleResponse{} }
+func (m *UpdateAlertBundleResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateAlertBundleResponse) ProtoMessage()    {}
+func (*UpdateAlertBundleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0669528d4dffbbe2, []int{16}
+}
+
+func (m *UpdateAlertBundleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateAlertBundleResponse.Unmarshal(m, b)
+}
+func (m *UpdateAlertBundleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateAlertBundleResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateAlertBundleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateAlertBundleResponse.Merge(m, src)
+}
+func (m *UpdateAlertBundleResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateAlertBundleResponse.Size(m)
+}
+func (m *UpdateAlertBundleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateAlertBundleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateAlertBundleResponse proto.InternalMessageInfo
+
+func (m *UpdateAlertBundleResponse) GetAlertId() string {
+	if m != nil {
+		return m.AlertId
+	}
+	return ""
+}
+
+func (m *UpdateAlertBundleResponse) GetRsFilterId() string {
+	if m != nil {
+		return m.RsFilterId
+	}
+	return ""
+}
+
+func (m *UpdateAlertBundleResponse) GetPolicyId() string {
+	if m != nil {
+		return m.PolicyId
+	}
+	return ""
+}
+
+func (m *UpdateAlertBundleResponse) GetActionId() string {
+	if m != nil {
+		return m.ActionId
+	}
+	return ""
+}
+
+func (m *UpdateAlertBundleResponse) GetRuleId() []string {
+	if m != nil {
+		return m.RuleId
+	}
+	return nil
+}
+
+type ExportAlertsRequest struct {
+	ResourceSearch       string   `protobuf:"bytes,1,opt,name=resource_search,json=resourceSearch,proto3" json:"resource_search"`
+	AlertName            []string `protobuf:"bytes,2,rep,name=alert_name,json=alertName,proto3" json:"alert_name"`
+	Format               string   `protobuf:"bytes,3,opt,name=format,proto3" json:"format"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportAlertsRequest) Reset()         { *m = ExportAlertsRequest{} }
+func (m *ExportAlertsRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportAlertsRequest) ProtoMessage()    {}
+func (*ExportAlertsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0669528d4dffbbe2, []int{17}
+}
+
+func (m *ExportAlertsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportAlertsRequest.Unmarshal(m, b)
+}
+func (m *ExportAlertsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportAlertsRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportAlertsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportAlertsRequest.Merge(m, src)
+}
+func (m *ExportAlertsRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportAlertsRequest.Size(m)
+}
+func (m *ExportAlertsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportAlertsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportAlertsRequest proto.InternalMessageInfo
+
+func (m *ExportAlertsRequest) GetResourceSearch() string {
+	if m != nil {
+		return m.ResourceSearch
+	}
+	return ""
+}
+
+func (m *ExportAlertsRequest) GetAlertName() []string {
+	if m != nil {
+		return m.AlertName
+	}
+	return nil
+}
+
+func (m *ExportAlertsRequest) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+type ExportAlertsResponse struct {
+	Document             string   `protobuf:"bytes,1,opt,name=document,proto3" json:"document"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportAlertsResponse) Reset()         { *m = ExportAlertsResponse{} }
+func (m *ExportAlertsResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportAlertsResponse) ProtoMessage()    {}
+func (*ExportAlertsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0669528d4dffbbe2, []int{18}
+}
+
+func (m *ExportAlertsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportAlertsResponse.Unmarshal(m, b)
+}
+func (m *ExportAlertsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportAlertsResponse.Marshal(b, m, deterministic)
+}
+func (m *ExportAlertsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportAlertsResponse.Merge(m, src)
+}
+func (m *ExportAlertsResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportAlertsResponse.Size(m)
+}
+func (m *ExportAlertsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportAlertsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportAlertsResponse proto.InternalMessageInfo
+
+func (m *ExportAlertsResponse) GetDocument() string {
+	if m != nil {
+		return m.Document
+	}
+	return ""
+}
+
+type ImportAlertsRequest struct {
+	ResourceSearch       string   `protobuf:"bytes,1,opt,name=resource_search,json=resourceSearch,proto3" json:"resource_search"`
+	Document             string   `protobuf:"bytes,2,opt,name=document,proto3" json:"document"`
+	DryRun               bool     `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportAlertsRequest) Reset()         { *m = ImportAlertsRequest{} }
+func (m *ImportAlertsRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportAlertsRequest) ProtoMessage()    {}
+func (*ImportAlertsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0669528d4dffbbe2, []int{19}
+}
+
+func (m *ImportAlertsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportAlertsRequest.Unmarshal(m, b)
+}
+func (m *ImportAlertsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportAlertsRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportAlertsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportAlertsRequest.Merge(m, src)
+}
+func (m *ImportAlertsRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportAlertsRequest.Size(m)
+}
+func (m *ImportAlertsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportAlertsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportAlertsRequest proto.InternalMessageInfo
+
+func (m *ImportAlertsRequest) GetResourceSearch() string {
+	if m != nil {
+		return m.ResourceSearch
+	}
+	return ""
+}
+
+func (m *ImportAlertsRequest) GetDocument() string {
+	if m != nil {
+		return m.Document
+	}
+	return ""
+}
+
+func (m *ImportAlertsRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type AlertDocumentChange struct {
+	AlertName            string   `protobuf:"bytes,1,opt,name=alert_name,json=alertName,proto3" json:"alert_name"`
+	AlertId              string   `protobuf:"bytes,2,opt,name=alert_id,json=alertId,proto3" json:"alert_id"`
+	Operation            string   `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation"`
+	Diff                 []string `protobuf:"bytes,4,rep,name=diff,proto3" json:"diff"`
+	Error                string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlertDocumentChange) Reset()         { *m = AlertDocumentChange{} }
+func (m *AlertDocumentChange) String() string { return proto.CompactTextString(m) }
+func (*AlertDocumentChange) ProtoMessage()    {}
+func (*AlertDocumentChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0669528d4dffbbe2, []int{20}
+}
+
+func (m *AlertDocumentChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertDocumentChange.Unmarshal(m, b)
+}
+func (m *AlertDocumentChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertDocumentChange.Marshal(b, m, deterministic)
+}
+func (m *AlertDocumentChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertDocumentChange.Merge(m, src)
+}
+func (m *AlertDocumentChange) XXX_Size() int {
+	return xxx_messageInfo_AlertDocumentChange.Size(m)
+}
+func (m *AlertDocumentChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertDocumentChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertDocumentChange proto.InternalMessageInfo
+
+func (m *AlertDocumentChange) GetAlertName() string {
+	if m != nil {
+		return m.AlertName
+	}
+	return ""
+}
+
+func (m *AlertDocumentChange) GetAlertId() string {
+	if m != nil {
+		return m.AlertId
+	}
+	return ""
+}
+
+func (m *AlertDocumentChange) GetOperation() string {
+	if m != nil {
+		return m.Operation
+	}
+	return ""
+}
+
+func (m *AlertDocumentChange) GetDiff() []string {
+	if m != nil {
+		return m.Diff
+	}
+	return nil
+}
+
+func (m *AlertDocumentChange) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ImportAlertsResponse struct {
+	Changes              []*AlertDocumentChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes"`
+	DryRun               bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ImportAlertsResponse) Reset()         { *m = ImportAlertsResponse{} }
+func (m *ImportAlertsResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportAlertsResponse) ProtoMessage()    {}
+func (*ImportAlertsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0669528d4dffbbe2, []int{21}
+}
+
+func (m *ImportAlertsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportAlertsResponse.Unmarshal(m, b)
+}
+func (m *ImportAlertsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportAlertsResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportAlertsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportAlertsResponse.Merge(m, src)
+}
+func (m *ImportAlertsResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportAlertsResponse.Size(m)
+}
+func (m *ImportAlertsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportAlertsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportAlertsResponse proto.InternalMessageInfo
+
+func (m *ImportAlertsResponse) GetChanges() []*AlertDocumentChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *ImportAlertsResponse) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type ReceiveAlertsRequest struct {
+	Alerts               string   `protobuf:"bytes,1,opt,name=alerts,proto3" json:"alerts"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiveAlertsRequest) Reset()         { *m = ReceiveAlertsRequest{} }
+func (m *ReceiveAlertsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReceiveAlertsRequest) ProtoMessage()    {}
+func (*ReceiveAlertsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0669528d4dffbbe2, []int{22}
+}
+
+func (m *ReceiveAlertsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiveAlertsRequest.Unmarshal(m, b)
+}
+func (m *ReceiveAlertsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiveAlertsRequest.Marshal(b, m, deterministic)
+}
+func (m *ReceiveAlertsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiveAlertsRequest.Merge(m, src)
+}
+func (m *ReceiveAlertsRequest) XXX_Size() int {
+	return xxx_messageInfo_ReceiveAlertsRequest.Size(m)
+}
+func (m *ReceiveAlertsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiveAlertsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiveAlertsRequest proto.InternalMessageInfo
+
+func (m *ReceiveAlertsRequest) GetAlerts() string {
+	if m != nil {
+		return m.Alerts
+	}
+	return ""
+}
+
+type ReceivedAlert struct {
+	AlertId              string   `protobuf:"bytes,1,opt,name=alert_id,json=alertId,proto3" json:"alert_id"`
+	AlertName            string   `protobuf:"bytes,2,opt,name=alert_name,json=alertName,proto3" json:"alert_name"`
+	RuleId               string   `protobuf:"bytes,3,opt,name=rule_id,json=ruleId,proto3" json:"rule_id"`
+	ResourceName         string   `protobuf:"bytes,4,opt,name=resource_name,json=resourceName,proto3" json:"resource_name"`
+	Event                string   `protobuf:"bytes,5,opt,name=event,proto3" json:"event"`
+	AlertCreated         bool     `protobuf:"varint,6,opt,name=alert_created,json=alertCreated,proto3" json:"alert_created"`
+	RuleCreated          bool     `protobuf:"varint,7,opt,name=rule_created,json=ruleCreated,proto3" json:"rule_created"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceivedAlert) Reset()         { *m = ReceivedAlert{} }
+func (m *ReceivedAlert) String() string { return proto.CompactTextString(m) }
+func (*ReceivedAlert) ProtoMessage()    {}
+func (*ReceivedAlert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0669528d4dffbbe2, []int{23}
+}
+
+func (m *ReceivedAlert) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceivedAlert.Unmarshal(m, b)
+}
+func (m *ReceivedAlert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceivedAlert.Marshal(b, m, deterministic)
+}
+func (m *ReceivedAlert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceivedAlert.Merge(m, src)
+}
+func (m *ReceivedAlert) XXX_Size() int {
+	return xxx_messageInfo_ReceivedAlert.Size(m)
+}
+func (m *ReceivedAlert) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceivedAlert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceivedAlert proto.InternalMessageInfo
+
+func (m *ReceivedAlert) GetAlertId() string {
+	if m != nil {
+		return m.AlertId
+	}
+	return ""
+}
+
+func (m *ReceivedAlert) GetAlertName() string {
+	if m != nil {
+		return m.AlertName
+	}
+	return ""
+}
+
+func (m *ReceivedAlert) GetRuleId() string {
+	if m != nil {
+		return m.RuleId
+	}
+	return ""
+}
+
+func (m *ReceivedAlert) GetResourceName() string {
+	if m != nil {
+		return m.ResourceName
+	}
+	return ""
+}
+
+func (m *ReceivedAlert) GetEvent() string {
+	if m != nil {
+		return m.Event
+	}
+	return ""
+}
+
+func (m *ReceivedAlert) GetAlertCreated() bool {
+	if m != nil {
+		return m.AlertCreated
+	}
+	return false
+}
+
+func (m *ReceivedAlert) GetRuleCreated() bool {
+	if m != nil {
+		return m.RuleCreated
+	}
+	return false
+}
+
+type ReceiveAlertsResponse struct {
+	Alerts               []*ReceivedAlert `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ReceiveAlertsResponse) Reset()         { *m = ReceiveAlertsResponse{} }
+func (m *ReceiveAlertsResponse) String() string { return proto.CompactTextString(m) }
+func (*ReceiveAlertsResponse) ProtoMessage()    {}
+func (*ReceiveAlertsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0669528d4dffbbe2, []int{24}
+}
+
+func (m *ReceiveAlertsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiveAlertsResponse.Unmarshal(m, b)
+}
+func (m *ReceiveAlertsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiveAlertsResponse.Marshal(b, m, deterministic)
+}
+func (m *ReceiveAlertsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiveAlertsResponse.Merge(m, src)
+}
+func (m *ReceiveAlertsResponse) XXX_Size() int {
+	return xxx_messageInfo_ReceiveAlertsResponse.Size(m)
+}
+func (m *ReceiveAlertsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiveAlertsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiveAlertsResponse proto.InternalMessageInfo
+
+func (m *ReceiveAlertsResponse) GetAlerts() []*ReceivedAlert {
+	if m != nil {
+		return m.Alerts
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*DescribeAlertsWithResourceRequest)(nil), "kubesphere.alert.DescribeAlertsWithResourceRequest")
+	proto.RegisterType((*DescribeAlertsWithResourceResponse)(nil), "kubesphere.alert.DescribeAlertsWithResourceResponse")
+	proto.RegisterType((*AlertDetail)(nil), "kubesphere.alert.AlertDetail")
+	proto.RegisterType((*DescribeAlertDetailsRequest)(nil), "kubesphere.alert.DescribeAlertDetailsRequest")
+	proto.RegisterType((*DescribeAlertDetailsResponse)(nil), "kubesphere.alert.DescribeAlertDetailsResponse")
+	proto.RegisterType((*ResourceStatus)(nil), "kubesphere.alert.ResourceStatus")
+	proto.RegisterType((*AlertStatus)(nil), "kubesphere.alert.AlertStatus")
+	proto.RegisterType((*DescribeAlertStatusRequest)(nil), "kubesphere.alert.DescribeAlertStatusRequest")
+	proto.RegisterType((*DescribeAlertStatusResponse)(nil), "kubesphere.alert.DescribeAlertStatusResponse")
+	proto.RegisterType((*HistoryDetail)(nil), "kubesphere.alert.HistoryDetail")
+	proto.RegisterType((*DescribeHistoryDetailRequest)(nil), "kubesphere.alert.DescribeHistoryDetailRequest")
+	proto.RegisterType((*DescribeHistoryDetailResponse)(nil), "kubesphere.alert.DescribeHistoryDetailResponse")
+	proto.RegisterType((*CreateAlertBundleRequest)(nil), "kubesphere.alert.CreateAlertBundleRequest")
+	proto.RegisterType((*CreateAlertBundleResponse)(nil), "kubesphere.alert.CreateAlertBundleResponse")
+	proto.RegisterType((*UpdateAlertBundleRule)(nil), "kubesphere.alert.UpdateAlertBundleRule")
+	proto.RegisterType((*UpdateAlertBundleRequest)(nil), "kubesphere.alert.UpdateAlertBundleRequest")
+	proto.RegisterType((*UpdateAlertBundleResponse)(nil), "kubesphere.alert.UpdateAlertBundleResponse")
+	proto.RegisterType((*ExportAlertsRequest)(nil), "kubesphere.alert.ExportAlertsRequest")
+	proto.RegisterType((*ExportAlertsResponse)(nil), "kubesphere.alert.ExportAlertsResponse")
+	proto.RegisterType((*ImportAlertsRequest)(nil), "kubesphere.alert.ImportAlertsRequest")
+	proto.RegisterType((*AlertDocumentChange)(nil), "kubesphere.alert.AlertDocumentChange")
+	proto.RegisterType((*ImportAlertsResponse)(nil), "kubesphere.alert.ImportAlertsResponse")
+	proto.RegisterType((*ReceiveAlertsRequest)(nil), "kubesphere.alert.ReceiveAlertsRequest")
+	proto.RegisterType((*ReceivedAlert)(nil), "kubesphere.alert.ReceivedAlert")
+	proto.RegisterType((*ReceiveAlertsResponse)(nil), "kubesphere.alert.ReceiveAlertsResponse")
+}
+
+func init() { proto.RegisterFile("custom.proto", fileDescriptor_0669528d4dffbbe2) }
+
+var fileDescriptor_0669528d4dffbbe2 = []byte{
+	// 1696 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0xcd, 0x6e, 0x23, 0xc7,
+	0x11, 0x06, 0x45, 0xfd, 0x90, 0xc5, 0x5f, 0xb5, 0xb4, 0xd2, 0x2c, 0xed, 0xf5, 0xd2, 0x5c, 0xc7,
+	0x16, 0x90, 0x15, 0xe9, 0x68, 0x9d, 0x93, 0x81, 0x00, 0xca, 0x6e, 0x0c, 0x13, 0xd9, 0x04, 0x06,
+	0x65, 0xc0, 0x40, 0x2e, 0x83, 0xd1, 0x4c, 0x93, 0x6c, 0x78, 0x38, 0x33, 0xe9, 0xee, 0xd1, 0x5a,
+	0x48, 0x4e, 0xc9, 0x1b, 0x6c, 0xde, 0x21, 0x87, 0x00, 0x41, 0x4e, 0x39, 0x06, 0x48, 0x80, 0x5c,
+	0x73, 0x08, 0x72, 0xca, 0x3d, 0x0f, 0x90, 0x47, 0x08, 0xba, 0xaa, 0x87, 0x9c, 0x19, 0x52, 0x3f,
+	0x8b, 0xf8, 0x62, 0x60, 0x4f, 0x52, 0x7f, 0x55, 0xdd, 0x53, 0x5d, 0xf5, 0xd5, 0x57, 0x33, 0x84,
+	0xa6, 0x9f, 0x2a, 0x1d, 0x2f, 0x86, 0x89, 0x8c, 0x75, 0xcc, 0xba, 0x5f, 0xa7, 0x97, 0x5c, 0x25,
+	0x73, 0x2e, 0xf9, 0xd0, 0x0b, 0xb9, 0xd4, 0xbd, 0x77, 0x67, 0x71, 0x3c, 0x0b, 0xf9, 0xc8, 0x4b,
+	0xc4, 0xc8, 0x8b, 0xa2, 0x58, 0x7b, 0x5a, 0xc4, 0x91, 0x22, 0xff, 0xde, 0x7b, 0xd6, 0x8a, 0xab,
+	0xcb, 0x74, 0x3a, 0x7a, 0x25, 0xbd, 0x24, 0xe1, 0x32, 0xb3, 0x3f, 0xc5, 0x3f, 0xfe, 0xe9, 0x8c,
+	0x47, 0xa7, 0xea, 0x95, 0x37, 0x9b, 0x71, 0x39, 0x8a, 0x13, 0x3c, 0x61, 0xc3, 0x69, 0x8f, 0xcb,
+	0xa7, 0x69, 0xb1, 0xe0, 0x4a, 0x7b, 0x8b, 0xc4, 0x3a, 0x34, 0x30, 0x26, 0x5a, 0x0c, 0xfe, 0x58,
+	0x85, 0xf7, 0x5f, 0x70, 0xe5, 0x4b, 0x71, 0xc9, 0xcf, 0x0d, 0xae, 0xbe, 0x12, 0x7a, 0x3e, 0xe1,
+	0x2a, 0x4e, 0xa5, 0xcf, 0x27, 0xfc, 0x97, 0x29, 0x57, 0x9a, 0x3d, 0x86, 0x86, 0xe2, 0x9e, 0xf4,
+	0xe7, 0xee, 0xab, 0x58, 0x06, 0x4e, 0xa5, 0x5f, 0x39, 0xa9, 0x4f, 0x80, 0xa0, 0xaf, 0x62, 0x19,
+	0xb0, 0x87, 0x50, 0x53, 0xb1, 0xd4, 0xee, 0xd7, 0xfc, 0xda, 0xd9, 0x42, 0xeb, 0x9e, 0x59, 0xff,
+	0x94, 0x5f, 0x33, 0x07, 0xf6, 0x24, 0xbf, 0xe2, 0x52, 0x71, 0xa7, 0xda, 0xaf, 0x9c, 0xd4, 0x26,
+	0xd9, 0x92, 0x1d, 0xc1, 0x6e, 0x3c, 0x9d, 0x2a, 0xae, 0x9d, 0xed, 0x7e, 0xe5, 0xa4, 0x35, 0xb1,
+	0x2b, 0x76, 0x08, 0x3b, 0xa1, 0x58, 0x08, 0xed, 0xec, 0x20, 0x4c, 0x0b, 0xf6, 0x11, 0x74, 0xa4,
+	0x0d, 0xcb, 0xa5, 0x27, 0x3b, 0xbb, 0xf8, 0xa4, 0x76, 0x06, 0x5f, 0x20, 0x6a, 0x62, 0xc1, 0x1b,
+	0xba, 0x22, 0x70, 0xf6, 0xfa, 0x55, 0x13, 0x0b, 0xae, 0xc7, 0x01, 0x7b, 0x04, 0x40, 0xa6, 0xc8,
+	0x5b, 0x70, 0xa7, 0x86, 0xc6, 0x3a, 0x22, 0x3f, 0xf7, 0x16, 0x9c, 0xf5, 0xa0, 0x16, 0x08, 0xe5,
+	0x5d, 0x86, 0x3c, 0x70, 0xea, 0xfd, 0xea, 0x49, 0x6d, 0xb2, 0x5c, 0xb3, 0xef, 0x41, 0x5b, 0xa6,
+	0x51, 0x24, 0xa2, 0x99, 0xab, 0xb4, 0xa7, 0x53, 0xe5, 0x00, 0x6e, 0x6f, 0x59, 0xf4, 0x02, 0x41,
+	0xf6, 0x0e, 0xd4, 0x93, 0x38, 0x14, 0xfe, 0xb5, 0x79, 0x7a, 0x03, 0x3d, 0x6a, 0x04, 0x8c, 0x03,
+	0xd6, 0x87, 0xa6, 0x54, 0xee, 0x54, 0x84, 0x9a, 0x4b, 0x63, 0x6f, 0xa2, 0x1d, 0xa4, 0xfa, 0x0c,
+	0xa1, 0x71, 0x60, 0x12, 0xcd, 0xbf, 0xe1, 0x7e, 0xaa, 0x63, 0x74, 0x68, 0x91, 0x43, 0x06, 0x8d,
+	0x83, 0x41, 0x02, 0x83, 0xdb, 0xca, 0xa5, 0x92, 0x38, 0x52, 0xdc, 0x64, 0x50, 0xc7, 0xda, 0x0b,
+	0xb1, 0x52, 0xad, 0x09, 0x2d, 0xd8, 0x27, 0x40, 0x77, 0x75, 0x4d, 0xca, 0xb7, 0xfa, 0xd5, 0x93,
+	0xc6, 0xd9, 0xf1, 0xb0, 0xcc, 0xd5, 0x21, 0x1e, 0x3b, 0xa1, 0x14, 0x5e, 0x70, 0x3d, 0xf8, 0xc7,
+	0x2e, 0x34, 0x10, 0x7b, 0xc1, 0xb5, 0x27, 0xc2, 0x42, 0x7a, 0x89, 0x08, 0x37, 0xa4, 0x97, 0x78,
+	0x70, 0x43, 0x7a, 0x89, 0x0a, 0xab, 0xf4, 0x7e, 0x0a, 0x0d, 0x5f, 0x72, 0x4f, 0x73, 0xd7, 0xd0,
+	0x15, 0x09, 0xd1, 0x38, 0xeb, 0x0d, 0x89, 0xcb, 0xc3, 0x8c, 0xcb, 0xc3, 0x2f, 0x33, 0x2e, 0x4f,
+	0x80, 0xdc, 0x0d, 0xb0, 0xa1, 0x36, 0x3b, 0xf8, 0xec, 0x52, 0x6d, 0xde, 0x87, 0xa6, 0xbd, 0x3f,
+	0x39, 0x11, 0x7d, 0xa8, 0x1d, 0x36, 0x95, 0x6f, 0x0f, 0xed, 0xab, 0xf2, 0x7d, 0x00, 0xed, 0x55,
+	0xf9, 0x2c, 0x83, 0x8c, 0x47, 0x33, 0x2b, 0x20, 0xde, 0xf2, 0x43, 0xe8, 0xac, 0xbc, 0x12, 0x4f,
+	0x7a, 0x0b, 0xa7, 0x6e, 0xa3, 0xb1, 0x6e, 0x5f, 0x18, 0xd0, 0x92, 0x41, 0x5f, 0x27, 0x9c, 0xce,
+	0x02, 0x6a, 0x2a, 0xa9, 0xbe, 0xbc, 0x4e, 0x38, 0x9e, 0x54, 0x22, 0x43, 0x83, 0x1c, 0x56, 0x64,
+	0x30, 0x0e, 0x36, 0x5a, 0x3c, 0xa1, 0x49, 0x0e, 0x04, 0xe1, 0x09, 0xa7, 0xc0, 0xac, 0x43, 0x80,
+	0xa4, 0x41, 0xd1, 0x70, 0x5a, 0xe8, 0xb7, 0x4f, 0x96, 0x17, 0x2b, 0x03, 0x7b, 0x02, 0x2d, 0xeb,
+	0xee, 0xc7, 0xd1, 0x54, 0xcc, 0x9c, 0x36, 0xdd, 0x8f, 0xc0, 0xe7, 0x88, 0x99, 0x7e, 0xc6, 0xd4,
+	0xc7, 0xd2, 0xe9, 0x50, 0xf9, 0xed, 0x92, 0x7d, 0x0c, 0x87, 0xde, 0x95, 0x27, 0x42, 0x53, 0x51,
+	0x93, 0x63, 0xa9, 0xa9, 0x98, 0x5d, 0x74, 0x63, 0x4b, 0xdb, 0x85, 0x31, 0x61, 0xe1, 0x9e, 0xc2,
+	0x0a, 0x75, 0x79, 0x14, 0x90, 0xff, 0x3e, 0xfa, 0x77, 0x97, 0x96, 0x9f, 0x44, 0x01, 0x7a, 0x3b,
+	0xb0, 0xb7, 0xe0, 0x5a, 0x0a, 0x5f, 0x39, 0x8c, 0xfa, 0xda, 0x2e, 0x4d, 0x22, 0x64, 0x1a, 0x72,
+	0xe5, 0xfa, 0x71, 0x1a, 0x69, 0xe7, 0x00, 0x59, 0x0f, 0x08, 0x3d, 0x37, 0x88, 0x11, 0x8f, 0x24,
+	0x56, 0x42, 0x8b, 0xab, 0xa5, 0xd3, 0x21, 0x3a, 0xb5, 0x97, 0x30, 0x39, 0x3e, 0x83, 0xa3, 0x45,
+	0xac, 0xb4, 0x2b, 0xb9, 0xcf, 0x23, 0xed, 0x12, 0x5f, 0x30, 0xaa, 0x07, 0x18, 0xd5, 0x81, 0xb1,
+	0x4e, 0xd0, 0x88, 0x4d, 0x81, 0x81, 0x7d, 0x1f, 0x58, 0x34, 0x75, 0xbd, 0x20, 0x90, 0x5c, 0x29,
+	0x37, 0x14, 0x0a, 0x9b, 0xe3, 0x08, 0x37, 0x74, 0xa2, 0xe9, 0x39, 0x19, 0x5e, 0x0a, 0xa5, 0xc7,
+	0xc1, 0xe0, 0x6f, 0x55, 0x78, 0xa7, 0xd0, 0xc2, 0xd4, 0x57, 0xea, 0xad, 0xd6, 0x7e, 0xab, 0x5a,
+	0x9b, 0xa3, 0x29, 0xc9, 0xec, 0x92, 0xa6, 0x65, 0x15, 0x6e, 0xdd, 0xa5, 0xc2, 0xed, 0x35, 0x15,
+	0xfe, 0x35, 0xbc, 0xbb, 0xb9, 0x84, 0xb7, 0xea, 0xef, 0x67, 0xd0, 0xc1, 0xfb, 0x07, 0xe8, 0x9d,
+	0x53, 0xe1, 0x47, 0x37, 0xa8, 0x30, 0x1d, 0x3b, 0x69, 0xe7, 0x76, 0x19, 0x45, 0xfe, 0xeb, 0x16,
+	0xb4, 0x33, 0xc9, 0xb7, 0xa9, 0x78, 0x02, 0xad, 0x65, 0xc1, 0x30, 0xdf, 0x15, 0xab, 0x4c, 0x16,
+	0xc4, 0x94, 0x3f, 0x81, 0x96, 0x9f, 0x4a, 0x69, 0x78, 0x1d, 0xf2, 0x2b, 0x1e, 0x5a, 0xf6, 0x34,
+	0x2d, 0xf8, 0xd2, 0x60, 0x26, 0xf7, 0x59, 0x4b, 0xd8, 0x46, 0xa9, 0xe2, 0x1d, 0x5a, 0x19, 0x4a,
+	0x7d, 0xf2, 0x31, 0x1c, 0xfa, 0xe9, 0x22, 0x0d, 0x3d, 0xcd, 0x03, 0x57, 0x99, 0xd6, 0x25, 0x67,
+	0x62, 0x17, 0x5b, 0xda, 0x2e, 0x78, 0x14, 0x2c, 0x77, 0x44, 0xfc, 0x1b, 0xd3, 0x59, 0xe8, 0x2e,
+	0x22, 0xcd, 0xe5, 0x95, 0x17, 0x5a, 0xe2, 0x31, 0x63, 0x9b, 0xa0, 0x69, 0x6c, 0x2d, 0x46, 0x1d,
+	0x70, 0x87, 0x01, 0x51, 0x21, 0xb0, 0x0f, 0x89, 0x88, 0x5d, 0x63, 0xb9, 0xb0, 0x06, 0xdb, 0x84,
+	0xfb, 0xde, 0x6c, 0x26, 0xf9, 0x0c, 0x43, 0xc2, 0x94, 0x29, 0x2b, 0xe1, 0xdd, 0x95, 0x81, 0x86,
+	0xe6, 0xe0, 0xef, 0xdb, 0x76, 0xa8, 0xd9, 0xfc, 0x1d, 0xc3, 0x9e, 0x51, 0x8b, 0xd5, 0x4c, 0xdb,
+	0x35, 0xcb, 0x71, 0x60, 0x38, 0x86, 0x86, 0xdc, 0x44, 0xab, 0x19, 0xe0, 0xce, 0x81, 0xf6, 0x11,
+	0x74, 0x16, 0x71, 0x24, 0x0c, 0x85, 0x12, 0x2e, 0x45, 0x1c, 0x28, 0x9b, 0x9b, 0xb6, 0x85, 0xbf,
+	0x20, 0xd4, 0x1c, 0xa2, 0x4c, 0x93, 0x0a, 0x7d, 0x6d, 0xc7, 0xd6, 0x72, 0x6d, 0x26, 0x96, 0x95,
+	0x38, 0x1c, 0x14, 0xd9, 0xc4, 0xb2, 0x98, 0x19, 0x14, 0xa6, 0x5e, 0x7e, 0x1c, 0x05, 0xc2, 0x08,
+	0x38, 0x39, 0xd1, 0x9d, 0x5b, 0x4b, 0x14, 0xdd, 0xde, 0x03, 0xd0, 0x73, 0xc9, 0xd5, 0x3c, 0x0e,
+	0x03, 0x65, 0xe7, 0x56, 0x0e, 0x61, 0x0c, 0xb6, 0xd3, 0x48, 0x68, 0x3b, 0xaa, 0xf0, 0x7f, 0x93,
+	0x51, 0xdf, 0xd0, 0xd9, 0x4f, 0x73, 0x6c, 0x00, 0xbc, 0x44, 0x37, 0x67, 0xa0, 0xf2, 0x3a, 0xb0,
+	0x27, 0xa2, 0xb9, 0xb8, 0x14, 0x1a, 0x07, 0x55, 0x6d, 0x92, 0x2d, 0x4d, 0x37, 0x51, 0xc0, 0x85,
+	0x29, 0x45, 0x10, 0xa6, 0xf1, 0x47, 0x50, 0xcf, 0x78, 0xaa, 0xb0, 0x1b, 0x1b, 0x67, 0xfd, 0xf5,
+	0x8e, 0x28, 0x32, 0x7e, 0xb2, 0xda, 0x52, 0x7e, 0x77, 0x68, 0xbf, 0xd1, 0xbb, 0xc3, 0xa7, 0xd0,
+	0x48, 0x93, 0x60, 0xb9, 0xb9, 0x73, 0xf7, 0x66, 0x72, 0x37, 0xc0, 0xe0, 0xdf, 0x55, 0xe8, 0x15,
+	0x84, 0xc0, 0x06, 0xf7, 0x56, 0xca, 0xbf, 0x2b, 0x52, 0x9e, 0xef, 0xfc, 0x0e, 0x1a, 0x6d, 0xe7,
+	0x0f, 0x7e, 0x55, 0x1a, 0xd3, 0x59, 0x69, 0xef, 0x25, 0xf1, 0x74, 0xd7, 0x7b, 0x48, 0xbc, 0x3d,
+	0xb5, 0x9d, 0xdb, 0x65, 0x24, 0xfe, 0x2f, 0x3b, 0xd0, 0xfa, 0x5c, 0x28, 0x1d, 0xcb, 0x6b, 0xfb,
+	0xda, 0xfd, 0x08, 0x60, 0x4e, 0xc0, 0x4a, 0xa4, 0xea, 0x16, 0x19, 0x07, 0x46, 0x29, 0x32, 0x73,
+	0x4e, 0xaa, 0x1a, 0x16, 0xc3, 0x32, 0xe5, 0x6e, 0x5a, 0xbd, 0x59, 0xe3, 0xb6, 0x4b, 0x1a, 0x77,
+	0x08, 0x3b, 0xfc, 0x8a, 0x47, 0xda, 0x6a, 0x13, 0x2d, 0x0c, 0xab, 0xa2, 0x58, 0x8b, 0xa9, 0xf0,
+	0xf1, 0xdb, 0xd3, 0x9c, 0x69, 0x59, 0x95, 0x87, 0xc7, 0x01, 0x1b, 0xc1, 0x41, 0xc1, 0xd1, 0x92,
+	0x80, 0x34, 0x8a, 0xe5, 0x4d, 0x96, 0x09, 0x79, 0x39, 0xac, 0x95, 0xe4, 0xb0, 0xfc, 0xca, 0x5c,
+	0x5f, 0x7b, 0x65, 0x5e, 0x7f, 0x45, 0x87, 0x0d, 0xaf, 0xe8, 0x25, 0x45, 0x6a, 0xac, 0x29, 0xd2,
+	0xba, 0xa8, 0x36, 0xef, 0x16, 0xd5, 0xd6, 0x8d, 0xa2, 0xda, 0xce, 0x89, 0x6a, 0xb1, 0x97, 0x3a,
+	0xe5, 0x6f, 0xa4, 0x0d, 0x5f, 0x0f, 0xdd, 0x4d, 0x5f, 0x0f, 0x6b, 0x03, 0x7f, 0x7f, 0xc3, 0xc0,
+	0x2f, 0x09, 0x23, 0xfb, 0x7f, 0x84, 0xf1, 0xe0, 0x8d, 0x84, 0xf1, 0xcf, 0xd5, 0xd5, 0x1b, 0x52,
+	0x81, 0xc7, 0xdf, 0x49, 0x69, 0x2c, 0xf6, 0x1e, 0x89, 0xe3, 0x2d, 0xbd, 0x47, 0x02, 0x59, 0xe8,
+	0xbd, 0x62, 0xd5, 0xeb, 0x65, 0x05, 0x2d, 0x74, 0x20, 0x09, 0xe4, 0x86, 0x0e, 0x24, 0x5d, 0xb4,
+	0x1d, 0x98, 0xeb, 0xe6, 0x66, 0x5e, 0xb7, 0xd6, 0x99, 0x41, 0xa2, 0x58, 0x64, 0xc6, 0x11, 0xec,
+	0xd2, 0x17, 0x0e, 0x72, 0xb3, 0x36, 0xb1, 0xab, 0xc1, 0x6f, 0x2b, 0xf0, 0xe8, 0x86, 0xba, 0xdd,
+	0xaa, 0x7b, 0x2f, 0x61, 0xdf, 0x5e, 0x77, 0xed, 0xe5, 0xf6, 0xf1, 0xba, 0xf2, 0x15, 0x4f, 0xee,
+	0x16, 0x76, 0x5e, 0x70, 0x7d, 0xf6, 0xdf, 0x1d, 0x60, 0xa8, 0x8e, 0x3f, 0xf3, 0x22, 0x6f, 0xc6,
+	0xe5, 0x73, 0xfc, 0x75, 0x8d, 0xfd, 0xb3, 0x52, 0x9a, 0xb6, 0x85, 0x1f, 0x3f, 0xd8, 0xb3, 0xf5,
+	0x07, 0xdd, 0xf9, 0xcb, 0x56, 0xef, 0x93, 0x37, 0xdb, 0x44, 0x49, 0x18, 0x7c, 0xfe, 0xfa, 0xfc,
+	0x43, 0xf6, 0x41, 0x60, 0x1d, 0xfb, 0x24, 0xde, 0xfd, 0x57, 0x42, 0xcf, 0xfb, 0x59, 0x9a, 0xfb,
+	0xc4, 0xa8, 0xdf, 0xfc, 0xeb, 0x3f, 0xbf, 0xdb, 0x7a, 0xc8, 0x8e, 0x47, 0x57, 0x3f, 0x18, 0x11,
+	0x03, 0x8c, 0x97, 0x9b, 0x79, 0xb1, 0x3f, 0x54, 0xe0, 0x70, 0xd3, 0xa7, 0x04, 0x3b, 0xbd, 0x23,
+	0xb0, 0xe2, 0x57, 0x63, 0x6f, 0x78, 0x5f, 0x77, 0x7b, 0x83, 0x67, 0xaf, 0xcf, 0x1d, 0x76, 0x54,
+	0xbc, 0x41, 0x9f, 0x2a, 0xa0, 0x30, 0x66, 0xc6, 0xba, 0xab, 0x98, 0xc9, 0xc0, 0x7e, 0x5f, 0x81,
+	0x83, 0x0d, 0x33, 0x91, 0x3d, 0xbd, 0xe3, 0xe1, 0x85, 0xb7, 0xa2, 0xde, 0xe9, 0x3d, 0xbd, 0x6d,
+	0xa4, 0x67, 0xaf, 0xcf, 0x8f, 0xd9, 0x83, 0x52, 0xa4, 0x34, 0x46, 0xd6, 0x03, 0x25, 0x9c, 0xfd,
+	0xa9, 0x02, 0x0f, 0x36, 0xd2, 0x98, 0xdd, 0x92, 0xa7, 0x4d, 0x3a, 0xd5, 0x1b, 0xdd, 0xdb, 0xdf,
+	0x86, 0xfb, 0xc3, 0xd7, 0xe7, 0x0f, 0xd9, 0xf1, 0x32, 0x5c, 0xcb, 0x6d, 0x9b, 0x5a, 0x0c, 0xf8,
+	0x80, 0xed, 0x9b, 0x80, 0x0b, 0xac, 0xff, 0xf1, 0xf6, 0x2f, 0xb6, 0x92, 0xcb, 0xcb, 0x5d, 0x94,
+	0xd5, 0x67, 0xff, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x3f, 0xb4, 0x5c, 0x7a, 0x50, 0x16, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AlertManagerCustomClient is the client API for AlertManagerCustom service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AlertManagerCustomClient interface {
+	//0.Alert
+	//********************************************************************************************************
+	DescribeAlertsWithResource(ctx context.Context, in *DescribeAlertsWithResourceRequest, opts ...grpc.CallOption) (*DescribeAlertsWithResourceResponse, error)
+	DescribeAlertDetails(ctx context.Context, in *DescribeAlertDetailsRequest, opts ...grpc.CallOption) (*DescribeAlertDetailsResponse, error)
+	DescribeAlertStatus(ctx context.Context, in *DescribeAlertStatusRequest, opts ...grpc.CallOption) (*DescribeAlertStatusResponse, error)
+	//1.History
+	//********************************************************************************************************
+	DescribeHistoryDetail(ctx context.Context, in *DescribeHistoryDetailRequest, opts ...grpc.CallOption) (*DescribeHistoryDetailResponse, error)
+	//2.Alert Bundle
+	//********************************************************************************************************
+	CreateAlertBundle(ctx context.Context, in *CreateAlertBundleRequest, opts ...grpc.CallOption) (*CreateAlertBundleResponse, error)
+	UpdateAlertBundle(ctx context.Context, in *UpdateAlertBundleRequest, opts ...grpc.CallOption) (*UpdateAlertBundleResponse, error)
+	ExportAlerts(ctx context.Context, in *ExportAlertsRequest, opts ...grpc.CallOption) (*ExportAlertsResponse, error)
+	ImportAlerts(ctx context.Context, in *ImportAlertsRequest, opts ...grpc.CallOption) (*ImportAlertsResponse, error)
+	ReceiveAlerts(ctx context.Context, in *ReceiveAlertsRequest, opts ...grpc.CallOption) (*ReceiveAlertsResponse, error)
+}
+
+type alertManagerCustomClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAlertManagerCustomClient(cc *grpc.ClientConn) AlertManagerCustomClient {
+	return &alertManagerCustomClient{cc}
+}
+
+func (c *alertManagerCustomClient) DescribeAlertsWithResource(ctx context.Context, in *DescribeAlertsWithResourceRequest, opts ...grpc.CallOption) (*DescribeAlertsWithResourceResponse, error) {
+	out := new(DescribeAlertsWithResourceResponse)
+	err := c.cc.Invoke(ctx, "/kubesphere.alert.AlertManagerCustom/DescribeAlertsWithResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertManagerCustomClient) DescribeAlertDetails(ctx context.Context, in *DescribeAlertDetailsRequest, opts ...grpc.CallOption) (*DescribeAlertDetailsResponse, error) {
+	out := new(DescribeAlertDetailsResponse)
+	err := c.cc.Invoke(ctx, "/kubesphere.alert.AlertManagerCustom/DescribeAlertDetails", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertManagerCustomClient) DescribeAlertStatus(ctx context.Context, in *DescribeAlertStatusRequest, opts ...grpc.CallOption) (*DescribeAlertStatusResponse, error) {
+	out := new(DescribeAlertStatusResponse)
+	err := c.cc.Invoke(ctx, "/kubesphere.alert.AlertManagerCustom/DescribeAlertStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertManagerCustomClient) DescribeHistoryDetail(ctx context.Context, in *DescribeHistoryDetailRequest, opts ...grpc.CallOption) (*DescribeHistoryDetailResponse, error) {
+	out := new(DescribeHistoryDetailResponse)
+	err := c.cc.Invoke(ctx, "/kubesphere.alert.AlertManagerCustom/DescribeHistoryDetail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertManagerCustomClient) CreateAlertBundle(ctx context.Context, in *CreateAlertBundleRequest, opts ...grpc.CallOption) (*CreateAlertBundleResponse, error) {
+	out := new(CreateAlertBundleResponse)
+	err := c.cc.Invoke(ctx, "/kubesphere.alert.AlertManagerCustom/CreateAlertBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertManagerCustomClient) UpdateAlertBundle(ctx context.Context, in *UpdateAlertBundleRequest, opts ...grpc.CallOption) (*UpdateAlertBundleResponse, error) {
+	out := new(UpdateAlertBundleResponse)
+	err := c.cc.Invoke(ctx, "/kubesphere.alert.AlertManagerCustom/UpdateAlertBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertManagerCustomClient) ExportAlerts(ctx context.Context, in *ExportAlertsRequest, opts ...grpc.CallOption) (*ExportAlertsResponse, error) {
+	out := new(ExportAlertsResponse)
+	err := c.cc.Invoke(ctx, "/kubesphere.alert.AlertManagerCustom/ExportAlerts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertManagerCustomClient) ImportAlerts(ctx context.Context, in *ImportAlertsRequest, opts ...grpc.CallOption) (*ImportAlertsResponse, error) {
+	out := new(ImportAlertsResponse)
+	err := c.cc.Invoke(ctx, "/kubesphere.alert.AlertManagerCustom/ImportAlerts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertManagerCustomClient) ReceiveAlerts(ctx context.Context, in *ReceiveAlertsRequest, opts ...grpc.CallOption) (*ReceiveAlertsResponse, error) {
+	out := new(ReceiveAlertsResponse)
+	err := c.cc.Invoke(ctx, "/kubesphere.alert.AlertManagerCustom/ReceiveAlerts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AlertManagerCustomServer is the server API for AlertManagerCustom service.
+type AlertManagerCustomServer interface {
+	//0.Alert
+	//********************************************************************************************************
+	DescribeAlertsWithResource(context.Context, *DescribeAlertsWithResourceRequest) (*DescribeAlertsWithResourceResponse, error)
+	DescribeAlertDetails(context.Context, *DescribeAlertDetailsRequest) (*DescribeAlertDetailsResponse, error)
+	DescribeAlertStatus(context.Context, *DescribeAlertStatusRequest) (*DescribeAlertStatusResponse, error)
+	//1.History
+	//********************************************************************************************************
+	DescribeHistoryDetail(context.Context, *DescribeHistoryDetailRequest) (*DescribeHistoryDetailResponse, error)
+	//2.Alert Bundle
+	//********************************************************************************************************
+	CreateAlertBundle(context.Context, *CreateAlertBundleRequest) (*CreateAlertBundleResponse, error)
+	UpdateAlertBundle(context.Context, *UpdateAlertBundleRequest) (*UpdateAlertBundleResponse, error)
+	ExportAlerts(context.Context, *ExportAlertsRequest) (*ExportAlertsResponse, error)
+	ImportAlerts(context.Context, *ImportAlertsRequest) (*ImportAlertsResponse, error)
+	ReceiveAlerts(context.Context, *ReceiveAlertsRequest) (*ReceiveAlertsResponse, error)
+}
+
+// UnimplementedAlertManagerCustomServer can be embedded to have forward compatible implementations.
+type UnimplementedAlertManagerCustomServer struct {
+}
+
+func (*UnimplementedAlertManagerCustomServer) DescribeAlertsWithResource(ctx context.Context, req *DescribeAlertsWithResourceRequest) (*DescribeAlertsWithResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeAlertsWithResource not implemented")
+}
+func (*UnimplementedAlertManagerCustomServer) DescribeAlertDetails(ctx context.Context, req *DescribeAlertDetailsRequest) (*DescribeAlertDetailsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeAlertDetails not implemented")
+}
+func (*UnimplementedAlertManagerCustomServer) DescribeAlertStatus(ctx context.Context, req *DescribeAlertStatusRequest) (*DescribeAlertStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeAlertStatus not implemented")
+}
+func (*UnimplementedAlertManagerCustomServer) DescribeHistoryDetail(ctx context.Context, req *DescribeHistoryDetailRequest) (*DescribeHistoryDetailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeHistoryDetail not implemented")
+}
+func (*UnimplementedAlertManagerCustomServer) CreateAlertBundle(ctx context.Context, req *CreateAlertBundleRequest) (*CreateAlertBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAlertBundle not implemented")
+}
+func (*UnimplementedAlertManagerCustomServer) UpdateAlertBundle(ctx context.Context, req *UpdateAlertBundleRequest) (*UpdateAlertBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAlertBundle not implemented")
+}
+func (*UnimplementedAlertManagerCustomServer) ExportAlerts(ctx context.Context, req *ExportAlertsRequest) (*ExportAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportAlerts not implemented")
+}
+func (*UnimplementedAlertManagerCustomServer) ImportAlerts(ctx context.Context, req *ImportAlertsRequest) (*ImportAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportAlerts not implemented")
+}
+func (*UnimplementedAlertManagerCustomServer) ReceiveAlerts(ctx context.Context, req *ReceiveAlertsRequest) (*ReceiveAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveAlerts not implemented")
+}
+
+func RegisterAlertManagerCustomServer(s *grpc.Server, srv AlertManagerCustomServer) {
+	s.RegisterService(&_AlertManagerCustom_serviceDesc, srv)
+}
+
+func _AlertManagerCustom_DescribeAlertsWithResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeAlertsWithResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
 		return srv.(AlertManagerCustomServer).DescribeAlertsWithResource(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
@@ -1719,6 +2643,96 @@ func _AlertManagerCustom_DescribeHistoryDetail_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AlertManagerCustom_CreateAlertBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAlertBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertManagerCustomServer).CreateAlertBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubesphere.alert.AlertManagerCustom/CreateAlertBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertManagerCustomServer).CreateAlertBundle(ctx, req.(*CreateAlertBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlertManagerCustom_UpdateAlertBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAlertBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertManagerCustomServer).UpdateAlertBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubesphere.alert.AlertManagerCustom/UpdateAlertBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertManagerCustomServer).UpdateAlertBundle(ctx, req.(*UpdateAlertBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlertManagerCustom_ExportAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertManagerCustomServer).ExportAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubesphere.alert.AlertManagerCustom/ExportAlerts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertManagerCustomServer).ExportAlerts(ctx, req.(*ExportAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlertManagerCustom_ImportAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertManagerCustomServer).ImportAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubesphere.alert.AlertManagerCustom/ImportAlerts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertManagerCustomServer).ImportAlerts(ctx, req.(*ImportAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlertManagerCustom_ReceiveAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertManagerCustomServer).ReceiveAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubesphere.alert.AlertManagerCustom/ReceiveAlerts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertManagerCustomServer).ReceiveAlerts(ctx, req.(*ReceiveAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AlertManagerCustom_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "kubesphere.alert.AlertManagerCustom",
 	HandlerType: (*AlertManagerCustomServer)(nil),
@@ -1739,6 +2753,26 @@ var _AlertManagerCustom_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DescribeHistoryDetail",
 			Handler:    _AlertManagerCustom_DescribeHistoryDetail_Handler,
 		},
+		{
+			MethodName: "CreateAlertBundle",
+			Handler:    _AlertManagerCustom_CreateAlertBundle_Handler,
+		},
+		{
+			MethodName: "UpdateAlertBundle",
+			Handler:    _AlertManagerCustom_UpdateAlertBundle_Handler,
+		},
+		{
+			MethodName: "ExportAlerts",
+			Handler:    _AlertManagerCustom_ExportAlerts_Handler,
+		},
+		{
+			MethodName: "ImportAlerts",
+			Handler:    _AlertManagerCustom_ImportAlerts_Handler,
+		},
+		{
+			MethodName: "ReceiveAlerts",
+			Handler:    _AlertManagerCustom_ReceiveAlerts_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "custom.proto",
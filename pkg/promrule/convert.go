@@ -0,0 +1,296 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+// Package promrule converts Prometheus alerting rules into alert documents, which the manager
+// imports as alerts, policies and rules.
+package promrule
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"kubesphere.io/alert/pkg/models"
+)
+
+// PrometheusRule is a PrometheusRule object of the Prometheus operator, or a Prometheus rule file
+// which has the groups at the top level.
+type PrometheusRule struct {
+	Metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	Spec   RuleSpec    `json:"spec"`
+	Groups []RuleGroup `json:"groups"`
+}
+
+type RuleSpec struct {
+	Groups []RuleGroup `json:"groups"`
+}
+
+type RuleGroup struct {
+	Name     string `json:"name"`
+	Interval string `json:"interval,omitempty"`
+	Rules    []Rule `json:"rules"`
+}
+
+type Rule struct {
+	Record      string            `json:"record,omitempty"`
+	Alert       string            `json:"alert,omitempty"`
+	Expr        string            `json:"expr"`
+	For         string            `json:"for,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Options are the settings of the alerts converted that Prometheus rules do not carry.
+type Options struct {
+	RsTypeName    string // resource type of the metrics, such as pod
+	RsFilterParam string // resources watched, such as {"ns_name":"default"}
+}
+
+// UnsupportedRule is an alerting rule that cannot be expressed as an alert rule.
+type UnsupportedRule struct {
+	Group  string `json:"group"`
+	Alert  string `json:"alert"`
+	Reason string `json:"reason"`
+}
+
+// Result is the outcome of a conversion: the alerts converted, the names of the metrics their
+// rules use, which must be defined for the resource type, and the rules left out.
+type Result struct {
+	Document    *models.AlertDocument
+	MetricNames []string
+	Unsupported []UnsupportedRule
+}
+
+const defaultEvaluationInterval = 60 * time.Second
+
+var (
+	metricNameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	durationRegexp   = regexp.MustCompile(`^([0-9]+)(ms|s|m|h|d|w|y)$`)
+)
+
+var durationUnits = map[string]time.Duration{
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+	"y":  365 * 24 * time.Hour,
+}
+
+// reversed is the condition of a comparison with its operands swapped.
+var reversed = map[string]string{
+	">":  "<",
+	">=": "<=",
+	"<":  ">",
+	"<=": ">=",
+}
+
+// ParsePrometheusRule reads a PrometheusRule object or a rule file, in yaml or json.
+func ParsePrometheusRule(data []byte) (*PrometheusRule, error) {
+	promRule := &PrometheusRule{}
+	err := yaml.Unmarshal(data, promRule)
+	if err != nil {
+		return nil, err
+	}
+	return promRule, nil
+}
+
+// parseDuration parses a Prometheus duration, such as 5m or 1d.
+func parseDuration(s string) (time.Duration, error) {
+	matches := durationRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if matches == nil {
+		return 0, fmt.Errorf("invalid duration [%s]", s)
+	}
+	n, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration [%s]", s)
+	}
+	return time.Duration(n) * durationUnits[matches[2]], nil
+}
+
+// parseExpr parses the comparison of a metric with a number, such as pod_cpu_usage > 0.9, into the
+// metric name, condition type and threshold of a rule.
+func parseExpr(expr string) (string, string, string, error) {
+	expr = strings.TrimSpace(expr)
+
+	var left, condition, right string
+	for i := 0; i < len(expr); i++ {
+		if expr[i] != '>' && expr[i] != '<' {
+			continue
+		}
+		condition = expr[i : i+1]
+		if i+1 < len(expr) && expr[i+1] == '=' {
+			condition = expr[i : i+2]
+		}
+		left = strings.TrimSpace(expr[:i])
+		right = strings.TrimSpace(expr[i+len(condition):])
+		break
+	}
+	if condition == "" {
+		return "", "", "", fmt.Errorf("expr is not a comparison with >, >=, < or <=")
+	}
+
+	_, err := strconv.ParseFloat(right, 64)
+	if err != nil {
+		_, err = strconv.ParseFloat(left, 64)
+		if err != nil {
+			return "", "", "", fmt.Errorf("expr does not compare a metric with a number")
+		}
+		left, right = right, left
+		condition = reversed[condition]
+	}
+
+	if !metricNameRegexp.MatchString(left) {
+		return "", "", "", fmt.Errorf("expr [%s] is not a metric name without label matchers or functions", left)
+	}
+	return left, condition, right, nil
+}
+
+// convertRule converts an alerting rule of a group evaluated every interval.
+func convertRule(rule Rule, interval time.Duration) (*models.RuleDefinition, error) {
+	metricName, conditionType, thresholds, err := parseExpr(rule.Expr)
+	if err != nil {
+		return nil, err
+	}
+
+	severity, ok := models.ParseSeverity(rule.Labels["severity"])
+	if !ok {
+		return nil, fmt.Errorf("severity [%s] is not supported", rule.Labels["severity"])
+	}
+
+	// an alert fires once its expr has held for the duration, that is for the evaluation it
+	// becomes true and then every evaluation of the duration
+	consecutiveCount := uint32(1)
+	if rule.For != "" {
+		forDuration, err := parseDuration(rule.For)
+		if err != nil {
+			return nil, err
+		}
+		consecutiveCount += uint32((forDuration + interval - 1) / interval)
+	}
+
+	monitorPeriods := uint32(interval / time.Minute)
+	if monitorPeriods == 0 {
+		monitorPeriods = 1
+	}
+
+	return &models.RuleDefinition{
+		RuleName:           fmt.Sprintf("%s-%s", rule.Alert, severity),
+		MetricName:         metricName,
+		MonitorPeriods:     monitorPeriods,
+		EvaluationInterval: uint32(interval / time.Second),
+		Severity:           severity,
+		ConditionType:      conditionType,
+		Thresholds:         thresholds,
+		ConsecutiveCount:   consecutiveCount,
+	}, nil
+}
+
+// getPolicyDescription returns the description of an alert from the annotations of its rule.
+func getPolicyDescription(annotations map[string]string) string {
+	for _, key := range []string{"description", "summary", "message"} {
+		if annotations[key] != "" {
+			return annotations[key]
+		}
+	}
+	return ""
+}
+
+// Convert converts the alerting rules of promRule into an alert document. Rules alerting under
+// the same name become the rules of one alert, one per severity; the annotations of the first of
+// them describe the alert. Recording rules are skipped.
+func Convert(promRule *PrometheusRule, opts Options) *Result {
+	result := &Result{
+		Document: &models.AlertDocument{Version: models.AlertDocumentVersion},
+	}
+
+	groups := promRule.Spec.Groups
+	if len(groups) == 0 {
+		groups = promRule.Groups
+	}
+
+	alerts := make(map[string]int)
+	metricNames := make(map[string]bool)
+	for _, group := range groups {
+		interval := defaultEvaluationInterval
+		if group.Interval != "" {
+			var err error
+			interval, err = parseDuration(group.Interval)
+			if err != nil || interval < time.Second {
+				for _, rule := range group.Rules {
+					if rule.Alert != "" {
+						result.Unsupported = append(result.Unsupported, UnsupportedRule{group.Name, rule.Alert, fmt.Sprintf("group interval [%s] is not supported", group.Interval)})
+					}
+				}
+				continue
+			}
+		}
+
+		for _, rule := range group.Rules {
+			if rule.Alert == "" {
+				continue
+			}
+
+			ruleDef, err := convertRule(rule, interval)
+			if err != nil {
+				result.Unsupported = append(result.Unsupported, UnsupportedRule{group.Name, rule.Alert, err.Error()})
+				continue
+			}
+
+			i, ok := alerts[rule.Alert]
+			if !ok {
+				i = len(result.Document.Alerts)
+				alerts[rule.Alert] = i
+				result.Document.Alerts = append(result.Document.Alerts, models.AlertDefinition{
+					AlertName: rule.Alert,
+					ResourceFilter: models.ResourceFilterDefinition{
+						RsFilterName:  rule.Alert,
+						RsFilterParam: opts.RsFilterParam,
+						RsTypeName:    opts.RsTypeName,
+					},
+					Policy: models.PolicyDefinition{
+						PolicyName:         rule.Alert,
+						PolicyDescription:  getPolicyDescription(rule.Annotations),
+						AvailableStartTime: "00:00:00",
+						AvailableEndTime:   "23:59:59",
+					},
+					Action: models.ActionDefinition{
+						ActionName: rule.Alert,
+					},
+				})
+			}
+
+			alert := &result.Document.Alerts[i]
+			duplicated := false
+			for _, existing := range alert.Rules {
+				if existing.RuleName == ruleDef.RuleName {
+					duplicated = true
+				}
+			}
+			if duplicated {
+				result.Unsupported = append(result.Unsupported, UnsupportedRule{group.Name, rule.Alert, fmt.Sprintf("alert already has a rule of severity [%s]", ruleDef.Severity)})
+				continue
+			}
+
+			alert.Rules = append(alert.Rules, *ruleDef)
+			metricNames[ruleDef.MetricName] = true
+		}
+	}
+
+	for metricName := range metricNames {
+		result.MetricNames = append(result.MetricNames, metricName)
+	}
+	sort.Strings(result.MetricNames)
+
+	return result
+}
@@ -0,0 +1,118 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package promrule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/models"
+)
+
+const testPrometheusRule = `
+apiVersion: monitoring.coreos.com/v1
+kind: PrometheusRule
+metadata:
+  name: pod-rules
+  namespace: default
+spec:
+  groups:
+  - name: pod
+    interval: 30s
+    rules:
+    - record: pod:cpu_usage:sum
+      expr: sum(pod_cpu_usage)
+    - alert: PodCpuHigh
+      expr: pod_cpu_usage > 0.8
+      for: 2m
+      labels:
+        severity: warning
+      annotations:
+        summary: CPU of {{ $labels.pod }} is high
+    - alert: PodCpuHigh
+      expr: 0.95 <= pod_cpu_usage
+      labels:
+        severity: critical
+    - alert: PodRestarting
+      expr: rate(pod_restarts_total[5m]) > 0
+    - alert: PodMemoryHigh
+      expr: pod_memory_usage_wo_cache > 1e9
+      labels:
+        severity: urgent
+`
+
+func TestConvert(t *testing.T) {
+	promRule, err := ParsePrometheusRule([]byte(testPrometheusRule))
+	require.NoError(t, err)
+
+	result := Convert(promRule, Options{RsTypeName: "pod", RsFilterParam: `{"ns_name":"default"}`})
+
+	require.Equal(t, models.AlertDocumentVersion, result.Document.Version)
+	require.Len(t, result.Document.Alerts, 1)
+
+	alert := result.Document.Alerts[0]
+	require.Equal(t, "PodCpuHigh", alert.AlertName)
+	require.Equal(t, models.ResourceFilterDefinition{RsFilterName: "PodCpuHigh", RsFilterParam: `{"ns_name":"default"}`, RsTypeName: "pod"}, alert.ResourceFilter)
+	require.Equal(t, "CPU of {{ $labels.pod }} is high", alert.Policy.PolicyDescription)
+	require.Equal(t, []models.RuleDefinition{
+		{
+			RuleName:           "PodCpuHigh-minor",
+			MetricName:         "pod_cpu_usage",
+			MonitorPeriods:     1,
+			EvaluationInterval: 30,
+			Severity:           "minor",
+			ConditionType:      ">",
+			Thresholds:         "0.8",
+			ConsecutiveCount:   5,
+		},
+		{
+			RuleName:           "PodCpuHigh-critical",
+			MetricName:         "pod_cpu_usage",
+			MonitorPeriods:     1,
+			EvaluationInterval: 30,
+			Severity:           "critical",
+			ConditionType:      ">=",
+			Thresholds:         "0.95",
+			ConsecutiveCount:   1,
+		},
+	}, alert.Rules)
+
+	require.Equal(t, []string{"pod_cpu_usage"}, result.MetricNames)
+	require.Equal(t, []UnsupportedRule{
+		{"pod", "PodRestarting", "expr [rate(pod_restarts_total[5m])] is not a metric name without label matchers or functions"},
+		{"pod", "PodMemoryHigh", "severity [urgent] is not supported"},
+	}, result.Unsupported)
+}
+
+func TestParseExpr(t *testing.T) {
+	tests := []struct {
+		expr          string
+		metricName    string
+		conditionType string
+		thresholds    string
+		err           bool
+	}{
+		{expr: "node_cpu_utilisation >= 90", metricName: "node_cpu_utilisation", conditionType: ">=", thresholds: "90"},
+		{expr: " 10 > node_disk_size_available ", metricName: "node_disk_size_available", conditionType: "<", thresholds: "10"},
+		{expr: "namespace:pod_count < 1", metricName: "namespace:pod_count", conditionType: "<", thresholds: "1"},
+		{expr: `pod_cpu_usage{namespace="default"} > 1`, err: true},
+		{expr: "pod_cpu_usage == 1", err: true},
+		{expr: "pod_cpu_usage > pod_cpu_limit", err: true},
+		{expr: "pod_cpu_usage > 1 and pod_memory_usage > 1", err: true},
+	}
+
+	for _, test := range tests {
+		metricName, conditionType, thresholds, err := parseExpr(test.expr)
+		if test.err {
+			require.Error(t, err, test.expr)
+			continue
+		}
+		require.NoError(t, err, test.expr)
+		require.Equal(t, test.metricName, metricName)
+		require.Equal(t, test.conditionType, conditionType)
+		require.Equal(t, test.thresholds, thresholds)
+	}
+}
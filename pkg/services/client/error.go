@@ -0,0 +1,116 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"unicode"
+
+	"github.com/emicklei/go-restful"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"kubesphere.io/alert/pkg/gerr"
+	"kubesphere.io/alert/pkg/pb"
+	"kubesphere.io/alert/pkg/util/ctxutil"
+	"kubesphere.io/alert/pkg/util/idutil"
+)
+
+const RequestIdHeader = "X-Request-Id"
+
+// ErrorResponse is the body of every failed request.
+type ErrorResponse struct {
+	// Code is the name of the error, eg. resource_not_found.
+	Code      string `json:"code" description:"error name, eg. resource_not_found"`
+	Message   string `json:"message" description:"error message"`
+	Details   string `json:"details,omitempty" description:"cause of the error"`
+	RequestId string `json:"request_id" description:"id of the request, also in the X-Request-Id response header"`
+}
+
+// codeName turns a grpc code into the snake case its error names use, eg. DeadlineExceeded
+// into deadline_exceeded.
+func codeName(code codes.Code) string {
+	name := []rune{}
+	lower := false
+	for _, r := range code.String() {
+		if unicode.IsUpper(r) {
+			if lower {
+				name = append(name, '_')
+			}
+			r = unicode.ToLower(r)
+			lower = false
+		} else {
+			lower = true
+		}
+		name = append(name, r)
+	}
+	return string(name)
+}
+
+// newErrorResponse converts err, a grpc status error of gerr in general, into an http status
+// code and an error body.
+func newErrorResponse(ctx context.Context, err error) (int, *ErrorResponse) {
+	s, ok := status.FromError(err)
+	if !ok {
+		s = status.New(codes.Unknown, err.Error())
+	}
+
+	errorResponse := &ErrorResponse{
+		Code:      codeName(s.Code()),
+		Message:   s.Message(),
+		RequestId: ctxutil.GetRequestId(ctx),
+	}
+	for _, detail := range s.Details() {
+		if d, ok := detail.(*pb.ErrorDetail); ok {
+			errorResponse.Code = d.ErrorName
+			errorResponse.Details = d.Cause
+			break
+		}
+	}
+
+	return runtime.HTTPStatusFromCode(s.Code()), errorResponse
+}
+
+// writeError answers a request with the http status code and the error body of err.
+func writeError(request *restful.Request, response *restful.Response, err error) {
+	code, errorResponse := newErrorResponse(request.Request.Context(), err)
+	response.WriteHeaderAndJson(code, errorResponse, restful.MIME_JSON)
+}
+
+// writeBadRequest answers a request whose body or parameters can not be read.
+func writeBadRequest(request *restful.Request, response *restful.Response, err error) {
+	writeError(request, response, gerr.NewWithDetail(request.Request.Context(), codes.InvalidArgument, err, gerr.ErrorValidateFailed))
+}
+
+// writeManagerUnavailable answers a request when the manager can not be reached.
+func writeManagerUnavailable(request *restful.Request, response *restful.Response, err error) {
+	writeError(request, response, gerr.NewWithDetail(request.Request.Context(), codes.Unavailable, err, gerr.ErrorManagerUnavailable))
+}
+
+// requestIdFilter gives every request an id, taken from the X-Request-Id header if any, which
+// the manager logs and audits it with and failed requests answer with.
+func requestIdFilter(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+	requestId := request.HeaderParameter(RequestIdHeader)
+	if requestId == "" {
+		requestId = idutil.GetUuid("req-")
+	}
+	request.Request = request.Request.WithContext(ctxutil.SetRequestId(request.Request.Context(), requestId))
+	response.AddHeader(RequestIdHeader, requestId)
+	chain.ProcessFilter(request, response)
+}
+
+// errorReturns documents the error responses of a route.
+func errorReturns(builder *restful.RouteBuilder) {
+	builder.
+		Returns(http.StatusBadRequest, "invalid request", ErrorResponse{}).
+		Returns(http.StatusUnauthorized, "authentication failure", ErrorResponse{}).
+		Returns(http.StatusForbidden, "permission denied", ErrorResponse{}).
+		Returns(http.StatusNotFound, "resource not found", ErrorResponse{}).
+		Returns(http.StatusConflict, "resource already exists", ErrorResponse{}).
+		Returns(http.StatusInternalServerError, "internal error", ErrorResponse{}).
+		Returns(http.StatusServiceUnavailable, "manager unavailable", ErrorResponse{})
+}
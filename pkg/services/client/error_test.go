@@ -0,0 +1,50 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"kubesphere.io/alert/pkg/gerr"
+	"kubesphere.io/alert/pkg/util/ctxutil"
+)
+
+func TestCodeName(t *testing.T) {
+	require.Equal(t, "ok", codeName(codes.OK))
+	require.Equal(t, "deadline_exceeded", codeName(codes.DeadlineExceeded))
+	require.Equal(t, "unavailable", codeName(codes.Unavailable))
+}
+
+func TestNewErrorResponse(t *testing.T) {
+	ctx := ctxutil.SetRequestId(context.Background(), "req-1")
+
+	code, errorResponse := newErrorResponse(ctx, gerr.New(ctx, codes.AlreadyExists, gerr.ErrorResourceAlreadyExists, "alert-1"))
+	require.Equal(t, http.StatusConflict, code)
+	require.Equal(t, gerr.ErrorResourceAlreadyExists.Name, errorResponse.Code)
+	require.Equal(t, "req-1", errorResponse.RequestId)
+	require.Equal(t, "", errorResponse.Details)
+
+	code, errorResponse = newErrorResponse(ctx, gerr.NewWithDetail(ctx, codes.InvalidArgument, fmt.Errorf("bad body"), gerr.ErrorValidateFailed))
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Equal(t, gerr.ErrorValidateFailed.Name, errorResponse.Code)
+	require.Equal(t, "bad body", errorResponse.Details)
+
+	// errors the manager did not build with gerr, eg. of the connection
+	code, errorResponse = newErrorResponse(ctx, status.Error(codes.Unavailable, "connection refused"))
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "unavailable", errorResponse.Code)
+	require.Equal(t, "connection refused", errorResponse.Message)
+
+	code, errorResponse = newErrorResponse(ctx, fmt.Errorf("broken"))
+	require.Equal(t, http.StatusInternalServerError, code)
+	require.Equal(t, "unknown", errorResponse.Code)
+}
@@ -4,19 +4,25 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/emicklei/go-restful"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc/codes"
 
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	alclient "kubesphere.io/alert/pkg/client/alert"
 	k8sclient "kubesphere.io/alert/pkg/client/kubernetes"
+	"kubesphere.io/alert/pkg/constants"
+	"kubesphere.io/alert/pkg/gerr"
 	"kubesphere.io/alert/pkg/logger"
 	"kubesphere.io/alert/pkg/models"
 	"kubesphere.io/alert/pkg/pb"
+	"kubesphere.io/alert/pkg/util/pbutil"
 	"kubesphere.io/alert/pkg/util/stringutil"
 )
 
@@ -50,6 +56,15 @@ func parseUint32(s string) (uint32, error) {
 	}
 }
 
+// parseTime parses a RFC3339 time, eg. 2019-06-01T08:00:00Z, and returns nil for an empty or illegal one.
+func parseTime(s string) *timestamp.Timestamp {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return pbutil.ToProtoTimestamp(t)
+}
+
 func parseUint32s(ss []string) []uint32 {
 	vs := []uint32{}
 
@@ -91,19 +106,17 @@ func CreateResourceType(request *restful.Request, response *restful.Response) {
 
 	err := request.ReadEntity(&resourceType)
 	if err != nil {
-		logger.Debug(nil, "CreateResourceType request data error %+v.", err)
-		response.WriteAsJson(&pb.CreateResourceTypeResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.CreateResourceTypeResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.CreateResourceTypeRequest{
@@ -113,12 +126,12 @@ func CreateResourceType(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.CreateResourceType(ctx, req)
 	if err != nil {
-		logger.Error(nil, "CreateResourceType failed: %+v", err)
-		response.WriteAsJson(&pb.CreateResourceTypeResponse{})
+		logger.Error(ctx, "CreateResourceType failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "CreateResourceType success: %+v", resp)
+	logger.Debug(ctx, "CreateResourceType success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -134,12 +147,11 @@ func DescribeResourceTypes(request *restful.Request, response *restful.Response)
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DescribeResourceTypesResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DescribeResourceTypesRequest{
@@ -153,12 +165,12 @@ func DescribeResourceTypes(request *restful.Request, response *restful.Response)
 
 	resp, err := client.DescribeResourceTypes(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DescribeResourceTypes failed: %+v", err)
-		response.WriteAsJson(&pb.DescribeResourceTypesResponse{})
+		logger.Error(ctx, "DescribeResourceTypes failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DescribeResourceTypes success: %+v", resp)
+	logger.Debug(ctx, "DescribeResourceTypes success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -168,17 +180,17 @@ func ModifyResourceType(request *restful.Request, response *restful.Response) {
 
 	err := request.ReadEntity(&resourceType)
 	if err != nil {
-		logger.Debug(nil, "ModifyResourceType request data error %+v.", err)
-		response.WriteAsJson(&pb.ModifyResourceTypeResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
+		writeManagerUnavailable(request, response, err)
+		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.ModifyResourceTypeRequest{
@@ -189,12 +201,12 @@ func ModifyResourceType(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.ModifyResourceType(ctx, req)
 	if err != nil {
-		logger.Error(nil, "ModifyResourceType failed: %+v", err)
-		response.WriteAsJson(&pb.ModifyResourceTypeResponse{})
+		logger.Error(ctx, "ModifyResourceType failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "ModifyResourceType success: %+v", resp)
+	logger.Debug(ctx, "ModifyResourceType success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -204,12 +216,11 @@ func DeleteResourceTypes(request *restful.Request, response *restful.Response) {
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DeleteResourceTypesResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DeleteResourceTypesRequest{
@@ -218,12 +229,12 @@ func DeleteResourceTypes(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.DeleteResourceTypes(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DeleteResourceTypes failed: %+v", err)
-		response.WriteAsJson(&pb.DeleteResourceTypesResponse{})
+		logger.Error(ctx, "DeleteResourceTypes failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DeleteResourceTypes success: %+v", resp)
+	logger.Debug(ctx, "DeleteResourceTypes success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -233,19 +244,17 @@ func CreateResourceFilter(request *restful.Request, response *restful.Response)
 
 	err := request.ReadEntity(&rsFilter)
 	if err != nil {
-		logger.Debug(nil, "CreateResourceFilter request data error %+v.", err)
-		response.WriteAsJson(&pb.CreateResourceFilterResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.CreateResourceFilterResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.CreateResourceFilterRequest{
@@ -257,12 +266,12 @@ func CreateResourceFilter(request *restful.Request, response *restful.Response)
 
 	resp, err := client.CreateResourceFilter(ctx, req)
 	if err != nil {
-		logger.Error(nil, "CreateResourceFilter failed: %+v", err)
-		response.WriteAsJson(&pb.CreateResourceFilterResponse{})
+		logger.Error(ctx, "CreateResourceFilter failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "CreateResourceFilter success: %+v", resp)
+	logger.Debug(ctx, "CreateResourceFilter success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -280,12 +289,11 @@ func DescribeResourceFilters(request *restful.Request, response *restful.Respons
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DescribeResourceFiltersResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DescribeResourceFiltersRequest{
@@ -301,12 +309,12 @@ func DescribeResourceFilters(request *restful.Request, response *restful.Respons
 
 	resp, err := client.DescribeResourceFilters(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DescribeResourceFilters failed: %+v", err)
-		response.WriteAsJson(&pb.DescribeResourceFiltersResponse{})
+		logger.Error(ctx, "DescribeResourceFilters failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DescribeResourceFilters success: %+v", resp)
+	logger.Debug(ctx, "DescribeResourceFilters success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -316,19 +324,17 @@ func ModifyResourceFilter(request *restful.Request, response *restful.Response)
 
 	err := request.ReadEntity(&rsFilter)
 	if err != nil {
-		logger.Debug(nil, "ModifyResourceFilter request data error %+v.", err)
-		response.WriteAsJson(&pb.ModifyResourceFilterResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.ModifyResourceFilterResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.ModifyResourceFilterRequest{
@@ -341,12 +347,12 @@ func ModifyResourceFilter(request *restful.Request, response *restful.Response)
 
 	resp, err := client.ModifyResourceFilter(ctx, req)
 	if err != nil {
-		logger.Error(nil, "ModifyResourceFilter failed: %+v", err)
-		response.WriteAsJson(&pb.ModifyResourceFilterResponse{})
+		logger.Error(ctx, "ModifyResourceFilter failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "ModifyResourceFilter success: %+v", resp)
+	logger.Debug(ctx, "ModifyResourceFilter success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -356,12 +362,11 @@ func DeleteResourceFilters(request *restful.Request, response *restful.Response)
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DeleteResourceFiltersResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DeleteResourceFiltersRequest{
@@ -370,12 +375,12 @@ func DeleteResourceFilters(request *restful.Request, response *restful.Response)
 
 	resp, err := client.DeleteResourceFilters(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DeleteResourceFilters failed: %+v", err)
-		response.WriteAsJson(&pb.DeleteResourceFiltersResponse{})
+		logger.Error(ctx, "DeleteResourceFilters failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DeleteResourceFilters success: %+v", resp)
+	logger.Debug(ctx, "DeleteResourceFilters success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -385,19 +390,17 @@ func CreateMetric(request *restful.Request, response *restful.Response) {
 
 	err := request.ReadEntity(&metric)
 	if err != nil {
-		logger.Debug(nil, "CreateMetric request data error %+v.", err)
-		response.WriteAsJson(&pb.CreateMetricResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.CreateMetricResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.CreateMetricRequest{
@@ -409,12 +412,12 @@ func CreateMetric(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.CreateMetric(ctx, req)
 	if err != nil {
-		logger.Error(nil, "CreateMetric failed: %+v", err)
-		response.WriteAsJson(&pb.CreateMetricResponse{})
+		logger.Error(ctx, "CreateMetric failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "CreateMetric success: %+v", resp)
+	logger.Debug(ctx, "CreateMetric success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -432,12 +435,11 @@ func DescribeMetrics(request *restful.Request, response *restful.Response) {
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DescribeMetricsResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DescribeMetricsRequest{
@@ -453,12 +455,12 @@ func DescribeMetrics(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.DescribeMetrics(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DescribeMetrics failed: %+v", err)
-		response.WriteAsJson(&pb.DescribeMetricsResponse{})
+		logger.Error(ctx, "DescribeMetrics failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DescribeMetrics success: %+v", resp)
+	logger.Debug(ctx, "DescribeMetrics success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -468,19 +470,17 @@ func ModifyMetric(request *restful.Request, response *restful.Response) {
 
 	err := request.ReadEntity(&metric)
 	if err != nil {
-		logger.Debug(nil, "ModifyMetric request data error %+v.", err)
-		response.WriteAsJson(&pb.ModifyMetricResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.ModifyMetricResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.ModifyMetricRequest{
@@ -493,12 +493,12 @@ func ModifyMetric(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.ModifyMetric(ctx, req)
 	if err != nil {
-		logger.Error(nil, "ModifyMetric failed: %+v", err)
-		response.WriteAsJson(&pb.ModifyMetricResponse{})
+		logger.Error(ctx, "ModifyMetric failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "ModifyMetric success: %+v", resp)
+	logger.Debug(ctx, "ModifyMetric success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -508,12 +508,11 @@ func DeleteMetrics(request *restful.Request, response *restful.Response) {
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DeleteMetricsResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DeleteMetricsRequest{
@@ -522,12 +521,12 @@ func DeleteMetrics(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.DeleteMetrics(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DeleteMetrics failed: %+v", err)
-		response.WriteAsJson(&pb.DeleteMetricsResponse{})
+		logger.Error(ctx, "DeleteMetrics failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DeleteMetrics success: %+v", resp)
+	logger.Debug(ctx, "DeleteMetrics success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -537,19 +536,17 @@ func CreatePolicy(request *restful.Request, response *restful.Response) {
 
 	err := request.ReadEntity(&policy)
 	if err != nil {
-		logger.Debug(nil, "CreatePolicy request data error %+v.", err)
-		response.WriteAsJson(&pb.CreatePolicyResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.CreatePolicyResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.CreatePolicyRequest{
@@ -563,12 +560,12 @@ func CreatePolicy(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.CreatePolicy(ctx, req)
 	if err != nil {
-		logger.Error(nil, "CreatePolicy failed: %+v", err)
-		response.WriteAsJson(&pb.CreatePolicyResponse{})
+		logger.Error(ctx, "CreatePolicy failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "CreatePolicy success: %+v", resp)
+	logger.Debug(ctx, "CreatePolicy success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -587,12 +584,11 @@ func DescribePolicies(request *restful.Request, response *restful.Response) {
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DescribePoliciesResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DescribePoliciesRequest{
@@ -609,12 +605,12 @@ func DescribePolicies(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.DescribePolicies(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DescribePolicies failed: %+v", err)
-		response.WriteAsJson(&pb.DescribePoliciesResponse{})
+		logger.Error(ctx, "DescribePolicies failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DescribePolicies success: %+v", resp)
+	logger.Debug(ctx, "DescribePolicies success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -624,17 +620,17 @@ func ModifyPolicy(request *restful.Request, response *restful.Response) {
 
 	err := request.ReadEntity(&policy)
 	if err != nil {
-		logger.Debug(nil, "ModifyPolicy request data error %+v.", err)
-		response.WriteAsJson(&pb.ModifyPolicyResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
+		writeManagerUnavailable(request, response, err)
+		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.ModifyPolicyRequest{
@@ -650,12 +646,12 @@ func ModifyPolicy(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.ModifyPolicy(ctx, req)
 	if err != nil {
-		logger.Error(nil, "ModifyPolicy failed: %+v", err)
-		response.WriteAsJson(&pb.ModifyPolicyResponse{})
+		logger.Error(ctx, "ModifyPolicy failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "ModifyPolicy success: %+v", resp)
+	logger.Debug(ctx, "ModifyPolicy success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -665,12 +661,11 @@ func DeletePolicies(request *restful.Request, response *restful.Response) {
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DeletePoliciesResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DeletePoliciesRequest{
@@ -679,12 +674,12 @@ func DeletePolicies(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.DeletePolicies(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DeletePolicies failed: %+v", err)
-		response.WriteAsJson(&pb.DeletePoliciesResponse{})
+		logger.Error(ctx, "DeletePolicies failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DeletePolicies success: %+v", resp)
+	logger.Debug(ctx, "DeletePolicies success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -706,34 +701,42 @@ type ModifyPolicyByAlertResponse struct {
 	AlertName string `json:"alert_name"`
 }
 
+// checkSingleAlert answers the request with an error unless exactly one alert is named.
+func checkSingleAlert(ctx context.Context, request *restful.Request, response *restful.Response, respAlerts *pb.DescribeAlertsWithResourceResponse, alertName string) bool {
+	switch {
+	case respAlerts.Total == 0:
+		writeError(request, response, gerr.New(ctx, codes.NotFound, gerr.ErrorResourceNotFound, alertName))
+		return false
+	case respAlerts.Total > 1:
+		writeError(request, response, gerr.New(ctx, codes.InvalidArgument, gerr.ErrorUnsupportedParameterValue, "alert_name", alertName))
+		return false
+	}
+	return true
+}
+
 func modifyPolicyByAlert(resourceMap map[string]string, request *restful.Request, response *restful.Response) {
 	resourceSearch, _ := json.Marshal(resourceMap)
-	resp := ModifyPolicyByAlertResponse{}
-
 	policyByAlert := new(PolicyByAlert)
 
 	err := request.ReadEntity(&policyByAlert)
 	if err != nil {
-		logger.Debug(nil, "ModifyPolicyByAlert request data error %+v.", err)
-		response.WriteAsJson(resp)
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	alertNames := stringutil.SimplifyStringList(strings.Split(policyByAlert.AlertName, ","))
 	if len(alertNames) == 0 {
-		logger.Debug(nil, "ModifyPolicyByAlert has no alert name specified.")
-		response.WriteAsJson(resp)
+		writeError(request, response, gerr.New(request.Request.Context(), codes.InvalidArgument, gerr.ErrorMissingParameter, "alert_name"))
 		return
 	}
 
 	clientCustom, err := alclient.NewCustomClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(resp)
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	reqAlerts := &pb.DescribeAlertsWithResourceRequest{
@@ -742,17 +745,19 @@ func modifyPolicyByAlert(resourceMap map[string]string, request *restful.Request
 	}
 
 	respAlerts, err := clientCustom.DescribeAlertsWithResource(ctx, reqAlerts)
+	if err != nil {
+		logger.Error(ctx, "ModifyPolicyByAlert check alert name failed: %+v", err)
+		writeError(request, response, err)
+		return
+	}
 
-	if respAlerts.Total != 1 {
-		logger.Debug(nil, "ModifyPolicyByAlert get no match alert name or duplicate names.")
-		response.WriteAsJson(resp)
+	if !checkSingleAlert(ctx, request, response, respAlerts, policyByAlert.AlertName) {
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(resp)
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
@@ -769,21 +774,21 @@ func modifyPolicyByAlert(resourceMap map[string]string, request *restful.Request
 
 	respModify, err := client.ModifyPolicy(ctx, req)
 	if err != nil {
-		logger.Error(nil, "ModifyPolicyByAlert failed: %+v", err)
-		response.WriteAsJson(resp)
+		logger.Error(ctx, "ModifyPolicyByAlert failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
 	if respModify.PolicyId != respAlerts.AlertSet[0].PolicyId {
-		logger.Debug(nil, "ModifyPolicyByAlert failed, PolicyId request[%+v] response[%+v] mismatch", respAlerts.AlertSet[0].PolicyId, respModify.PolicyId)
-		response.WriteAsJson(resp)
+		logger.Error(ctx, "ModifyPolicyByAlert failed, PolicyId request[%+v] response[%+v] mismatch", respAlerts.AlertSet[0].PolicyId, respModify.PolicyId)
+		writeError(request, response, gerr.New(ctx, codes.Internal, gerr.ErrorInternalError))
 		return
 	}
 
-	resp = ModifyPolicyByAlertResponse{
+	resp := ModifyPolicyByAlertResponse{
 		AlertName: policyByAlert.AlertName,
 	}
-	logger.Debug(nil, "ModifyPolicyByAlert success: %+v", resp)
+	logger.Debug(ctx, "ModifyPolicyByAlert success: %+v", resp)
 	response.WriteAsJson(resp)
 }
 
@@ -845,47 +850,56 @@ func ModifyPolicyByAlertContainer(request *restful.Request, response *restful.Re
 	modifyPolicyByAlert(resourceMap, request, response)
 }
 
+func ModifyPolicyByAlertExternal(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "external"
+	resourceMap["ns_name"] = request.QueryParameter("ns_name")
+
+	modifyPolicyByAlert(resourceMap, request, response)
+}
+
 func CreateRule(request *restful.Request, response *restful.Response) {
 	rule := new(models.Rule)
 
 	err := request.ReadEntity(&rule)
 	if err != nil {
-		logger.Debug(nil, "CreateRule request data error %+v.", err)
-		response.WriteAsJson(&pb.CreateRuleResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
+		writeManagerUnavailable(request, response, err)
+		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.CreateRuleRequest{
-		RuleName:         rule.RuleName,
-		Disabled:         rule.Disabled,
-		MonitorPeriods:   rule.MonitorPeriods,
-		Severity:         rule.Severity,
-		MetricsType:      rule.MetricsType,
-		ConditionType:    rule.ConditionType,
-		Thresholds:       rule.Thresholds,
-		Unit:             rule.Unit,
-		ConsecutiveCount: rule.ConsecutiveCount,
-		Inhibit:          rule.Inhibit,
-		PolicyId:         rule.PolicyId,
-		MetricId:         rule.MetricId,
+		RuleName:           rule.RuleName,
+		Disabled:           rule.Disabled,
+		MonitorPeriods:     rule.MonitorPeriods,
+		Severity:           rule.Severity,
+		MetricsType:        rule.MetricsType,
+		ConditionType:      rule.ConditionType,
+		Thresholds:         rule.Thresholds,
+		Unit:               rule.Unit,
+		ConsecutiveCount:   rule.ConsecutiveCount,
+		Inhibit:            rule.Inhibit,
+		PolicyId:           rule.PolicyId,
+		MetricId:           rule.MetricId,
+		EvaluationInterval: rule.EvaluationInterval,
 	}
 
 	resp, err := client.CreateRule(ctx, req)
 	if err != nil {
-		logger.Error(nil, "CreateRule failed: %+v", err)
-		response.WriteAsJson(&pb.CreateRuleResponse{})
+		logger.Error(ctx, "CreateRule failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "CreateRule success: %+v", resp)
+	logger.Debug(ctx, "CreateRule success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -912,12 +926,11 @@ func DescribeRules(request *restful.Request, response *restful.Response) {
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DescribeRulesResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DescribeRulesRequest{
@@ -942,12 +955,12 @@ func DescribeRules(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.DescribeRules(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DescribeRules failed: %+v", err)
-		response.WriteAsJson(&pb.DescribeRulesResponse{})
+		logger.Error(ctx, "DescribeRules failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DescribeRules success: %+v", resp)
+	logger.Debug(ctx, "DescribeRules success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -957,43 +970,42 @@ func ModifyRule(request *restful.Request, response *restful.Response) {
 
 	err := request.ReadEntity(&rule)
 	if err != nil {
-		logger.Debug(nil, "ModifyRule request data error %+v.", err)
-		response.WriteAsJson(&pb.ModifyRuleResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.ModifyRuleResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.ModifyRuleRequest{
-		RuleId:           rule.RuleId,
-		RuleName:         rule.RuleName,
-		Disabled:         rule.Disabled,
-		MonitorPeriods:   rule.MonitorPeriods,
-		Severity:         rule.Severity,
-		MetricsType:      rule.MetricsType,
-		ConditionType:    rule.ConditionType,
-		Thresholds:       rule.Thresholds,
-		Unit:             rule.Unit,
-		ConsecutiveCount: rule.ConsecutiveCount,
-		Inhibit:          rule.Inhibit,
+		RuleId:             rule.RuleId,
+		RuleName:           rule.RuleName,
+		Disabled:           rule.Disabled,
+		MonitorPeriods:     rule.MonitorPeriods,
+		Severity:           rule.Severity,
+		MetricsType:        rule.MetricsType,
+		ConditionType:      rule.ConditionType,
+		Thresholds:         rule.Thresholds,
+		Unit:               rule.Unit,
+		ConsecutiveCount:   rule.ConsecutiveCount,
+		Inhibit:            rule.Inhibit,
+		EvaluationInterval: rule.EvaluationInterval,
 	}
 
 	resp, err := client.ModifyRule(ctx, req)
 	if err != nil {
-		logger.Error(nil, "ModifyRule failed: %+v", err)
-		response.WriteAsJson(&pb.ModifyRuleResponse{})
+		logger.Error(ctx, "ModifyRule failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "ModifyRule success: %+v", resp)
+	logger.Debug(ctx, "ModifyRule success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -1003,12 +1015,11 @@ func DeleteRules(request *restful.Request, response *restful.Response) {
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DeleteRulesResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DeleteRulesRequest{
@@ -1017,12 +1028,12 @@ func DeleteRules(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.DeleteRules(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DeleteRules failed: %+v", err)
-		response.WriteAsJson(&pb.DeleteRulesResponse{})
+		logger.Error(ctx, "DeleteRules failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DeleteRules success: %+v", resp)
+	logger.Debug(ctx, "DeleteRules success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -1032,19 +1043,17 @@ func CreateAlert(request *restful.Request, response *restful.Response) {
 
 	err := request.ReadEntity(&alert)
 	if err != nil {
-		logger.Debug(nil, "CreateAlert request data error %+v.", err)
-		response.WriteAsJson(&pb.CreateAlertResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.CreateAlertResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.CreateAlertRequest{
@@ -1055,12 +1064,12 @@ func CreateAlert(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.CreateAlert(ctx, req)
 	if err != nil {
-		logger.Error(nil, "CreateAlert failed: %+v", err)
-		response.WriteAsJson(&pb.CreateAlertResponse{})
+		logger.Error(ctx, "CreateAlert failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "CreateAlert success: %+v", resp)
+	logger.Debug(ctx, "CreateAlert success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -1081,12 +1090,11 @@ func DescribeAlerts(request *restful.Request, response *restful.Response) {
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DescribeAlertsResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DescribeAlertsRequest{
@@ -1105,12 +1113,12 @@ func DescribeAlerts(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.DescribeAlerts(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DescribeAlerts failed: %+v", err)
-		response.WriteAsJson(&pb.DescribeAlertsResponse{})
+		logger.Error(ctx, "DescribeAlerts failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DescribeAlerts success: %+v", resp)
+	logger.Debug(ctx, "DescribeAlerts success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -1120,19 +1128,17 @@ func ModifyAlert(request *restful.Request, response *restful.Response) {
 
 	err := request.ReadEntity(&alert)
 	if err != nil {
-		logger.Debug(nil, "ModifyAlert request data error %+v.", err)
-		response.WriteAsJson(&pb.ModifyAlertResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.ModifyAlertResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.ModifyAlertRequest{
@@ -1145,12 +1151,12 @@ func ModifyAlert(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.ModifyAlert(ctx, req)
 	if err != nil {
-		logger.Error(nil, "ModifyAlert failed: %+v", err)
-		response.WriteAsJson(&pb.ModifyAlertResponse{})
+		logger.Error(ctx, "ModifyAlert failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "ModifyAlert success: %+v", resp)
+	logger.Debug(ctx, "ModifyAlert success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -1160,12 +1166,11 @@ func DeleteAlerts(request *restful.Request, response *restful.Response) {
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DeleteAlertsResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DeleteAlertsRequest{
@@ -1174,334 +1179,534 @@ func DeleteAlerts(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.DeleteAlerts(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DeleteAlerts failed: %+v", err)
-		response.WriteAsJson(&pb.DeleteAlertsResponse{})
+		logger.Error(ctx, "DeleteAlerts failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DeleteAlerts success: %+v", resp)
+	logger.Debug(ctx, "DeleteAlerts success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
 
-func removeResourceFilter(client pb.AlertManagerClient, ctx context.Context, rsFilterId string) {
-	var reqDeleteResourceFilter = &pb.DeleteResourceFiltersRequest{
-		RsFilterId: strings.Split(rsFilterId, ","),
-	}
-
-	client.DeleteResourceFilters(ctx, reqDeleteResourceFilter)
-}
-
-func removePolicy(client pb.AlertManagerClient, ctx context.Context, policyId string) {
-	var reqDeletePolicies = &pb.DeletePoliciesRequest{
-		PolicyId: strings.Split(policyId, ","),
-	}
-
-	client.DeletePolicies(ctx, reqDeletePolicies)
-}
-
 func createAlertInfo(resourceMap map[string]string, request *restful.Request, response *restful.Response) {
 	alertInfo := new(models.AlertInfo)
 
 	err := request.ReadEntity(&alertInfo)
 	if err != nil {
-		logger.Debug(nil, "createAlertInfo request data error %+v.", err)
-		response.WriteAsJson(&pb.CreateAlertResponse{})
-		return
-	}
-
-	client, err := alclient.NewClient()
-	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.CreateAlertResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	clientCustom, err := alclient.NewCustomClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.CreateAlertResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
-	//1. Check Rules Length
-	if len(alertInfo.Rules) == 0 {
-		logger.Error(nil, "CreateAlertInfo Rules Length error")
-		response.WriteAsJson(&pb.CreateAlertResponse{})
-		return
-	}
+	resourceSearch, _ := json.Marshal(resourceMap)
 
-	//2. Check if resource_type match
-	rsTypeIds := strings.Split(alertInfo.RsFilter.RsTypeId, ",")
+	var reqBundle = &pb.CreateAlertBundleRequest{
+		ResourceSearch: string(resourceSearch),
+		RsFilter: &pb.CreateResourceFilterRequest{
+			RsFilterName:  alertInfo.RsFilter.RsFilterName,
+			RsFilterParam: alertInfo.RsFilter.RsFilterParam,
+			Status:        alertInfo.RsFilter.Status,
+			RsTypeId:      alertInfo.RsFilter.RsTypeId,
+		},
+		Policy: &pb.CreatePolicyRequest{
+			PolicyName:         alertInfo.Policy.PolicyName,
+			PolicyDescription:  alertInfo.Policy.PolicyDescription,
+			PolicyConfig:       alertInfo.Policy.PolicyConfig,
+			Creator:            alertInfo.Policy.Creator,
+			AvailableStartTime: alertInfo.Policy.AvailableStartTime,
+			AvailableEndTime:   alertInfo.Policy.AvailableEndTime,
+		},
+		Action: alertInfoActionRequest(alertInfo),
+		Alert: &pb.CreateAlertRequest{
+			AlertName: alertInfo.Alert.AlertName,
+		},
+	}
 
-	var reqRsType = &pb.DescribeResourceTypesRequest{
-		RsTypeId: rsTypeIds,
+	for _, rule := range alertInfo.Rules {
+		reqBundle.Rules = append(reqBundle.Rules, &pb.CreateRuleRequest{
+			RuleName:           rule.RuleName,
+			Disabled:           rule.Disabled,
+			MonitorPeriods:     rule.MonitorPeriods,
+			Severity:           rule.Severity,
+			MetricsType:        rule.MetricsType,
+			ConditionType:      rule.ConditionType,
+			Thresholds:         rule.Thresholds,
+			Unit:               rule.Unit,
+			ConsecutiveCount:   rule.ConsecutiveCount,
+			Inhibit:            rule.Inhibit,
+			MetricId:           rule.MetricId,
+			EvaluationInterval: rule.EvaluationInterval,
+		})
 	}
 
-	respRsType, err := client.DescribeResourceTypes(ctx, reqRsType)
+	respBundle, err := clientCustom.CreateAlertBundle(ctx, reqBundle)
 	if err != nil {
-		logger.Error(nil, "CreateAlertInfo DescribeResourceTypes failed: %+v", err)
-		response.WriteAsJson(&pb.CreateAlertResponse{})
+		logger.Error(ctx, "CreateAlertInfo failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	if respRsType.Total != 1 {
-		logger.Error(nil, "CreateAlertInfo resource type error")
-		response.WriteAsJson(&pb.CreateAlertResponse{})
-		return
-	}
+	logger.Debug(ctx, "CreateAlertInfo success: %+v", respBundle)
 
-	if respRsType.ResourceTypeSet[0].RsTypeName != resourceMap["rs_type_name"] {
-		logger.Error(nil, "CreateAlertInfo resource type mismatch")
-		response.WriteAsJson(&pb.CreateAlertResponse{})
-		return
+	response.WriteAsJson(respBundle)
+}
+
+func CreateAlertCluster(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "cluster"
+
+	createAlertInfo(resourceMap, request, response)
+}
+
+func CreateAlertNode(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "node"
+
+	createAlertInfo(resourceMap, request, response)
+}
+
+func CreateAlertWorkspace(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "workspace"
+	resourceMap["ws_name"] = request.PathParameter("ws_name")
+
+	createAlertInfo(resourceMap, request, response)
+}
+
+func CreateAlertNamespace(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "namespace"
+	resourceMap["ns_name"] = request.PathParameter("ns_name")
+
+	createAlertInfo(resourceMap, request, response)
+}
+
+func CreateAlertWorkload(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "workload"
+	resourceMap["ns_name"] = request.PathParameter("ns_name")
+	resourceMap["node_id"] = request.PathParameter("node_id")
+
+	createAlertInfo(resourceMap, request, response)
+}
+
+func CreateAlertPod(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "pod"
+	resourceMap["ns_name"] = request.PathParameter("ns_name")
+	resourceMap["node_id"] = request.PathParameter("node_id")
+
+	createAlertInfo(resourceMap, request, response)
+}
+
+func CreateAlertContainer(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "container"
+	resourceMap["ns_name"] = request.PathParameter("ns_name")
+	resourceMap["node_id"] = request.PathParameter("node_id")
+	resourceMap["pod_name"] = request.PathParameter("pod_name")
+
+	createAlertInfo(resourceMap, request, response)
+}
+
+// alertInfoActionRequest builds the action of the alert bundle from the action of alertInfo,
+// the bundle replaces the action as a whole so every field is passed on.
+func alertInfoActionRequest(alertInfo *models.AlertInfo) *pb.CreateActionRequest {
+	return &pb.CreateActionRequest{
+		ActionName:      alertInfo.Action.ActionName,
+		TriggerStatus:   alertInfo.Action.TriggerStatus,
+		TriggerAction:   alertInfo.Action.TriggerAction,
+		NfAddressListId: alertInfo.Action.NfAddressListId,
 	}
+}
+
+// updateAlertInfo replaces the resource filter, policy, action and rules of the alert named in
+// the request in one go, rules without a rule_id are added and the ones left out removed.
+func updateAlertInfo(resourceMap map[string]string, request *restful.Request, response *restful.Response) {
+	alertInfo := new(models.AlertInfo)
 
-	//3. Check workspace namespace matches
-	rsFilterURI := make(map[string]string)
-	err = json.Unmarshal([]byte(alertInfo.RsFilter.RsFilterParam), &rsFilterURI)
+	err := request.ReadEntity(&alertInfo)
 	if err != nil {
-		logger.Error(nil, "CreateAlertInfo Unmarshal rsFilterURI Error: %+v", err)
-		response.WriteAsJson(&pb.CreateAlertResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
-	uriCorrect := true
-	switch resourceMap["rs_type_name"] {
-	case "cluster":
-		break
-	case "node":
-		break
-	case "workspace":
-		if resourceMap["ws_name"] != rsFilterURI["ws_name"] {
-			uriCorrect = false
-		}
-	case "namespace":
-		if resourceMap["ns_name"] != rsFilterURI["ns_name"] {
-			uriCorrect = false
-		}
-	case "workload":
-		if resourceMap["ns_name"] != rsFilterURI["ns_name"] {
-			uriCorrect = false
-		}
-	case "pod":
-		if resourceMap["ns_name"] != rsFilterURI["ns_name"] {
-			uriCorrect = false
-		}
-	case "container":
-		if resourceMap["ns_name"] != rsFilterURI["ns_name"] {
-			uriCorrect = false
-		}
+	if alertInfo.Alert.AlertName == "" {
+		writeError(request, response, gerr.New(request.Request.Context(), codes.InvalidArgument, gerr.ErrorMissingParameter, "alert_name"))
+		return
 	}
 
-	if !uriCorrect {
-		logger.Error(nil, "CreateAlertInfo uri mismatch")
-		response.WriteAsJson(&pb.CreateAlertResponse{})
+	clientCustom, err := alclient.NewCustomClient()
+	if err != nil {
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	//4. Check if same alert_name exists
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
+	defer cancel()
+
 	resourceSearch, _ := json.Marshal(resourceMap)
-	alertNames := strings.Split(alertInfo.Alert.AlertName, ",")
 	var reqCheck = &pb.DescribeAlertsWithResourceRequest{
 		ResourceSearch: string(resourceSearch),
-		AlertName:      alertNames,
+		AlertName:      []string{alertInfo.Alert.AlertName},
 	}
 
-	respCheck, err := clientCustom.DescribeAlertsWithResource(ctx, reqCheck)
+	respAlerts, err := clientCustom.DescribeAlertsWithResource(ctx, reqCheck)
 	if err != nil {
-		logger.Error(nil, "CreateAlertInfo check alert name failed: %+v", err)
-		response.WriteAsJson(&pb.CreateAlertResponse{})
+		logger.Error(ctx, "UpdateAlertInfo check alert name failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	if respCheck.Total != 0 {
-		logger.Error(nil, "CreateAlertInfo alert name already exists")
-		response.WriteAsJson(&pb.CreateAlertResponse{})
+	if !checkSingleAlert(ctx, request, response, respAlerts, alertInfo.Alert.AlertName) {
 		return
 	}
 
-	//5. Create Resource Filter
-	var reqRsFilter = &pb.CreateResourceFilterRequest{
-		RsFilterName:  alertInfo.RsFilter.RsFilterName,
-		RsFilterParam: alertInfo.RsFilter.RsFilterParam,
-		Status:        alertInfo.RsFilter.Status,
-		RsTypeId:      alertInfo.RsFilter.RsTypeId,
+	var reqBundle = &pb.UpdateAlertBundleRequest{
+		AlertId:        respAlerts.AlertSet[0].AlertId,
+		ResourceSearch: string(resourceSearch),
+		RsFilter: &pb.CreateResourceFilterRequest{
+			RsFilterName:  alertInfo.RsFilter.RsFilterName,
+			RsFilterParam: alertInfo.RsFilter.RsFilterParam,
+			Status:        alertInfo.RsFilter.Status,
+			RsTypeId:      alertInfo.RsFilter.RsTypeId,
+		},
+		Policy: &pb.CreatePolicyRequest{
+			PolicyName:         alertInfo.Policy.PolicyName,
+			PolicyDescription:  alertInfo.Policy.PolicyDescription,
+			PolicyConfig:       alertInfo.Policy.PolicyConfig,
+			Creator:            alertInfo.Policy.Creator,
+			AvailableStartTime: alertInfo.Policy.AvailableStartTime,
+			AvailableEndTime:   alertInfo.Policy.AvailableEndTime,
+		},
+		Action: alertInfoActionRequest(alertInfo),
+		Alert: &pb.CreateAlertRequest{
+			AlertName: alertInfo.Alert.AlertName,
+			Disabled:  alertInfo.Alert.Disabled,
+		},
+	}
+
+	for _, rule := range alertInfo.Rules {
+		reqBundle.Rules = append(reqBundle.Rules, &pb.UpdateAlertBundleRule{
+			RuleId: rule.RuleId,
+			Rule: &pb.CreateRuleRequest{
+				RuleName:           rule.RuleName,
+				Disabled:           rule.Disabled,
+				MonitorPeriods:     rule.MonitorPeriods,
+				Severity:           rule.Severity,
+				MetricsType:        rule.MetricsType,
+				ConditionType:      rule.ConditionType,
+				Thresholds:         rule.Thresholds,
+				Unit:               rule.Unit,
+				ConsecutiveCount:   rule.ConsecutiveCount,
+				Inhibit:            rule.Inhibit,
+				MetricId:           rule.MetricId,
+				EvaluationInterval: rule.EvaluationInterval,
+			},
+		})
 	}
 
-	respRsFilter, err := client.CreateResourceFilter(ctx, reqRsFilter)
+	respBundle, err := clientCustom.UpdateAlertBundle(ctx, reqBundle)
 	if err != nil {
-		logger.Error(nil, "CreateAlertInfo Resource Filter failed: %+v", err)
-		response.WriteAsJson(&pb.CreateAlertResponse{})
+		logger.Error(ctx, "UpdateAlertInfo failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "CreateAlertInfo Resource Filter success: %+v", respRsFilter)
-	rsFilterId := respRsFilter.RsFilterId
+	logger.Debug(ctx, "UpdateAlertInfo success: %+v", respBundle)
 
-	//6. Create Policy
-	var reqPolicy = &pb.CreatePolicyRequest{
-		PolicyName:         alertInfo.Policy.PolicyName,
-		PolicyDescription:  alertInfo.Policy.PolicyDescription,
-		PolicyConfig:       alertInfo.Policy.PolicyConfig,
-		Creator:            alertInfo.Policy.Creator,
-		AvailableStartTime: alertInfo.Policy.AvailableStartTime,
-		AvailableEndTime:   alertInfo.Policy.AvailableEndTime,
-		RsTypeId:           alertInfo.RsFilter.RsTypeId,
-	}
+	response.WriteAsJson(respBundle)
+}
 
-	respPolicy, err := client.CreatePolicy(ctx, reqPolicy)
-	if err != nil {
-		logger.Error(nil, "CreateAlertInfo Policy failed: %+v", err)
+func UpdateAlertCluster(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "cluster"
+
+	updateAlertInfo(resourceMap, request, response)
+}
+
+func UpdateAlertNode(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "node"
+
+	updateAlertInfo(resourceMap, request, response)
+}
+
+func UpdateAlertWorkspace(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "workspace"
+	resourceMap["ws_name"] = request.PathParameter("ws_name")
+
+	updateAlertInfo(resourceMap, request, response)
+}
+
+func UpdateAlertNamespace(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "namespace"
+	resourceMap["ns_name"] = request.PathParameter("ns_name")
+
+	updateAlertInfo(resourceMap, request, response)
+}
+
+func UpdateAlertWorkload(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "workload"
+	resourceMap["ns_name"] = request.PathParameter("ns_name")
+	resourceMap["node_id"] = request.PathParameter("node_id")
+
+	updateAlertInfo(resourceMap, request, response)
+}
+
+func UpdateAlertPod(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "pod"
+	resourceMap["ns_name"] = request.PathParameter("ns_name")
+	resourceMap["node_id"] = request.PathParameter("node_id")
+
+	updateAlertInfo(resourceMap, request, response)
+}
 
-		removeResourceFilter(client, ctx, rsFilterId)
+func UpdateAlertContainer(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "container"
+	resourceMap["ns_name"] = request.PathParameter("ns_name")
+	resourceMap["node_id"] = request.PathParameter("node_id")
+	resourceMap["pod_name"] = request.PathParameter("pod_name")
 
-		response.WriteAsJson(&pb.CreateAlertResponse{})
+	updateAlertInfo(resourceMap, request, response)
+}
+
+// exportAlertDocument answers the alerts named in the request, all of them if none, as an alert
+// document in yaml or json.
+func exportAlertDocument(resourceMap map[string]string, request *restful.Request, response *restful.Response) {
+	resourceSearch, _ := json.Marshal(resourceMap)
+	alertNames := strings.Split(request.QueryParameter("alert_names"), ",")
+	format := request.QueryParameter("format")
+
+	clientCustom, err := alclient.NewCustomClient()
+	if err != nil {
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "CreateAlertInfo Policy success: %+v", respPolicy)
-	policyId := respPolicy.PolicyId
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
+	defer cancel()
 
-	//7. Create Action
-	var reqAction = &pb.CreateActionRequest{
-		ActionName:      alertInfo.Action.ActionName,
-		PolicyId:        policyId,
-		NfAddressListId: alertInfo.Action.NfAddressListId,
+	var req = &pb.ExportAlertsRequest{
+		ResourceSearch: string(resourceSearch),
+		AlertName:      stringutil.SimplifyStringList(alertNames),
+		Format:         format,
 	}
 
-	respAction, err := client.CreateAction(ctx, reqAction)
+	resp, err := clientCustom.ExportAlerts(ctx, req)
 	if err != nil {
-		logger.Error(nil, "CreateAlertInfo Action failed: %+v", err)
+		logger.Error(ctx, "ExportAlertDocument failed: %+v", err)
+		writeError(request, response, err)
+		return
+	}
 
-		removeResourceFilter(client, ctx, rsFilterId)
-		removePolicy(client, ctx, policyId)
+	logger.Debug(ctx, "ExportAlertDocument success: %d bytes", len(resp.Document))
 
-		response.WriteAsJson(&pb.CreateAlertResponse{})
-		return
+	if format == models.AlertDocumentFormatJson {
+		response.AddHeader("Content-Type", restful.MIME_JSON)
+	} else {
+		response.AddHeader("Content-Type", constants.MIME_YAML)
 	}
+	response.Write([]byte(resp.Document))
+}
 
-	logger.Debug(nil, "CreateAlertInfo Action success: %+v", respAction)
+func ExportAlertDocumentCluster(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "cluster"
 
-	//8. Create Rules
-	createRulesSuccess := true
-	for _, rule := range alertInfo.Rules {
-		var reqRule = &pb.CreateRuleRequest{
-			RuleName:         rule.RuleName,
-			Disabled:         rule.Disabled,
-			MonitorPeriods:   rule.MonitorPeriods,
-			Severity:         rule.Severity,
-			MetricsType:      rule.MetricsType,
-			ConditionType:    rule.ConditionType,
-			Thresholds:       rule.Thresholds,
-			Unit:             rule.Unit,
-			ConsecutiveCount: rule.ConsecutiveCount,
-			Inhibit:          rule.Inhibit,
-			PolicyId:         policyId,
-			MetricId:         rule.MetricId,
-		}
+	exportAlertDocument(resourceMap, request, response)
+}
 
-		_, err := client.CreateRule(ctx, reqRule)
-		if err != nil {
-			createRulesSuccess = false
-			break
-		}
-	}
+func ExportAlertDocumentNode(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "node"
 
-	if !createRulesSuccess {
-		logger.Error(nil, "CreateAlertInfo Rules failed")
+	exportAlertDocument(resourceMap, request, response)
+}
 
-		removeResourceFilter(client, ctx, rsFilterId)
-		removePolicy(client, ctx, policyId)
+func ExportAlertDocumentWorkspace(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "workspace"
+	resourceMap["ws_name"] = request.PathParameter("ws_name")
 
-		response.WriteAsJson(&pb.CreateAlertResponse{})
-		return
-	}
+	exportAlertDocument(resourceMap, request, response)
+}
+
+func ExportAlertDocumentNamespace(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "namespace"
+	resourceMap["ns_name"] = request.PathParameter("ns_name")
 
-	logger.Debug(nil, "CreateAlertInfo Rules success")
+	exportAlertDocument(resourceMap, request, response)
+}
 
-	//9. Create Alert
-	var reqAlert = &pb.CreateAlertRequest{
-		AlertName:  alertInfo.Alert.AlertName,
-		PolicyId:   policyId,
-		RsFilterId: rsFilterId,
+func ExportAlertDocumentWorkload(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "workload"
+	resourceMap["ns_name"] = request.PathParameter("ns_name")
+	resourceMap["node_id"] = request.PathParameter("node_id")
+
+	exportAlertDocument(resourceMap, request, response)
+}
+
+func ExportAlertDocumentPod(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "pod"
+	resourceMap["ns_name"] = request.PathParameter("ns_name")
+	resourceMap["node_id"] = request.PathParameter("node_id")
+
+	exportAlertDocument(resourceMap, request, response)
+}
+
+func ExportAlertDocumentContainer(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "container"
+	resourceMap["ns_name"] = request.PathParameter("ns_name")
+	resourceMap["node_id"] = request.PathParameter("node_id")
+	resourceMap["pod_name"] = request.PathParameter("pod_name")
+
+	exportAlertDocument(resourceMap, request, response)
+}
+
+// importAlertDocument creates or updates the alerts of the alert document in the request body,
+// matching them by name, or only describes the changes in a dry run.
+func importAlertDocument(resourceMap map[string]string, request *restful.Request, response *restful.Response) {
+	document, err := ioutil.ReadAll(request.Request.Body)
+	if err != nil {
+		writeBadRequest(request, response, err)
+		return
 	}
 
-	respAlert, err := client.CreateAlert(ctx, reqAlert)
+	clientCustom, err := alclient.NewCustomClient()
 	if err != nil {
-		logger.Error(nil, "CreateAlertInfo Alert failed: %+v", err)
+		writeManagerUnavailable(request, response, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
+	defer cancel()
 
-		removeResourceFilter(client, ctx, rsFilterId)
-		removePolicy(client, ctx, policyId)
+	resourceSearch, _ := json.Marshal(resourceMap)
+	var req = &pb.ImportAlertsRequest{
+		ResourceSearch: string(resourceSearch),
+		Document:       string(document),
+		DryRun:         parseBool(request.QueryParameter("dry_run")),
+	}
 
-		response.WriteAsJson(&pb.CreateAlertResponse{})
+	resp, err := clientCustom.ImportAlerts(ctx, req)
+	if err != nil {
+		logger.Error(ctx, "ImportAlertDocument failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "CreateAlertInfo Alert success: %+v", respAlert)
+	logger.Debug(ctx, "ImportAlertDocument success: %+v", resp)
 
-	response.WriteAsJson(respAlert)
+	response.WriteAsJson(resp)
 }
 
-func CreateAlertCluster(request *restful.Request, response *restful.Response) {
+func ImportAlertDocumentCluster(request *restful.Request, response *restful.Response) {
 	resourceMap := map[string]string{}
 	resourceMap["rs_type_name"] = "cluster"
 
-	createAlertInfo(resourceMap, request, response)
+	importAlertDocument(resourceMap, request, response)
 }
 
-func CreateAlertNode(request *restful.Request, response *restful.Response) {
+func ImportAlertDocumentNode(request *restful.Request, response *restful.Response) {
 	resourceMap := map[string]string{}
 	resourceMap["rs_type_name"] = "node"
 
-	createAlertInfo(resourceMap, request, response)
+	importAlertDocument(resourceMap, request, response)
 }
 
-func CreateAlertWorkspace(request *restful.Request, response *restful.Response) {
+func ImportAlertDocumentWorkspace(request *restful.Request, response *restful.Response) {
 	resourceMap := map[string]string{}
 	resourceMap["rs_type_name"] = "workspace"
 	resourceMap["ws_name"] = request.PathParameter("ws_name")
 
-	createAlertInfo(resourceMap, request, response)
+	importAlertDocument(resourceMap, request, response)
 }
 
-func CreateAlertNamespace(request *restful.Request, response *restful.Response) {
+func ImportAlertDocumentNamespace(request *restful.Request, response *restful.Response) {
 	resourceMap := map[string]string{}
 	resourceMap["rs_type_name"] = "namespace"
 	resourceMap["ns_name"] = request.PathParameter("ns_name")
 
-	createAlertInfo(resourceMap, request, response)
+	importAlertDocument(resourceMap, request, response)
 }
 
-func CreateAlertWorkload(request *restful.Request, response *restful.Response) {
+func ImportAlertDocumentWorkload(request *restful.Request, response *restful.Response) {
 	resourceMap := map[string]string{}
 	resourceMap["rs_type_name"] = "workload"
 	resourceMap["ns_name"] = request.PathParameter("ns_name")
 	resourceMap["node_id"] = request.PathParameter("node_id")
 
-	createAlertInfo(resourceMap, request, response)
+	importAlertDocument(resourceMap, request, response)
 }
 
-func CreateAlertPod(request *restful.Request, response *restful.Response) {
+func ImportAlertDocumentPod(request *restful.Request, response *restful.Response) {
 	resourceMap := map[string]string{}
 	resourceMap["rs_type_name"] = "pod"
 	resourceMap["ns_name"] = request.PathParameter("ns_name")
 	resourceMap["node_id"] = request.PathParameter("node_id")
 
-	createAlertInfo(resourceMap, request, response)
+	importAlertDocument(resourceMap, request, response)
 }
 
-func CreateAlertContainer(request *restful.Request, response *restful.Response) {
+func ImportAlertDocumentContainer(request *restful.Request, response *restful.Response) {
 	resourceMap := map[string]string{}
 	resourceMap["rs_type_name"] = "container"
 	resourceMap["ns_name"] = request.PathParameter("ns_name")
 	resourceMap["node_id"] = request.PathParameter("node_id")
 	resourceMap["pod_name"] = request.PathParameter("pod_name")
 
-	createAlertInfo(resourceMap, request, response)
+	importAlertDocument(resourceMap, request, response)
+}
+
+func ReceiveAlerts(request *restful.Request, response *restful.Response) {
+	alerts, err := ioutil.ReadAll(request.Request.Body)
+	if err != nil {
+		writeBadRequest(request, response, err)
+		return
+	}
+
+	clientCustom, err := alclient.NewCustomClient()
+	if err != nil {
+		writeManagerUnavailable(request, response, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	var req = &pb.ReceiveAlertsRequest{
+		Alerts: string(alerts),
+	}
+
+	resp, err := clientCustom.ReceiveAlerts(ctx, req)
+	if err != nil {
+		logger.Error(ctx, "ReceiveAlerts failed: %+v", err)
+		writeError(request, response, err)
+		return
+	}
+
+	logger.Debug(ctx, "ReceiveAlerts success: %+v", resp)
+
+	response.WriteAsJson(resp)
 }
 
 type ModifyAlertByNameResponse struct {
@@ -1513,26 +1718,23 @@ func modifyAlertByName(resourceMap map[string]string, request *restful.Request,
 
 	err := request.ReadEntity(&alert)
 	if err != nil {
-		logger.Debug(nil, "ModifyAlertByName request data error %+v.", err)
-		response.WriteAsJson(&ModifyAlertByNameResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	alertNames := stringutil.SimplifyStringList(strings.Split(alert.AlertName, ","))
 	if len(alertNames) == 0 {
-		logger.Debug(nil, "ModifyAlertByName has no alert name specified.")
-		response.WriteAsJson(&ModifyAlertByNameResponse{})
+		writeError(request, response, gerr.New(request.Request.Context(), codes.InvalidArgument, gerr.ErrorMissingParameter, "alert_name"))
 		return
 	}
 
 	clientCustom, err := alclient.NewCustomClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&ModifyAlertByNameResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	resourceSearch, _ := json.Marshal(resourceMap)
@@ -1543,21 +1745,18 @@ func modifyAlertByName(resourceMap map[string]string, request *restful.Request,
 
 	respAlerts, err := clientCustom.DescribeAlertsWithResource(ctx, reqCheck)
 	if err != nil {
-		logger.Error(nil, "ModifyAlertByName check alert name failed: %+v", err)
-		response.WriteAsJson(&ModifyAlertByNameResponse{})
+		logger.Error(ctx, "ModifyAlertByName check alert name failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	if respAlerts.Total != 1 {
-		logger.Debug(nil, "ModifyAlertByName get no match alert name or duplicate names.")
-		response.WriteAsJson(&ModifyAlertByNameResponse{})
+	if !checkSingleAlert(ctx, request, response, respAlerts, alert.AlertName) {
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&ModifyAlertByNameResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
@@ -1570,21 +1769,21 @@ func modifyAlertByName(resourceMap map[string]string, request *restful.Request,
 
 	respModify, err := client.ModifyAlert(ctx, req)
 	if err != nil {
-		logger.Error(nil, "ModifyAlertByName failed: %+v", err)
-		response.WriteAsJson(&ModifyAlertByNameResponse{})
+		logger.Error(ctx, "ModifyAlertByName failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
 	if respModify.AlertId != respAlerts.AlertSet[0].AlertId {
-		logger.Debug(nil, "ModifyAlertByName failed, AlertId request[%+v] response[%+v] mismatch", respAlerts.AlertSet[0].AlertId, respModify.AlertId)
-		response.WriteAsJson(&ModifyAlertByNameResponse{})
+		logger.Error(ctx, "ModifyAlertByName failed, AlertId request[%+v] response[%+v] mismatch", respAlerts.AlertSet[0].AlertId, respModify.AlertId)
+		writeError(request, response, gerr.New(ctx, codes.Internal, gerr.ErrorInternalError))
 		return
 	}
 
 	resp := ModifyAlertByNameResponse{
 		AlertName: alert.AlertName,
 	}
-	logger.Debug(nil, "ModifyAlertByName success: %+v", resp)
+	logger.Debug(ctx, "ModifyAlertByName success: %+v", resp)
 	response.WriteAsJson(resp)
 }
 
@@ -1646,6 +1845,14 @@ func ModifyAlertByNameContainer(request *restful.Request, response *restful.Resp
 	modifyAlertByName(resourceMap, request, response)
 }
 
+func ModifyAlertByNameExternal(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "external"
+	resourceMap["ns_name"] = request.QueryParameter("ns_name")
+
+	modifyAlertByName(resourceMap, request, response)
+}
+
 type DeleteAlertsByNameResponse struct {
 	AlertName []string `json:"alert_name"`
 }
@@ -1653,19 +1860,17 @@ type DeleteAlertsByNameResponse struct {
 func deleteAlertsByName(resourceMap map[string]string, request *restful.Request, response *restful.Response) {
 	alertNames := stringutil.SimplifyStringList(strings.Split(request.QueryParameter("alert_names"), ","))
 	if len(alertNames) == 0 {
-		logger.Debug(nil, "DeleteAlertsByName has no alert name specified.")
-		response.WriteAsJson(&DeleteAlertsByNameResponse{})
+		writeError(request, response, gerr.New(request.Request.Context(), codes.InvalidArgument, gerr.ErrorMissingParameter, "alert_names"))
 		return
 	}
 
 	clientCustom, err := alclient.NewCustomClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&DeleteAlertsByNameResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	resourceSearch, _ := json.Marshal(resourceMap)
@@ -1676,14 +1881,13 @@ func deleteAlertsByName(resourceMap map[string]string, request *restful.Request,
 
 	respAlerts, err := clientCustom.DescribeAlertsWithResource(ctx, reqCheck)
 	if err != nil {
-		logger.Error(nil, "DeleteAlertsByName check alert name failed: %+v", err)
-		response.WriteAsJson(&DeleteAlertsByNameResponse{})
+		logger.Error(ctx, "DeleteAlertsByName check alert name failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
 	if respAlerts.Total == 0 {
-		logger.Debug(nil, "DeleteAlertsByName get no match alert name.")
-		response.WriteAsJson(&DeleteAlertsByNameResponse{})
+		writeError(request, response, gerr.New(ctx, codes.NotFound, gerr.ErrorResourceNotFound, strings.Join(alertNames, ",")))
 		return
 	}
 
@@ -1701,15 +1905,14 @@ func deleteAlertsByName(resourceMap map[string]string, request *restful.Request,
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&DeleteAlertsByNameResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
 	respDelete, err := client.DeleteAlerts(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DeleteAlertsByName failed: %+v", err)
-		response.WriteAsJson(&DeleteAlertsByNameResponse{})
+		logger.Error(ctx, "DeleteAlertsByName failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
@@ -1722,7 +1925,7 @@ func deleteAlertsByName(resourceMap map[string]string, request *restful.Request,
 		AlertName: alertNamesSuccess,
 	}
 
-	logger.Debug(nil, "DeleteAlertsByName success: %+v", resp)
+	logger.Debug(ctx, "DeleteAlertsByName success: %+v", resp)
 	response.WriteAsJson(resp)
 }
 
@@ -1784,6 +1987,14 @@ func DeleteAlertsByNameContainer(request *restful.Request, response *restful.Res
 	deleteAlertsByName(resourceMap, request, response)
 }
 
+func DeleteAlertsByNameExternal(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "external"
+	resourceMap["ns_name"] = request.QueryParameter("ns_name")
+
+	deleteAlertsByName(resourceMap, request, response)
+}
+
 func describeAlertDetails(resourceMap map[string]string, request *restful.Request, response *restful.Response) {
 	resourceSearch, _ := json.Marshal(resourceMap)
 	alertIds := strings.Split(request.QueryParameter("alert_ids"), ",")
@@ -1802,12 +2013,11 @@ func describeAlertDetails(resourceMap map[string]string, request *restful.Reques
 
 	clientCustom, err := alclient.NewCustomClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DescribeAlertDetailsResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DescribeAlertDetailsRequest{
@@ -1829,12 +2039,12 @@ func describeAlertDetails(resourceMap map[string]string, request *restful.Reques
 
 	resp, err := clientCustom.DescribeAlertDetails(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DescribeAlertDetails failed: %+v", err)
-		response.WriteAsJson(&pb.DescribeAlertDetailsResponse{})
+		logger.Error(ctx, "DescribeAlertDetails failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DescribeAlertDetails success: %+v", resp)
+	logger.Debug(ctx, "DescribeAlertDetails success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -1897,6 +2107,14 @@ func DescribeAlertDetailsContainer(request *restful.Request, response *restful.R
 	describeAlertDetails(resourceMap, request, response)
 }
 
+func DescribeAlertDetailsExternal(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "external"
+	resourceMap["ns_name"] = request.QueryParameter("ns_name")
+
+	describeAlertDetails(resourceMap, request, response)
+}
+
 func describeAlertStatus(resourceMap map[string]string, request *restful.Request, response *restful.Response) {
 	resourceSearch, _ := json.Marshal(resourceMap)
 	alertIds := strings.Split(request.QueryParameter("alert_ids"), ",")
@@ -1916,12 +2134,11 @@ func describeAlertStatus(resourceMap map[string]string, request *restful.Request
 
 	clientCustom, err := alclient.NewCustomClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DescribeAlertStatusResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DescribeAlertStatusRequest{
@@ -1943,11 +2160,11 @@ func describeAlertStatus(resourceMap map[string]string, request *restful.Request
 
 	resp, err := clientCustom.DescribeAlertStatus(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DescribeAlertStatus failed: %+v", err)
-		response.WriteAsJson(&pb.DescribeAlertStatusResponse{})
+		logger.Error(ctx, "DescribeAlertStatus failed: %+v", err)
+		writeError(request, response, err)
 	}
 
-	logger.Debug(nil, "DescribeAlertStatus success: %+v", resp)
+	logger.Debug(ctx, "DescribeAlertStatus success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -2010,6 +2227,14 @@ func DescribeAlertStatusContainer(request *restful.Request, response *restful.Re
 	describeAlertStatus(resourceMap, request, response)
 }
 
+func DescribeAlertStatusExternal(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "external"
+	resourceMap["ns_name"] = request.QueryParameter("ns_name")
+
+	describeAlertStatus(resourceMap, request, response)
+}
+
 func DescribeHistories(request *restful.Request, response *restful.Response) {
 	historyIds := strings.Split(request.QueryParameter("history_ids"), ",")
 	historyNames := strings.Split(request.QueryParameter("history_names"), ",")
@@ -2024,12 +2249,11 @@ func DescribeHistories(request *restful.Request, response *restful.Response) {
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DescribeHistoriesResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DescribeHistoriesRequest{
@@ -2046,12 +2270,12 @@ func DescribeHistories(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.DescribeHistories(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DescribeHistories failed: %+v", err)
-		response.WriteAsJson(&pb.DescribeHistoriesResponse{})
+		logger.Error(ctx, "DescribeHistories failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DescribeHistories success: %+v", resp)
+	logger.Debug(ctx, "DescribeHistories success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -2074,12 +2298,11 @@ func describeHistoryDetail(resourceMap map[string]string, request *restful.Reque
 
 	clientCustom, err := alclient.NewCustomClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DescribeHistoryDetailResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DescribeHistoryDetailRequest{
@@ -2101,12 +2324,12 @@ func describeHistoryDetail(resourceMap map[string]string, request *restful.Reque
 
 	resp, err := clientCustom.DescribeHistoryDetail(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DescribeHistoryDetail failed: %+v", err)
-		response.WriteAsJson(&pb.DescribeHistoryDetailResponse{})
+		logger.Error(ctx, "DescribeHistoryDetail failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DescribeHistoryDetail success: %+v", resp)
+	logger.Debug(ctx, "DescribeHistoryDetail success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -2169,24 +2392,30 @@ func DescribeHistoryDetailContainer(request *restful.Request, response *restful.
 	describeHistoryDetail(resourceMap, request, response)
 }
 
+func DescribeHistoryDetailExternal(request *restful.Request, response *restful.Response) {
+	resourceMap := map[string]string{}
+	resourceMap["rs_type_name"] = "external"
+	resourceMap["ns_name"] = request.QueryParameter("ns_name")
+
+	describeHistoryDetail(resourceMap, request, response)
+}
+
 func CreateComment(request *restful.Request, response *restful.Response) {
 	comment := new(models.Comment)
 
 	err := request.ReadEntity(&comment)
 	if err != nil {
-		logger.Debug(nil, "CreateComment request data error %+v.", err)
-		response.WriteAsJson(&pb.CreateCommentResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create comment grpc client %+v.", err)
-		response.WriteAsJson(&pb.CreateCommentResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.CreateCommentRequest{
@@ -2197,12 +2426,12 @@ func CreateComment(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.CreateComment(ctx, req)
 	if err != nil {
-		logger.Error(nil, "CreateComment failed: %+v", err)
-		response.WriteAsJson(&pb.CreateCommentResponse{})
+		logger.Error(ctx, "CreateComment failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "CreateComment success: %+v", resp)
+	logger.Debug(ctx, "CreateComment success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -2220,12 +2449,11 @@ func DescribeComments(request *restful.Request, response *restful.Response) {
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DescribeCommentsResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DescribeCommentsRequest{
@@ -2241,12 +2469,63 @@ func DescribeComments(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.DescribeComments(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DescribeComments failed: %+v", err)
-		response.WriteAsJson(&pb.DescribeCommentsResponse{})
+		logger.Error(ctx, "DescribeComments failed: %+v", err)
+		writeError(request, response, err)
+		return
+	}
+
+	logger.Debug(ctx, "DescribeComments success: %+v", resp)
+
+	response.WriteAsJson(resp)
+}
+
+func DescribeAuditLogs(request *restful.Request, response *restful.Response) {
+	auditIds := strings.Split(request.QueryParameter("audit_ids"), ",")
+	actors := strings.Split(request.QueryParameter("actors"), ",")
+	operations := strings.Split(request.QueryParameter("operations"), ",")
+	resourceTypes := strings.Split(request.QueryParameter("resource_types"), ",")
+	resourceIds := strings.Split(request.QueryParameter("resource_ids"), ",")
+	requestIds := strings.Split(request.QueryParameter("request_ids"), ",")
+	startTime := parseTime(request.QueryParameter("start_time"))
+	endTime := parseTime(request.QueryParameter("end_time"))
+
+	sortKey := request.QueryParameter("sort_key")
+	reverse := parseBool(request.QueryParameter("reverse"))
+	offset, _ := parseUint32(request.QueryParameter("offset"))
+	limit, _ := parseUint32(request.QueryParameter("limit"))
+
+	client, err := alclient.NewClient()
+	if err != nil {
+		writeManagerUnavailable(request, response, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	var req = &pb.DescribeAuditLogsRequest{
+		AuditId:      auditIds,
+		Actor:        actors,
+		Operation:    operations,
+		ResourceType: resourceTypes,
+		ResourceId:   resourceIds,
+		RequestId:    requestIds,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		SortKey:      sortKey,
+		Reverse:      reverse,
+		Offset:       offset,
+		Limit:        limit,
+	}
+
+	resp, err := client.DescribeAuditLogs(ctx, req)
+	if err != nil {
+		logger.Error(ctx, "DescribeAuditLogs failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DescribeComments success: %+v", resp)
+	logger.Debug(ctx, "DescribeAuditLogs success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -2256,19 +2535,17 @@ func CreateAction(request *restful.Request, response *restful.Response) {
 
 	err := request.ReadEntity(&action)
 	if err != nil {
-		logger.Debug(nil, "CreateAction request data error %+v.", err)
-		response.WriteAsJson(&pb.CreateActionResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.CreateActionResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.CreateActionRequest{
@@ -2281,12 +2558,12 @@ func CreateAction(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.CreateAction(ctx, req)
 	if err != nil {
-		logger.Error(nil, "CreateAction failed: %+v", err)
-		response.WriteAsJson(&pb.CreateActionResponse{})
+		logger.Error(ctx, "CreateAction failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "CreateAction success: %+v", resp)
+	logger.Debug(ctx, "CreateAction success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -2306,12 +2583,11 @@ func DescribeActions(request *restful.Request, response *restful.Response) {
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DescribeActionsResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DescribeActionsRequest{
@@ -2329,12 +2605,12 @@ func DescribeActions(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.DescribeActions(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DescribeActions failed: %+v", err)
-		response.WriteAsJson(&pb.DescribeActionsResponse{})
+		logger.Error(ctx, "DescribeActions failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DescribeActions success: %+v", resp)
+	logger.Debug(ctx, "DescribeActions success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -2344,19 +2620,17 @@ func ModifyAction(request *restful.Request, response *restful.Response) {
 
 	err := request.ReadEntity(&action)
 	if err != nil {
-		logger.Debug(nil, "ModifyAction request data error %+v.", err)
-		response.WriteAsJson(&pb.ModifyActionResponse{})
+		writeBadRequest(request, response, err)
 		return
 	}
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.ModifyActionResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.ModifyActionRequest{
@@ -2370,12 +2644,12 @@ func ModifyAction(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.ModifyAction(ctx, req)
 	if err != nil {
-		logger.Error(nil, "ModifyAction failed: %+v", err)
-		response.WriteAsJson(&pb.ModifyActionResponse{})
+		logger.Error(ctx, "ModifyAction failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "ModifyAction success: %+v", resp)
+	logger.Debug(ctx, "ModifyAction success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -2385,12 +2659,11 @@ func DeleteActions(request *restful.Request, response *restful.Response) {
 
 	client, err := alclient.NewClient()
 	if err != nil {
-		logger.Error(nil, "Failed to create alert grpc client %+v.", err)
-		response.WriteAsJson(&pb.DeleteActionsResponse{})
+		writeManagerUnavailable(request, response, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(request.Request.Context(), 60*time.Second)
 	defer cancel()
 
 	var req = &pb.DeleteActionsRequest{
@@ -2399,12 +2672,12 @@ func DeleteActions(request *restful.Request, response *restful.Response) {
 
 	resp, err := client.DeleteActions(ctx, req)
 	if err != nil {
-		logger.Error(nil, "DeleteActions failed: %+v", err)
-		response.WriteAsJson(&pb.DeleteActionsResponse{})
+		logger.Error(ctx, "DeleteActions failed: %+v", err)
+		writeError(request, response, err)
 		return
 	}
 
-	logger.Debug(nil, "DeleteActions success: %+v", resp)
+	logger.Debug(ctx, "DeleteActions success: %+v", resp)
 
 	response.WriteAsJson(resp)
 }
@@ -2418,8 +2691,7 @@ func DescribeResourcesNode(request *restful.Request, response *restful.Response)
 	resourceSelector := []map[string]string{}
 	err := json.Unmarshal([]byte(request.QueryParameter("selector")), &resourceSelector)
 	if err != nil {
-		logger.Error(nil, "Unmarshal DescribeResourcesNode Error: %+v", err)
-		response.WriteAsJson(resources)
+		writeError(request, response, gerr.NewWithDetail(request.Request.Context(), codes.InvalidArgument, err, gerr.ErrorUnsupportedParameterValue, "selector", request.QueryParameter("selector")))
 		return
 	}
 
@@ -2427,8 +2699,7 @@ func DescribeResourcesNode(request *restful.Request, response *restful.Response)
 
 	nodeList, err := k8sclient.NewK8sClient().CoreV1().Nodes().List(metaV1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
-		logger.Error(nil, "getResourceFilterURIBySelector list nodes error: %+v", err)
-		response.WriteAsJson(resources)
+		writeError(request, response, gerr.NewWithDetail(request.Request.Context(), codes.Internal, err, gerr.ErrorDescribeResourcesFailed))
 		return
 	}
 
@@ -2451,8 +2722,7 @@ func DescribeResourcesWorkload(request *restful.Request, response *restful.Respo
 	resourceSelector := []map[string]string{}
 	err := json.Unmarshal([]byte(request.QueryParameter("selector")), &resourceSelector)
 	if err != nil {
-		logger.Error(nil, "Unmarshal DescribeResourcesNode Error: %+v", err)
-		response.WriteAsJson(resources)
+		writeError(request, response, gerr.NewWithDetail(request.Request.Context(), codes.InvalidArgument, err, gerr.ErrorUnsupportedParameterValue, "selector", request.QueryParameter("selector")))
 		return
 	}
 
@@ -2464,8 +2734,8 @@ func DescribeResourcesWorkload(request *restful.Request, response *restful.Respo
 	case "deployment":
 		deploymentList, err := k8sclient.NewK8sClient().ExtensionsV1beta1().Deployments(namespace).List(metaV1.ListOptions{LabelSelector: labelSelector})
 		if err != nil {
-			logger.Error(nil, "DescribeResourcesWorkload list deployments error: %+v", err)
-			break
+			writeError(request, response, gerr.NewWithDetail(request.Request.Context(), codes.Internal, err, gerr.ErrorDescribeResourcesFailed))
+			return
 		}
 		for _, deployment := range deploymentList.Items {
 			resources = append(resources, deployment.Name)
@@ -2473,8 +2743,8 @@ func DescribeResourcesWorkload(request *restful.Request, response *restful.Respo
 	case "statefulset":
 		statefulsetList, err := k8sclient.NewK8sClient().AppsV1().StatefulSets(namespace).List(metaV1.ListOptions{LabelSelector: labelSelector})
 		if err != nil {
-			logger.Error(nil, "DescribeResourcesWorkload list statefulsets error: %+v", err)
-			break
+			writeError(request, response, gerr.NewWithDetail(request.Request.Context(), codes.Internal, err, gerr.ErrorDescribeResourcesFailed))
+			return
 		}
 		for _, statefulset := range statefulsetList.Items {
 			resources = append(resources, statefulset.Name)
@@ -2482,8 +2752,8 @@ func DescribeResourcesWorkload(request *restful.Request, response *restful.Respo
 	case "daemonset":
 		daemonsetList, err := k8sclient.NewK8sClient().ExtensionsV1beta1().DaemonSets(namespace).List(metaV1.ListOptions{LabelSelector: labelSelector})
 		if err != nil {
-			logger.Error(nil, "DescribeResourcesWorkload list daemonsets error: %+v", err)
-			break
+			writeError(request, response, gerr.NewWithDetail(request.Request.Context(), codes.Internal, err, gerr.ErrorDescribeResourcesFailed))
+			return
 		}
 		for _, daemonset := range daemonsetList.Items {
 			resources = append(resources, daemonset.Name)
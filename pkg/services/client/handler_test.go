@@ -0,0 +1,31 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/pb"
+)
+
+func TestAlertInfoActionRequest(t *testing.T) {
+	body := `{"alert":{"alert_name":"cpu"},"action":{"action_name":"webhook","trigger_status":"alarming",` +
+		`"trigger_action":"{\"type\":\"alertmanager_webhook\",\"url\":\"http://receiver:5001/\"}","nf_address_list_id":"nl-1"}}`
+
+	alertInfo := new(models.AlertInfo)
+	require.NoError(t, json.Unmarshal([]byte(body), alertInfo))
+
+	// the alert bundle replaces the action, an update must keep its trigger status and action
+	require.Equal(t, &pb.CreateActionRequest{
+		ActionName:      "webhook",
+		TriggerStatus:   "alarming",
+		TriggerAction:   `{"type":"alertmanager_webhook","url":"http://receiver:5001/"}`,
+		NfAddressListId: "nl-1",
+	}, alertInfoActionRequest(alertInfo))
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -8,12 +9,18 @@ import (
 	"github.com/emicklei/go-restful"
 	"github.com/emicklei/go-restful-openapi"
 
+	"kubesphere.io/alert/pkg/auth"
+	alclient "kubesphere.io/alert/pkg/client/alert"
 	"kubesphere.io/alert/pkg/config"
 	"kubesphere.io/alert/pkg/constants"
 	"kubesphere.io/alert/pkg/global"
 	"kubesphere.io/alert/pkg/logger"
 	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/monitoring"
+	"kubesphere.io/alert/pkg/notification"
 	"kubesphere.io/alert/pkg/pb"
+	"kubesphere.io/alert/pkg/tracing"
+	"kubesphere.io/alert/pkg/util/ctxutil"
 )
 
 const (
@@ -25,6 +32,10 @@ func WebService() *restful.WebService {
 
 	ws := new(restful.WebService)
 	ws.Path("/api/v1").Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).Produces(restful.MIME_JSON)
+	ws.Filter(traceFilter)
+	ws.Filter(requestIdFilter)
+	ws.Filter(actorFilter)
+	ws.Filter(authFilter)
 
 	tags := []string{"ResourceType"}
 
@@ -38,7 +49,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeResourceTypesResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeResourceTypesResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeResourceTypesResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -56,7 +68,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeMetricsResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeMetricsResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeMetricsResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -67,7 +80,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(PolicyByAlert{}).
 		Writes(ModifyPolicyByAlertResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -76,7 +90,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(PolicyByAlert{}).
 		Writes(ModifyPolicyByAlertResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -85,7 +100,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(PolicyByAlert{}).
 		Writes(ModifyPolicyByAlertResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -95,7 +111,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(PolicyByAlert{}).
 		Writes(ModifyPolicyByAlertResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -104,7 +121,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(PolicyByAlert{}).
 		Writes(ModifyPolicyByAlertResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -114,7 +132,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(PolicyByAlert{}).
 		Writes(ModifyPolicyByAlertResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -124,7 +143,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(PolicyByAlert{}).
 		Writes(ModifyPolicyByAlertResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -134,7 +154,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(PolicyByAlert{}).
 		Writes(ModifyPolicyByAlertResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -144,7 +165,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(PolicyByAlert{}).
 		Writes(ModifyPolicyByAlertResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -155,7 +177,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(PolicyByAlert{}).
 		Writes(ModifyPolicyByAlertResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -166,7 +189,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(PolicyByAlert{}).
 		Writes(ModifyPolicyByAlertResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -176,8 +200,9 @@ func WebService() *restful.WebService {
 		Doc("Create Alert Cluster level").
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.AlertInfo{}).
-		Writes(pb.CreateAlertResponse{}).
-		Returns(http.StatusOK, RespOK, pb.CreateAlertResponse{})).
+		Writes(pb.CreateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.CreateAlertBundleResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -185,8 +210,9 @@ func WebService() *restful.WebService {
 		Doc("Create Alert Node Level").
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.AlertInfo{}).
-		Writes(pb.CreateAlertResponse{}).
-		Returns(http.StatusOK, RespOK, pb.CreateAlertResponse{})).
+		Writes(pb.CreateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.CreateAlertBundleResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -194,8 +220,9 @@ func WebService() *restful.WebService {
 		Doc("Create Alert Workspace Level").
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.AlertInfo{}).
-		Writes(pb.CreateAlertResponse{}).
-		Returns(http.StatusOK, RespOK, pb.CreateAlertResponse{})).
+		Writes(pb.CreateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.CreateAlertBundleResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -204,8 +231,9 @@ func WebService() *restful.WebService {
 		Param(ws.PathParameter("ws_name", "Specify workspace").DataType("string").Required(true).DefaultValue("")).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.AlertInfo{}).
-		Writes(pb.CreateAlertResponse{}).
-		Returns(http.StatusOK, RespOK, pb.CreateAlertResponse{})).
+		Writes(pb.CreateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.CreateAlertBundleResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -213,8 +241,9 @@ func WebService() *restful.WebService {
 		Doc("Create Alert Namespace Level").
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.AlertInfo{}).
-		Writes(pb.CreateAlertResponse{}).
-		Returns(http.StatusOK, RespOK, pb.CreateAlertResponse{})).
+		Writes(pb.CreateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.CreateAlertBundleResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -223,8 +252,9 @@ func WebService() *restful.WebService {
 		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.AlertInfo{}).
-		Writes(pb.CreateAlertResponse{}).
-		Returns(http.StatusOK, RespOK, pb.CreateAlertResponse{})).
+		Writes(pb.CreateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.CreateAlertBundleResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -233,8 +263,9 @@ func WebService() *restful.WebService {
 		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.AlertInfo{}).
-		Writes(pb.CreateAlertResponse{}).
-		Returns(http.StatusOK, RespOK, pb.CreateAlertResponse{})).
+		Writes(pb.CreateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.CreateAlertBundleResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -243,8 +274,9 @@ func WebService() *restful.WebService {
 		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.AlertInfo{}).
-		Writes(pb.CreateAlertResponse{}).
-		Returns(http.StatusOK, RespOK, pb.CreateAlertResponse{})).
+		Writes(pb.CreateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.CreateAlertBundleResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -253,8 +285,9 @@ func WebService() *restful.WebService {
 		Param(ws.PathParameter("node_id", "Specify node id").DataType("string").Required(true).DefaultValue("")).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.AlertInfo{}).
-		Writes(pb.CreateAlertResponse{}).
-		Returns(http.StatusOK, RespOK, pb.CreateAlertResponse{})).
+		Writes(pb.CreateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.CreateAlertBundleResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -264,8 +297,9 @@ func WebService() *restful.WebService {
 		Param(ws.PathParameter("pod_name", "Specify pod").DataType("string").Required(true).DefaultValue("")).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.AlertInfo{}).
-		Writes(pb.CreateAlertResponse{}).
-		Returns(http.StatusOK, RespOK, pb.CreateAlertResponse{})).
+		Writes(pb.CreateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.CreateAlertBundleResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -275,17 +309,387 @@ func WebService() *restful.WebService {
 		Param(ws.PathParameter("pod_name", "Specify pod").DataType("string").Required(true).DefaultValue("")).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.AlertInfo{}).
-		Writes(pb.CreateAlertResponse{}).
-		Returns(http.StatusOK, RespOK, pb.CreateAlertResponse{})).
+		Writes(pb.CreateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.CreateAlertBundleResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
+	ws.Route(ws.PUT("/clusters/alert").To(UpdateAlertCluster).
+		Doc("Update Alert Cluster level").
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertInfo{}).
+		Writes(pb.UpdateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.UpdateAlertBundleResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.PUT("/nodes/alert").To(UpdateAlertNode).
+		Doc("Update Alert Node Level").
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertInfo{}).
+		Writes(pb.UpdateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.UpdateAlertBundleResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.PUT("/workspaces/alert").To(UpdateAlertWorkspace).
+		Doc("Update Alert Workspace Level").
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertInfo{}).
+		Writes(pb.UpdateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.UpdateAlertBundleResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.PUT("/workspaces/{ws_name}/alert").To(UpdateAlertWorkspace).
+		Doc("Update Alert Workspace Level").
+		Param(ws.PathParameter("ws_name", "Specify workspace").DataType("string").Required(true).DefaultValue("")).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertInfo{}).
+		Writes(pb.UpdateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.UpdateAlertBundleResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.PUT("/namespaces/alert").To(UpdateAlertNamespace).
+		Doc("Update Alert Namespace Level").
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertInfo{}).
+		Writes(pb.UpdateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.UpdateAlertBundleResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.PUT("/namespaces/{ns_name}/alert").To(UpdateAlertNamespace).
+		Doc("Update Alert Namespace Level").
+		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertInfo{}).
+		Writes(pb.UpdateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.UpdateAlertBundleResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.PUT("/namespaces/{ns_name}/workloads/alert").To(UpdateAlertWorkload).
+		Doc("Update Alert Workload Level").
+		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertInfo{}).
+		Writes(pb.UpdateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.UpdateAlertBundleResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.PUT("/namespaces/{ns_name}/pods/alert").To(UpdateAlertPod).
+		Doc("Update Alert Pod Level").
+		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertInfo{}).
+		Writes(pb.UpdateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.UpdateAlertBundleResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.PUT("/nodes/{node_id}/pods/alert").To(UpdateAlertPod).
+		Doc("Update Alert Pod Level").
+		Param(ws.PathParameter("node_id", "Specify node id").DataType("string").Required(true).DefaultValue("")).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertInfo{}).
+		Writes(pb.UpdateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.UpdateAlertBundleResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.PUT("/namespaces/{ns_name}/pods/{pod_name}/containers/alert").To(UpdateAlertContainer).
+		Doc("Update Alert Container Level").
+		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.PathParameter("pod_name", "Specify pod").DataType("string").Required(true).DefaultValue("")).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertInfo{}).
+		Writes(pb.UpdateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.UpdateAlertBundleResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.PUT("/nodes/{node_id}/pods/{pod_name}/containers/alert").To(UpdateAlertContainer).
+		Doc("Update Alert Container Level").
+		Param(ws.PathParameter("node_id", "Specify node id").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.PathParameter("pod_name", "Specify pod").DataType("string").Required(true).DefaultValue("")).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertInfo{}).
+		Writes(pb.UpdateAlertBundleResponse{}).
+		Returns(http.StatusOK, RespOK, pb.UpdateAlertBundleResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.GET("/clusters/alert_document").To(ExportAlertDocumentCluster).
+		Doc("Export Alert Document Cluster level").
+		Param(ws.QueryParameter("alert_names", "Specify alert names to export, comma-separated, eg. alert-1,alert-2, all the alerts if empty.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("format", "Document format, yaml or json.").DataType("string").DefaultValue("yaml").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(models.AlertDocument{}).
+		Returns(http.StatusOK, RespOK, models.AlertDocument{}).
+		Do(errorReturns).
+		Produces(constants.MIME_YAML, restful.MIME_JSON))
+
+	ws.Route(ws.GET("/nodes/alert_document").To(ExportAlertDocumentNode).
+		Doc("Export Alert Document Node Level").
+		Param(ws.QueryParameter("alert_names", "Specify alert names to export, comma-separated, eg. alert-1,alert-2, all the alerts if empty.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("format", "Document format, yaml or json.").DataType("string").DefaultValue("yaml").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(models.AlertDocument{}).
+		Returns(http.StatusOK, RespOK, models.AlertDocument{}).
+		Do(errorReturns).
+		Produces(constants.MIME_YAML, restful.MIME_JSON))
+
+	ws.Route(ws.GET("/workspaces/alert_document").To(ExportAlertDocumentWorkspace).
+		Doc("Export Alert Document Workspace Level").
+		Param(ws.QueryParameter("alert_names", "Specify alert names to export, comma-separated, eg. alert-1,alert-2, all the alerts if empty.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("format", "Document format, yaml or json.").DataType("string").DefaultValue("yaml").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(models.AlertDocument{}).
+		Returns(http.StatusOK, RespOK, models.AlertDocument{}).
+		Do(errorReturns).
+		Produces(constants.MIME_YAML, restful.MIME_JSON))
+
+	ws.Route(ws.GET("/workspaces/{ws_name}/alert_document").To(ExportAlertDocumentWorkspace).
+		Doc("Export Alert Document Workspace Level").
+		Param(ws.PathParameter("ws_name", "Specify workspace").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.QueryParameter("alert_names", "Specify alert names to export, comma-separated, eg. alert-1,alert-2, all the alerts if empty.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("format", "Document format, yaml or json.").DataType("string").DefaultValue("yaml").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(models.AlertDocument{}).
+		Returns(http.StatusOK, RespOK, models.AlertDocument{}).
+		Do(errorReturns).
+		Produces(constants.MIME_YAML, restful.MIME_JSON))
+
+	ws.Route(ws.GET("/namespaces/alert_document").To(ExportAlertDocumentNamespace).
+		Doc("Export Alert Document Namespace Level").
+		Param(ws.QueryParameter("alert_names", "Specify alert names to export, comma-separated, eg. alert-1,alert-2, all the alerts if empty.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("format", "Document format, yaml or json.").DataType("string").DefaultValue("yaml").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(models.AlertDocument{}).
+		Returns(http.StatusOK, RespOK, models.AlertDocument{}).
+		Do(errorReturns).
+		Produces(constants.MIME_YAML, restful.MIME_JSON))
+
+	ws.Route(ws.GET("/namespaces/{ns_name}/alert_document").To(ExportAlertDocumentNamespace).
+		Doc("Export Alert Document Namespace Level").
+		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.QueryParameter("alert_names", "Specify alert names to export, comma-separated, eg. alert-1,alert-2, all the alerts if empty.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("format", "Document format, yaml or json.").DataType("string").DefaultValue("yaml").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(models.AlertDocument{}).
+		Returns(http.StatusOK, RespOK, models.AlertDocument{}).
+		Do(errorReturns).
+		Produces(constants.MIME_YAML, restful.MIME_JSON))
+
+	ws.Route(ws.GET("/namespaces/{ns_name}/workloads/alert_document").To(ExportAlertDocumentWorkload).
+		Doc("Export Alert Document Workload Level").
+		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.QueryParameter("alert_names", "Specify alert names to export, comma-separated, eg. alert-1,alert-2, all the alerts if empty.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("format", "Document format, yaml or json.").DataType("string").DefaultValue("yaml").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(models.AlertDocument{}).
+		Returns(http.StatusOK, RespOK, models.AlertDocument{}).
+		Do(errorReturns).
+		Produces(constants.MIME_YAML, restful.MIME_JSON))
+
+	ws.Route(ws.GET("/namespaces/{ns_name}/pods/alert_document").To(ExportAlertDocumentPod).
+		Doc("Export Alert Document Pod Level").
+		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.QueryParameter("alert_names", "Specify alert names to export, comma-separated, eg. alert-1,alert-2, all the alerts if empty.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("format", "Document format, yaml or json.").DataType("string").DefaultValue("yaml").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(models.AlertDocument{}).
+		Returns(http.StatusOK, RespOK, models.AlertDocument{}).
+		Do(errorReturns).
+		Produces(constants.MIME_YAML, restful.MIME_JSON))
+
+	ws.Route(ws.GET("/nodes/{node_id}/pods/alert_document").To(ExportAlertDocumentPod).
+		Doc("Export Alert Document Pod Level").
+		Param(ws.PathParameter("node_id", "Specify node id").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.QueryParameter("alert_names", "Specify alert names to export, comma-separated, eg. alert-1,alert-2, all the alerts if empty.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("format", "Document format, yaml or json.").DataType("string").DefaultValue("yaml").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(models.AlertDocument{}).
+		Returns(http.StatusOK, RespOK, models.AlertDocument{}).
+		Do(errorReturns).
+		Produces(constants.MIME_YAML, restful.MIME_JSON))
+
+	ws.Route(ws.GET("/namespaces/{ns_name}/pods/{pod_name}/containers/alert_document").To(ExportAlertDocumentContainer).
+		Doc("Export Alert Document Container Level").
+		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.PathParameter("pod_name", "Specify pod").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.QueryParameter("alert_names", "Specify alert names to export, comma-separated, eg. alert-1,alert-2, all the alerts if empty.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("format", "Document format, yaml or json.").DataType("string").DefaultValue("yaml").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(models.AlertDocument{}).
+		Returns(http.StatusOK, RespOK, models.AlertDocument{}).
+		Do(errorReturns).
+		Produces(constants.MIME_YAML, restful.MIME_JSON))
+
+	ws.Route(ws.GET("/nodes/{node_id}/pods/{pod_name}/containers/alert_document").To(ExportAlertDocumentContainer).
+		Doc("Export Alert Document Container Level").
+		Param(ws.PathParameter("node_id", "Specify node id").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.PathParameter("pod_name", "Specify pod").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.QueryParameter("alert_names", "Specify alert names to export, comma-separated, eg. alert-1,alert-2, all the alerts if empty.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("format", "Document format, yaml or json.").DataType("string").DefaultValue("yaml").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(models.AlertDocument{}).
+		Returns(http.StatusOK, RespOK, models.AlertDocument{}).
+		Do(errorReturns).
+		Produces(constants.MIME_YAML, restful.MIME_JSON))
+
+	ws.Route(ws.POST("/clusters/alert_document").To(ImportAlertDocumentCluster).
+		Doc("Import Alert Document Cluster level").
+		Param(ws.QueryParameter("dry_run", "Only describe the changes, without applying them.").DataType("bool").DefaultValue("false").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertDocument{}).
+		Writes(pb.ImportAlertsResponse{}).
+		Returns(http.StatusOK, RespOK, pb.ImportAlertsResponse{}).
+		Do(errorReturns).
+		Consumes(constants.MIME_YAML, restful.MIME_JSON).
+		Produces(restful.MIME_JSON))
+
+	ws.Route(ws.POST("/nodes/alert_document").To(ImportAlertDocumentNode).
+		Doc("Import Alert Document Node Level").
+		Param(ws.QueryParameter("dry_run", "Only describe the changes, without applying them.").DataType("bool").DefaultValue("false").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertDocument{}).
+		Writes(pb.ImportAlertsResponse{}).
+		Returns(http.StatusOK, RespOK, pb.ImportAlertsResponse{}).
+		Do(errorReturns).
+		Consumes(constants.MIME_YAML, restful.MIME_JSON).
+		Produces(restful.MIME_JSON))
+
+	ws.Route(ws.POST("/workspaces/alert_document").To(ImportAlertDocumentWorkspace).
+		Doc("Import Alert Document Workspace Level").
+		Param(ws.QueryParameter("dry_run", "Only describe the changes, without applying them.").DataType("bool").DefaultValue("false").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertDocument{}).
+		Writes(pb.ImportAlertsResponse{}).
+		Returns(http.StatusOK, RespOK, pb.ImportAlertsResponse{}).
+		Do(errorReturns).
+		Consumes(constants.MIME_YAML, restful.MIME_JSON).
+		Produces(restful.MIME_JSON))
+
+	ws.Route(ws.POST("/workspaces/{ws_name}/alert_document").To(ImportAlertDocumentWorkspace).
+		Doc("Import Alert Document Workspace Level").
+		Param(ws.PathParameter("ws_name", "Specify workspace").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.QueryParameter("dry_run", "Only describe the changes, without applying them.").DataType("bool").DefaultValue("false").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertDocument{}).
+		Writes(pb.ImportAlertsResponse{}).
+		Returns(http.StatusOK, RespOK, pb.ImportAlertsResponse{}).
+		Do(errorReturns).
+		Consumes(constants.MIME_YAML, restful.MIME_JSON).
+		Produces(restful.MIME_JSON))
+
+	ws.Route(ws.POST("/namespaces/alert_document").To(ImportAlertDocumentNamespace).
+		Doc("Import Alert Document Namespace Level").
+		Param(ws.QueryParameter("dry_run", "Only describe the changes, without applying them.").DataType("bool").DefaultValue("false").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertDocument{}).
+		Writes(pb.ImportAlertsResponse{}).
+		Returns(http.StatusOK, RespOK, pb.ImportAlertsResponse{}).
+		Do(errorReturns).
+		Consumes(constants.MIME_YAML, restful.MIME_JSON).
+		Produces(restful.MIME_JSON))
+
+	ws.Route(ws.POST("/namespaces/{ns_name}/alert_document").To(ImportAlertDocumentNamespace).
+		Doc("Import Alert Document Namespace Level").
+		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.QueryParameter("dry_run", "Only describe the changes, without applying them.").DataType("bool").DefaultValue("false").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertDocument{}).
+		Writes(pb.ImportAlertsResponse{}).
+		Returns(http.StatusOK, RespOK, pb.ImportAlertsResponse{}).
+		Do(errorReturns).
+		Consumes(constants.MIME_YAML, restful.MIME_JSON).
+		Produces(restful.MIME_JSON))
+
+	ws.Route(ws.POST("/namespaces/{ns_name}/workloads/alert_document").To(ImportAlertDocumentWorkload).
+		Doc("Import Alert Document Workload Level").
+		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.QueryParameter("dry_run", "Only describe the changes, without applying them.").DataType("bool").DefaultValue("false").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertDocument{}).
+		Writes(pb.ImportAlertsResponse{}).
+		Returns(http.StatusOK, RespOK, pb.ImportAlertsResponse{}).
+		Do(errorReturns).
+		Consumes(constants.MIME_YAML, restful.MIME_JSON).
+		Produces(restful.MIME_JSON))
+
+	ws.Route(ws.POST("/namespaces/{ns_name}/pods/alert_document").To(ImportAlertDocumentPod).
+		Doc("Import Alert Document Pod Level").
+		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.QueryParameter("dry_run", "Only describe the changes, without applying them.").DataType("bool").DefaultValue("false").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertDocument{}).
+		Writes(pb.ImportAlertsResponse{}).
+		Returns(http.StatusOK, RespOK, pb.ImportAlertsResponse{}).
+		Do(errorReturns).
+		Consumes(constants.MIME_YAML, restful.MIME_JSON).
+		Produces(restful.MIME_JSON))
+
+	ws.Route(ws.POST("/nodes/{node_id}/pods/alert_document").To(ImportAlertDocumentPod).
+		Doc("Import Alert Document Pod Level").
+		Param(ws.PathParameter("node_id", "Specify node id").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.QueryParameter("dry_run", "Only describe the changes, without applying them.").DataType("bool").DefaultValue("false").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertDocument{}).
+		Writes(pb.ImportAlertsResponse{}).
+		Returns(http.StatusOK, RespOK, pb.ImportAlertsResponse{}).
+		Do(errorReturns).
+		Consumes(constants.MIME_YAML, restful.MIME_JSON).
+		Produces(restful.MIME_JSON))
+
+	ws.Route(ws.POST("/namespaces/{ns_name}/pods/{pod_name}/containers/alert_document").To(ImportAlertDocumentContainer).
+		Doc("Import Alert Document Container Level").
+		Param(ws.PathParameter("ns_name", "Specify namespace").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.PathParameter("pod_name", "Specify pod").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.QueryParameter("dry_run", "Only describe the changes, without applying them.").DataType("bool").DefaultValue("false").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertDocument{}).
+		Writes(pb.ImportAlertsResponse{}).
+		Returns(http.StatusOK, RespOK, pb.ImportAlertsResponse{}).
+		Do(errorReturns).
+		Consumes(constants.MIME_YAML, restful.MIME_JSON).
+		Produces(restful.MIME_JSON))
+
+	ws.Route(ws.POST("/nodes/{node_id}/pods/{pod_name}/containers/alert_document").To(ImportAlertDocumentContainer).
+		Doc("Import Alert Document Container Level").
+		Param(ws.PathParameter("node_id", "Specify node id").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.PathParameter("pod_name", "Specify pod").DataType("string").Required(true).DefaultValue("")).
+		Param(ws.QueryParameter("dry_run", "Only describe the changes, without applying them.").DataType("bool").DefaultValue("false").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.AlertDocument{}).
+		Writes(pb.ImportAlertsResponse{}).
+		Returns(http.StatusOK, RespOK, pb.ImportAlertsResponse{}).
+		Do(errorReturns).
+		Consumes(constants.MIME_YAML, restful.MIME_JSON).
+		Produces(restful.MIME_JSON))
+
 	ws.Route(ws.PATCH("/clusters/alert").To(ModifyAlertByNameCluster).
 		Doc("Modify Alert By Name Cluster level").
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.Alert{}).
 		Writes(ModifyAlertByNameResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -294,7 +698,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.Alert{}).
 		Writes(ModifyAlertByNameResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -303,7 +708,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.Alert{}).
 		Writes(ModifyAlertByNameResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -313,7 +719,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.Alert{}).
 		Writes(ModifyAlertByNameResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -322,7 +729,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.Alert{}).
 		Writes(ModifyAlertByNameResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -332,7 +740,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.Alert{}).
 		Writes(ModifyAlertByNameResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -342,7 +751,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.Alert{}).
 		Writes(ModifyAlertByNameResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -352,7 +762,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.Alert{}).
 		Writes(ModifyAlertByNameResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -362,7 +773,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.Alert{}).
 		Writes(ModifyAlertByNameResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -373,7 +785,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.Alert{}).
 		Writes(ModifyAlertByNameResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -384,7 +797,8 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.Alert{}).
 		Writes(ModifyAlertByNameResponse{}).
-		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{})).
+		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -393,7 +807,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("alert_names", "Specify alert names to delete, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(DeleteAlertsByNameResponse{}).
-		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{})).
+		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -402,7 +817,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("alert_names", "Specify alert names to delete, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(DeleteAlertsByNameResponse{}).
-		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{})).
+		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -411,7 +827,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("alert_names", "Specify alert names to delete, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(DeleteAlertsByNameResponse{}).
-		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{})).
+		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -421,7 +838,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("alert_names", "Specify alert names to delete, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(DeleteAlertsByNameResponse{}).
-		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{})).
+		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -430,7 +848,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("alert_names", "Specify alert names to delete, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(DeleteAlertsByNameResponse{}).
-		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{})).
+		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -440,7 +859,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("alert_names", "Specify alert names to delete, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(DeleteAlertsByNameResponse{}).
-		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{})).
+		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -450,7 +870,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("alert_names", "Specify alert names to delete, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(DeleteAlertsByNameResponse{}).
-		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{})).
+		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -460,7 +881,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("alert_names", "Specify alert names to delete, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(DeleteAlertsByNameResponse{}).
-		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{})).
+		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -470,7 +892,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("alert_names", "Specify alert names to delete, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(DeleteAlertsByNameResponse{}).
-		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{})).
+		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -481,7 +904,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("alert_names", "Specify alert names to delete, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(DeleteAlertsByNameResponse{}).
-		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{})).
+		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -492,7 +916,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("alert_names", "Specify alert names to delete, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(DeleteAlertsByNameResponse{}).
-		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{})).
+		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -513,7 +938,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertDetailsResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -534,7 +960,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertDetailsResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -555,7 +982,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertDetailsResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -577,7 +1005,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertDetailsResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -598,7 +1027,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertDetailsResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -620,7 +1050,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertDetailsResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -642,7 +1073,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertDetailsResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -664,7 +1096,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertDetailsResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -686,7 +1119,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertDetailsResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -709,7 +1143,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertDetailsResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -732,7 +1167,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertDetailsResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -753,7 +1189,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertStatusResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -774,7 +1211,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertStatusResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -795,7 +1233,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertStatusResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -817,7 +1256,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertStatusResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -838,7 +1278,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertStatusResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -860,7 +1301,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertStatusResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -882,7 +1324,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertStatusResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -904,7 +1347,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertStatusResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -926,7 +1370,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertStatusResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -949,7 +1394,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertStatusResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -972,7 +1418,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeAlertStatusResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -995,7 +1442,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeHistoryDetailResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1016,7 +1464,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeHistoryDetailResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1037,7 +1486,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeHistoryDetailResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1059,7 +1509,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeHistoryDetailResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1080,7 +1531,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeHistoryDetailResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1102,7 +1554,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeHistoryDetailResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1124,7 +1577,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeHistoryDetailResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1146,7 +1600,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeHistoryDetailResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1168,7 +1623,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeHistoryDetailResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1191,7 +1647,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeHistoryDetailResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1214,7 +1671,122 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeHistoryDetailResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	tags = []string{"External"}
+
+	ws.Route(ws.POST("/alerts").To(ReceiveAlerts).
+		Doc("Receive Alerts posted by external systems in the Alertmanager format").
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads([]notification.AlertmanagerAlert{}).
+		Writes(pb.ReceiveAlertsResponse{}).
+		Returns(http.StatusOK, RespOK, pb.ReceiveAlertsResponse{}).
+		Do(errorReturns).
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON))
+
+	ws.Route(ws.PATCH("/external/policy").To(ModifyPolicyByAlertExternal).
+		Doc("Modify Policy By Alert External level").
+		Param(ws.QueryParameter("ns_name", "Specify namespace of the external alerts").DataType("string").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(PolicyByAlert{}).
+		Writes(ModifyPolicyByAlertResponse{}).
+		Returns(http.StatusOK, RespOK, ModifyPolicyByAlertResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.GET("/external/alert").To(DescribeAlertDetailsExternal).
+		Doc("Describe Alert Details External level").
+		Param(ws.QueryParameter("ns_name", "Specify namespace of the external alerts").DataType("string").Required(false)).
+		Param(ws.QueryParameter("search_word", "Specify search word").DataType("string").Required(false)).
+		Param(ws.QueryParameter("alert_ids", "Specify alert ids to query, comma-separated, eg. al-WgBBMmRv3rMP,al-QVjOxnkD3mwW.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("alert_names", "Specify alert names to query, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("disables", "Specify alert disabled status, comma-separated, eg. true,false.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("running_status", "Specify alert running status, comma-separated, eg. adding,running,deleting,updating,migrating.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("policy_ids", "Specify policy ids to query, comma-separated, eg. pl-zZmOyYqqx7vo,pl-zZPP12kqx7vo.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("creators", "Specify creators to query, comma-separated, eg. admin,user1.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("rs_filter_ids", "Specify resource filter ids to query, comma-separated, eg. rf-ZyzVP265N3l5,rf-zZ416xNqx7vo.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("executor_ids", "Specify alert executor ids to query, comma-separated, eg. alerting-executor-5f8d9bb8b9-4rl95,alerting-executor-5f8d9bb8b9-jw728.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("sort_key", "Sort key. One of t1.alert_id, t1.alert_name, t1.disabled, t1.running_status, t1.alert_status, t1.create_time, t1.update_time, t1.policy_id, t1.rs_filter_id, t1.executor_id, t2.policy_id, t2.policy_name, t2.policy_description, t2.policy_config, t2.creator, t2.available_start_time, t2.available_end_time, t2.create_time, t2.update_time, t2.rs_type_id, t3.rs_filter_id, t3.rs_filter_name, t3.rs_filter_param, t3.status, t3.create_time, t3.update_time, t3.rs_type_id, t4.rs_type_id, t4.rs_type_name, t4.rs_type_param, t4.create_time, t4.update_time, t5.action_id, t5.action_name, t5.trigger_status, t5.trigger_action, t5.create_time, t5.update_time, t5.policy_id, t5.nf_address_list_id.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("reverse", "Sort order, true-desc, false-asc.").DataType("bool").DefaultValue("false").Required(false)).
+		Param(ws.QueryParameter("offset", "Beginning index of result to return. Use this option together with limit.").DataType("uint32").Required(false)).
+		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(pb.DescribeAlertDetailsResponse{}).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertDetailsResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.GET("/external/alert_status").To(DescribeAlertStatusExternal).
+		Doc("Describe Alert Status External level").
+		Param(ws.QueryParameter("ns_name", "Specify namespace of the external alerts").DataType("string").Required(false)).
+		Param(ws.QueryParameter("alert_ids", "Specify alert ids to query, comma-separated, eg. al-WgBBMmRv3rMP,al-QVjOxnkD3mwW.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("alert_names", "Specify alert names to query, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("disables", "Specify alert disabled status, comma-separated, eg. true,false.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("running_status", "Specify alert running status, comma-separated, eg. adding,running,deleting,updating,migrating.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("policy_ids", "Specify policy ids to query, comma-separated, eg. pl-zZmOyYqqx7vo,pl-zZPP12kqx7vo.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("creators", "Specify creators to query, comma-separated, eg. admin,user1.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("rs_filter_ids", "Specify resource filter ids to query, comma-separated, eg. rf-ZyzVP265N3l5,rf-zZ416xNqx7vo.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("executor_ids", "Specify alert executor ids to query, comma-separated, eg. alerting-executor-5f8d9bb8b9-4rl95,alerting-executor-5f8d9bb8b9-jw728.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("rule_ids", "Specify rule ids to query, comma-separated, eg. rl-nKEQK7kAGDYv,rl-RG3GJ8X8JQY1.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("sort_key", "Sort key. One of t1.alert_id, t1.alert_name, t1.disabled, t1.running_status, t1.alert_status, t1.create_time, t1.update_time, t1.policy_id, t1.rs_filter_id, t1.executor_id, t2.policy_id, t2.policy_name, t2.policy_description, t2.policy_config, t2.creator, t2.available_start_time, t2.available_end_time, t2.create_time, t2.update_time, t2.rs_type_id, t3.rs_filter_id, t3.rs_filter_name, t3.rs_filter_param, t3.status, t3.create_time, t3.update_time, t3.rs_type_id, t4.rs_type_id, t4.rs_type_name, t4.rs_type_param, t4.create_time, t4.update_time, t5.action_id, t5.action_name, t5.trigger_status, t5.trigger_action, t5.create_time, t5.update_time, t5.policy_id, t5.nf_address_list_id.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("reverse", "Sort order, true-desc, false-asc.").DataType("bool").DefaultValue("false").Required(false)).
+		Param(ws.QueryParameter("offset", "Beginning index of result to return. Use this option together with limit.").DataType("uint32").Required(false)).
+		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(pb.DescribeAlertStatusResponse{}).
+		Returns(http.StatusOK, RespOK, pb.DescribeAlertStatusResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.GET("/external/history").To(DescribeHistoryDetailExternal).
+		Doc("Describe History Detail External level").
+		Param(ws.QueryParameter("ns_name", "Specify namespace of the external alerts").DataType("string").Required(false)).
+		Param(ws.QueryParameter("search_word", "Specify search word").DataType("string").Required(false)).
+		Param(ws.QueryParameter("history_ids", "Specify history ids to query, comma-separated, eg. hs-zzz8NpjopLvj,hs-zzzlXz1ELM6y.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("history_names", "Specify history names to query, comma-separated, eg. alert-trigger,alert-resume.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("alert_names", "Specify alert names to query, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("rule_names", "Specify rule names to query, comma-separated, eg. 内存利用率,CPU利用率.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("events", "Specify history events to query, comma-separated, eg. triggered,resumed,sent_success,sent_failed,commented.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("rule_ids", "Specify rule ids to query, comma-separated, eg. rl-nKEQK7kAGDYv,rl-RG3GJ8X8JQY1.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("resource_names", "Specify resource names to query, comma-separated, eg. master,node1.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("recent", "List most recent history after latest trigger event. One of true, false.").DataType("bool").DefaultValue("false").Required(false)).
+		Param(ws.QueryParameter("sort_key", "Sort key. One of t1.history_id, t1.history_name, t1.event, t1.content, t1.notification_id, t1.create_time, t1.update_time, t1.alert_id, t1.rule_id, t1.resource_name, t2.rule_id, t2.rule_name, t2.disabled, t2.monitor_periods, t2.severity, t2.metrics_type, t2.condition_type, t2.thresholds, t2.unit, t2.consecutive_count, t2.inhibit, t2.create_time, t2.update_time, t2.policy_id, t2.metric_id, t3.alert_id, t3.alert_name, t3.disabled, t3.running_status, t3.alert_status, t3.create_time, t3.update_time, t3.policy_id, t3.rs_filter_id, t3.executor_id, t4.rs_filter_id, t4.rs_filter_name, t4.rs_filter_param, t4.status, t4.create_time, t4.update_time, t4.rs_type_id, t5.metric_id, t5.metric_name, t5.metric_param, t5.status, t5.create_time, t5.update_time, t5.rs_type_id, t6.rs_type_id, t6.rs_type_name, t6.rs_type_param, t6.create_time, t6.update_time.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("reverse", "Sort order, true-desc, false-asc.").DataType("bool").DefaultValue("false").Required(false)).
+		Param(ws.QueryParameter("offset", "Beginning index of result to return. Use this option together with limit.").DataType("uint32").Required(false)).
+		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(pb.DescribeHistoryDetailResponse{}).
+		Returns(http.StatusOK, RespOK, pb.DescribeHistoryDetailResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.DELETE("/external/alert").To(DeleteAlertsByNameExternal).
+		Doc("Delete Alerts By Name External level").
+		Param(ws.QueryParameter("ns_name", "Specify namespace of the external alerts").DataType("string").Required(false)).
+		Param(ws.QueryParameter("alert_names", "Specify alert names to delete, comma-separated, eg. alert-1,alert-2.").DataType("string").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(DeleteAlertsByNameResponse{}).
+		Returns(http.StatusOK, RespOK, DeleteAlertsByNameResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	ws.Route(ws.PATCH("/external/alert").To(ModifyAlertByNameExternal).
+		Doc("Modify Alert By Name External level").
+		Param(ws.QueryParameter("ns_name", "Specify namespace of the external alerts").DataType("string").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Reads(models.Alert{}).
+		Writes(ModifyAlertByNameResponse{}).
+		Returns(http.StatusOK, RespOK, ModifyAlertByNameResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1225,12 +1797,13 @@ func WebService() *restful.WebService {
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Reads(models.Comment{}).
 		Writes(pb.CreateCommentResponse{}).
-		Returns(http.StatusOK, RespOK, pb.CreateCommentResponse{})).
+		Returns(http.StatusOK, RespOK, pb.CreateCommentResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
 	ws.Route(ws.GET("/comment").To(DescribeComments).
-		Doc("Describe Comments").
+		Doc("Describe Comments, callers other than admins have to name the history_ids of their alerts").
 		Param(ws.QueryParameter("comment_ids", "Specify comment ids to query, comma-separated, eg. cm-Dp7Z7VjvKnYL, cm-zyyGZZ640Op9.").DataType("string").Required(false)).
 		Param(ws.QueryParameter("addressers", "Specify comment addresser names to query, comma-separated, eg. user1,tester2.").DataType("string").Required(false)).
 		Param(ws.QueryParameter("contents", "Specify comment contents to query, comma-separated.").DataType("string").Required(false)).
@@ -1241,7 +1814,31 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes(pb.DescribeCommentsResponse{}).
-		Returns(http.StatusOK, RespOK, pb.DescribeCommentsResponse{})).
+		Returns(http.StatusOK, RespOK, pb.DescribeCommentsResponse{}).
+		Do(errorReturns)).
+		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
+		Produces(restful.MIME_JSON)
+
+	tags = []string{"Audit"}
+
+	ws.Route(ws.GET("/audit").To(DescribeAuditLogs).
+		Doc("Describe Audit Logs of configuration changes").
+		Param(ws.QueryParameter("audit_ids", "Specify audit ids to query, comma-separated, eg. ad-Dp7Z7VjvKnYL,ad-zyyGZZ640Op9.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("actors", "Specify actors to query, comma-separated, eg. admin,tester2.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("operations", "Specify operations to query, comma-separated, eg. CreateRule,DeleteAlerts.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("resource_types", "Specify changed resource types to query, comma-separated, eg. rule,alert.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("resource_ids", "Specify changed resource ids to query, comma-separated, eg. rl-3m8ZmxVylG90,al-vnAjqwNP5OPJ.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("request_ids", "Specify request ids to query, comma-separated.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("start_time", "Specify the earliest change time to query, eg. 2019-06-01T08:00:00Z.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("end_time", "Specify the latest change time to query, eg. 2019-06-02T08:00:00Z.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("sort_key", "Sort key. One of audit_id, actor, operation, resource_type, resource_id, create_time.").DataType("string").Required(false)).
+		Param(ws.QueryParameter("reverse", "Sort order, true-desc, false-asc.").DataType("bool").DefaultValue("false").Required(false)).
+		Param(ws.QueryParameter("offset", "Beginning index of result to return. Use this option together with limit.").DataType("uint32").Required(false)).
+		Param(ws.QueryParameter("limit", "Size of result to return.").DataType("uint32").Required(false)).
+		Metadata(restfulspec.KeyOpenAPITags, tags).
+		Writes(pb.DescribeAuditLogsResponse{}).
+		Returns(http.StatusOK, RespOK, pb.DescribeAuditLogsResponse{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1252,7 +1849,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("selector", "Specify selector, eg. [{\"app\": \"fluentbit-operator\"}]").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes([]string{}).
-		Returns(http.StatusOK, RespOK, []string{})).
+		Returns(http.StatusOK, RespOK, []string{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1261,7 +1859,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("selector", "Specify selector, eg. [{\"app\": \"fluentbit-operator\"}]").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes([]string{}).
-		Returns(http.StatusOK, RespOK, []string{})).
+		Returns(http.StatusOK, RespOK, []string{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1270,7 +1869,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("selector", "Specify selector, eg. [{\"app\": \"fluentbit-operator\"}]").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes([]string{}).
-		Returns(http.StatusOK, RespOK, []string{})).
+		Returns(http.StatusOK, RespOK, []string{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1280,7 +1880,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("selector", "Specify selector, eg. [{\"app\": \"fluentbit-operator\"}]").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes([]string{}).
-		Returns(http.StatusOK, RespOK, []string{})).
+		Returns(http.StatusOK, RespOK, []string{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1289,7 +1890,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("selector", "Specify selector, eg. [{\"app\": \"fluentbit-operator\"}]").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes([]string{}).
-		Returns(http.StatusOK, RespOK, []string{})).
+		Returns(http.StatusOK, RespOK, []string{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1299,7 +1901,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("selector", "Specify selector, eg. [{\"app\": \"fluentbit-operator\"}]").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes([]string{}).
-		Returns(http.StatusOK, RespOK, []string{})).
+		Returns(http.StatusOK, RespOK, []string{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1310,7 +1913,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("selector", "Specify selector, eg. [{\"app\": \"fluentbit-operator\"}]").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes([]string{}).
-		Returns(http.StatusOK, RespOK, []string{})).
+		Returns(http.StatusOK, RespOK, []string{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1320,7 +1924,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("selector", "Specify selector, eg. [{\"app\": \"fluentbit-operator\"}]").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes([]string{}).
-		Returns(http.StatusOK, RespOK, []string{})).
+		Returns(http.StatusOK, RespOK, []string{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1330,7 +1935,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("selector", "Specify selector, eg. [{\"app\": \"fluentbit-operator\"}]").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes([]string{}).
-		Returns(http.StatusOK, RespOK, []string{})).
+		Returns(http.StatusOK, RespOK, []string{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1341,7 +1947,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("selector", "Specify selector, eg. [{\"app\": \"fluentbit-operator\"}]").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes([]string{}).
-		Returns(http.StatusOK, RespOK, []string{})).
+		Returns(http.StatusOK, RespOK, []string{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1352,7 +1959,8 @@ func WebService() *restful.WebService {
 		Param(ws.QueryParameter("selector", "Specify selector, eg. [{\"app\": \"fluentbit-operator\"}]").DataType("string").Required(false)).
 		Metadata(restfulspec.KeyOpenAPITags, tags).
 		Writes([]string{}).
-		Returns(http.StatusOK, RespOK, []string{})).
+		Returns(http.StatusOK, RespOK, []string{}).
+		Do(errorReturns)).
 		Consumes(restful.MIME_JSON, constants.MIME_MERGEPATCH).
 		Produces(restful.MIME_JSON)
 
@@ -1365,7 +1973,10 @@ func Run() {
 	Container.Add(WebService())
 	enableCORS()
 
-	global.GetInstance()
+	g := global.GetInstance()
+	monitoring.AddLivenessCheck("database", g.CheckDB)
+	monitoring.AddLivenessCheck("etcd", g.CheckEtcd)
+	monitoring.AddReadinessCheck("manager", checkManagerConnection)
 
 	cfg := config.GetInstance()
 	apiPort, _ := strconv.Atoi(cfg.App.ApiPort)
@@ -1374,10 +1985,55 @@ func Run() {
 	logger.Info(nil, "%+v", http.ListenAndServe(listen, nil))
 }
 
+// traceFilter starts a server span for each request, handlers derive their
+// manager call contexts from the request context.
+func traceFilter(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+	ctx := tracing.ExtractHTTP(request.Request.Context(), request.Request.Header)
+	ctx, span := tracing.StartSpanWithKind(ctx, request.Request.Method+" "+request.SelectedRoutePath(), tracing.SpanKindServer)
+	defer span.End()
+	span.SetAttribute("http.method", request.Request.Method)
+	span.SetAttribute("http.target", request.Request.URL.RequestURI())
+
+	request.Request = request.Request.WithContext(ctx)
+	chain.ProcessFilter(request, response)
+
+	span.SetAttribute("http.status_code", strconv.Itoa(response.StatusCode()))
+	if response.StatusCode() >= http.StatusInternalServerError {
+		span.SetError(fmt.Errorf("%s", http.StatusText(response.StatusCode())))
+	}
+}
+
+// actorFilter passes the X-Actor header on to the manager, which records it in audit logs.
+func actorFilter(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+	if actor := request.HeaderParameter(constants.ActorHeader); actor != "" {
+		request.Request = request.Request.WithContext(ctxutil.SetActor(request.Request.Context(), actor))
+	}
+	chain.ProcessFilter(request, response)
+}
+
+// authFilter passes the Authorization header on to the manager, which authenticates the caller
+// and authorizes the request.
+func authFilter(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+	if authorization := request.HeaderParameter("Authorization"); authorization != "" {
+		request.Request = request.Request.WithContext(auth.NewOutgoingContext(request.Request.Context(), authorization))
+	}
+	chain.ProcessFilter(request, response)
+}
+
+func checkManagerConnection(ctx context.Context) error {
+	conn, err := alclient.NewConn()
+	if err != nil {
+		return err
+	}
+
+	return monitoring.CheckGrpcConn(ctx, conn)
+}
+
 func enableCORS() {
 	// Optionally, you may need to enable CORS for the UI to work.
 	cors := restful.CrossOriginResourceSharing{
-		AllowedHeaders: []string{"Content-Type", "Accept"},
+		AllowedHeaders: []string{"Content-Type", "Accept", "Authorization", constants.ActorHeader, RequestIdHeader},
+		ExposeHeaders:  []string{RequestIdHeader},
 		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
 		CookiesAllowed: false,
 		AllowedDomains: []string{"*"},
@@ -0,0 +1,46 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/constants"
+)
+
+func findRoute(t *testing.T, ws *restful.WebService, method string, path string) restful.Route {
+	for _, route := range ws.Routes() {
+		if route.Method == method && route.Path == path {
+			return route
+		}
+	}
+	require.FailNow(t, "route not found", "%s %s", method, path)
+	return restful.Route{}
+}
+
+func TestRouteMimeTypes(t *testing.T) {
+	ws := WebService()
+
+	route := findRoute(t, ws, "GET", "/api/v1/clusters/alert_document")
+	require.Equal(t, []string{constants.MIME_YAML, restful.MIME_JSON}, route.Produces)
+
+	route = findRoute(t, ws, "POST", "/api/v1/clusters/alert_document")
+	require.Equal(t, []string{constants.MIME_YAML, restful.MIME_JSON}, route.Consumes)
+	require.Equal(t, []string{restful.MIME_JSON}, route.Produces)
+
+	// the routes following them keep the default mime types
+	route = findRoute(t, ws, "PATCH", "/api/v1/clusters/alert")
+	require.Equal(t, []string{restful.MIME_JSON, constants.MIME_MERGEPATCH}, route.Consumes)
+	require.Equal(t, []string{restful.MIME_JSON}, route.Produces)
+
+	route = findRoute(t, ws, "POST", "/api/v1/alerts")
+	require.Equal(t, []string{restful.MIME_JSON}, route.Consumes)
+
+	route = findRoute(t, ws, "PATCH", "/api/v1/external/policy")
+	require.Equal(t, []string{restful.MIME_JSON, constants.MIME_MERGEPATCH}, route.Consumes)
+}
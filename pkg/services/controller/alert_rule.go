@@ -0,0 +1,181 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package controller
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/pb"
+)
+
+// AlertRuleResource is the AlertRule custom resource, see deploy/controller.yaml for its
+// definition.
+var AlertRuleResource = schema.GroupVersionResource{
+	Group:    "alerting.kubesphere.io",
+	Version:  "v1alpha1",
+	Resource: "alertrules",
+}
+
+// AlertRuleFinalizer keeps an AlertRule until its alert is deleted.
+const AlertRuleFinalizer = "alerting.kubesphere.io/alert"
+
+// alertRuleCreatorPrefix marks the alerts of AlertRules, the creator of their policy is the
+// prefix followed by the uid of the AlertRule.
+const alertRuleCreatorPrefix = "alertrule/"
+
+// AlertRule is an alert declared as a Kubernetes resource, watching resources of its namespace.
+// The spec is the alert info the client API creates alerts from, the alert is named after the
+// resource unless the spec names it. The creator of the policy marks the alert as the AlertRule's,
+// the one of the spec is left out.
+type AlertRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   models.AlertInfo `json:"spec"`
+	Status AlertRuleStatus  `json:"status,omitempty"`
+}
+
+type AlertRuleStatus struct {
+	AlertId            string `json:"alertId,omitempty"`
+	RunningStatus      string `json:"runningStatus,omitempty"`
+	FiringCount        uint32 `json:"firingCount"`
+	ObservedGeneration int64  `json:"observedGeneration,omitempty"`
+	Message            string `json:"message,omitempty"`
+}
+
+func newAlertRule(obj *unstructured.Unstructured) (*AlertRule, error) {
+	alertRule := &AlertRule{}
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), alertRule)
+	if err != nil {
+		return nil, err
+	}
+	return alertRule, nil
+}
+
+// getAlertName returns the name of the alert of alertRule.
+func getAlertName(alertRule *AlertRule) string {
+	if alertRule.Spec.Alert.AlertName != "" {
+		return alertRule.Spec.Alert.AlertName
+	}
+	return alertRule.Name
+}
+
+// setAlertRuleStatus writes status into obj, leaving the rest of obj as it is.
+func setAlertRuleStatus(obj *unstructured.Unstructured, status AlertRuleStatus) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return err
+	}
+	return unstructured.SetNestedMap(obj.Object, content, "status")
+}
+
+// getAlertRuleCreator returns the creator marking the alert of alertRule.
+func getAlertRuleCreator(alertRule *AlertRule) string {
+	return alertRuleCreatorPrefix + string(alertRule.UID)
+}
+
+// isAlertRuleOwner reports whether alert is the alert of alertRule, either marked as its alert or
+// the alert its status records.
+func isAlertRuleOwner(alertRule *AlertRule, alert *pb.AlertDetail) bool {
+	if alert.Creator == getAlertRuleCreator(alertRule) {
+		return true
+	}
+	return alertRule.Status.AlertId != "" && alertRule.Status.AlertId == alert.AlertId
+}
+
+// getRsFilterParam returns the resource filter of alertRule, the whole namespace unless the spec
+// narrows it.
+func getRsFilterParam(alertRule *AlertRule) string {
+	if alertRule.Spec.RsFilter.RsFilterParam != "" {
+		return alertRule.Spec.RsFilter.RsFilterParam
+	}
+	rsFilterParam, _ := json.Marshal(map[string]string{"ns_name": alertRule.Namespace})
+	return string(rsFilterParam)
+}
+
+func newCreateRuleRequest(rule *models.Rule) *pb.CreateRuleRequest {
+	return &pb.CreateRuleRequest{
+		RuleName:           rule.RuleName,
+		Disabled:           rule.Disabled,
+		MonitorPeriods:     rule.MonitorPeriods,
+		Severity:           rule.Severity,
+		MetricsType:        rule.MetricsType,
+		ConditionType:      rule.ConditionType,
+		Thresholds:         rule.Thresholds,
+		Unit:               rule.Unit,
+		ConsecutiveCount:   rule.ConsecutiveCount,
+		Inhibit:            rule.Inhibit,
+		MetricId:           rule.MetricId,
+		EvaluationInterval: rule.EvaluationInterval,
+	}
+}
+
+func newCreateAlertBundleRequest(resourceSearch string, alertName string, alertRule *AlertRule) *pb.CreateAlertBundleRequest {
+	spec := &alertRule.Spec
+	req := &pb.CreateAlertBundleRequest{
+		ResourceSearch: resourceSearch,
+		RsFilter: &pb.CreateResourceFilterRequest{
+			RsFilterName:  spec.RsFilter.RsFilterName,
+			RsFilterParam: getRsFilterParam(alertRule),
+			Status:        spec.RsFilter.Status,
+			RsTypeId:      spec.RsFilter.RsTypeId,
+		},
+		Policy: &pb.CreatePolicyRequest{
+			PolicyName:         spec.Policy.PolicyName,
+			PolicyDescription:  spec.Policy.PolicyDescription,
+			PolicyConfig:       spec.Policy.PolicyConfig,
+			Creator:            getAlertRuleCreator(alertRule),
+			AvailableStartTime: spec.Policy.AvailableStartTime,
+			AvailableEndTime:   spec.Policy.AvailableEndTime,
+		},
+		Action: &pb.CreateActionRequest{
+			ActionName:      spec.Action.ActionName,
+			TriggerStatus:   spec.Action.TriggerStatus,
+			TriggerAction:   spec.Action.TriggerAction,
+			NfAddressListId: spec.Action.NfAddressListId,
+		},
+		Alert: &pb.CreateAlertRequest{
+			AlertName: alertName,
+			Disabled:  spec.Alert.Disabled,
+		},
+	}
+	for i := range spec.Rules {
+		req.Rules = append(req.Rules, newCreateRuleRequest(&spec.Rules[i]))
+	}
+	return req
+}
+
+// newUpdateAlertBundleRequest replaces the alert alertId with the spec of alertRule, rules are
+// matched by name with the rules of the alert.
+func newUpdateAlertBundleRequest(resourceSearch string, alertName string, alertId string, alertRule *AlertRule, rules []*pb.Rule) *pb.UpdateAlertBundleRequest {
+	createReq := newCreateAlertBundleRequest(resourceSearch, alertName, alertRule)
+
+	ruleIds := map[string]string{}
+	for _, rule := range rules {
+		ruleIds[rule.RuleName] = rule.RuleId
+	}
+
+	req := &pb.UpdateAlertBundleRequest{
+		AlertId:        alertId,
+		ResourceSearch: resourceSearch,
+		RsFilter:       createReq.RsFilter,
+		Policy:         createReq.Policy,
+		Action:         createReq.Action,
+		Alert:          createReq.Alert,
+	}
+	for _, rule := range createReq.Rules {
+		req.Rules = append(req.Rules, &pb.UpdateAlertBundleRule{
+			RuleId: ruleIds[rule.RuleName],
+			Rule:   rule,
+		})
+	}
+	return req
+}
@@ -0,0 +1,346 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+
+	alclient "kubesphere.io/alert/pkg/client/alert"
+	k8sclient "kubesphere.io/alert/pkg/client/kubernetes"
+	"kubesphere.io/alert/pkg/config"
+	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/pb"
+)
+
+// AlertManager is the part of the manager API the controller reconciles AlertRules with.
+type AlertManager interface {
+	DescribeResourceTypes(ctx context.Context, in *pb.DescribeResourceTypesRequest, opts ...grpc.CallOption) (*pb.DescribeResourceTypesResponse, error)
+	DescribeRules(ctx context.Context, in *pb.DescribeRulesRequest, opts ...grpc.CallOption) (*pb.DescribeRulesResponse, error)
+	DeleteAlerts(ctx context.Context, in *pb.DeleteAlertsRequest, opts ...grpc.CallOption) (*pb.DeleteAlertsResponse, error)
+	DescribeAlertDetails(ctx context.Context, in *pb.DescribeAlertDetailsRequest, opts ...grpc.CallOption) (*pb.DescribeAlertDetailsResponse, error)
+	CreateAlertBundle(ctx context.Context, in *pb.CreateAlertBundleRequest, opts ...grpc.CallOption) (*pb.CreateAlertBundleResponse, error)
+	UpdateAlertBundle(ctx context.Context, in *pb.UpdateAlertBundleRequest, opts ...grpc.CallOption) (*pb.UpdateAlertBundleResponse, error)
+}
+
+type managerClient struct {
+	*alclient.Client
+	*alclient.CustomClient
+}
+
+// Controller reconciles AlertRule resources into alerts of the manager, and writes the state of
+// the alerts back into their status.
+type Controller struct {
+	client       dynamic.Interface
+	manager      AlertManager
+	namespace    string
+	resyncPeriod time.Duration
+}
+
+func NewController(client dynamic.Interface, manager AlertManager, namespace string, resyncPeriod time.Duration) *Controller {
+	return &Controller{
+		client:       client,
+		manager:      manager,
+		namespace:    namespace,
+		resyncPeriod: resyncPeriod,
+	}
+}
+
+// Run reconciles the AlertRules of the configured namespace as they change, and all of them
+// every resync period to refresh their status.
+func Run() {
+	cfg := config.GetInstance()
+
+	client, err := alclient.NewClient()
+	if err != nil {
+		logger.Critical(nil, "Controller connect manager error: %+v", err)
+		return
+	}
+	customClient, err := alclient.NewCustomClient()
+	if err != nil {
+		logger.Critical(nil, "Controller connect manager error: %+v", err)
+		return
+	}
+
+	dynamicClient := k8sclient.NewDynamicClient()
+	if dynamicClient == nil {
+		logger.Critical(nil, "Controller create kubernetes client failed")
+		return
+	}
+
+	c := NewController(dynamicClient, &managerClient{client, customClient}, cfg.Controller.Namespace, time.Duration(cfg.Controller.ResyncPeriod)*time.Second)
+	c.Serve(make(chan struct{}))
+}
+
+func (c *Controller) resource() dynamic.ResourceInterface {
+	return c.client.Resource(AlertRuleResource).Namespace(c.namespace)
+}
+
+// Serve reconciles until stopCh is closed.
+func (c *Controller) Serve(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(c.resyncPeriod)
+	defer ticker.Stop()
+
+	for {
+		resourceVersion := c.SyncAll()
+
+		watcher, err := c.resource().Watch(metav1.ListOptions{ResourceVersion: resourceVersion})
+		if err != nil {
+			logger.Error(nil, "Controller watch AlertRules error: %+v", err)
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+			}
+			continue
+		}
+
+		if !c.watch(watcher, ticker.C, stopCh) {
+			watcher.Stop()
+			return
+		}
+		watcher.Stop()
+	}
+}
+
+// watch reconciles the AlertRules changed until the resync period is over, it reports whether
+// to go on. Changes of the status only, like the ones of the controller itself, wait for the
+// resync.
+func (c *Controller) watch(watcher watch.Interface, resync <-chan time.Time, stopCh <-chan struct{}) bool {
+	for {
+		select {
+		case <-stopCh:
+			return false
+		case <-resync:
+			return true
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return true
+			}
+			obj, ok := event.Object.(*unstructured.Unstructured)
+			if !ok || event.Type == watch.Deleted || event.Type == watch.Error {
+				continue
+			}
+			if event.Type == watch.Modified && !isAlertRuleChanged(obj) {
+				continue
+			}
+			c.Reconcile(obj)
+		}
+	}
+}
+
+// SyncAll reconciles every AlertRule, it returns the resource version to watch changes from.
+func (c *Controller) SyncAll() string {
+	list, err := c.resource().List(metav1.ListOptions{})
+	if err != nil {
+		logger.Error(nil, "Controller list AlertRules error: %+v", err)
+		return ""
+	}
+
+	for i := range list.Items {
+		c.Reconcile(&list.Items[i])
+	}
+	return list.GetResourceVersion()
+}
+
+// Reconcile creates, updates or deletes the alert of obj as obj requires and records the outcome
+// in its status.
+func (c *Controller) Reconcile(obj *unstructured.Unstructured) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	err := c.reconcile(ctx, obj)
+	if err != nil {
+		logger.Error(ctx, "Controller reconcile AlertRule [%s/%s] error: %+v", obj.GetNamespace(), obj.GetName(), err)
+	}
+}
+
+func (c *Controller) reconcile(ctx context.Context, obj *unstructured.Unstructured) error {
+	alertRule, err := newAlertRule(obj)
+	if err != nil {
+		return c.updateStatus(obj, AlertRuleStatus{Message: err.Error()})
+	}
+
+	if alertRule.DeletionTimestamp != nil {
+		return c.finalize(ctx, obj, alertRule)
+	}
+
+	if !hasFinalizer(obj) {
+		obj.SetFinalizers(append(obj.GetFinalizers(), AlertRuleFinalizer))
+		obj, err = c.client.Resource(AlertRuleResource).Namespace(obj.GetNamespace()).Update(obj, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	alertStatus, err := c.applyAlert(ctx, alertRule)
+	if err != nil {
+		alertStatus.Message = err.Error()
+		updateErr := c.updateStatus(obj, alertStatus)
+		if updateErr != nil {
+			logger.Error(ctx, "Controller update AlertRule [%s/%s] status error: %+v", obj.GetNamespace(), obj.GetName(), updateErr)
+		}
+		return err
+	}
+
+	return c.updateStatus(obj, alertStatus)
+}
+
+func hasFinalizer(obj *unstructured.Unstructured) bool {
+	for _, finalizer := range obj.GetFinalizers() {
+		if finalizer == AlertRuleFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// isAlertRuleChanged reports whether obj changed in a way the alert of it has to follow, changes
+// of the status only leave the generation as it is.
+func isAlertRuleChanged(obj *unstructured.Unstructured) bool {
+	if obj.GetDeletionTimestamp() != nil || !hasFinalizer(obj) {
+		return true
+	}
+	observedGeneration, _, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	return observedGeneration != obj.GetGeneration()
+}
+
+// finalize deletes the alert of a deleted AlertRule, then lets the AlertRule go.
+func (c *Controller) finalize(ctx context.Context, obj *unstructured.Unstructured, alertRule *AlertRule) error {
+	if !hasFinalizer(obj) {
+		return nil
+	}
+
+	if alertRule.Status.AlertId != "" {
+		_, err := c.manager.DeleteAlerts(ctx, &pb.DeleteAlertsRequest{AlertId: []string{alertRule.Status.AlertId}})
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+	}
+
+	finalizers := []string{}
+	for _, finalizer := range obj.GetFinalizers() {
+		if finalizer != AlertRuleFinalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	obj.SetFinalizers(finalizers)
+	_, err := c.client.Resource(AlertRuleResource).Namespace(obj.GetNamespace()).Update(obj, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *Controller) updateStatus(obj *unstructured.Unstructured, alertStatus AlertRuleStatus) error {
+	obj = obj.DeepCopy()
+	err := setAlertRuleStatus(obj, alertStatus)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.Resource(AlertRuleResource).Namespace(obj.GetNamespace()).UpdateStatus(obj, metav1.UpdateOptions{})
+	return err
+}
+
+// getResourceSearch returns the resources searched for the alert of alertRule, the ones of its
+// namespace.
+func (c *Controller) getResourceSearch(ctx context.Context, alertRule *AlertRule) (string, error) {
+	rsTypeId := alertRule.Spec.RsFilter.RsTypeId
+	resp, err := c.manager.DescribeResourceTypes(ctx, &pb.DescribeResourceTypesRequest{RsTypeId: []string{rsTypeId}})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.ResourceTypeSet) == 0 {
+		return "", fmt.Errorf("resource type [%s] not found", rsTypeId)
+	}
+
+	rsTypeName := resp.ResourceTypeSet[0].RsTypeName
+	switch rsTypeName {
+	case "namespace", "workload", "pod", "container":
+	default:
+		return "", fmt.Errorf("resource type [%s] is not namespaced", rsTypeName)
+	}
+
+	resourceSearch, _ := json.Marshal(map[string]string{
+		"rs_type_name": rsTypeName,
+		"ns_name":      alertRule.Namespace,
+	})
+	return string(resourceSearch), nil
+}
+
+// applyAlert creates the alert of alertRule, or updates it when the spec changed since it was
+// last applied, and returns the status of the alert.
+func (c *Controller) applyAlert(ctx context.Context, alertRule *AlertRule) (AlertRuleStatus, error) {
+	alertStatus := AlertRuleStatus{
+		AlertId:            alertRule.Status.AlertId,
+		ObservedGeneration: alertRule.Status.ObservedGeneration,
+	}
+
+	resourceSearch, err := c.getResourceSearch(ctx, alertRule)
+	if err != nil {
+		return alertStatus, err
+	}
+
+	alertName := getAlertName(alertRule)
+	existing, err := c.describeAlert(ctx, resourceSearch, alertName)
+	if err != nil {
+		return alertStatus, err
+	}
+
+	switch {
+	case existing != nil && !isAlertRuleOwner(alertRule, existing):
+		return alertStatus, fmt.Errorf("alert [%s] exists and does not belong to the AlertRule", alertName)
+	case existing == nil:
+		resp, err := c.manager.CreateAlertBundle(ctx, newCreateAlertBundleRequest(resourceSearch, alertName, alertRule))
+		if err != nil {
+			return alertStatus, err
+		}
+		alertStatus.AlertId = resp.AlertId
+	case alertRule.Status.AlertId != existing.AlertId || alertRule.Status.ObservedGeneration != alertRule.Generation:
+		rules, err := c.manager.DescribeRules(ctx, &pb.DescribeRulesRequest{PolicyId: []string{existing.PolicyId}})
+		if err != nil {
+			return alertStatus, err
+		}
+		_, err = c.manager.UpdateAlertBundle(ctx, newUpdateAlertBundleRequest(resourceSearch, alertName, existing.AlertId, alertRule, rules.RuleSet))
+		if err != nil {
+			return alertStatus, err
+		}
+		alertStatus.AlertId = existing.AlertId
+	}
+	alertStatus.ObservedGeneration = alertRule.Generation
+
+	alert, err := c.describeAlert(ctx, resourceSearch, alertName)
+	if err != nil {
+		return alertStatus, err
+	}
+	if alert != nil {
+		alertStatus.RunningStatus = alert.RunningStatus
+		alertStatus.FiringCount = alert.PositivesCount
+	}
+
+	return alertStatus, nil
+}
+
+func (c *Controller) describeAlert(ctx context.Context, resourceSearch string, alertName string) (*pb.AlertDetail, error) {
+	resp, err := c.manager.DescribeAlertDetails(ctx, &pb.DescribeAlertDetailsRequest{
+		ResourceSearch: resourceSearch,
+		AlertName:      []string{alertName},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.AlertdetailSet) == 0 {
+		return nil, nil
+	}
+	return resp.AlertdetailSet[0], nil
+}
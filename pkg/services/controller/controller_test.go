@@ -0,0 +1,242 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic/fake"
+
+	"kubesphere.io/alert/pkg/pb"
+)
+
+type fakeManager struct {
+	alerts  map[string]*pb.AlertDetail
+	rules   map[string][]*pb.Rule
+	created []*pb.CreateAlertBundleRequest
+	updated []*pb.UpdateAlertBundleRequest
+	deleted []string
+}
+
+func newFakeManager() *fakeManager {
+	return &fakeManager{
+		alerts: map[string]*pb.AlertDetail{},
+		rules:  map[string][]*pb.Rule{},
+	}
+}
+
+func (m *fakeManager) DescribeResourceTypes(ctx context.Context, in *pb.DescribeResourceTypesRequest, opts ...grpc.CallOption) (*pb.DescribeResourceTypesResponse, error) {
+	rsTypeNames := map[string]string{"rst-namespace": "namespace", "rst-cluster": "cluster"}
+	resp := &pb.DescribeResourceTypesResponse{}
+	for _, rsTypeId := range in.RsTypeId {
+		if rsTypeName, ok := rsTypeNames[rsTypeId]; ok {
+			resp.ResourceTypeSet = append(resp.ResourceTypeSet, &pb.ResourceType{RsTypeId: rsTypeId, RsTypeName: rsTypeName})
+		}
+	}
+	return resp, nil
+}
+
+func (m *fakeManager) DescribeRules(ctx context.Context, in *pb.DescribeRulesRequest, opts ...grpc.CallOption) (*pb.DescribeRulesResponse, error) {
+	return &pb.DescribeRulesResponse{RuleSet: m.rules[in.PolicyId[0]]}, nil
+}
+
+func (m *fakeManager) DeleteAlerts(ctx context.Context, in *pb.DeleteAlertsRequest, opts ...grpc.CallOption) (*pb.DeleteAlertsResponse, error) {
+	m.deleted = append(m.deleted, in.AlertId...)
+	return &pb.DeleteAlertsResponse{AlertId: in.AlertId}, nil
+}
+
+func (m *fakeManager) DescribeAlertDetails(ctx context.Context, in *pb.DescribeAlertDetailsRequest, opts ...grpc.CallOption) (*pb.DescribeAlertDetailsResponse, error) {
+	resp := &pb.DescribeAlertDetailsResponse{}
+	if alert, ok := m.alerts[in.ResourceSearch+in.AlertName[0]]; ok {
+		resp.AlertdetailSet = append(resp.AlertdetailSet, alert)
+	}
+	return resp, nil
+}
+
+func (m *fakeManager) CreateAlertBundle(ctx context.Context, in *pb.CreateAlertBundleRequest, opts ...grpc.CallOption) (*pb.CreateAlertBundleResponse, error) {
+	m.created = append(m.created, in)
+	m.alerts[in.ResourceSearch+in.Alert.AlertName] = &pb.AlertDetail{
+		AlertId:        "al-1",
+		AlertName:      in.Alert.AlertName,
+		PolicyId:       "pl-1",
+		Creator:        in.Policy.Creator,
+		RunningStatus:  "adding",
+		PositivesCount: 2,
+	}
+	for i, rule := range in.Rules {
+		m.rules["pl-1"] = append(m.rules["pl-1"], &pb.Rule{RuleId: fmt.Sprintf("rl-%d", i+1), RuleName: rule.RuleName})
+	}
+	return &pb.CreateAlertBundleResponse{AlertId: "al-1", PolicyId: "pl-1"}, nil
+}
+
+func (m *fakeManager) UpdateAlertBundle(ctx context.Context, in *pb.UpdateAlertBundleRequest, opts ...grpc.CallOption) (*pb.UpdateAlertBundleResponse, error) {
+	m.updated = append(m.updated, in)
+	return &pb.UpdateAlertBundleResponse{AlertId: in.AlertId}, nil
+}
+
+func newTestAlertRule(name string, rsTypeId string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "alerting.kubesphere.io/v1alpha1",
+		"kind":       "AlertRule",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "ns1",
+			"uid":       "uid-" + name,
+		},
+		"spec": map[string]interface{}{
+			"resource_filter": map[string]interface{}{
+				"rs_filter_name": "ns1",
+				"rs_type_id":     rsTypeId,
+			},
+			"policy": map[string]interface{}{
+				"policy_name":          name,
+				"available_start_time": "00:00:00",
+				"available_end_time":   "23:59:59",
+			},
+			"action": map[string]interface{}{
+				"action_name": name,
+			},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"rule_name":  "cpu",
+					"metric_id":  "mt-1",
+					"severity":   "minor",
+					"thresholds": "80",
+				},
+			},
+		},
+	}}
+}
+
+func getTestAlertRule(t *testing.T, c *Controller, name string) *AlertRule {
+	obj, err := c.client.Resource(AlertRuleResource).Namespace("ns1").Get(name, metav1.GetOptions{})
+	require.NoError(t, err)
+	alertRule, err := newAlertRule(obj)
+	require.NoError(t, err)
+	return alertRule
+}
+
+func TestReconcile(t *testing.T) {
+	manager := newFakeManager()
+	client := fake.NewSimpleDynamicClient(runtime.NewScheme(), newTestAlertRule("pod-cpu", "rst-namespace"))
+	c := NewController(client, manager, "", time.Minute)
+
+	c.SyncAll()
+
+	require.Len(t, manager.created, 1)
+	require.Equal(t, `{"ns_name":"ns1","rs_type_name":"namespace"}`, manager.created[0].ResourceSearch)
+	require.Equal(t, "alertrule/uid-pod-cpu", manager.created[0].Policy.Creator)
+	require.Equal(t, `{"ns_name":"ns1"}`, manager.created[0].RsFilter.RsFilterParam)
+	require.Equal(t, "pod-cpu", manager.created[0].Alert.AlertName)
+	require.Equal(t, "mt-1", manager.created[0].Rules[0].MetricId)
+
+	alertRule := getTestAlertRule(t, c, "pod-cpu")
+	require.Equal(t, []string{AlertRuleFinalizer}, alertRule.Finalizers)
+	require.Equal(t, AlertRuleStatus{AlertId: "al-1", RunningStatus: "adding", FiringCount: 2}, alertRule.Status)
+
+	// unchanged specs are not applied again
+	c.SyncAll()
+	require.Len(t, manager.created, 1)
+	require.Len(t, manager.updated, 0)
+
+	obj, err := client.Resource(AlertRuleResource).Namespace("ns1").Get("pod-cpu", metav1.GetOptions{})
+	require.NoError(t, err)
+	obj.SetGeneration(2)
+	rules, _, err := unstructured.NestedSlice(obj.Object, "spec", "rules")
+	require.NoError(t, err)
+	rules[0].(map[string]interface{})["thresholds"] = "90"
+	require.NoError(t, unstructured.SetNestedSlice(obj.Object, rules, "spec", "rules"))
+	c.Reconcile(obj)
+
+	require.Len(t, manager.updated, 1)
+	require.Equal(t, "al-1", manager.updated[0].AlertId)
+	require.Equal(t, "rl-1", manager.updated[0].Rules[0].RuleId)
+	require.Equal(t, int64(2), getTestAlertRule(t, c, "pod-cpu").Status.ObservedGeneration)
+}
+
+func TestReconcileDeleted(t *testing.T) {
+	manager := newFakeManager()
+	client := fake.NewSimpleDynamicClient(runtime.NewScheme(), newTestAlertRule("pod-cpu", "rst-namespace"))
+	c := NewController(client, manager, "", time.Minute)
+
+	c.SyncAll()
+
+	obj, err := client.Resource(AlertRuleResource).Namespace("ns1").Get("pod-cpu", metav1.GetOptions{})
+	require.NoError(t, err)
+	now := metav1.Now()
+	obj.SetDeletionTimestamp(&now)
+	c.Reconcile(obj)
+
+	require.Equal(t, []string{"al-1"}, manager.deleted)
+	require.Empty(t, getTestAlertRule(t, c, "pod-cpu").Finalizers)
+}
+
+func TestReconcileNotNamespaced(t *testing.T) {
+	manager := newFakeManager()
+	client := fake.NewSimpleDynamicClient(runtime.NewScheme(), newTestAlertRule("cluster-cpu", "rst-cluster"))
+	c := NewController(client, manager, "", time.Minute)
+
+	c.SyncAll()
+
+	require.Len(t, manager.created, 0)
+	require.Equal(t, "resource type [cluster] is not namespaced", getTestAlertRule(t, c, "cluster-cpu").Status.Message)
+}
+
+func TestReconcileNotOwned(t *testing.T) {
+	manager := newFakeManager()
+	resourceSearch := `{"ns_name":"ns1","rs_type_name":"namespace"}`
+	manager.alerts[resourceSearch+"pod-cpu"] = &pb.AlertDetail{AlertId: "al-9", AlertName: "pod-cpu", PolicyId: "pl-9", Creator: "admin"}
+	client := fake.NewSimpleDynamicClient(runtime.NewScheme(), newTestAlertRule("pod-cpu", "rst-namespace"))
+	c := NewController(client, manager, "", time.Minute)
+
+	// alerts of the same name created otherwise are left alone
+	c.SyncAll()
+
+	require.Len(t, manager.created, 0)
+	require.Len(t, manager.updated, 0)
+	alertRule := getTestAlertRule(t, c, "pod-cpu")
+	require.Equal(t, "", alertRule.Status.AlertId)
+	require.Equal(t, "alert [pod-cpu] exists and does not belong to the AlertRule", alertRule.Status.Message)
+
+	// alerts marked as the AlertRule's are adopted
+	manager.alerts[resourceSearch+"pod-cpu"].Creator = "alertrule/uid-pod-cpu"
+	c.SyncAll()
+
+	require.Len(t, manager.updated, 1)
+	require.Equal(t, "al-9", manager.updated[0].AlertId)
+	require.Equal(t, "al-9", getTestAlertRule(t, c, "pod-cpu").Status.AlertId)
+}
+
+func TestWatchSkipsStatusChanges(t *testing.T) {
+	manager := newFakeManager()
+	client := fake.NewSimpleDynamicClient(runtime.NewScheme(), newTestAlertRule("pod-cpu", "rst-namespace"))
+	c := NewController(client, manager, "", time.Minute)
+	c.SyncAll()
+	require.Len(t, manager.created, 1)
+
+	obj, err := client.Resource(AlertRuleResource).Namespace("ns1").Get("pod-cpu", metav1.GetOptions{})
+	require.NoError(t, err)
+	changed := obj.DeepCopy()
+	changed.SetGeneration(2)
+
+	watcher := watch.NewFakeWithChanSize(2, false)
+	watcher.Modify(obj)
+	watcher.Modify(changed)
+	watcher.Stop()
+	require.True(t, c.watch(watcher, nil, nil))
+
+	// the status update of the controller is skipped, the spec change is applied
+	require.Len(t, manager.updated, 1)
+	require.Equal(t, int64(2), getTestAlertRule(t, c, "pod-cpu").Status.ObservedGeneration)
+}
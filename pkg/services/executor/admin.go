@@ -0,0 +1,87 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"kubesphere.io/alert/pkg/monitoring"
+)
+
+type AdminRunner struct {
+	AlertId     string          `json:"alert_id"`
+	UpdateTime  time.Time       `json:"update_time"`
+	Verbose     bool            `json:"verbose"`
+	AlertStatus json.RawMessage `json:"alert_status"`
+}
+
+type AdminVerbose struct {
+	AlertIds []string `json:"alert_ids"`
+}
+
+func (e *Executor) registerAdminHandlers() {
+	monitoring.HandleAdmin("/admin/runners", http.HandlerFunc(e.serveRunners))
+	monitoring.HandleAdmin("/admin/verbose", http.HandlerFunc(e.serveVerbose))
+}
+
+// serveRunners dumps the state of all runners of the executor.
+func (e *Executor) serveRunners(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		monitoring.WriteJSON(w, http.StatusMethodNotAllowed, monitoring.AdminError{Error: "method not allowed"})
+		return
+	}
+
+	verboseAlerts := make(map[string]bool)
+	for _, alertId := range e.GetVerboseAlerts() {
+		verboseAlerts[alertId] = true
+	}
+
+	runners := []AdminRunner{}
+	for _, runner := range e.GetRunners() {
+		alertStatus := json.RawMessage("null")
+		if json.Valid([]byte(runner.AlertStatus)) {
+			alertStatus = json.RawMessage(runner.AlertStatus)
+		}
+
+		runners = append(runners, AdminRunner{
+			AlertId:     runner.AlertId,
+			UpdateTime:  runner.UpdateTime,
+			Verbose:     verboseAlerts[runner.AlertId],
+			AlertStatus: alertStatus,
+		})
+	}
+
+	monitoring.WriteJSON(w, http.StatusOK, runners)
+}
+
+// serveVerbose lists the alerts traced verbosely on GET, and toggles one on PUT or POST, eg.
+// curl -X PUT 'http://127.0.0.1:9203/admin/verbose?alert_id=al-xxx&enable=true'
+func (e *Executor) serveVerbose(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		alertId := r.URL.Query().Get("alert_id")
+		if alertId == "" {
+			monitoring.WriteJSON(w, http.StatusBadRequest, monitoring.AdminError{Error: "alert_id is required"})
+			return
+		}
+
+		enable, err := strconv.ParseBool(r.URL.Query().Get("enable"))
+		if err != nil {
+			monitoring.WriteJSON(w, http.StatusBadRequest, monitoring.AdminError{Error: "enable should be true or false"})
+			return
+		}
+
+		e.SetVerbose(alertId, enable)
+	default:
+		monitoring.WriteJSON(w, http.StatusMethodNotAllowed, monitoring.AdminError{Error: "method not allowed"})
+		return
+	}
+
+	monitoring.WriteJSON(w, http.StatusOK, AdminVerbose{AlertIds: e.GetVerboseAlerts()})
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newAdminExecutor() *Executor {
+	return &Executor{runner: &Runner{Map: make(map[string]*AlertRunner)}}
+}
+
+func TestServeVerbose(t *testing.T) {
+	e := newAdminExecutor()
+
+	w := httptest.NewRecorder()
+	e.serveVerbose(w, httptest.NewRequest(http.MethodPut, "/admin/verbose?alert_id=al-1&enable=true", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	verbose := AdminVerbose{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &verbose))
+	require.Equal(t, []string{"al-1"}, verbose.AlertIds)
+
+	w = httptest.NewRecorder()
+	e.serveVerbose(w, httptest.NewRequest(http.MethodPut, "/admin/verbose?alert_id=al-1&enable=false", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, e.GetVerboseAlerts())
+
+	for _, url := range []string{"/admin/verbose?enable=true", "/admin/verbose?alert_id=al-1&enable=yes"} {
+		w = httptest.NewRecorder()
+		e.serveVerbose(w, httptest.NewRequest(http.MethodPut, url, nil))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	}
+	require.Empty(t, e.GetVerboseAlerts())
+
+	w = httptest.NewRecorder()
+	e.serveVerbose(w, httptest.NewRequest(http.MethodDelete, "/admin/verbose", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestServeRunners(t *testing.T) {
+	e := newAdminExecutor()
+
+	w := httptest.NewRecorder()
+	e.serveRunners(w, httptest.NewRequest(http.MethodGet, "/admin/runners", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `[]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	e.serveRunners(w, httptest.NewRequest(http.MethodPut, "/admin/runners", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -1,6 +1,7 @@
 package executor
 
 import (
+	"context"
 	"errors"
 	"strconv"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"kubesphere.io/alert/pkg/config"
 	"kubesphere.io/alert/pkg/constants"
 	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/monitoring"
 )
 
 type AlertReceiver struct {
@@ -61,6 +63,11 @@ func (ar *AlertReceiver) ExtractAlerts() error {
 	}
 
 	for {
+		if ar.executor.IsCordoned() {
+			time.Sleep(3 * time.Second)
+			continue
+		}
+
 		alertId, err := ar.alertQueue.Dequeue()
 		if err != nil {
 			logger.Error(nil, "AlertReceiver failed to dequeue alert from etcd queue: %+v", err)
@@ -73,6 +80,18 @@ func (ar *AlertReceiver) ExtractAlerts() error {
 	}
 }
 
+func (ar *AlertReceiver) CheckQueue(ctx context.Context) error {
+	if ar.alertQueue == nil {
+		return errors.New("AlertQueue not initialized")
+	}
+
+	return monitoring.CheckQueue(ctx)
+}
+
+func (ar *AlertReceiver) GetQueueDepth() int {
+	return len(ar.runningAlertIds)
+}
+
 func (ar *AlertReceiver) HandleAlert(handlerNum string) {
 	for {
 		alertId := <-ar.runningAlertIds
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
 
 	"kubesphere.io/alert/pkg/global"
 	"kubesphere.io/alert/pkg/logger"
@@ -15,16 +16,21 @@ import (
 
 // ExecutorInfo is the service register information to etcd
 type ExecutorInfo struct {
-	Name      string
-	TaskCount int
+	Name          string
+	TaskCount     int
+	BootTime      time.Time
+	HeartbeatTime time.Time
 }
 
 type AliveReporter struct {
 	executor *Executor
+	bootTime time.Time
 }
 
 func NewAliveReporter() *AliveReporter {
-	ar := &AliveReporter{}
+	ar := &AliveReporter{
+		bootTime: time.Now(),
+	}
 
 	return ar
 }
@@ -56,8 +62,10 @@ func (ar *AliveReporter) putKey(expireTime int64) error {
 	key := "alert-executors/" + ar.executor.GetName()
 
 	info := &ExecutorInfo{
-		Name:      ar.executor.GetName(),
-		TaskCount: ar.executor.GetTaskCount(),
+		Name:          ar.executor.GetName(),
+		TaskCount:     ar.executor.GetTaskCount(),
+		BootTime:      ar.bootTime,
+		HeartbeatTime: time.Now(),
 	}
 
 	value, _ := json.Marshal(info)
@@ -78,7 +86,11 @@ func (ar *AliveReporter) putKey(expireTime int64) error {
 	return err
 }
 
+// HeartBoot registers the executor. The status a previous executor of the same name was left
+// with, such as cordoned, does not carry over to this one.
 func (ar *AliveReporter) HeartBoot() {
+	ar.clearCordon()
+
 	err := ar.putKey(30)
 
 	if err != nil {
@@ -109,3 +121,49 @@ func (ar *AliveReporter) HeartBeat() {
 		}
 	}
 }
+
+func (ar *AliveReporter) loadCordon() {
+	ctx := context.Background()
+	e := global.GetInstance().GetEtcd()
+	key := "alert-executor-status/" + ar.executor.GetName()
+	resp, err := e.Get(ctx, key)
+	if err != nil {
+		logger.Error(nil, "AliveReporter get executor status [%s] from etcd failed: %+v", key, err)
+		return
+	}
+
+	cordoned := false
+	for _, kv := range resp.Kvs {
+		cordoned = string(kv.Value) == "cordoned"
+	}
+	ar.executor.SetCordoned(cordoned)
+}
+
+func (ar *AliveReporter) clearCordon() {
+	e := global.GetInstance().GetEtcd()
+	key := "alert-executor-status/" + ar.executor.GetName()
+	_, err := e.Delete(context.Background(), key)
+	if err != nil {
+		logger.Error(nil, "AliveReporter clear executor status [%s] in etcd failed: %+v", key, err)
+	}
+}
+
+func (ar *AliveReporter) WatchCordon() {
+	ar.loadCordon()
+
+	e := global.GetInstance().GetEtcd()
+	key := "alert-executor-status/" + ar.executor.GetName()
+	watchRes := e.Watch(context.Background(), key)
+
+	for res := range watchRes {
+		for _, ev := range res.Events {
+			if ev.Type == mvccpb.PUT {
+				logger.Info(nil, "AliveReporter got executor status [%s] [%s]", string(ev.Kv.Key), string(ev.Kv.Value))
+				ar.executor.SetCordoned(string(ev.Kv.Value) == "cordoned")
+			} else if ev.Type == mvccpb.DELETE {
+				logger.Info(nil, "AliveReporter executor status [%s] cleared", string(ev.Kv.Key))
+				ar.executor.SetCordoned(false)
+			}
+		}
+	}
+}
@@ -8,13 +8,15 @@ import (
 
 	"kubesphere.io/alert/pkg/global"
 	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/tracing"
 	"kubesphere.io/alert/pkg/util/jsonutil"
 )
 
 // BroadcastInfo is the service register information to etcd
 type BroadcastInfo struct {
-	AlertId   string
-	Operation string
+	AlertId     string
+	Operation   string
+	Traceparent string
 }
 
 type BroadcastReceiver struct {
@@ -31,6 +33,18 @@ func (br *BroadcastReceiver) SetExecutor(executor *Executor) {
 	br.executor = executor
 }
 
+// processBroadcast continues the trace of the manager call which sent the broadcast.
+func (br *BroadcastReceiver) processBroadcast(info BroadcastInfo) {
+	ctx := tracing.Extract(context.Background(), info.Traceparent)
+	ctx, span := tracing.StartSpan(ctx, "Executor.ProcessAlert")
+	defer span.End()
+	span.SetAttribute("alert_id", info.AlertId)
+	span.SetAttribute("operation", info.Operation)
+	span.SetAttribute("executor", br.executor.GetName())
+
+	br.executor.ProcessAlert(ctx, info.AlertId, info.Operation)
+}
+
 func (br *BroadcastReceiver) WatchBroadcast() {
 	e := global.GetInstance().GetEtcd()
 	watchRes := e.Watch(context.Background(), "al-broadcast/", clientv3.WithPrefix())
@@ -44,7 +58,7 @@ func (br *BroadcastReceiver) WatchBroadcast() {
 					logger.Error(nil, "WatchBroadcast decode event [%s] [%s] failed: %+v", string(ev.Kv.Key), string(ev.Kv.Value), err)
 				} else {
 					logger.Info(nil, "WatchBroadcast got put event [%s] [%s]", string(ev.Kv.Key), string(ev.Kv.Value))
-					br.executor.ProcessAlert(info.AlertId, info.Operation)
+					br.processBroadcast(info)
 				}
 			} else if ev.Type == mvccpb.DELETE {
 				logger.Info(nil, "WatchBroadcast got delete event [%s] [%s]", string(ev.Kv.Key), string(ev.Kv.Value))
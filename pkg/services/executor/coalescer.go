@@ -0,0 +1,218 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"kubesphere.io/alert/pkg/client/adapter"
+	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/metric"
+	"kubesphere.io/alert/pkg/tracing"
+	"kubesphere.io/alert/pkg/util/stringutil"
+)
+
+// MetricCoalescer merges metric requests of alert runners querying the same metric of the same
+// resources within a batch window into one adapter call, and fans the results out to the waiting
+// runners by rule id.
+type MetricCoalescer struct {
+	sync.Mutex
+	window       time.Duration
+	timeout      time.Duration
+	batches      map[string]*metricBatch
+	requestCount uint64
+	callCount    uint64
+}
+
+type metricBatch struct {
+	param   metric.MetricParam
+	parent  tracing.SpanContext
+	waiters int
+	result  []metric.ResourceMetrics
+	err     error
+	doneCh  chan struct{}
+}
+
+// NewMetricCoalescer creates a coalescer, timeout bounds the shared adapter call of a batch.
+func NewMetricCoalescer(window time.Duration, timeout time.Duration) *MetricCoalescer {
+	mc := &MetricCoalescer{
+		window:  window,
+		timeout: timeout,
+		batches: make(map[string]*metricBatch),
+	}
+
+	return mc
+}
+
+// GetPhaseKey returns the key of runners ticking at the same phase, so that their requests of
+// the same metrics meet in a batch.
+func GetPhaseKey(metricParam metric.MetricParam, period uint32) string {
+	return fmt.Sprintf("%s|%s|%s|%d", metricParam.RsTypeName, metricParam.RsTypeParam, metricParam.ExtraQueryParams, period)
+}
+
+// GetBatchKey returns the key of requests which can be served by one adapter call. The adapter
+// queries the resources of a single filter, so the key covers resource type, filter, metric and
+// evaluation period, and runners of other tenants never share a call.
+func GetBatchKey(metricParam metric.MetricParam, metricName string, period uint32) string {
+	return fmt.Sprintf("%s|%s|%s|%s", GetPhaseKey(metricParam, period), metricParam.RsFilterName, metricParam.RsFilterParam, metricName)
+}
+
+func newMetricBatch(metricParam metric.MetricParam, metricName string, parent tracing.SpanContext) *metricBatch {
+	return &metricBatch{
+		param: metric.MetricParam{
+			RsTypeName:       metricParam.RsTypeName,
+			RsTypeParam:      metricParam.RsTypeParam,
+			RsFilterName:     metricParam.RsFilterName,
+			RsFilterParam:    metricParam.RsFilterParam,
+			ExtraQueryParams: metricParam.ExtraQueryParams,
+			Metrics:          []string{metricName},
+			MetricToRule:     map[string][]string{metricName: {}},
+		},
+		parent: parent,
+		doneCh: make(chan struct{}),
+	}
+}
+
+// addRules adds the rules of the batch metric a runner requests.
+func (batch *metricBatch) addRules(ruleIds []string) {
+	metricName := batch.param.Metrics[0]
+	for _, ruleId := range ruleIds {
+		if !stringutil.StringIn(ruleId, batch.param.MetricToRule[metricName]) {
+			batch.param.MetricToRule[metricName] = append(batch.param.MetricToRule[metricName], ruleId)
+		}
+	}
+}
+
+// GetResourceMetrics returns the metrics of the rules in metricParam, waiting at most
+// one batch window for other runners asking for the same metrics. The batch window
+// and the adapter call have deadlines of their own, so the window does not shorten
+// the call. It returns early with the context error when ctx is done.
+func (mc *MetricCoalescer) GetResourceMetrics(ctx context.Context, metricParam metric.MetricParam, period uint32) ([]metric.ResourceMetrics, error) {
+	atomic.AddUint64(&mc.requestCount, uint64(len(metricParam.MetricToRule)))
+
+	if mc.window <= 0 {
+		ctx, cancel := context.WithTimeout(ctx, mc.timeout)
+		defer cancel()
+
+		atomic.AddUint64(&mc.callCount, 1)
+		return sendMetricRequest(ctx, metricParam)
+	}
+
+	batches := make(map[string]*metricBatch)
+
+	mc.Lock()
+	for _, metricName := range metricParam.Metrics {
+		key := GetBatchKey(metricParam, metricName, period)
+		if _, ok := batches[key]; ok {
+			continue
+		}
+
+		batch, ok := mc.batches[key]
+		if !ok {
+			batch = newMetricBatch(metricParam, metricName, tracing.SpanContextFromContext(ctx))
+			mc.batches[key] = batch
+
+			time.AfterFunc(mc.window, func() {
+				mc.dispatch(key, batch)
+			})
+		}
+		batch.addRules(metricParam.MetricToRule[metricName])
+		batch.waiters++
+		batches[key] = batch
+	}
+	mc.Unlock()
+
+	//Fan out results of the rules requested by this runner
+	ruleIds := make(map[string]bool)
+	for _, rules := range metricParam.MetricToRule {
+		for _, ruleId := range rules {
+			ruleIds[ruleId] = true
+		}
+	}
+
+	resourceMetrics := []metric.ResourceMetrics{}
+	for _, batch := range batches {
+		select {
+		case <-batch.doneCh:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if batch.err != nil {
+			return nil, batch.err
+		}
+
+		for _, rm := range batch.result {
+			if ruleIds[rm.RuleId] {
+				resourceMetrics = append(resourceMetrics, rm)
+			}
+		}
+	}
+
+	return resourceMetrics, nil
+}
+
+func (mc *MetricCoalescer) dispatch(key string, batch *metricBatch) {
+	mc.Lock()
+	delete(mc.batches, key)
+	mc.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), mc.timeout)
+	defer cancel()
+
+	//The shared call is traced under the runner which opened the batch
+	ctx, span := tracing.StartSpan(tracing.ContextWithSpanContext(ctx, batch.parent), "MetricCoalescer.dispatch")
+	defer span.End()
+	span.SetAttribute("batch_key", key)
+	span.SetAttribute("waiters", strconv.Itoa(batch.waiters))
+
+	atomic.AddUint64(&mc.callCount, 1)
+	batch.result, batch.err = sendMetricRequest(ctx, batch.param)
+	span.SetError(batch.err)
+
+	if batch.waiters > 1 {
+		logger.Debug(nil, "MetricCoalescer merged %d requests of [%s] into one adapter call", batch.waiters, key)
+	}
+
+	close(batch.doneCh)
+}
+
+// GetCallCount returns how many metrics runners requested, how many adapter calls
+// were actually sent and how many calls were saved by batching.
+func (mc *MetricCoalescer) GetCallCount() (uint64, uint64, uint64) {
+	callCount := atomic.LoadUint64(&mc.callCount)
+	requestCount := atomic.LoadUint64(&mc.requestCount)
+
+	return requestCount, callCount, requestCount - callCount
+}
+
+func sendMetricRequest(ctx context.Context, metricParam metric.MetricParam) ([]metric.ResourceMetrics, error) {
+	metricParamBytes, err := json.Marshal(metricParam)
+	if err != nil {
+		logger.Error(ctx, "Marshal Metric Param error: %v", err)
+		return nil, err
+	}
+
+	resourceMetricsStr, err := adapter.SendMetricRequest(ctx, string(metricParamBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	resourceMetrics := []metric.ResourceMetrics{}
+
+	err = json.Unmarshal([]byte(resourceMetricsStr), &resourceMetrics)
+	if err != nil {
+		logger.Error(ctx, "Unmarshal Metric Result [%s] error: %v", resourceMetricsStr, err)
+		return nil, err
+	}
+
+	return resourceMetrics, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/metric"
+	"kubesphere.io/alert/pkg/tracing"
+)
+
+func TestGetBatchKey(t *testing.T) {
+	ns1 := metric.MetricParam{RsTypeName: "pod", RsFilterParam: `{"ns_name":"ns1"}`}
+	ns2 := metric.MetricParam{RsTypeName: "pod", RsFilterParam: `{"ns_name":"ns2"}`}
+	node := metric.MetricParam{RsTypeName: "node"}
+
+	// runners filtering other resources tick together but never share an adapter call
+	require.Equal(t, GetPhaseKey(ns1, 60), GetPhaseKey(ns2, 60))
+	require.NotEqual(t, GetBatchKey(ns1, "pod_cpu_usage", 60), GetBatchKey(ns2, "pod_cpu_usage", 60))
+	require.NotEqual(t, GetBatchKey(ns1, "pod_cpu_usage", 60), GetBatchKey(ns1, "pod_memory_usage", 60))
+	require.NotEqual(t, GetBatchKey(ns1, "pod_cpu_usage", 60), GetBatchKey(ns1, "pod_cpu_usage", 300))
+	require.NotEqual(t, GetPhaseKey(ns1, 60), GetPhaseKey(node, 60))
+}
+
+func TestMetricBatchParam(t *testing.T) {
+	metricParam := metric.MetricParam{RsTypeName: "pod", RsFilterName: "ns1", RsFilterParam: `{"ns_name":"ns1"}`}
+	batch := newMetricBatch(metricParam, "pod_cpu_usage", tracing.SpanContext{})
+
+	// the batch queries the filter of its runners for the rules of all of them
+	batch.addRules([]string{"rl-1"})
+	batch.addRules([]string{"rl-1", "rl-2"})
+	require.Equal(t, metric.MetricParam{
+		RsTypeName:    "pod",
+		RsFilterName:  "ns1",
+		RsFilterParam: `{"ns_name":"ns1"}`,
+		Metrics:       []string{"pod_cpu_usage"},
+		MetricToRule:  map[string][]string{"pod_cpu_usage": {"rl-1", "rl-2"}},
+	}, batch.param)
+}
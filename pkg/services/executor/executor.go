@@ -5,10 +5,18 @@
 package executor
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	nf "kubesphere.io/alert/pkg/client/notification"
+	"kubesphere.io/alert/pkg/config"
+	"kubesphere.io/alert/pkg/global"
 	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/monitoring"
 	rs "kubesphere.io/alert/pkg/services/executor/resource_control"
 )
 
@@ -19,6 +27,11 @@ type Executor struct {
 	aliveReporter     *AliveReporter
 	broadcastReceiver *BroadcastReceiver
 	healthChecker     *HealthChecker
+	scheduler         *Scheduler
+	coalescer         *MetricCoalescer
+	cordoned          int32
+	draining          int32
+	verboseAlerts     sync.Map
 }
 
 type Runner struct {
@@ -26,7 +39,7 @@ type Runner struct {
 	Map map[string]*AlertRunner
 }
 
-func NewExecutor(name string, alertReceiver *AlertReceiver, aliveReporter *AliveReporter, broadcastReceiver *BroadcastReceiver, healthChecker *HealthChecker) *Executor {
+func NewExecutor(name string, alertReceiver *AlertReceiver, aliveReporter *AliveReporter, broadcastReceiver *BroadcastReceiver, healthChecker *HealthChecker, scheduler *Scheduler, coalescer *MetricCoalescer) *Executor {
 	e := &Executor{
 		name:              name,
 		alertReceiver:     alertReceiver,
@@ -34,37 +47,44 @@ func NewExecutor(name string, alertReceiver *AlertReceiver, aliveReporter *Alive
 		aliveReporter:     aliveReporter,
 		broadcastReceiver: broadcastReceiver,
 		healthChecker:     healthChecker,
+		scheduler:         scheduler,
+		coalescer:         coalescer,
 	}
 	return e
 }
 
 func (e *Executor) startRunner(alertId string) bool {
+	ctx := logger.WithField(nil, logger.AlertIdField, alertId)
+
 	e.runner.Lock()
 	_, ok := e.runner.Map[alertId]
 	e.runner.Unlock()
 	if ok {
-		logger.Error(nil, "Executor startRunner error: alert %s already exists", alertId)
+		logger.Error(ctx, "Executor startRunner error: alert %s already exists", alertId)
 		return false
 	}
 
 	//Query DB, check if this alert is in adding or migrating state
-	alert := rs.GetAlertInfo(alertId)
+	alert := rs.GetAlertInfo(ctx, alertId)
 	if !((alert.RunningStatus == "adding" || alert.RunningStatus == "migrating") && alert.ExecutorId == "") {
-		logger.Error(nil, "Executor startRunner error: alert %s should not be dispatched", alertId)
+		logger.Error(ctx, "Executor startRunner error: alert %s should not be dispatched", alertId)
 		return false
 	}
 
 	initStatus := alert.RunningStatus
 
 	//Update DB, set this alert to running
-	err := rs.UpdateAlertInfo(alertId, e.name, "running")
+	err := rs.UpdateAlertInfo(ctx, alertId, e.name, "running")
 
 	if err != nil {
-		logger.Error(nil, "Executor startRunner error: update alert "+alertId+" error")
+		logger.Error(ctx, "Executor startRunner error: update alert "+alertId+" error")
 		return false
 	}
 
-	var runner = NewAlertRunner(alertId, e.healthChecker.UpdateCh)
+	var runner = NewAlertRunner(alertId, e.healthChecker.UpdateCh, e.scheduler, e.coalescer)
+	if _, ok := e.verboseAlerts.Load(alertId); ok {
+		runner.SetVerbose(true)
+	}
 
 	e.runner.Lock()
 	e.runner.Map[alertId] = runner
@@ -72,32 +92,34 @@ func (e *Executor) startRunner(alertId string) bool {
 
 	go runner.Run(initStatus)
 
-	logger.Debug(nil, "Executor startRunner "+alertId+" success")
+	logger.Debug(ctx, "Executor startRunner "+alertId+" success")
 
 	return true
 }
 
-func (e *Executor) stopRunner(alertId string) bool {
+func (e *Executor) stopRunner(ctx context.Context, alertId string) bool {
+	ctx = logger.WithField(ctx, logger.AlertIdField, alertId)
+
 	e.runner.Lock()
 	runner, ok := e.runner.Map[alertId]
 	e.runner.Unlock()
 	if !ok {
-		logger.Error(nil, "Executor stopRunner error: runner does not exist")
+		logger.Error(ctx, "Executor stopRunner error: runner does not exist")
 		return false
 	}
 
 	//Query DB, check if this alert is in deleting state
-	alert := rs.GetAlertInfo(alertId)
+	alert := rs.GetAlertInfo(ctx, alertId)
 	if !(alert.RunningStatus == "deleting" && alert.ExecutorId == e.name) {
-		logger.Error(nil, "Executor stopRunner error: runner alert %s should not be deleted", alertId)
+		logger.Error(ctx, "Executor stopRunner error: runner alert %s should not be deleted", alertId)
 		return false
 	}
 
 	//Update DB, delete this alert
-	err := rs.DeleteAlert(alertId)
+	err := rs.DeleteAlert(ctx, alertId)
 
 	if err != nil {
-		logger.Error(nil, "Executor stopRunner error: delete alert "+alertId+" error")
+		logger.Error(ctx, "Executor stopRunner error: delete alert "+alertId+" error")
 		return false
 	}
 
@@ -105,63 +127,67 @@ func (e *Executor) stopRunner(alertId string) bool {
 	delete(e.runner.Map, alertId)
 	e.runner.Unlock()
 
-	runner.SignalCh <- "Stop"
+	runner.Stop()
 
-	logger.Debug(nil, "Executor stopRunner "+alertId+" success")
+	logger.Debug(ctx, "Executor stopRunner "+alertId+" success")
 
 	return true
 }
 
-func (e *Executor) updateRunner(alertId string) bool {
+func (e *Executor) updateRunner(ctx context.Context, alertId string) bool {
+	ctx = logger.WithField(ctx, logger.AlertIdField, alertId)
+
 	e.runner.Lock()
 	runner, ok := e.runner.Map[alertId]
 	e.runner.Unlock()
 	if !ok {
-		logger.Error(nil, "Executor updateRunner error: runner does not exist")
+		logger.Error(ctx, "Executor updateRunner error: runner does not exist")
 		return false
 	}
 
 	//Query DB, check if this alert is in updating state
-	alert := rs.GetAlertInfo(alertId)
+	alert := rs.GetAlertInfo(ctx, alertId)
 	if !(alert.RunningStatus == "updating" && alert.ExecutorId == e.name) {
-		logger.Error(nil, "Executor updateRunner error: runner alert %s should not be updated", alertId)
+		logger.Error(ctx, "Executor updateRunner error: runner alert %s should not be updated", alertId)
 		return false
 	}
 
 	//Update DB, update this alert
-	err := rs.UpdateAlertInfo(alertId, e.name, "running")
+	err := rs.UpdateAlertInfo(ctx, alertId, e.name, "running")
 
 	if err != nil {
-		logger.Error(nil, "Executor updateRunner error: update alert "+alertId+" error")
+		logger.Error(ctx, "Executor updateRunner error: update alert "+alertId+" error")
 		return false
 	}
 
 	runner.SignalCh <- "Update"
 
-	logger.Debug(nil, "Executor updateRunner "+alertId+" success")
+	logger.Debug(ctx, "Executor updateRunner "+alertId+" success")
 
 	return true
 }
 
-func (e *Executor) commentRunner(alertId string, historyId string) bool {
+func (e *Executor) commentRunner(ctx context.Context, alertId string, historyId string) bool {
+	ctx = logger.WithField(ctx, logger.AlertIdField, alertId)
+
 	e.runner.Lock()
 	runner, ok := e.runner.Map[alertId]
 	e.runner.Unlock()
 	if !ok {
-		logger.Error(nil, "Executor commentRunner error: runner does not exist")
+		logger.Error(ctx, "Executor commentRunner error: runner does not exist")
 		return false
 	}
 
 	//Query DB, check if this alert is in running state
-	alert := rs.GetAlertInfo(alertId)
+	alert := rs.GetAlertInfo(ctx, alertId)
 	if !(alert.RunningStatus == "running" && alert.ExecutorId == e.name) {
-		logger.Error(nil, "Executor commentRunner error: runner alert %s should not be commented", alertId)
+		logger.Error(ctx, "Executor commentRunner error: runner alert %s should not be commented", alertId)
 		return false
 	}
 
 	runner.SignalCh <- "Comment " + historyId
 
-	logger.Debug(nil, "Executor commentRunner "+alertId+" "+historyId+" success")
+	logger.Debug(ctx, "Executor commentRunner "+alertId+" "+historyId+" success")
 
 	return true
 }
@@ -169,7 +195,7 @@ func (e *Executor) commentRunner(alertId string, historyId string) bool {
 func (e *Executor) stopAllRunners() {
 	e.runner.Lock()
 	for alertId, _ := range e.runner.Map {
-		e.runner.Map[alertId].SignalCh <- "Stop"
+		e.runner.Map[alertId].Stop()
 		delete(e.runner.Map, alertId)
 	}
 	e.runner.Unlock()
@@ -179,6 +205,45 @@ func (e *Executor) GetName() string {
 	return e.name
 }
 
+// SetCordoned marks the executor as cordoned, a cordoned executor keeps
+// its running alerts but stops taking new ones from the alert queue.
+func (e *Executor) SetCordoned(cordoned bool) {
+	if cordoned {
+		atomic.StoreInt32(&e.cordoned, 1)
+	} else {
+		atomic.StoreInt32(&e.cordoned, 0)
+	}
+}
+
+func (e *Executor) IsCordoned() bool {
+	return atomic.LoadInt32(&e.cordoned) == 1
+}
+
+// SetDraining marks the executor as shutting down, its runners are being stopped.
+func (e *Executor) SetDraining() {
+	atomic.StoreInt32(&e.draining, 1)
+}
+
+func (e *Executor) IsDraining() bool {
+	return atomic.LoadInt32(&e.draining) == 1
+}
+
+// checkDraining reports a cordoned or shutting down executor as not ready.
+func (e *Executor) checkDraining(ctx context.Context) error {
+	if e.IsDraining() {
+		return errors.New("executor is shutting down")
+	}
+	if e.IsCordoned() {
+		return errors.New("executor is cordoned")
+	}
+
+	return nil
+}
+
+func (e *Executor) GetMetricCallCount() (uint64, uint64, uint64) {
+	return e.coalescer.GetCallCount()
+}
+
 func (e *Executor) GetTaskCount() int {
 	e.runner.Lock()
 	count := len(e.runner.Map)
@@ -187,6 +252,21 @@ func (e *Executor) GetTaskCount() int {
 	return count
 }
 
+// GetFiringCount returns the number of resources in alerting state of all runners by severity.
+func (e *Executor) GetFiringCount() map[string]int {
+	firingCount := make(map[string]int)
+
+	e.runner.Lock()
+	for _, runner := range e.runner.Map {
+		for severity, count := range runner.GetFiringCount() {
+			firingCount[severity] += count
+		}
+	}
+	e.runner.Unlock()
+
+	return firingCount
+}
+
 func (e *Executor) getRunnerInfo(alertId string) rs.RunnerInfo {
 	runnerInfo := rs.RunnerInfo{}
 
@@ -209,16 +289,55 @@ func (e *Executor) GetRunners() []rs.RunnerInfo {
 	return runners
 }
 
+// SetVerbose toggles verbose evaluation tracing of an alert, the setting is kept
+// for alerts which are not running on this executor yet.
+func (e *Executor) SetVerbose(alertId string, verbose bool) {
+	if verbose {
+		e.verboseAlerts.Store(alertId, true)
+	} else {
+		e.verboseAlerts.Delete(alertId)
+	}
+
+	e.runner.Lock()
+	runner, ok := e.runner.Map[alertId]
+	e.runner.Unlock()
+	if ok {
+		runner.SetVerbose(verbose)
+	}
+
+	logger.Info(logger.WithField(nil, logger.AlertIdField, alertId), "Executor set alert %s verbose %t", alertId, verbose)
+}
+
+func (e *Executor) GetVerboseAlerts() []string {
+	alertIds := []string{}
+	e.verboseAlerts.Range(func(key, value interface{}) bool {
+		alertIds = append(alertIds, key.(string))
+		return true
+	})
+
+	return alertIds
+}
+
+// DumpRunners writes the state of all runners to the log.
+func (e *Executor) DumpRunners() {
+	runners := e.GetRunners()
+	logger.Info(nil, "Executor %s has %d runners", e.name, len(runners))
+	for _, runner := range runners {
+		ctx := logger.WithField(nil, logger.AlertIdField, runner.AlertId)
+		logger.Info(ctx, "Runner alert %s updated at %s status %s", runner.AlertId, runner.UpdateTime.Format(time.RFC3339), runner.AlertStatus)
+	}
+}
+
 func (e *Executor) AddAlert(alertId string) {
 	e.startRunner(alertId)
 }
 
-func (e *Executor) ProcessAlert(alertId string, operation string) {
+func (e *Executor) ProcessAlert(ctx context.Context, alertId string, operation string) {
 	switch operation {
 	case "deleting":
-		e.stopRunner(alertId)
+		e.stopRunner(ctx, alertId)
 	case "updating":
-		e.updateRunner(alertId)
+		e.updateRunner(ctx, alertId)
 	default:
 		param := strings.Split(operation, " ")
 		if len(param) != 2 {
@@ -226,21 +345,24 @@ func (e *Executor) ProcessAlert(alertId string, operation string) {
 		}
 		switch param[0] {
 		case "commenting":
-			e.commentRunner(alertId, param[1])
+			e.commentRunner(ctx, alertId, param[1])
 		}
 	}
 }
 
 func (e *Executor) DeleteAllAlerts() {
+	e.SetDraining()
 	e.stopAllRunners()
 }
 
 func (e *Executor) TerminateRunner(alertId string) {
+	ctx := logger.WithField(nil, logger.AlertIdField, alertId)
+
 	e.runner.Lock()
 	runner, ok := e.runner.Map[alertId]
 	e.runner.Unlock()
 	if !ok {
-		logger.Error(nil, "Executor TerminateRunner error: runner does not exist")
+		logger.Error(ctx, "Executor TerminateRunner error: runner does not exist")
 		return
 	}
 
@@ -248,15 +370,54 @@ func (e *Executor) TerminateRunner(alertId string) {
 	delete(e.runner.Map, alertId)
 	e.runner.Unlock()
 
-	runner.SignalCh <- "Stop"
+	runner.Stop()
 
-	logger.Debug(nil, "Executor TerminateRunner "+alertId+" success")
+	logger.Debug(ctx, "Executor TerminateRunner "+alertId+" success")
+}
+
+func (e *Executor) registerMetrics() {
+	monitoring.RegisterGaugeFunc("executor", "runners", "Number of alert runners on this executor.", func() float64 {
+		return float64(e.GetTaskCount())
+	})
+	monitoring.RegisterGaugeFunc("executor", "scheduled_jobs", "Number of jobs in the evaluation scheduler.", func() float64 {
+		return float64(e.scheduler.GetJobCount())
+	})
+	monitoring.RegisterGaugeFunc("executor", "receiver_queue_depth", "Number of alerts taken from the alert queue and waiting for a runner.", func() float64 {
+		return float64(e.alertReceiver.GetQueueDepth())
+	})
+	monitoring.RegisterCounterFunc("executor", "missed_ticks_total", "Number of evaluation ticks missed by busy runners.", func() float64 {
+		_, missed := e.scheduler.GetTickCount()
+		return float64(missed)
+	})
+	monitoring.RegisterCounterFunc("executor", "metric_requests_total", "Number of metric requests made by runners.", func() float64 {
+		requestCount, _, _ := e.GetMetricCallCount()
+		return float64(requestCount)
+	})
+	monitoring.RegisterCounterFunc("executor", "metric_calls_total", "Number of metric calls sent to the adapter after batching.", func() float64 {
+		_, callCount, _ := e.GetMetricCallCount()
+		return float64(callCount)
+	})
+}
+
+func (e *Executor) registerHealthChecks() {
+	g := global.GetInstance()
+	monitoring.AddLivenessCheck("database", g.CheckDB)
+	monitoring.AddLivenessCheck("etcd", g.CheckEtcd)
+	monitoring.AddReadinessCheck("queue", e.alertReceiver.CheckQueue)
+	monitoring.AddReadinessCheck("notification", nf.CheckConnection)
+	monitoring.AddReadinessCheck("draining", e.checkDraining)
 }
 
 func (e *Executor) Serve() {
+	e.registerMetrics()
+	e.registerHealthChecks()
+	e.registerAdminHandlers()
+
+	go e.scheduler.Run()
 	go e.alertReceiver.Serve()
 	go e.broadcastReceiver.WatchBroadcast()
 	go e.healthChecker.HealthCheck()
+	go e.aliveReporter.WatchCordon()
 	e.aliveReporter.HeartBeat()
 }
 
@@ -269,11 +430,16 @@ func (e *Executor) HeartBoot() {
 }
 
 func Init(name string) *Executor {
+	logger.SetGlobalField(logger.ExecutorField, name)
+
 	alertReceiver := NewAlertReceiver()
 	aliveReporter := NewAliveReporter()
 	broadcastReceiver := NewBroadcastReceiver()
 	healthChecker := NewHealthChecker()
-	executor := NewExecutor(name, alertReceiver, aliveReporter, broadcastReceiver, healthChecker)
+	cfg := config.GetInstance()
+	scheduler := NewScheduler(cfg.Executor.MissedTickPolicy)
+	coalescer := NewMetricCoalescer(time.Duration(cfg.Executor.MetricBatchWindow)*time.Millisecond, time.Duration(cfg.App.AdapterTimeout)*time.Millisecond)
+	executor := NewExecutor(name, alertReceiver, aliveReporter, broadcastReceiver, healthChecker, scheduler, coalescer)
 
 	alertReceiver.SetExecutor(executor)
 	aliveReporter.SetExecutor(executor)
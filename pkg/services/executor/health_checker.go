@@ -5,6 +5,7 @@ import (
 
 	"kubesphere.io/alert/pkg/logger"
 	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/monitoring"
 	rs "kubesphere.io/alert/pkg/services/executor/resource_control"
 )
 
@@ -29,17 +30,26 @@ func (hc *HealthChecker) update() {
 	runners := hc.executor.GetRunners()
 
 	//Update runner status
-	rs.UpdateAlertStatus(runners, hc.executor.GetName())
+	rs.UpdateAlertStatus(nil, runners, hc.executor.GetName())
+
+	hc.updateFiringResources()
+}
+
+func (hc *HealthChecker) updateFiringResources() {
+	monitoring.FiringResources.Reset()
+	for severity, count := range hc.executor.GetFiringCount() {
+		monitoring.FiringResources.WithLabelValues(severity).Set(float64(count))
+	}
 }
 
 func (hc *HealthChecker) checkAndUpdate() {
 	runners := hc.executor.GetRunners()
 
 	//Update runner status
-	rs.UpdateAlertStatus(runners, hc.executor.GetName())
+	rs.UpdateAlertStatus(nil, runners, hc.executor.GetName())
 
 	//Check wild runners
-	alerts := rs.QueryAlerts(hc.executor.GetName(), "running")
+	alerts := rs.QueryAlerts(nil, hc.executor.GetName(), "running")
 	wildRunners := difference(runners, alerts)
 	if len(wildRunners) > 0 {
 		logger.Error(nil, "Wild Runners %v", wildRunners)
@@ -48,6 +58,11 @@ func (hc *HealthChecker) checkAndUpdate() {
 	for _, alertId := range wildRunners {
 		hc.executor.TerminateRunner(alertId)
 	}
+
+	hc.updateFiringResources()
+
+	requestCount, callCount, savedCount := hc.executor.GetMetricCallCount()
+	logger.Info(nil, "Metric requests %d, adapter calls %d, saved calls %d", requestCount, callCount, savedCount)
 }
 
 func (hc *HealthChecker) HealthCheck() {
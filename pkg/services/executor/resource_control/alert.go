@@ -1,9 +1,13 @@
 package resource_control
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"github.com/jinzhu/gorm"
+
+	"kubesphere.io/alert/pkg/config"
 	aldb "kubesphere.io/alert/pkg/db"
 	"kubesphere.io/alert/pkg/global"
 	"kubesphere.io/alert/pkg/logger"
@@ -23,6 +27,7 @@ type AlertDetail struct {
 	AvailableStartTime string `gorm:"column:available_start_time" json:"available_start_time"`
 	AvailableEndTime   string `gorm:"column:available_end_time" json:"available_end_time"`
 	NfAddressListId    string `gorm:"column:nf_address_list_id" json:"nf_address_list_id"`
+	TriggerAction      string `gorm:"column:trigger_action" json:"trigger_action"`
 }
 
 type RunnerInfo struct {
@@ -31,9 +36,25 @@ type RunnerInfo struct {
 	UpdateTime  time.Time
 }
 
-func QueryAlertDetail(alertId string) AlertDetail {
-	dbChain := aldb.GetChain(global.GetInstance().GetDB().Table("alert t1").
-		Select("t1.alert_id, t1.alert_name, t1.disabled, t1.alert_status, t3.rs_type_name, t3.rs_type_param, t2.rs_filter_name, t2.rs_filter_param, t4.policy_config, t4.available_start_time, t4.available_end_time, t5.nf_address_list_id").
+// WithDBTimeout bounds executor DB calls by the configured deadline.
+func WithDBTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return context.WithTimeout(ctx, time.Duration(config.GetInstance().Database.Timeout)*time.Millisecond)
+}
+
+func QueryAlertDetail(ctx context.Context, alertId string) AlertDetail {
+	ctx, cancel := WithDBTimeout(ctx)
+	defer cancel()
+
+	//gorm only passes ctx to the queries of a transaction
+	tx := global.GetInstance().GetDB().BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	dbChain := aldb.GetChain(tx.Table("alert t1").
+		Select("t1.alert_id, t1.alert_name, t1.disabled, t1.alert_status, t3.rs_type_name, t3.rs_type_param, t2.rs_filter_name, t2.rs_filter_param, t4.policy_config, t4.available_start_time, t4.available_end_time, t5.nf_address_list_id, t5.trigger_action").
 		Joins("left join resource_filter t2 on t2.rs_filter_id=t1.rs_filter_id").
 		Joins("left join resource_type t3 on t3.rs_type_id=t2.rs_type_id").
 		Joins("left join policy t4 on t4.policy_id=t1.policy_id").
@@ -50,8 +71,8 @@ func QueryAlertDetail(alertId string) AlertDetail {
 		Limit(100).
 		Scan(&ads).
 		Error
-	if err != nil {
-		logger.Error(nil, "Failed to QueryAlertDetail [%v], error: %+v.", alertId, err)
+	if err != nil || len(ads) == 0 {
+		logger.Error(ctx, "Failed to QueryAlertDetail [%v], error: %+v.", alertId, err)
 		return ad
 	}
 
@@ -60,73 +81,92 @@ func QueryAlertDetail(alertId string) AlertDetail {
 	return ad
 }
 
-func GetAlertInfo(alertId string) models.Alert {
-	db := global.GetInstance().GetDB()
-	var alert models.Alert
-	db.First(&alert, "alert_id = ?", alertId)
+func GetAlertInfo(ctx context.Context, alertId string) models.Alert {
+	ctx, cancel := WithDBTimeout(ctx)
+	defer cancel()
+
+	alert, err := getAlertInfo(ctx, global.GetInstance().GetDB(), alertId)
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		logger.Error(ctx, "Failed to GetAlertInfo [%v], error: %+v.", alertId, err)
+	}
 	return alert
 }
 
-func UpdateAlertInfo(alertId string, executorId string, runningStatus string) error {
+func getAlertInfo(ctx context.Context, db *gorm.DB, alertId string) (models.Alert, error) {
+	//gorm only passes ctx to the queries of a transaction
+	tx := db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	var alert models.Alert
+	err := tx.First(&alert, "alert_id = ?", alertId).Error
+	return alert, err
+}
+
+func UpdateAlertInfo(ctx context.Context, alertId string, executorId string, runningStatus string) error {
+	ctx, cancel := WithDBTimeout(ctx)
+	defer cancel()
+
 	db := global.GetInstance().GetDB()
-	tx := db.Begin()
+	tx := db.BeginTx(ctx, nil)
 	var alert models.Alert
 	err := tx.Model(&alert).Where("alert_id = ?", alertId).Updates(map[string]interface{}{"executor_id": executorId, "running_status": runningStatus, "update_time": time.Now()})
 	if err.Error != nil {
 		tx.Rollback()
-		logger.Error(nil, "Update alert failed, [%+v]\n", err.Error)
+		logger.Error(ctx, "Update alert failed, [%+v]\n", err.Error)
 		return err.Error
 	}
 	tx.Commit()
 	return nil
 }
 
-func DeleteAlert(alertId string) error {
-	db := global.GetInstance().GetDB()
-	tx := db.Begin()
+func DeleteAlert(ctx context.Context, alertId string) error {
+	ctx, cancel := WithDBTimeout(ctx)
+	defer cancel()
+
+	return deleteAlert(ctx, global.GetInstance().GetDB(), alertId)
+}
+
+func deleteAlert(ctx context.Context, db *gorm.DB, alertId string) error {
+	tx := db.BeginTx(ctx, nil)
 
 	//1. Delete ResourceFilter
-	sql := fmt.Sprintf("DELETE rf FROM resource_filter rf, alert al WHERE rf.rs_filter_id=al.rs_filter_id AND al.alert_id IN ('%s')", alertId)
-	err := tx.Exec(sql)
+	err := tx.Exec("DELETE FROM resource_filter WHERE rs_filter_id IN (SELECT rs_filter_id FROM alert WHERE alert_id = ?)", alertId)
 	if err.Error != nil {
 		tx.Rollback()
-		logger.Error(nil, "DeleteAlert Delete ResourceFilter failed, [%+v]\n", err.Error)
+		logger.Error(ctx, "DeleteAlert Delete ResourceFilter failed, [%+v]\n", err.Error)
 		return err.Error
 	}
 
 	//2. Delete Rule
-	sql = fmt.Sprintf("DELETE rl FROM rule rl, alert al WHERE rl.policy_id=al.policy_id AND al.alert_id IN ('%s')", alertId)
-	err = tx.Exec(sql)
+	err = tx.Exec("DELETE FROM rule WHERE policy_id IN (SELECT policy_id FROM alert WHERE alert_id = ?)", alertId)
 	if err.Error != nil {
 		tx.Rollback()
-		logger.Error(nil, "DeleteAlert Delete Rules failed, [%+v]\n", err.Error)
+		logger.Error(ctx, "DeleteAlert Delete Rules failed, [%+v]\n", err.Error)
 		return err.Error
 	}
 
 	//3. Delete Action
-	sql = fmt.Sprintf("DELETE ac FROM action ac, alert al WHERE ac.policy_id=al.policy_id AND al.alert_id IN ('%s')", alertId)
-	err = tx.Exec(sql)
+	err = tx.Exec("DELETE FROM action WHERE policy_id IN (SELECT policy_id FROM alert WHERE alert_id = ?)", alertId)
 	if err.Error != nil {
 		tx.Rollback()
-		logger.Error(nil, "DeleteAlert Delete Action failed, [%+v]\n", err.Error)
+		logger.Error(ctx, "DeleteAlert Delete Action failed, [%+v]\n", err.Error)
 		return err.Error
 	}
 
 	//4. Delete Policy
-	sql = fmt.Sprintf("DELETE pl FROM policy pl, alert al WHERE pl.policy_id=al.policy_id AND al.alert_id IN ('%s')", alertId)
-	err = tx.Exec(sql)
+	err = tx.Exec("DELETE FROM policy WHERE policy_id IN (SELECT policy_id FROM alert WHERE alert_id = ?)", alertId)
 	if err.Error != nil {
 		tx.Rollback()
-		logger.Error(nil, "DeleteAlert Delete Policy failed, [%+v]\n", err.Error)
+		logger.Error(ctx, "DeleteAlert Delete Policy failed, [%+v]\n", err.Error)
 		return err.Error
 	}
 
 	//5. Delete Alert
 	var alert models.Alert
-	err = tx.Model(&alert).Where("alert_id in (?)", alertId).Delete(models.Alert{})
+	err = tx.Model(&alert).Where("alert_id = ?", alertId).Delete(models.Alert{})
 	if err.Error != nil {
 		tx.Rollback()
-		logger.Error(nil, "DeleteAlert Delete Alert failed, [%+v]\n", err.Error)
+		logger.Error(ctx, "DeleteAlert Delete Alert failed, [%+v]\n", err.Error)
 		return err.Error
 	}
 
@@ -134,39 +174,58 @@ func DeleteAlert(alertId string) error {
 	return nil
 }
 
-func QueryAlerts(executorId string, runningStatus string) []models.Alert {
+func QueryAlerts(ctx context.Context, executorId string, runningStatus string) []models.Alert {
+	ctx, cancel := WithDBTimeout(ctx)
+	defer cancel()
+
+	alerts, err := queryAlerts(ctx, global.GetInstance().GetDB(), executorId, runningStatus)
+	if err != nil {
+		logger.Error(ctx, "Failed to QueryAlerts of executor [%s], error: %+v.", executorId, err)
+	}
+	return alerts
+}
+
+func queryAlerts(ctx context.Context, db *gorm.DB, executorId string, runningStatus string) ([]models.Alert, error) {
+	tx := db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
 	var alerts []models.Alert
+	err := tx.Where("executor_id = ? AND running_status = ?", executorId, runningStatus).Find(&alerts).Error
+	return alerts, err
+}
 
-	db := global.GetInstance().GetDB()
-	db.Where("executor_id = ? AND running_status = ?", executorId, runningStatus).Find(&alerts)
+func UpdateAlertStatus(ctx context.Context, runners []RunnerInfo, executorId string) error {
+	ctx, cancel := WithDBTimeout(ctx)
+	defer cancel()
 
-	return alerts
+	return updateAlertStatus(ctx, global.GetInstance().GetDB(), runners, executorId)
 }
 
-func UpdateAlertStatus(runners []RunnerInfo, executorId string) error {
+func updateAlertStatus(ctx context.Context, db *gorm.DB, runners []RunnerInfo, executorId string) error {
 	if len(runners) == 0 {
 		return nil
 	}
 
-	db := global.GetInstance().GetDB()
 	coreAlertStatus := ""
 	coreUpdateTime := ""
-	where := ""
-	for i, runner := range runners {
-		coreAlertStatus += fmt.Sprintf("WHEN '%s' THEN '%s' ", runner.AlertId, runner.AlertStatus)
-		coreUpdateTime += fmt.Sprintf("WHEN '%s' THEN '%s' ", runner.AlertId, runner.UpdateTime.Format("2006-01-02 15:04:05"))
-		if i == 0 {
-			where += fmt.Sprintf("'%s'", runner.AlertId)
-		} else {
-			where += fmt.Sprintf(",'%s'", runner.AlertId)
-		}
+	alertStatusArgs := []interface{}{}
+	updateTimeArgs := []interface{}{}
+	alertIds := []string{}
+	for _, runner := range runners {
+		coreAlertStatus += "WHEN ? THEN ? "
+		coreUpdateTime += "WHEN ? THEN ? "
+		alertStatusArgs = append(alertStatusArgs, runner.AlertId, runner.AlertStatus)
+		updateTimeArgs = append(updateTimeArgs, runner.AlertId, runner.UpdateTime)
+		alertIds = append(alertIds, runner.AlertId)
 	}
-	sql := fmt.Sprintf("UPDATE `alert` SET `alert_status`= (CASE `alert_id` %s END), `update_time`= (CASE `alert_id` %s END) WHERE `alert_id` IN (%s) AND `executor_id` = '%s' AND `running_status` = 'running'", coreAlertStatus, coreUpdateTime, where, executorId)
-	tx := db.Begin()
-	err := tx.Exec(sql)
+	sql := fmt.Sprintf("UPDATE alert SET alert_status = (CASE alert_id %s END), update_time = (CASE alert_id %s END) WHERE alert_id IN (?) AND executor_id = ? AND running_status = 'running'", coreAlertStatus, coreUpdateTime)
+	args := append(append(alertStatusArgs, updateTimeArgs...), alertIds, executorId)
+
+	tx := db.BeginTx(ctx, nil)
+	err := tx.Exec(sql, args...)
 	if err.Error != nil {
 		tx.Rollback()
-		logger.Error(nil, "UpdateAlertStatus failed, [%+v]\n", err.Error)
+		logger.Error(ctx, "UpdateAlertStatus failed, [%+v]\n", err.Error)
 		return err.Error
 	}
 	tx.Commit()
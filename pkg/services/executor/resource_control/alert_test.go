@@ -0,0 +1,91 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package resource_control
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/internal/testutil"
+	"kubesphere.io/alert/pkg/models"
+)
+
+const hostileId = "al-'); DROP TABLE alert; --"
+
+func TestUpdateAlertStatus(t *testing.T) {
+	db := testutil.OpenAlertDB(t)
+	defer db.Close()
+
+	testutil.CreateAlert(t, db, hostileId, "1")
+	testutil.CreateAlert(t, db, "al-2", "2")
+	testutil.CreateAlert(t, db, "al-3", "3")
+
+	status := `{"rl-1":{"resources":{"pod's \"name\"":{"cumulated_send_count":1}}},"note":"x' WHERE 1=1; --"}`
+	updateTime := time.Now().Add(time.Minute).Truncate(time.Second)
+	runners := []RunnerInfo{
+		{AlertId: hostileId, AlertStatus: status, UpdateTime: updateTime},
+		{AlertId: "al-2", AlertStatus: `{"it's":"ok"}`, UpdateTime: updateTime},
+	}
+	require.NoError(t, updateAlertStatus(context.Background(), db, runners, "executor-1"))
+
+	var alert models.Alert
+	require.NoError(t, db.First(&alert, "alert_id = ?", hostileId).Error)
+	require.Equal(t, status, alert.AlertStatus)
+	require.True(t, updateTime.Equal(alert.UpdateTime))
+
+	require.NoError(t, db.First(&alert, "alert_id = ?", "al-2").Error)
+	require.Equal(t, `{"it's":"ok"}`, alert.AlertStatus)
+
+	require.NoError(t, db.First(&alert, "alert_id = ?", "al-3").Error)
+	require.Equal(t, "{}", alert.AlertStatus)
+
+	// alerts of other executors are left alone
+	require.NoError(t, updateAlertStatus(context.Background(), db, runners[1:], "executor-2"))
+	require.NoError(t, db.First(&alert, "alert_id = ?", "al-2").Error)
+	require.Equal(t, `{"it's":"ok"}`, alert.AlertStatus)
+}
+
+func TestDeleteAlert(t *testing.T) {
+	db := testutil.OpenAlertDB(t)
+	defer db.Close()
+
+	testutil.CreateAlert(t, db, hostileId, "1")
+	testutil.CreateAlert(t, db, "al-2", "2")
+
+	require.NoError(t, deleteAlert(context.Background(), db, hostileId))
+
+	for _, table := range []string{"alert", "resource_filter", "policy", "rule", "action"} {
+		require.Equal(t, 1, testutil.CountRows(t, db, table), table)
+	}
+
+	var alert models.Alert
+	require.NoError(t, db.First(&alert, "alert_id = ?", "al-2").Error)
+	require.Equal(t, "rf-2", alert.RsFilterId)
+}
+
+func TestQueryAlerts(t *testing.T) {
+	db := testutil.OpenAlertDB(t)
+	defer db.Close()
+
+	testutil.CreateAlert(t, db, "al-1", "1")
+
+	alerts, err := queryAlerts(context.Background(), db, "executor-1", "running")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(alerts))
+	alert, err := getAlertInfo(context.Background(), db, "al-1")
+	require.NoError(t, err)
+	require.Equal(t, "pl-1", alert.PolicyId)
+
+	// the queries give up once ctx is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = queryAlerts(ctx, db, "executor-1", "running")
+	require.Error(t, err)
+	_, err = getAlertInfo(ctx, db, "al-1")
+	require.Error(t, err)
+}
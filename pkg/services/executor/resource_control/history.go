@@ -9,8 +9,11 @@ import (
 )
 
 func CreateHistory(ctx context.Context, history *models.History) error {
+	ctx, cancel := WithDBTimeout(ctx)
+	defer cancel()
+
 	db := global.GetInstance().GetDB()
-	tx := db.Begin()
+	tx := db.BeginTx(ctx, nil)
 	err := tx.Create(&history).Error
 	if err != nil {
 		tx.Rollback()
@@ -4,18 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"kubesphere.io/alert/pkg/client/adapter"
 	nf "kubesphere.io/alert/pkg/client/notification"
+	"kubesphere.io/alert/pkg/config"
 	"kubesphere.io/alert/pkg/logger"
 	"kubesphere.io/alert/pkg/metric"
 	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/monitoring"
 	"kubesphere.io/alert/pkg/notification"
 	rs "kubesphere.io/alert/pkg/services/executor/resource_control"
+	"kubesphere.io/alert/pkg/tracing"
 )
 
 type AlertRunner struct {
@@ -23,10 +28,18 @@ type AlertRunner struct {
 	AlertStatus StatusAlert
 	SignalCh    chan string
 	UpdateCh    chan string
+	TickCh      chan uint32
+	scheduler   *Scheduler
+	coalescer   *MetricCoalescer
+	jobKeys     []string
+	ctx         context.Context
+	cancel      context.CancelFunc
+	verbose     int32
 }
 
 type ConfigAlert struct {
 	AlertId            string
+	AlertName          string
 	Disabled           bool
 	RsTypeName         string
 	RsTypeParam        string
@@ -38,33 +51,43 @@ type ConfigAlert struct {
 	Rules              map[string]RuleInfo
 	Requests           MonitoringRequest
 	NfAddressListId    string
+	Action             models.ActionConfig
 }
 
-type ConfigPolicy struct {
-	RepeatType              string `json:"repeat_type"`
-	RepeatIntervalInitvalue uint32 `json:"repeat_interval_initvalue"`
-	MaxSendCount            uint32 `json:"max_send_count"`
-}
+type ConfigPolicy = models.RepeatPolicy
 
 type RuleInfo struct {
-	RuleName         string
-	Disabled         bool
-	MonitorPeriods   uint32
-	Severity         string
-	MetricsType      string
-	ConditionType    string
-	Thresholds       float64
-	Scale            float64
-	Unit             string
-	ConsecutiveCount uint32
-	Inhibit          bool
-	MetricName       string
+	RuleName           string
+	Disabled           bool
+	MonitorPeriods     uint32
+	EvaluationInterval uint32
+	Severity           string
+	MetricsType        string
+	ConditionType      string
+	Thresholds         float64
+	Scale              float64
+	Unit               string
+	ConsecutiveCount   uint32
+	Inhibit            bool
+	MetricName         string
 }
 
 type StatusAlert struct {
 	sync.RWMutex
-	ResourceStatus map[string]StatusResource `json:resource_status`
-	UpdateTime     time.Time
+	ResourceStatus   map[string]StatusResource   `json:resource_status`
+	EvaluationStatus map[string]StatusEvaluation `json:"evaluation_status"`
+	UpdateTime       time.Time
+}
+
+// StatusEvaluation is the outcome of the evaluations of a rule, LastDuration is in milliseconds.
+type StatusEvaluation struct {
+	State               string    `json:"state"`
+	Error               string    `json:"error"`
+	LastSuccessTime     time.Time `json:"last_success_time"`
+	LastErrorTime       time.Time `json:"last_error_time"`
+	ConsecutiveFailures uint32    `json:"consecutive_failures"`
+	LastDuration        uint32    `json:"last_duration"`
+	UpdateTime          time.Time `json:"update_time"`
 }
 
 type StatusResource struct {
@@ -74,6 +97,22 @@ type StatusResource struct {
 	NextResendInterval uint32          `json:next_resend_interval`
 	NextSendableTime   time.Time       `json:next_sendable_time`
 	AggregatedAlerts   AggregatedAlert `json:aggregated_alerts`
+	FiringTime         time.Time       `json:"firing_time"`
+	WebhookSent        bool            `json:"webhook_sent"`
+}
+
+func (s *StatusResource) repeatStatus() models.RepeatStatus {
+	return models.RepeatStatus{
+		CumulatedSendCount: s.CumulatedSendCount,
+		NextResendInterval: s.NextResendInterval,
+		NextSendableTime:   s.NextSendableTime,
+	}
+}
+
+func (s *StatusResource) setRepeatStatus(repeatStatus models.RepeatStatus) {
+	s.CumulatedSendCount = repeatStatus.CumulatedSendCount
+	s.NextResendInterval = repeatStatus.NextResendInterval
+	s.NextSendableTime = repeatStatus.NextSendableTime
 }
 
 type AggregatedAlert struct {
@@ -83,10 +122,9 @@ type AggregatedAlert struct {
 	LastAlertValues []RecordedMetric `json:"last_alert_values"`
 }
 
+// RulesSamePeriod groups rule ids by evaluation interval in seconds
 type MonitoringRequest struct {
 	RulesSamePeriod map[uint32][]string
-	TickCount       map[uint32]uint32
-	TickUpperBound  map[uint32]uint32
 }
 
 type RecordedMetric struct {
@@ -96,41 +134,92 @@ type RecordedMetric struct {
 }
 
 const (
-	TickPeriodSecond = 10
+	//Runner refreshes its update time at least this often, so that the watcher
+	//does not take a runner with long evaluation intervals as dead
+	HeartbeatPeriod = 20 * time.Second
+
+	//Tick delivered to TickCh for heartbeat only
+	heartbeatTick = 0
+)
+
+const (
+	EvaluationStateOk      = "ok"
+	EvaluationStateError   = "error"
+	EvaluationStateTimeout = "timeout"
 )
 
-func NewAlertRunner(alertId string, updateCh chan string) *AlertRunner {
+func NewAlertRunner(alertId string, updateCh chan string, scheduler *Scheduler, coalescer *MetricCoalescer) *AlertRunner {
 	runner := &AlertRunner{}
 
 	runner.AlertConfig.AlertId = alertId
 	runner.AlertStatus.UpdateTime = time.Now()
 	runner.SignalCh = make(chan string, 10)
 	runner.UpdateCh = updateCh
+	runner.TickCh = make(chan uint32, 10)
+	runner.scheduler = scheduler
+	runner.coalescer = coalescer
+	runner.ctx, runner.cancel = context.WithCancel(logger.WithField(context.Background(), logger.AlertIdField, alertId))
 
 	return runner
 }
 
-func (ar *AlertRunner) parseNotification(nfAddressListId string) {
-	ar.AlertConfig.NfAddressListId = nfAddressListId
+// Stop cancels the in-flight evaluation of the runner and asks it to exit.
+func (ar *AlertRunner) Stop() {
+	ar.cancel()
+	ar.SignalCh <- "Stop"
 }
 
-func (ar *AlertRunner) parsePolicyConfig(alertDetail rs.AlertDetail) {
-	err := json.Unmarshal([]byte(alertDetail.PolicyConfig), &ar.AlertConfig.PolicyConfig)
-	if err != nil {
-		//Fill a default config for policy
-		ar.AlertConfig.PolicyConfig = make(map[string]ConfigPolicy)
+// SetVerbose turns on verbose evaluation tracing of the runner: evaluation
+// details are logged at info level and all evaluation spans are recorded.
+func (ar *AlertRunner) SetVerbose(verbose bool) {
+	if verbose {
+		atomic.StoreInt32(&ar.verbose, 1)
+	} else {
+		atomic.StoreInt32(&ar.verbose, 0)
+	}
+}
+
+func (ar *AlertRunner) IsVerbose() bool {
+	return atomic.LoadInt32(&ar.verbose) == 1
+}
+
+// logEvaluation logs evaluation details, at debug level unless the runner is verbose.
+func (ar *AlertRunner) logEvaluation(ctx context.Context, format string, v ...interface{}) {
+	if ar.IsVerbose() {
+		logger.Info(ctx, format, v...)
+	} else {
+		logger.Debug(ctx, format, v...)
+	}
+}
 
-		ar.AlertConfig.PolicyConfig["minor"] = ConfigPolicy{"not-repeat", 3, 3}
-		ar.AlertConfig.PolicyConfig["major"] = ConfigPolicy{"exp-minutes", 2, 5}
-		ar.AlertConfig.PolicyConfig["critical"] = ConfigPolicy{"fixed-minutes", 1, 8}
+func getEvaluationInterval(ruleInfo RuleInfo) uint32 {
+	if ruleInfo.EvaluationInterval > 0 {
+		return ruleInfo.EvaluationInterval
 	}
 
+	return ruleInfo.MonitorPeriods * 60
+}
+
+func (ar *AlertRunner) parseNotification(nfAddressListId string, triggerAction string) {
+	ar.AlertConfig.NfAddressListId = nfAddressListId
+
+	actionConfig, err := models.ParseActionConfig(triggerAction)
+	if err != nil {
+		logger.Error(ar.ctx, "Parse Action config error: %v", err)
+		actionConfig = &models.ActionConfig{}
+	}
+	ar.AlertConfig.Action = *actionConfig
+}
+
+func (ar *AlertRunner) parsePolicyConfig(alertDetail rs.AlertDetail) {
+	ar.AlertConfig.PolicyConfig = models.ParsePolicyConfig(alertDetail.PolicyConfig)
+
 	ar.AlertConfig.AvailableStartTime = alertDetail.AvailableStartTime
 	ar.AlertConfig.AvailableEndTime = alertDetail.AvailableEndTime
 }
 
-func (ar *AlertRunner) parseRules() {
-	ruleDetails := rs.QueryRuleDetails(ar.AlertConfig.AlertId)
+func (ar *AlertRunner) parseRules(ctx context.Context) {
+	ruleDetails := rs.QueryRuleDetails(ctx, ar.AlertConfig.AlertId)
 	//logger.Debug(nil, "rules: %v", rules)
 
 	mapRules := make(map[string]RuleInfo)
@@ -139,17 +228,18 @@ func (ar *AlertRunner) parseRules() {
 		threshold, _ := strconv.ParseFloat(ruleDetail.Thresholds, 64)
 		scale, _ := strconv.ParseFloat(ruleDetail.MetricParam, 64)
 		ruleInfo := RuleInfo{
-			RuleName:         ruleDetail.RuleName,
-			Disabled:         ruleDetail.Disabled,
-			MonitorPeriods:   ruleDetail.MonitorPeriods,
-			Severity:         ruleDetail.Severity,
-			MetricsType:      ruleDetail.MetricsType,
-			ConditionType:    ruleDetail.ConditionType,
-			Thresholds:       threshold,
-			Scale:            scale,
-			Unit:             ruleDetail.Unit,
-			ConsecutiveCount: ruleDetail.ConsecutiveCount,
-			Inhibit:          ruleDetail.Inhibit,
+			RuleName:           ruleDetail.RuleName,
+			Disabled:           ruleDetail.Disabled,
+			MonitorPeriods:     ruleDetail.MonitorPeriods,
+			EvaluationInterval: ruleDetail.EvaluationInterval,
+			Severity:           ruleDetail.Severity,
+			MetricsType:        ruleDetail.MetricsType,
+			ConditionType:      ruleDetail.ConditionType,
+			Thresholds:         threshold,
+			Scale:              scale,
+			Unit:               ruleDetail.Unit,
+			ConsecutiveCount:   ruleDetail.ConsecutiveCount,
+			Inhibit:            ruleDetail.Inhibit,
 		}
 
 		ruleInfo.MetricName = ruleDetail.MetricName
@@ -157,21 +247,63 @@ func (ar *AlertRunner) parseRules() {
 	}
 	ar.AlertConfig.Rules = mapRules
 
-	//Put rules with same period into same MonitoringRequest group
+	//Put rules with same evaluation interval into same MonitoringRequest group
 	rulesSamePeriod := make(map[uint32][]string)
-	tickCount := make(map[uint32]uint32)
-	tickUpperBound := make(map[uint32]uint32)
 
 	for ruleId, ruleInfo := range ar.AlertConfig.Rules {
 		if ruleInfo.Disabled {
 			continue
 		}
-		rulesSamePeriod[ruleInfo.MonitorPeriods] = append(rulesSamePeriod[ruleInfo.MonitorPeriods], ruleId)
-		tickUpperBound[ruleInfo.MonitorPeriods] = ruleInfo.MonitorPeriods * 60 / TickPeriodSecond
-		tickCount[ruleInfo.MonitorPeriods] = tickUpperBound[ruleInfo.MonitorPeriods] - 1
+		period := getEvaluationInterval(ruleInfo)
+		if period == 0 {
+			continue
+		}
+		rulesSamePeriod[period] = append(rulesSamePeriod[period], ruleId)
+	}
+
+	ar.AlertConfig.Requests = MonitoringRequest{rulesSamePeriod}
+}
+
+func (ar *AlertRunner) getJobKey(period uint32) string {
+	return fmt.Sprintf("%s %d", ar.AlertConfig.AlertId, period)
+}
+
+func (ar *AlertRunner) newTickFunc(period uint32) ScheduleFunc {
+	return func() bool {
+		select {
+		case ar.TickCh <- period:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+func (ar *AlertRunner) unscheduleJobs() {
+	for _, key := range ar.jobKeys {
+		ar.scheduler.Remove(key)
+	}
+	ar.jobKeys = nil
+}
+
+// Register heartbeat and one job per evaluation interval to the executor scheduler
+func (ar *AlertRunner) scheduleJobs() {
+	ar.unscheduleJobs()
+
+	key := ar.getJobKey(heartbeatTick)
+	ar.scheduler.Add(key, "", HeartbeatPeriod, ar.newTickFunc(heartbeatTick))
+	ar.jobKeys = append(ar.jobKeys, key)
+
+	if ar.AlertConfig.Disabled {
+		return
 	}
 
-	ar.AlertConfig.Requests = MonitoringRequest{rulesSamePeriod, tickCount, tickUpperBound}
+	for period, _ := range ar.AlertConfig.Requests.RulesSamePeriod {
+		key := ar.getJobKey(period)
+		phase := GetPhaseKey(ar.getMetricParam(period), period)
+		ar.scheduler.Add(key, phase, time.Duration(period)*time.Second, ar.newTickFunc(period))
+		ar.jobKeys = append(ar.jobKeys, key)
+	}
 }
 
 func (ar *AlertRunner) getResetResourceStatus(ruleId string) StatusResource {
@@ -192,20 +324,143 @@ func (ar *AlertRunner) resetAlertStatus() {
 	ar.AlertStatus.ResourceStatus = make(map[string]StatusResource)
 }
 
+// RuleChanges are the rule ids of an alert update. Changed rules keep the state of their
+// resources, their metric, condition and severity are the same, reset rules do not.
+type RuleChanges struct {
+	Added   []string
+	Changed []string
+	Reset   []string
+	Removed []string
+}
+
+func diffRules(oldRules map[string]RuleInfo, newRules map[string]RuleInfo) RuleChanges {
+	changes := RuleChanges{}
+
+	for ruleId, newRule := range newRules {
+		oldRule, ok := oldRules[ruleId]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, ruleId)
+		case oldRule.MetricName != newRule.MetricName || oldRule.ConditionType != newRule.ConditionType || oldRule.Severity != newRule.Severity:
+			changes.Reset = append(changes.Reset, ruleId)
+		case oldRule != newRule:
+			changes.Changed = append(changes.Changed, ruleId)
+		}
+	}
+	for ruleId := range oldRules {
+		if _, ok := newRules[ruleId]; !ok {
+			changes.Removed = append(changes.Removed, ruleId)
+		}
+	}
+
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Changed)
+	sort.Strings(changes.Reset)
+	sort.Strings(changes.Removed)
+
+	return changes
+}
+
+// describeRuleChanges names the rules of changes for the history of the alert.
+func describeRuleChanges(oldRules map[string]RuleInfo, newRules map[string]RuleInfo, changes RuleChanges) string {
+	ruleNames := func(rules map[string]RuleInfo, ruleIds []string) string {
+		names := []string{}
+		for _, ruleId := range ruleIds {
+			names = append(names, rules[ruleId].RuleName)
+		}
+		return strings.Join(names, ",")
+	}
+
+	parts := []string{}
+	if len(changes.Added) > 0 {
+		parts = append(parts, fmt.Sprintf("added rules [%s]", ruleNames(newRules, changes.Added)))
+	}
+	if len(changes.Changed) > 0 {
+		parts = append(parts, fmt.Sprintf("changed rules [%s]", ruleNames(newRules, changes.Changed)))
+	}
+	if len(changes.Reset) > 0 {
+		parts = append(parts, fmt.Sprintf("reset rules [%s]", ruleNames(newRules, changes.Reset)))
+	}
+	if len(changes.Removed) > 0 {
+		parts = append(parts, fmt.Sprintf("removed rules [%s]", ruleNames(oldRules, changes.Removed)))
+	}
+	if len(parts) == 0 {
+		return "rules unchanged"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// keepAlertStatus restores the status of the resources and evaluations of the rules an update
+// left alone or only changed, rules added, reset and removed start over.
+func (ar *AlertRunner) keepAlertStatus(resourceStatus map[string]StatusResource, evaluationStatus map[string]StatusEvaluation, changes RuleChanges) {
+	dropped := make(map[string]bool)
+	for _, ruleIds := range [][]string{changes.Added, changes.Reset, changes.Removed} {
+		for _, ruleId := range ruleIds {
+			dropped[ruleId] = true
+		}
+	}
+
+	ar.AlertStatus.ResourceStatus = make(map[string]StatusResource)
+	for k, v := range resourceStatus {
+		ruleId := strings.Split(k, " ")[0]
+		if !dropped[ruleId] {
+			ar.AlertStatus.ResourceStatus[k] = v
+		}
+	}
+
+	ar.AlertStatus.EvaluationStatus = make(map[string]StatusEvaluation)
+	for ruleId, v := range evaluationStatus {
+		if !dropped[ruleId] {
+			ar.AlertStatus.EvaluationStatus[ruleId] = v
+		}
+	}
+}
+
+// updateAlertInfo reloads the alert after an update, keeping the status of the rules whose
+// metric, condition and severity are unchanged.
+func (ar *AlertRunner) updateAlertInfo(ctx context.Context) {
+	oldRules := ar.AlertConfig.Rules
+
+	//Loading the alert decodes the stored status into the maps, copy the current one
+	resourceStatus := make(map[string]StatusResource)
+	evaluationStatus := make(map[string]StatusEvaluation)
+	ar.AlertStatus.Lock()
+	for k, v := range ar.AlertStatus.ResourceStatus {
+		resourceStatus[k] = v
+	}
+	for k, v := range ar.AlertStatus.EvaluationStatus {
+		evaluationStatus[k] = v
+	}
+	ar.AlertStatus.Unlock()
+
+	ar.loadAlertInfo(ctx)
+
+	changes := diffRules(oldRules, ar.AlertConfig.Rules)
+
+	ar.AlertStatus.Lock()
+	ar.keepAlertStatus(resourceStatus, evaluationStatus, changes)
+	ar.AlertStatus.Unlock()
+
+	ar.writeHistory(ctx, "", "config_changed", describeRuleChanges(oldRules, ar.AlertConfig.Rules, changes), "", "", "")
+}
+
 func (ar *AlertRunner) parseAlertConfigStatus(alertDetail rs.AlertDetail) {
 	ar.AlertConfig.Disabled = alertDetail.Disabled
 
 	ar.AlertStatus.Lock()
 	err := json.Unmarshal([]byte(alertDetail.AlertStatus), &ar.AlertStatus)
 	if err != nil {
-		logger.Debug(nil, "Parse Alert Status error: %v", err)
+		logger.Debug(ar.ctx, "Parse Alert Status error: %v", err)
 		ar.resetAlertStatus()
 	}
 	ar.AlertStatus.Unlock()
 }
 
-func (ar *AlertRunner) loadAlertInfo() {
-	alertDetail := rs.QueryAlertDetail(ar.AlertConfig.AlertId)
+func (ar *AlertRunner) loadAlertInfo(ctx context.Context) {
+	alertDetail := rs.QueryAlertDetail(ctx, ar.AlertConfig.AlertId)
+
+	ar.AlertConfig.AlertName = alertDetail.AlertName
 
 	//1. Parse Resource
 	ar.AlertConfig.RsTypeName = alertDetail.RsTypeName
@@ -214,21 +469,21 @@ func (ar *AlertRunner) loadAlertInfo() {
 	ar.AlertConfig.RsFilterParam = alertDetail.RsFilterParam
 
 	//2. Parse Notification
-	ar.parseNotification(alertDetail.NfAddressListId)
+	ar.parseNotification(alertDetail.NfAddressListId, alertDetail.TriggerAction)
 
 	//3. Parse policy config
 	ar.parsePolicyConfig(alertDetail)
 
 	//4. Parse Rules config
-	ar.parseRules()
+	ar.parseRules(ctx)
 
 	//5. Parse Alert status
 	ar.parseAlertConfigStatus(alertDetail)
 
-	logger.Debug(nil, "loadAlertInfo alert: %v", ar)
+	logger.Debug(ctx, "loadAlertInfo alert: %v", ar)
 }
 
-func (ar *AlertRunner) getOneMetric(period uint32, ch chan metric.ResourceMetrics) {
+func (ar *AlertRunner) getMetricParam(period uint32) metric.MetricParam {
 	extraQueryParams := ""
 
 	metrics := []string{}
@@ -250,56 +505,130 @@ func (ar *AlertRunner) getOneMetric(period uint32, ch chan metric.ResourceMetric
 		MetricToRule:     metricToRule,
 	}
 
-	metricParamBytes, err := json.Marshal(metricParam)
-	if err != nil {
-		logger.Error(nil, "Marshal Metric Param error: %v", err)
-		return
+	return metricParam
+}
+
+func (ar *AlertRunner) getResourceMetrics(ctx context.Context, period uint32) ([]metric.ResourceMetrics, error) {
+	if _, ok := ar.AlertConfig.Requests.RulesSamePeriod[period]; !ok {
+		return nil, nil
 	}
 
-	resourceMetricsStr := adapter.SendMetricRequest(string(metricParamBytes))
+	metricParam := ar.getMetricParam(period)
 
-	resourceMetrics := []metric.ResourceMetrics{}
+	return ar.coalescer.GetResourceMetrics(ctx, metricParam, period)
+}
 
-	err = json.Unmarshal([]byte(resourceMetricsStr), &resourceMetrics)
+func isTimeout(err error) bool {
+	if err == context.DeadlineExceeded {
+		return true
+	}
+
+	if e, ok := err.(interface{ Timeout() bool }); ok {
+		return e.Timeout()
+	}
+
+	return false
+}
+
+func (ar *AlertRunner) updateEvaluationStatus(ctx context.Context, period uint32, err error, duration time.Duration) {
+	now := time.Now()
+	state := EvaluationStateOk
+	errStr := ""
 
 	if err != nil {
-		logger.Debug(nil, "Unmarshal Metric Result error: %v", err)
-		return
+		state = EvaluationStateError
+		if isTimeout(err) {
+			state = EvaluationStateTimeout
+		}
+		errStr = err.Error()
+		logger.Error(ctx, "AlertRunner alert %s evaluate period %d %s: %v", ar.AlertConfig.AlertId, period, state, err)
+	}
+
+	monitoring.EvaluationDuration.WithLabelValues(state).Observe(duration.Seconds())
+
+	failedRules := []string{}
+	recoveredRules := []string{}
+
+	ar.AlertStatus.Lock()
+	if ar.AlertStatus.EvaluationStatus == nil {
+		ar.AlertStatus.EvaluationStatus = make(map[string]StatusEvaluation)
 	}
+	for _, ruleId := range ar.AlertConfig.Requests.RulesSamePeriod[period] {
+		status := ar.AlertStatus.EvaluationStatus[ruleId]
+		status.State = state
+		status.Error = errStr
+		status.LastDuration = uint32(duration / time.Millisecond)
+		status.UpdateTime = now
+
+		if err != nil {
+			status.LastErrorTime = now
+			status.ConsecutiveFailures = status.ConsecutiveFailures + 1
+			if status.ConsecutiveFailures == ar.evaluationFailureThreshold() {
+				failedRules = append(failedRules, ruleId)
+			}
+		} else {
+			if ar.evaluationFailureThreshold() > 0 && status.ConsecutiveFailures >= ar.evaluationFailureThreshold() {
+				recoveredRules = append(recoveredRules, ruleId)
+			}
+			status.LastSuccessTime = now
+			status.ConsecutiveFailures = 0
+		}
 
-	for _, rm := range resourceMetrics {
-		ch <- rm
+		ar.AlertStatus.EvaluationStatus[ruleId] = status
+	}
+	ar.AlertStatus.Unlock()
+
+	for _, ruleId := range failedRules {
+		ar.sendEvaluationAlert(ctx, ruleId, "evaluation_failed", errStr)
+	}
+	for _, ruleId := range recoveredRules {
+		ar.sendEvaluationAlert(ctx, ruleId, "evaluation_recovered", "")
 	}
 }
 
-func (ar *AlertRunner) getResourceMetrics(ch chan metric.ResourceMetrics) {
-	wg := sync.WaitGroup{}
-	ctx := context.Background()
-	ctx, _ = context.WithTimeout(ctx, time.Second*3)
+// evaluationFailureThreshold returns after how many failed periods of a rule a
+// meta alert is fired, 0 disables meta alerts.
+func (ar *AlertRunner) evaluationFailureThreshold() uint32 {
+	threshold := config.GetInstance().Executor.EvaluationFailureThreshold
+	if threshold <= 0 {
+		return 0
+	}
 
-	for period, _ := range ar.AlertConfig.Requests.RulesSamePeriod {
-		ar.AlertConfig.Requests.TickCount[period] = (ar.AlertConfig.Requests.TickCount[period] + 1) % ar.AlertConfig.Requests.TickUpperBound[period]
-
-		if ar.AlertConfig.Requests.TickCount[period] == 0 {
-			wg.Add(1)
-			go func(period uint32) {
-				for {
-					select {
-					case <-ctx.Done():
-						logger.Debug(nil, "getResourceMetrics canceled")
-						wg.Done()
-						return
-					default:
-						ar.getOneMetric(period, ch)
-						wg.Done()
-						return
-					}
-				}
-			}(period)
+	return uint32(threshold)
+}
+
+// sendEvaluationAlert records a meta alert about the evaluation of a rule and
+// notifies the addresses of the alert.
+func (ar *AlertRunner) sendEvaluationAlert(ctx context.Context, ruleId string, event string, errStr string) {
+	ctx = logger.WithField(ctx, logger.RuleIdField, ruleId)
+
+	ruleName := ar.AlertConfig.Rules[ruleId].RuleName
+
+	title := ""
+	content := ""
+	switch event {
+	case "evaluation_failed":
+		title = fmt.Sprintf("Alert %s failed to evaluate", ar.AlertConfig.AlertName)
+		content = fmt.Sprintf("Rule %s of alert %s failed to evaluate for %d periods, last error: %s", ruleName, ar.AlertConfig.AlertName, ar.evaluationFailureThreshold(), errStr)
+	case "evaluation_recovered":
+		title = fmt.Sprintf("Alert %s evaluation recovered", ar.AlertConfig.AlertName)
+		content = fmt.Sprintf("Rule %s of alert %s is evaluated successfully again", ruleName, ar.AlertConfig.AlertName)
+	}
+
+	notificationId := ""
+	if ar.AlertConfig.NfAddressListId != "" {
+		nfAddressListId := fmt.Sprintf(`["%s"]`, ar.AlertConfig.NfAddressListId)
+		nfCtx, cancel := context.WithTimeout(ctx, time.Duration(config.GetInstance().App.NotificationTimeout)*time.Millisecond)
+		sentSuccess, id := nf.SendNotification(nfCtx, "other", nfAddressListId, title, content)
+		cancel()
+		if sentSuccess {
+			notificationId = id
+		} else {
+			logger.Error(ctx, "AlertRunner alert %s send %s notification failed", ar.AlertConfig.AlertId, event)
 		}
 	}
 
-	wg.Wait()
+	ar.writeHistory(ctx, "", event, content, notificationId, ruleId, "")
 }
 
 func (ar *AlertRunner) readRuleResourceMetric(resourceMetrics metric.ResourceMetrics, triggeredMetrics *[]RecordedMetric, resumedMetrics *[]RecordedMetric) string {
@@ -315,7 +644,7 @@ func (ar *AlertRunner) readRuleResourceMetric(resourceMetrics metric.ResourceMet
 		//Fetch last time value
 		v, err := strconv.ParseFloat(timeValue[(len(timeValue)-int(1))].V, 64)
 		if err != nil {
-			logger.Error(nil, "readRuleResourceMetric error %v, value will be ignored!", err)
+			logger.Error(ar.ctx, "readRuleResourceMetric error %v, value will be ignored!", err)
 			continue
 		}
 		resourceSet := false
@@ -348,7 +677,9 @@ func (ar *AlertRunner) signalUpdate() {
 	ar.UpdateCh <- "update"
 }
 
-func (ar *AlertRunner) checkOneMetric(resourceMetrics metric.ResourceMetrics) bool {
+func (ar *AlertRunner) checkOneMetric(ctx context.Context, resourceMetrics metric.ResourceMetrics) bool {
+	ctx = logger.WithField(ctx, logger.RuleIdField, resourceMetrics.RuleId)
+
 	triggeredMetrics := []RecordedMetric{}
 	resumedMetrics := []RecordedMetric{}
 
@@ -375,20 +706,19 @@ func (ar *AlertRunner) checkOneMetric(resourceMetrics metric.ResourceMetrics) bo
 		if newStatus.PositiveCount >= ar.AlertConfig.Rules[ruleId].ConsecutiveCount {
 			resourceIsAlert = true
 			if newStatus.CurrentLevel == "cleared" {
-				newStatus.CurrentLevel = ar.AlertConfig.Rules[ruleId].Severity
-				newStatus.NextSendableTime = time.Now()
+				ar.triggerResourceStatus(&newStatus, ruleId, triggeredMetric)
 				operation = "trigger"
 			}
 		}
 
 		if operation == "trigger" {
-			logger.Debug(nil, "Rule[%v] Resource[%v] %v triggered, write to message", ruleId, resourceName, triggeredMetric)
-			ar.writeHistory("", "triggered", fmt.Sprintf("%v", triggeredMetric), "", ruleId, resourceName)
+			ar.logEvaluation(ctx, "Rule[%v] Resource[%v] %v triggered, write to message", ruleId, resourceName, triggeredMetric)
+			ar.writeHistory(ctx, "", "triggered", fmt.Sprintf("%v", triggeredMetric), "", ruleId, resourceName)
 			needUpdate = true
 		}
 
 		if resourceIsAlert {
-			ar.sendNotification(&newStatus, ruleId, resourceName, triggeredMetrics)
+			ar.sendNotification(ctx, &newStatus, ruleId, resourceName, triggeredMetrics)
 		}
 
 		newResourceStatus[ruleResourceKey] = newStatus
@@ -407,13 +737,14 @@ func (ar *AlertRunner) checkOneMetric(resourceMetrics metric.ResourceMetrics) bo
 		operation := ""
 		newStatus.PositiveCount = 0
 		if newStatus.CurrentLevel != "cleared" {
+			ar.sendResolvedWebhook(ctx, &newStatus, ruleId, resourceName)
 			newStatus = ar.getResetResourceStatus(ruleId)
 			operation = "resume"
 		}
 
 		if operation == "resume" {
-			logger.Debug(nil, "Rule[%v] Resource[%v] %v resumed, write to message", ruleId, resourceName, resumedMetric)
-			ar.writeHistory("", "resumed", fmt.Sprintf("%v", resumedMetric), "", ruleId, resourceName)
+			ar.logEvaluation(ctx, "Rule[%v] Resource[%v] %v resumed, write to message", ruleId, resourceName, resumedMetric)
+			ar.writeHistory(ctx, "", "resumed", fmt.Sprintf("%v", resumedMetric), "", ruleId, resourceName)
 			needUpdate = true
 		}
 
@@ -433,13 +764,13 @@ func (ar *AlertRunner) checkOneMetric(resourceMetrics metric.ResourceMetrics) bo
 	return needUpdate
 }
 
-func (ar *AlertRunner) checkMetrics(ch chan metric.ResourceMetrics) {
+func (ar *AlertRunner) checkMetrics(ctx context.Context, resourceMetricsList []metric.ResourceMetrics) {
 	needUpdate := false
 
-	for resourceMetrics := range ch {
-		logger.Debug(nil, "resourceMetrics %v", resourceMetrics)
+	for _, resourceMetrics := range resourceMetricsList {
+		ar.logEvaluation(ctx, "resourceMetrics %v", resourceMetrics)
 
-		needUpdate = needUpdate || ar.checkOneMetric(resourceMetrics)
+		needUpdate = ar.checkOneMetric(ctx, resourceMetrics) || needUpdate
 	}
 
 	if needUpdate {
@@ -447,7 +778,9 @@ func (ar *AlertRunner) checkMetrics(ch chan metric.ResourceMetrics) {
 	}
 }
 
-func (ar *AlertRunner) writeHistory(historyName string, status string, content string, notificatioId string, ruleId string, resourceName string) {
+func (ar *AlertRunner) writeHistory(ctx context.Context, historyName string, status string, content string, notificatioId string, ruleId string, resourceName string) {
+	ctx = logger.WithField(ctx, logger.RuleIdField, ruleId)
+
 	history := models.NewHistory(
 		"",
 		status,
@@ -458,17 +791,29 @@ func (ar *AlertRunner) writeHistory(historyName string, status string, content s
 		resourceName,
 	)
 
-	err := rs.CreateHistory(nil, history)
+	err := rs.CreateHistory(ctx, history)
 	if err != nil {
-		logger.Error(nil, "writeHistory Alert[%s] %s in DB error, [%+v].", ar.AlertConfig.AlertId, status, err)
+		logger.Error(ctx, "writeHistory Alert[%s] %s in DB error, [%+v].", ar.AlertConfig.AlertId, status, err)
 		return
 	}
-	logger.Debug(nil, "writeHistory Alert[%s] %s in DB successfully.", ar.AlertConfig.AlertId, status)
+	logger.Debug(ctx, "writeHistory Alert[%s] %s in DB successfully.", ar.AlertConfig.AlertId, status)
 }
 
-func (ar *AlertRunner) commentAlert(historyId string) {
+func (ar *AlertRunner) commentAlert(ctx context.Context, historyId string) {
 	//TODO: Find and record which rule id and resource name created message id
-	ar.writeHistory("", "commented", historyId, "", "", "")
+	ar.writeHistory(ctx, "", "commented", historyId, "", "", "")
+}
+
+// triggerResourceStatus raises the resource out of cleared to the severity of ruleId, the alert
+// fires from the time of triggeredMetric until the resource resumes.
+func (ar *AlertRunner) triggerResourceStatus(newStatus *StatusResource, ruleId string, triggeredMetric RecordedMetric) {
+	newStatus.CurrentLevel = ar.AlertConfig.Rules[ruleId].Severity
+	newStatus.NextSendableTime = time.Now()
+	newStatus.FiringTime = getRecordedMetricTime(triggeredMetric)
+}
+
+func getRecordedMetricTime(recordedMetric RecordedMetric) time.Time {
+	return time.Unix(recordedMetric.tvs[len(recordedMetric.tvs)-1].T, 0)
 }
 
 func (ar *AlertRunner) pushAggregatedAlerts(newStatus *StatusResource, ruleId string, resourceName string, triggeredRuleMetrics []RecordedMetric) {
@@ -477,7 +822,7 @@ func (ar *AlertRunner) pushAggregatedAlerts(newStatus *StatusResource, ruleId st
 	aggregatedAlerts.CumulatedCount = aggregatedAlerts.CumulatedCount + 1
 
 	triggeredMetric := triggeredRuleMetrics[len(triggeredRuleMetrics)-1]
-	alertTime := time.Unix(triggeredMetric.tvs[len(triggeredMetric.tvs)-1].T, 0).Format("2006-01-02 15:04:05.99999")
+	alertTime := getRecordedMetricTime(triggeredMetric).Format(aggregatedAlertTimeFormat)
 	if aggregatedAlerts.FirstAlertTime == "" {
 		aggregatedAlerts.FirstAlertTime = alertTime
 	}
@@ -489,35 +834,7 @@ func (ar *AlertRunner) pushAggregatedAlerts(newStatus *StatusResource, ruleId st
 
 func (ar *AlertRunner) checkSendable(newStatus *StatusResource, ruleId string, resourceName string) bool {
 	policyConfig := ar.AlertConfig.PolicyConfig[ar.AlertConfig.Rules[ruleId].Severity]
-	switch policyConfig.RepeatType {
-	case "normal":
-		return true
-	case "not-repeat":
-		if newStatus.CumulatedSendCount > 0 {
-			return false
-		} else {
-			return true
-		}
-	case "fixed-minutes":
-		if newStatus.CumulatedSendCount >= policyConfig.MaxSendCount {
-			return false
-		}
-		if !newStatus.NextSendableTime.After(time.Now().Add(time.Duration(TickPeriodSecond/3) * time.Second)) {
-			return true
-		} else {
-			return false
-		}
-	case "exp-minutes":
-		if newStatus.CumulatedSendCount >= policyConfig.MaxSendCount {
-			return false
-		}
-		if !newStatus.NextSendableTime.After(time.Now().Add(time.Duration(TickPeriodSecond/3) * time.Second)) {
-			return true
-		} else {
-			return false
-		}
-	}
-	return false
+	return policyConfig.Sendable(newStatus.repeatStatus(), time.Now())
 }
 
 func (ar *AlertRunner) clearAggregatedAlerts(newStatus *StatusResource, ruleId string, resourceName string) {
@@ -529,16 +846,8 @@ func (ar *AlertRunner) refreshNextSendableTime(newStatus *StatusResource) {
 }
 
 func (ar *AlertRunner) processRepeat(newStatus *StatusResource, ruleId string, resourceName string) {
-	newStatus.CumulatedSendCount = newStatus.CumulatedSendCount + 1
-
-	//Update Next Sendable Time
-	switch ar.AlertConfig.PolicyConfig[ar.AlertConfig.Rules[ruleId].Severity].RepeatType {
-	case "fixed-minutes":
-		newStatus.NextSendableTime = newStatus.NextSendableTime.Add(time.Duration(newStatus.NextResendInterval) * time.Minute)
-	case "exp-minutes":
-		newStatus.NextSendableTime = newStatus.NextSendableTime.Add(time.Duration(newStatus.NextResendInterval) * time.Minute)
-		newStatus.NextResendInterval = newStatus.NextResendInterval * 2
-	}
+	policyConfig := ar.AlertConfig.PolicyConfig[ar.AlertConfig.Rules[ruleId].Severity]
+	newStatus.setRepeatStatus(policyConfig.Repeat(newStatus.repeatStatus()))
 }
 
 func processResourceName(resourceName string) string {
@@ -549,7 +858,7 @@ func processResourceName(resourceName string) string {
 	return resourceName
 }
 
-func (ar *AlertRunner) formatNotificationEmail(newStatus *StatusResource, ruleId string, resourceName string) *notification.Email {
+func (ar *AlertRunner) formatNotificationEmail(ctx context.Context, newStatus *StatusResource, ruleId string, resourceName string) *notification.Email {
 	aggregatedAlerts := newStatus.AggregatedAlerts
 	lastValue := ""
 	for _, recordedRuleMetric := range aggregatedAlerts.LastAlertValues {
@@ -572,11 +881,14 @@ func (ar *AlertRunner) formatNotificationEmail(newStatus *StatusResource, ruleId
 
 	notificationParamBytes, err := json.Marshal(notificationParam)
 	if err != nil {
-		logger.Error(nil, "Marshal Notification Param error: %v", err)
+		logger.Error(ctx, "Marshal Notification Param error: %v", err)
 		return nil
 	}
 
-	emailStr := adapter.SendEmailRequest(string(notificationParamBytes))
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(config.GetInstance().App.AdapterTimeout)*time.Millisecond)
+	defer cancel()
+
+	emailStr := adapter.SendEmailRequest(ctx, string(notificationParamBytes))
 
 	if emailStr == "" {
 		return nil
@@ -586,20 +898,20 @@ func (ar *AlertRunner) formatNotificationEmail(newStatus *StatusResource, ruleId
 
 	err = json.Unmarshal([]byte(emailStr), &email)
 	if err != nil {
-		logger.Error(nil, "Unmarshal Email error: %v", err)
+		logger.Error(ctx, "Unmarshal Email error: %v", err)
 		return nil
 	}
 
 	return &email
 }
 
-func (ar *AlertRunner) sendNotification(newStatus *StatusResource, ruleId string, resourceName string, triggeredRuleMetrics []RecordedMetric) {
+func (ar *AlertRunner) sendNotification(ctx context.Context, newStatus *StatusResource, ruleId string, resourceName string, triggeredRuleMetrics []RecordedMetric) {
 	ar.pushAggregatedAlerts(newStatus, ruleId, resourceName, triggeredRuleMetrics)
 
 	//Check Notification Sendable
 	if !nf.CheckTimeAvailable(ar.AlertConfig.AvailableStartTime, ar.AlertConfig.AvailableEndTime) {
 		ar.refreshNextSendableTime(newStatus)
-		logger.Debug(nil, "SendNotification not in available time")
+		logger.Debug(ctx, "SendNotification not in available time")
 		return
 	}
 
@@ -608,18 +920,36 @@ func (ar *AlertRunner) sendNotification(newStatus *StatusResource, ruleId string
 		return
 	}
 
+	email := ar.formatNotificationEmail(ctx, newStatus, ruleId, resourceName)
+
+	if ar.AlertConfig.Action.Type == models.ActionTypeAlertmanagerWebhook {
+		sentSuccess := ar.sendFiringWebhook(ctx, newStatus, ruleId, resourceName, email)
+		//Without notification address list, the webhook is the notification of the alert
+		if ar.AlertConfig.NfAddressListId == "" {
+			if sentSuccess {
+				ar.writeHistory(ctx, "", "sent_success", fmt.Sprintf("%v", triggeredRuleMetrics), "", ruleId, resourceName)
+				ar.clearAggregatedAlerts(newStatus, ruleId, resourceName)
+			} else {
+				ar.writeHistory(ctx, "", "sent_failed", fmt.Sprintf("%v", triggeredRuleMetrics), "", ruleId, resourceName)
+			}
+			ar.processRepeat(newStatus, ruleId, resourceName)
+			return
+		}
+	}
+
 	nfAddressListId := fmt.Sprintf(`["%s"]`, ar.AlertConfig.NfAddressListId)
-	email := ar.formatNotificationEmail(newStatus, ruleId, resourceName)
 	if email == nil {
-		logger.Error(nil, "formatNotificationEmail failed")
+		logger.Error(ctx, "formatNotificationEmail failed")
 	} else {
-		sentSuccess, notificationId := nf.SendNotification("other", nfAddressListId, email.Title, email.Content)
+		nfCtx, cancel := context.WithTimeout(ctx, time.Duration(config.GetInstance().App.NotificationTimeout)*time.Millisecond)
+		sentSuccess, notificationId := nf.SendNotification(nfCtx, "other", nfAddressListId, email.Title, email.Content)
+		cancel()
 		if sentSuccess {
-			ar.writeHistory("", "sent_success", fmt.Sprintf("%v", triggeredRuleMetrics), notificationId, ruleId, resourceName)
+			ar.writeHistory(ctx, "", "sent_success", fmt.Sprintf("%v", triggeredRuleMetrics), notificationId, ruleId, resourceName)
 			ar.clearAggregatedAlerts(newStatus, ruleId, resourceName)
 		} else {
-			ar.writeHistory("", "sent_failed", fmt.Sprintf("%v", triggeredRuleMetrics), "", ruleId, resourceName)
-			logger.Error(nil, "SendNotification failed")
+			ar.writeHistory(ctx, "", "sent_failed", fmt.Sprintf("%v", triggeredRuleMetrics), "", ruleId, resourceName)
+			logger.Error(ctx, "SendNotification failed")
 		}
 	}
 
@@ -647,25 +977,63 @@ func (ar *AlertRunner) GetAlertStatus() (string, time.Time) {
 	return alertStatus, updateTime
 }
 
-func (ar *AlertRunner) runAlertRules() {
+// GetFiringCount returns the number of resources in alerting state by severity.
+func (ar *AlertRunner) GetFiringCount() map[string]int {
+	firingCount := make(map[string]int)
+
+	ar.AlertStatus.Lock()
+	for _, status := range ar.AlertStatus.ResourceStatus {
+		if status.CurrentLevel != "cleared" {
+			firingCount[status.CurrentLevel]++
+		}
+	}
+	ar.AlertStatus.Unlock()
+
+	return firingCount
+}
+
+func (ar *AlertRunner) runAlertRules(ctx context.Context, period uint32) {
 	if ar.AlertConfig.Disabled {
 		return
 	}
 
-	ch := make(chan metric.ResourceMetrics, 100)
-	ar.getResourceMetrics(ch)
-	close(ch)
+	if ar.IsVerbose() {
+		ctx = tracing.WithForcedSampling(ctx)
+	}
+
+	ctx, span := tracing.StartSpan(ctx, "AlertRunner.runAlertRules")
+	defer span.End()
+	span.SetAttribute("alert_id", ar.AlertConfig.AlertId)
+	span.SetAttribute("period", strconv.Itoa(int(period)))
+
+	startTime := time.Now()
+	//The coalescer bounds the batch window and the adapter call, each by a deadline of its own
+	resourceMetrics, err := ar.getResourceMetrics(ctx, period)
+
+	//Runner is stopping, the evaluation was not completed
+	if ctx.Err() != nil {
+		return
+	}
 
-	ar.checkMetrics(ch)
+	ar.updateEvaluationStatus(ctx, period, err, time.Since(startTime))
+	span.SetError(err)
+	if err != nil {
+		return
+	}
+
+	ar.checkMetrics(ctx, resourceMetrics)
 }
 
 func (ar *AlertRunner) Run(initStatus string) {
-	timer := time.NewTicker(time.Second * TickPeriodSecond)
-	defer timer.Stop()
+	ctx := ar.ctx
+	defer ar.cancel()
 
-	ar.loadAlertInfo()
+	ar.loadAlertInfo(ctx)
 	ar.updateAlertUpdateTime()
 
+	ar.scheduleJobs()
+	defer ar.unscheduleJobs()
+
 	//If get alert from migrating, continue running with current status, only need to reset status when adding and updating
 	if initStatus == "adding" {
 		ar.AlertStatus.Lock()
@@ -675,9 +1043,11 @@ func (ar *AlertRunner) Run(initStatus string) {
 
 	for {
 		select {
-		case <-timer.C:
-			ar.runAlertRules()
-			logger.Debug(nil, "AlertRunner alert %s run", ar.AlertConfig.AlertId)
+		case period := <-ar.TickCh:
+			if period != heartbeatTick {
+				ar.runAlertRules(ctx, period)
+				ar.logEvaluation(ctx, "AlertRunner alert %s run period %d", ar.AlertConfig.AlertId, period)
+			}
 			ar.updateAlertUpdateTime()
 		case operation := <-ar.SignalCh:
 			switch operation {
@@ -686,15 +1056,13 @@ func (ar *AlertRunner) Run(initStatus string) {
 				for len(ar.SignalCh) > 0 {
 					<-ar.SignalCh
 				}
-				logger.Debug(nil, "AlertRunner alert %s stop", ar.AlertConfig.AlertId)
+				logger.Debug(ctx, "AlertRunner alert %s stop", ar.AlertConfig.AlertId)
 				return
 			case "Update":
-				ar.loadAlertInfo()
-				ar.AlertStatus.Lock()
-				ar.resetAlertStatus()
-				ar.AlertStatus.Unlock()
+				ar.updateAlertInfo(ctx)
+				ar.scheduleJobs()
 				ar.updateAlertUpdateTime()
-				logger.Debug(nil, "AlertRunner alert %s update", ar.AlertConfig.AlertId)
+				logger.Debug(ctx, "AlertRunner alert %s update", ar.AlertConfig.AlertId)
 			default:
 				param := strings.Split(operation, " ")
 				if len(param) != 2 {
@@ -702,9 +1070,9 @@ func (ar *AlertRunner) Run(initStatus string) {
 				}
 				switch param[0] {
 				case "Comment":
-					ar.commentAlert(param[1])
+					ar.commentAlert(ctx, param[1])
 					ar.updateAlertUpdateTime()
-					logger.Debug(nil, "AlertRunner alert %s comment", ar.AlertConfig.AlertId)
+					logger.Debug(ctx, "AlertRunner alert %s comment", ar.AlertConfig.AlertId)
 				}
 			}
 		}
@@ -0,0 +1,68 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffRules(t *testing.T) {
+	oldRules := map[string]RuleInfo{
+		"rl-1": {RuleName: "cpu", Severity: "minor", ConditionType: ">", Thresholds: 80, MetricName: "pod_cpu_usage"},
+		"rl-2": {RuleName: "memory", Severity: "minor", ConditionType: ">", Thresholds: 80, MetricName: "pod_memory_usage"},
+		"rl-3": {RuleName: "network", Severity: "major", ConditionType: ">", Thresholds: 10, MetricName: "pod_net_bytes_received"},
+		"rl-4": {RuleName: "disk", Severity: "minor", ConditionType: ">", Thresholds: 80, MetricName: "pod_disk_usage"},
+	}
+	newRules := map[string]RuleInfo{
+		"rl-1": {RuleName: "cpu", Severity: "minor", ConditionType: ">", Thresholds: 90, MetricName: "pod_cpu_usage"},
+		"rl-2": {RuleName: "memory", Severity: "critical", ConditionType: ">", Thresholds: 80, MetricName: "pod_memory_usage"},
+		"rl-3": {RuleName: "network", Severity: "major", ConditionType: ">", Thresholds: 10, MetricName: "pod_net_bytes_received"},
+		"rl-5": {RuleName: "restarts", Severity: "minor", ConditionType: ">", Thresholds: 3, MetricName: "pod_restarts"},
+	}
+
+	changes := diffRules(oldRules, newRules)
+	require.Equal(t, RuleChanges{
+		Added:   []string{"rl-5"},
+		Changed: []string{"rl-1"},
+		Reset:   []string{"rl-2"},
+		Removed: []string{"rl-4"},
+	}, changes)
+
+	require.Equal(t, "added rules [restarts], changed rules [cpu], reset rules [memory], removed rules [disk]", describeRuleChanges(oldRules, newRules, changes))
+	require.Equal(t, "rules unchanged", describeRuleChanges(oldRules, oldRules, diffRules(oldRules, oldRules)))
+}
+
+func TestKeepAlertStatus(t *testing.T) {
+	ar := &AlertRunner{}
+
+	resourceStatus := map[string]StatusResource{
+		getRuleResourceKey("rl-1", "pod-1"): {CurrentLevel: "minor", PositiveCount: 3, CumulatedSendCount: 2},
+		getRuleResourceKey("rl-2", "pod-1"): {CurrentLevel: "minor", PositiveCount: 3},
+		getRuleResourceKey("rl-3", "pod-1"): {CurrentLevel: "cleared"},
+		getRuleResourceKey("rl-4", "pod-1"): {CurrentLevel: "minor"},
+	}
+	evaluationStatus := map[string]StatusEvaluation{
+		"rl-1": {State: EvaluationStateOk},
+		"rl-2": {State: EvaluationStateOk},
+		"rl-4": {State: EvaluationStateError},
+	}
+
+	ar.keepAlertStatus(resourceStatus, evaluationStatus, RuleChanges{
+		Added:   []string{"rl-5"},
+		Changed: []string{"rl-1"},
+		Reset:   []string{"rl-2"},
+		Removed: []string{"rl-4"},
+	})
+
+	require.Equal(t, map[string]StatusResource{
+		getRuleResourceKey("rl-1", "pod-1"): {CurrentLevel: "minor", PositiveCount: 3, CumulatedSendCount: 2},
+		getRuleResourceKey("rl-3", "pod-1"): {CurrentLevel: "cleared"},
+	}, ar.AlertStatus.ResourceStatus)
+	require.Equal(t, map[string]StatusEvaluation{
+		"rl-1": {State: EvaluationStateOk},
+	}, ar.AlertStatus.EvaluationStatus)
+}
@@ -0,0 +1,235 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"container/heap"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+
+	"kubesphere.io/alert/pkg/logger"
+)
+
+const (
+	MissedTickSkip  = "skip"
+	MissedTickDelay = "delay"
+
+	// retry period of a delayed tick which could not be delivered
+	DelayRetryPeriod = time.Second
+)
+
+// ScheduleFunc delivers a tick of a scheduled job, it must not block and
+// returns false when the receiver is still busy with the previous tick.
+type ScheduleFunc func() bool
+
+type scheduleJob struct {
+	key      string
+	interval time.Duration
+	next     time.Time
+	fire     ScheduleFunc
+	index    int
+}
+
+type scheduleHeap []*scheduleJob
+
+func (h scheduleHeap) Len() int           { return len(h) }
+func (h scheduleHeap) Less(i, j int) bool { return h[i].next.Before(h[j].next) }
+func (h scheduleHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *scheduleHeap) Push(x interface{}) {
+	job := x.(*scheduleJob)
+	job.index = len(*h)
+	*h = append(*h, job)
+}
+
+func (h *scheduleHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	job := old[n-1]
+	old[n-1] = nil
+	job.index = -1
+	*h = old[0 : n-1]
+	return job
+}
+
+// Scheduler drives the evaluation of all alert runners of an executor from a
+// single goroutine, instead of one ticker per runner.
+type Scheduler struct {
+	sync.Mutex
+	jobs             scheduleHeap
+	keys             map[string]*scheduleJob
+	missedTickPolicy string
+	wakeCh           chan struct{}
+	firedCount       uint64
+	missedCount      uint64
+}
+
+func NewScheduler(missedTickPolicy string) *Scheduler {
+	if missedTickPolicy != MissedTickDelay {
+		missedTickPolicy = MissedTickSkip
+	}
+
+	s := &Scheduler{
+		keys:             make(map[string]*scheduleJob),
+		missedTickPolicy: missedTickPolicy,
+		wakeCh:           make(chan struct{}, 1),
+	}
+
+	return s
+}
+
+// getFirstTick spreads jobs over their interval. Jobs with the same phase share
+// the same offset in wall clock, so that their metric requests can be batched;
+// jobs without a phase get a random offset.
+func getFirstTick(now time.Time, phase string, interval time.Duration) time.Time {
+	if phase == "" {
+		return now.Add(time.Duration(rand.Int63n(int64(interval))))
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(phase))
+	offset := time.Duration(h.Sum64() % uint64(interval))
+
+	next := now.Truncate(interval).Add(offset)
+	if next.Before(now) {
+		next = next.Add(interval)
+	}
+
+	return next
+}
+
+// Add schedules fire every interval under key, replacing any job with the same key.
+func (s *Scheduler) Add(key string, phase string, interval time.Duration, fire ScheduleFunc) {
+	if interval <= 0 {
+		logger.Error(nil, "Scheduler add job %s error: invalid interval %v", key, interval)
+		return
+	}
+
+	s.Lock()
+	if job, ok := s.keys[key]; ok {
+		heap.Remove(&s.jobs, job.index)
+		delete(s.keys, key)
+	}
+
+	job := &scheduleJob{
+		key:      key,
+		interval: interval,
+		next:     getFirstTick(time.Now(), phase, interval),
+		fire:     fire,
+	}
+	heap.Push(&s.jobs, job)
+	s.keys[key] = job
+	s.Unlock()
+
+	s.wake()
+}
+
+func (s *Scheduler) Remove(key string) {
+	s.Lock()
+	if job, ok := s.keys[key]; ok {
+		heap.Remove(&s.jobs, job.index)
+		delete(s.keys, key)
+	}
+	s.Unlock()
+
+	s.wake()
+}
+
+func (s *Scheduler) GetJobCount() int {
+	s.Lock()
+	count := len(s.keys)
+	s.Unlock()
+
+	return count
+}
+
+// GetTickCount returns how many ticks were delivered and how many were missed.
+func (s *Scheduler) GetTickCount() (uint64, uint64) {
+	s.Lock()
+	fired, missed := s.firedCount, s.missedCount
+	s.Unlock()
+
+	return fired, missed
+}
+
+func (s *Scheduler) wake() {
+	select {
+	case s.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) reschedule(job *scheduleJob, now time.Time, fired bool) {
+	if !fired {
+		s.missedCount++
+		if s.missedTickPolicy == MissedTickDelay {
+			job.next = now.Add(DelayRetryPeriod)
+			return
+		}
+	} else {
+		s.firedCount++
+	}
+
+	job.next = job.next.Add(job.interval)
+	if job.next.After(now) {
+		return
+	}
+
+	//Scheduler was late for more than one interval
+	switch s.missedTickPolicy {
+	case MissedTickDelay:
+		job.next = now
+	default:
+		missed := now.Sub(job.next)/job.interval + 1
+		s.missedCount += uint64(missed)
+		job.next = job.next.Add(missed * job.interval)
+	}
+}
+
+// runDue fires all jobs due at now and returns the wait time until the next one.
+func (s *Scheduler) runDue(now time.Time) time.Duration {
+	s.Lock()
+	defer s.Unlock()
+
+	for len(s.jobs) > 0 {
+		job := s.jobs[0]
+		if job.next.After(now) {
+			return job.next.Sub(now)
+		}
+
+		s.reschedule(job, now, job.fire())
+		heap.Fix(&s.jobs, job.index)
+	}
+
+	return time.Hour
+}
+
+func (s *Scheduler) Run() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		wait := s.runDue(time.Now())
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+
+		select {
+		case <-timer.C:
+		case <-s.wakeCh:
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"container/heap"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// setTestNext moves the next tick of the job under key, to schedule jobs at known times.
+func setTestNext(s *Scheduler, key string, next time.Time) {
+	s.keys[key].next = next
+	heap.Init(&s.jobs)
+}
+
+func TestGetFirstTick(t *testing.T) {
+	interval := time.Minute
+	now := time.Date(2019, 7, 1, 10, 0, 30, 0, time.UTC)
+
+	// jobs of the same phase share the offset, however late they are added
+	first := getFirstTick(now, "pod/60", interval)
+	later := getFirstTick(now.Add(17*time.Second), "pod/60", interval)
+	require.False(t, first.Before(now))
+	require.True(t, first.Before(now.Add(interval)))
+	require.Equal(t, first.Sub(first.Truncate(interval)), later.Sub(later.Truncate(interval)))
+
+	for i := 0; i < 100; i++ {
+		next := getFirstTick(now, "", interval)
+		require.False(t, next.Before(now))
+		require.True(t, next.Before(now.Add(interval)))
+	}
+}
+
+func TestSchedulerOrdering(t *testing.T) {
+	s := NewScheduler(MissedTickSkip)
+	base := time.Now()
+
+	var fired []string
+	for _, key := range []string{"a", "b", "c"} {
+		key := key
+		s.Add(key, "", time.Minute, func() bool {
+			fired = append(fired, key)
+			return true
+		})
+	}
+	setTestNext(s, "a", base.Add(3*time.Second))
+	setTestNext(s, "b", base.Add(time.Second))
+	setTestNext(s, "c", base.Add(2*time.Second))
+
+	// due jobs fire in the order of their ticks, the wait runs until the next one
+	require.Equal(t, time.Second, s.runDue(base))
+	require.Empty(t, fired)
+	require.Equal(t, 58*time.Second, s.runDue(base.Add(3*time.Second)))
+	require.Equal(t, []string{"b", "c", "a"}, fired)
+
+	fired = nil
+	require.Equal(t, time.Second, s.runDue(base.Add(62*time.Second)))
+	require.Equal(t, []string{"b", "c"}, fired)
+
+	firedCount, missedCount := s.GetTickCount()
+	require.Equal(t, uint64(5), firedCount)
+	require.Equal(t, uint64(0), missedCount)
+}
+
+func TestSchedulerAddRemove(t *testing.T) {
+	s := NewScheduler(MissedTickSkip)
+	base := time.Now()
+
+	var fired []string
+	s.Add("a", "", time.Minute, func() bool {
+		fired = append(fired, "old")
+		return true
+	})
+	// adding a job under the same key replaces it
+	s.Add("a", "", time.Minute, func() bool {
+		fired = append(fired, "new")
+		return true
+	})
+	s.Add("b", "", time.Minute, func() bool {
+		fired = append(fired, "b")
+		return true
+	})
+	s.Add("c", "", 0, func() bool { return true })
+	require.Equal(t, 2, s.GetJobCount())
+
+	s.Remove("b")
+	s.Remove("unknown")
+	require.Equal(t, 1, s.GetJobCount())
+
+	setTestNext(s, "a", base)
+	s.runDue(base)
+	require.Equal(t, []string{"new"}, fired)
+
+	s.Remove("a")
+	require.Equal(t, 0, s.GetJobCount())
+	require.Equal(t, time.Hour, s.runDue(base.Add(time.Hour)))
+}
+
+func TestSchedulerMissedTick(t *testing.T) {
+	base := time.Now()
+	busy := func() bool { return false }
+
+	// a busy runner misses the tick, the next comes an interval later
+	s := NewScheduler("")
+	s.Add("a", "", time.Minute, busy)
+	setTestNext(s, "a", base)
+	require.Equal(t, time.Minute, s.runDue(base))
+	_, missedCount := s.GetTickCount()
+	require.Equal(t, uint64(1), missedCount)
+
+	// ticks the scheduler was too late for are missed too
+	s.runDue(base.Add(3*time.Minute + time.Second))
+	_, missedCount = s.GetTickCount()
+	require.Equal(t, uint64(4), missedCount)
+	require.Equal(t, base.Add(4*time.Minute), s.keys["a"].next)
+
+	// with the delay policy, the tick is retried until the runner takes it
+	s = NewScheduler(MissedTickDelay)
+	s.Add("a", "", time.Minute, busy)
+	setTestNext(s, "a", base)
+	require.Equal(t, DelayRetryPeriod, s.runDue(base))
+
+	s.keys["a"].fire = func() bool { return true }
+	require.Equal(t, time.Minute, s.runDue(base.Add(DelayRetryPeriod)))
+	firedCount, missedCount := s.GetTickCount()
+	require.Equal(t, uint64(1), firedCount)
+	require.Equal(t, uint64(1), missedCount)
+}
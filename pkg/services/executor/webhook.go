@@ -0,0 +1,138 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"kubesphere.io/alert/pkg/client/webhook"
+	"kubesphere.io/alert/pkg/config"
+	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/notification"
+)
+
+// aggregatedAlertTimeFormat is the format of the alert times of AggregatedAlert, in local time.
+const aggregatedAlertTimeFormat = "2006-01-02 15:04:05.99999"
+
+// getAlertmanagerLabels labels the alert of resourceName under ruleId with the alert, the rule and
+// the resource, along with the resource filter params such as ns_name.
+func (ar *AlertRunner) getAlertmanagerLabels(ruleId string, resourceName string) map[string]string {
+	labels := make(map[string]string)
+
+	rsFilterParam := make(map[string]interface{})
+	json.Unmarshal([]byte(ar.AlertConfig.RsFilterParam), &rsFilterParam)
+	for k, v := range rsFilterParam {
+		if s, ok := v.(string); ok && s != "" {
+			labels[k] = s
+		}
+	}
+
+	rule := ar.AlertConfig.Rules[ruleId]
+	labels["alertname"] = ar.AlertConfig.AlertName
+	labels["alert_id"] = ar.AlertConfig.AlertId
+	labels["rule_name"] = rule.RuleName
+	labels["rule_id"] = ruleId
+	labels["severity"] = rule.Severity
+	labels["rs_type_name"] = ar.AlertConfig.RsTypeName
+	labels["resource_name"] = processResourceName(resourceName)
+
+	return labels
+}
+
+func parseAggregatedAlertTime(alertTime string) time.Time {
+	t, err := time.ParseInLocation(aggregatedAlertTimeFormat, alertTime, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// getFiringTime returns the time the alert of status started firing. Statuses saved before the
+// firing time was recorded fall back to the first alert time not sent yet.
+func getFiringTime(status *StatusResource) time.Time {
+	if !status.FiringTime.IsZero() {
+		return status.FiringTime
+	}
+	return parseAggregatedAlertTime(status.AggregatedAlerts.FirstAlertTime)
+}
+
+// newAlertmanagerMessage describes the alert of resourceName under ruleId as Alertmanager
+// describes an alert to webhook receivers. It starts at startsAt, and ends at endsAt once
+// resolved.
+func (ar *AlertRunner) newAlertmanagerMessage(status string, ruleId string, resourceName string, startsAt time.Time, endsAt time.Time, annotations map[string]string) *notification.AlertmanagerMessage {
+	labels := ar.getAlertmanagerLabels(ruleId, resourceName)
+	groupLabels := map[string]string{"alert_id": ar.AlertConfig.AlertId}
+
+	alert := notification.AlertmanagerAlert{
+		Status:      status,
+		Labels:      labels,
+		Annotations: annotations,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		Fingerprint: notification.AlertmanagerFingerprint(labels),
+	}
+
+	return &notification.AlertmanagerMessage{
+		Version:           notification.AlertmanagerWebhookVersion,
+		GroupKey:          fmt.Sprintf(`{}:{alert_id="%s"}`, ar.AlertConfig.AlertId),
+		Status:            status,
+		Receiver:          ar.AlertConfig.AlertName,
+		GroupLabels:       groupLabels,
+		CommonLabels:      labels,
+		CommonAnnotations: annotations,
+		Alerts:            []notification.AlertmanagerAlert{alert},
+	}
+}
+
+func (ar *AlertRunner) sendWebhook(ctx context.Context, message *notification.AlertmanagerMessage) bool {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(config.GetInstance().App.NotificationTimeout)*time.Millisecond)
+	defer cancel()
+
+	err := webhook.SendAlertmanagerMessage(ctx, ar.AlertConfig.Action.Url, message)
+	if err != nil {
+		logger.Error(ctx, "AlertRunner alert %s send %s webhook failed: %v", ar.AlertConfig.AlertId, message.Status, err)
+		return false
+	}
+	return true
+}
+
+// sendFiringWebhook sends the firing alert of resourceName under ruleId, annotated with the
+// notification email rendered for it. Once the receiver got the firing alert, it gets the
+// resolved alert as well.
+func (ar *AlertRunner) sendFiringWebhook(ctx context.Context, newStatus *StatusResource, ruleId string, resourceName string, email *notification.Email) bool {
+	annotations := make(map[string]string)
+	if email != nil {
+		annotations["summary"] = email.Title
+		annotations["description"] = email.Content
+	} else {
+		annotations["summary"] = fmt.Sprintf("Rule %s of alert %s is firing on %s", ar.AlertConfig.Rules[ruleId].RuleName, ar.AlertConfig.AlertName, processResourceName(resourceName))
+	}
+
+	message := ar.newAlertmanagerMessage(notification.AlertStatusFiring, ruleId, resourceName, getFiringTime(newStatus), time.Time{}, annotations)
+	if !ar.sendWebhook(ctx, message) {
+		return false
+	}
+	newStatus.WebhookSent = true
+	return true
+}
+
+// sendResolvedWebhook sends the resolved alert of resourceName under ruleId when the receiver got
+// the firing alert.
+func (ar *AlertRunner) sendResolvedWebhook(ctx context.Context, oldStatus *StatusResource, ruleId string, resourceName string) {
+	if ar.AlertConfig.Action.Type != models.ActionTypeAlertmanagerWebhook || !oldStatus.WebhookSent {
+		return
+	}
+
+	annotations := map[string]string{
+		"summary": fmt.Sprintf("Rule %s of alert %s is resolved on %s", ar.AlertConfig.Rules[ruleId].RuleName, ar.AlertConfig.AlertName, processResourceName(resourceName)),
+	}
+
+	message := ar.newAlertmanagerMessage(notification.AlertStatusResolved, ruleId, resourceName, getFiringTime(oldStatus), time.Now(), annotations)
+	ar.sendWebhook(ctx, message)
+}
@@ -0,0 +1,153 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/config"
+	"kubesphere.io/alert/pkg/metric"
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/notification"
+)
+
+func TestNewAlertmanagerMessage(t *testing.T) {
+	ar := &AlertRunner{}
+	ar.AlertConfig.AlertId = "al-1"
+	ar.AlertConfig.AlertName = "pod-cpu"
+	ar.AlertConfig.RsTypeName = "pod"
+	ar.AlertConfig.RsFilterParam = `{"ns_name":"default","pod_names":["web-0"]}`
+	ar.AlertConfig.Rules = map[string]RuleInfo{
+		"rl-1": {RuleName: "cpu", Severity: "critical"},
+	}
+
+	startsAt := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2019, 6, 1, 10, 10, 0, 0, time.UTC)
+	message := ar.newAlertmanagerMessage(notification.AlertStatusResolved, "rl-1", "default:web-0", startsAt, endsAt, map[string]string{"summary": "resolved"})
+
+	labels := map[string]string{
+		"alertname":     "pod-cpu",
+		"alert_id":      "al-1",
+		"rule_name":     "cpu",
+		"rule_id":       "rl-1",
+		"severity":      "critical",
+		"rs_type_name":  "pod",
+		"resource_name": "web-0",
+		"ns_name":       "default",
+	}
+	require.Equal(t, "4", message.Version)
+	require.Equal(t, notification.AlertStatusResolved, message.Status)
+	require.Equal(t, map[string]string{"alert_id": "al-1"}, message.GroupLabels)
+	require.Equal(t, labels, message.CommonLabels)
+	require.Len(t, message.Alerts, 1)
+
+	alert := message.Alerts[0]
+	require.Equal(t, labels, alert.Labels)
+	require.Equal(t, map[string]string{"summary": "resolved"}, alert.Annotations)
+	require.Equal(t, startsAt, alert.StartsAt)
+	require.Equal(t, endsAt, alert.EndsAt)
+	require.Equal(t, notification.AlertmanagerFingerprint(labels), alert.Fingerprint)
+
+	data, err := json.Marshal(message)
+	require.NoError(t, err)
+	payload := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(data, &payload))
+	for _, key := range []string{"version", "groupKey", "truncatedAlerts", "status", "receiver", "groupLabels", "commonLabels", "commonAnnotations", "externalURL", "alerts"} {
+		require.Contains(t, payload, key)
+	}
+	require.Equal(t, "2019-06-01T10:10:00Z", payload["alerts"].([]interface{})[0].(map[string]interface{})["endsAt"])
+}
+
+func TestAlertmanagerFingerprint(t *testing.T) {
+	fingerprint := notification.AlertmanagerFingerprint(map[string]string{"alertname": "pod-cpu", "resource_name": "web-0"})
+	require.Len(t, fingerprint, 16)
+	require.Equal(t, fingerprint, notification.AlertmanagerFingerprint(map[string]string{"resource_name": "web-0", "alertname": "pod-cpu"}))
+	require.NotEqual(t, notification.AlertmanagerFingerprint(map[string]string{"a": "bc"}), notification.AlertmanagerFingerprint(map[string]string{"ab": "c"}))
+}
+
+func TestGetFiringTime(t *testing.T) {
+	firingTime := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	require.Equal(t, firingTime, getFiringTime(&StatusResource{FiringTime: firingTime}))
+	require.Equal(t, time.Date(2019, 6, 1, 10, 5, 0, 0, time.Local), getFiringTime(&StatusResource{AggregatedAlerts: AggregatedAlert{FirstAlertTime: "2019-06-01 10:05:00"}}))
+}
+
+func newWebhookRunner(url string) *AlertRunner {
+	ar := &AlertRunner{}
+	ar.AlertConfig.AlertId = "al-1"
+	ar.AlertConfig.AlertName = "pod-cpu"
+	ar.AlertConfig.RsTypeName = "pod"
+	ar.AlertConfig.Rules = map[string]RuleInfo{
+		"rl-1": {RuleName: "cpu", Severity: "critical"},
+	}
+	ar.AlertConfig.Action.Type = models.ActionTypeAlertmanagerWebhook
+	ar.AlertConfig.Action.Url = url
+	return ar
+}
+
+func newRecordedMetric(resourceName string, alertTime time.Time) RecordedMetric {
+	return RecordedMetric{RuleName: "cpu", ResourceName: resourceName, tvs: []metric.TV{{T: alertTime.Unix(), V: "0.9"}}}
+}
+
+func TestWebhookFiringResolved(t *testing.T) {
+	config.GetInstance().App.NotificationTimeout = 5000
+
+	var messages []notification.AlertmanagerMessage
+	receiverStatus := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		message := notification.AlertmanagerMessage{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+		messages = append(messages, message)
+		w.WriteHeader(receiverStatus)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ar := newWebhookRunner(server.URL)
+	firingTime := time.Unix(time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC).Unix(), 0)
+
+	//The receiver gets the firing alert twice and the resolved alert, all starting at the firing time.
+	status := StatusResource{CurrentLevel: "cleared"}
+	triggeredMetric := newRecordedMetric("default:web-0", firingTime)
+	ar.triggerResourceStatus(&status, "rl-1", triggeredMetric)
+	require.Equal(t, "critical", status.CurrentLevel)
+
+	ar.pushAggregatedAlerts(&status, "rl-1", "default:web-0", []RecordedMetric{triggeredMetric})
+	require.True(t, ar.sendFiringWebhook(ctx, &status, "rl-1", "default:web-0", nil))
+	ar.clearAggregatedAlerts(&status, "rl-1", "default:web-0")
+
+	ar.pushAggregatedAlerts(&status, "rl-1", "default:web-0", []RecordedMetric{newRecordedMetric("default:web-0", firingTime.Add(5*time.Minute))})
+	require.True(t, ar.sendFiringWebhook(ctx, &status, "rl-1", "default:web-0", nil))
+	ar.clearAggregatedAlerts(&status, "rl-1", "default:web-0")
+
+	ar.sendResolvedWebhook(ctx, &status, "rl-1", "default:web-0")
+
+	require.Len(t, messages, 3)
+	for i, expected := range []string{notification.AlertStatusFiring, notification.AlertStatusFiring, notification.AlertStatusResolved} {
+		require.Equal(t, expected, messages[i].Status)
+		require.True(t, firingTime.Equal(messages[i].Alerts[0].StartsAt))
+	}
+	require.True(t, messages[1].Alerts[0].EndsAt.IsZero())
+	require.False(t, messages[2].Alerts[0].EndsAt.IsZero())
+
+	//The receiver failing the firing alert does not get the resolved alert.
+	messages = nil
+	receiverStatus = http.StatusInternalServerError
+	status = StatusResource{CurrentLevel: "cleared"}
+	ar.triggerResourceStatus(&status, "rl-1", triggeredMetric)
+	ar.pushAggregatedAlerts(&status, "rl-1", "default:web-0", []RecordedMetric{triggeredMetric})
+	require.False(t, ar.sendFiringWebhook(ctx, &status, "rl-1", "default:web-0", nil))
+	ar.processRepeat(&status, "rl-1", "default:web-0")
+	require.Equal(t, uint32(1), status.CumulatedSendCount)
+
+	ar.sendResolvedWebhook(ctx, &status, "rl-1", "default:web-0")
+	require.Len(t, messages, 1)
+}
@@ -13,6 +13,7 @@ import (
 
 	"kubesphere.io/alert/pkg/global"
 	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/tracing"
 )
 
 type AlertBroadcast struct {
@@ -20,8 +21,9 @@ type AlertBroadcast struct {
 
 // BroadcastInfo is the service register information to etcd
 type BroadcastInfo struct {
-	AlertId   string
-	Operation string
+	AlertId     string
+	Operation   string
+	Traceparent string
 }
 
 func NewAlertBroadcast() *AlertBroadcast {
@@ -32,10 +34,16 @@ func formatTopic(prefix string, alertId string) string {
 	return fmt.Sprintf("%s/%s", prefix, alertId)
 }
 
-func (ab *AlertBroadcast) Broadcast(alertId string, operation string, expireTime int64) error {
+func (ab *AlertBroadcast) Broadcast(ctx context.Context, alertId string, operation string, expireTime int64) error {
+	ctx, span := tracing.StartSpan(ctx, "AlertBroadcast.Broadcast")
+	defer span.End()
+	span.SetAttribute("alert_id", alertId)
+	span.SetAttribute("operation", operation)
+
 	info := &BroadcastInfo{
-		AlertId:   alertId,
-		Operation: operation,
+		AlertId:     alertId,
+		Operation:   operation,
+		Traceparent: tracing.Inject(ctx),
 	}
 
 	key := formatTopic("al-broadcast", alertId)
@@ -45,12 +53,12 @@ func (ab *AlertBroadcast) Broadcast(alertId string, operation string, expireTime
 		return err
 	}
 
-	ctx := context.Background()
 	e := global.GetInstance().GetEtcd()
 
 	resp, err := e.Grant(ctx, expireTime)
 	if err != nil {
 		logger.Error(ctx, "Grant TTL from etcd failed: %+v", err)
+		span.SetError(err)
 		return err
 	}
 
@@ -58,6 +66,7 @@ func (ab *AlertBroadcast) Broadcast(alertId string, operation string, expireTime
 
 	if err != nil {
 		logger.Error(ctx, "AlertBroadcast [%s] [%s] to etcd failed: %+v", key, string(value), err)
+		span.SetError(err)
 		return err
 	}
 
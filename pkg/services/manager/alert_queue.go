@@ -5,6 +5,7 @@
 package manager
 
 import (
+	"context"
 	"errors"
 
 	lib "openpitrix.io/libqueue"
@@ -13,6 +14,7 @@ import (
 	"kubesphere.io/alert/pkg/config"
 	"kubesphere.io/alert/pkg/constants"
 	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/monitoring"
 )
 
 type AlertQueue struct {
@@ -42,6 +44,14 @@ func NewAlertQueue() *AlertQueue {
 	}
 }
 
+func (aq *AlertQueue) CheckQueue(ctx context.Context) error {
+	if aq.alertQueue == nil {
+		return errors.New("AlertQueue not initialized")
+	}
+
+	return monitoring.CheckQueue(ctx)
+}
+
 func (aq *AlertQueue) Enqueue(alertId string) error {
 	if aq.alertQueue == nil {
 		return errors.New("AlertQueue not initialized")
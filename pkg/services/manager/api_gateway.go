@@ -26,8 +26,10 @@ import (
 	staticSpec "kubesphere.io/alert/pkg/apigateway/spec"
 	staticSwaggerUI "kubesphere.io/alert/pkg/apigateway/swagger-ui"
 	"kubesphere.io/alert/pkg/config"
+	"kubesphere.io/alert/pkg/constants"
 	"kubesphere.io/alert/pkg/logger"
 	"kubesphere.io/alert/pkg/pb"
+	"kubesphere.io/alert/pkg/util/tlsutil"
 )
 
 type register struct {
@@ -135,12 +137,29 @@ func (s *Server) run() error {
 	return r.Run(fmt.Sprintf(":%s", cfg.App.ApiPort))
 }
 
+// incomingHeaderMatcher forwards the X-Actor header as the actor of audit logs,
+// besides the headers forwarded by default, Authorization among them.
+func incomingHeaderMatcher(key string) (string, bool) {
+	if strings.ToLower(key) == constants.ActorHeader {
+		return constants.ActorHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
 func (s *Server) mainHandler() http.Handler {
-	var gwmux = runtime.NewServeMux()
+	var gwmux = runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher))
 	var opts = []grpc.DialOption{grpc.WithInsecure()}
 	var err error
 
 	cfg := config.GetInstance()
+	if cfg.Tls.Enable {
+		creds, err := tlsutil.NewClientCredentials(cfg.Tls.CertFile, cfg.Tls.KeyFile, cfg.Tls.CaFile, cfg.Tls.ServerName)
+		if err != nil {
+			logger.Critical(nil, "Failed to load gateway certificates: %+v", err)
+			panic(err)
+		}
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	}
 	for _, r := range []register{{
 		pb.RegisterAlertManagerHandlerFromEndpoint,
 		fmt.Sprintf("localhost:%s", cfg.App.Port),
@@ -0,0 +1,331 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/pb"
+	rs "kubesphere.io/alert/pkg/services/manager/resource_control"
+	"kubesphere.io/alert/pkg/util/ctxutil"
+	"kubesphere.io/alert/pkg/util/jsonutil"
+	"kubesphere.io/alert/pkg/util/stringutil"
+)
+
+// getAuditRequest returns the resource type changed by a mutating request and the ids
+// it names, requests creating resources name no id.
+func getAuditRequest(req interface{}) (string, []string, bool) {
+	switch r := req.(type) {
+	case *pb.CreateResourceTypeRequest:
+		return models.TableResourceType, nil, true
+	case *pb.ModifyResourceTypeRequest:
+		return models.TableResourceType, []string{r.GetRsTypeId()}, true
+	case *pb.DeleteResourceTypesRequest:
+		return models.TableResourceType, r.GetRsTypeId(), true
+	case *pb.CreateResourceFilterRequest:
+		return models.TableResourceFilter, nil, true
+	case *pb.ModifyResourceFilterRequest:
+		return models.TableResourceFilter, []string{r.GetRsFilterId()}, true
+	case *pb.DeleteResourceFiltersRequest:
+		return models.TableResourceFilter, r.GetRsFilterId(), true
+	case *pb.CreateMetricRequest:
+		return models.TableMetric, nil, true
+	case *pb.ModifyMetricRequest:
+		return models.TableMetric, []string{r.GetMetricId()}, true
+	case *pb.DeleteMetricsRequest:
+		return models.TableMetric, r.GetMetricId(), true
+	case *pb.CreatePolicyRequest:
+		return models.TablePolicy, nil, true
+	case *pb.ModifyPolicyRequest:
+		return models.TablePolicy, []string{r.GetPolicyId()}, true
+	case *pb.DeletePoliciesRequest:
+		return models.TablePolicy, r.GetPolicyId(), true
+	case *pb.CreateRuleRequest:
+		return models.TableRule, nil, true
+	case *pb.ModifyRuleRequest:
+		return models.TableRule, []string{r.GetRuleId()}, true
+	case *pb.DeleteRulesRequest:
+		return models.TableRule, r.GetRuleId(), true
+	case *pb.CreateActionRequest:
+		return models.TableAction, nil, true
+	case *pb.ModifyActionRequest:
+		return models.TableAction, []string{r.GetActionId()}, true
+	case *pb.DeleteActionsRequest:
+		return models.TableAction, r.GetActionId(), true
+	case *pb.CreateAlertRequest:
+		return models.TableAlert, nil, true
+	case *pb.CreateAlertBundleRequest:
+		return models.TableAlert, nil, true
+	case *pb.ModifyAlertRequest:
+		return models.TableAlert, []string{r.GetAlertId()}, true
+	case *pb.UpdateAlertBundleRequest:
+		return models.TableAlert, []string{r.GetAlertId()}, true
+	case *pb.ImportAlertsRequest:
+		return models.TableAlert, nil, !r.GetDryRun()
+	case *pb.DeleteAlertsRequest:
+		return models.TableAlert, r.GetAlertId(), true
+	case *pb.ReceiveAlertsRequest:
+		return models.TableAlert, nil, true
+	}
+
+	return "", nil, false
+}
+
+// getAuditResponseIds returns the ids of the resources a mutating request actually changed.
+func getAuditResponseIds(resp interface{}) []string {
+	switch r := resp.(type) {
+	case *pb.CreateResourceTypeResponse:
+		return []string{r.GetRsTypeId()}
+	case *pb.ModifyResourceTypeResponse:
+		return []string{r.GetRsTypeId()}
+	case *pb.DeleteResourceTypesResponse:
+		return r.GetRsTypeId()
+	case *pb.CreateResourceFilterResponse:
+		return []string{r.GetRsFilterId()}
+	case *pb.ModifyResourceFilterResponse:
+		return []string{r.GetRsFilterId()}
+	case *pb.DeleteResourceFiltersResponse:
+		return r.GetRsFilterId()
+	case *pb.CreateMetricResponse:
+		return []string{r.GetMetricId()}
+	case *pb.ModifyMetricResponse:
+		return []string{r.GetMetricId()}
+	case *pb.DeleteMetricsResponse:
+		return r.GetMetricId()
+	case *pb.CreatePolicyResponse:
+		return []string{r.GetPolicyId()}
+	case *pb.ModifyPolicyResponse:
+		return []string{r.GetPolicyId()}
+	case *pb.DeletePoliciesResponse:
+		return r.GetPolicyId()
+	case *pb.CreateRuleResponse:
+		return []string{r.GetRuleId()}
+	case *pb.ModifyRuleResponse:
+		return []string{r.GetRuleId()}
+	case *pb.DeleteRulesResponse:
+		return r.GetRuleId()
+	case *pb.CreateActionResponse:
+		return []string{r.GetActionId()}
+	case *pb.ModifyActionResponse:
+		return []string{r.GetActionId()}
+	case *pb.DeleteActionsResponse:
+		return r.GetActionId()
+	case *pb.CreateAlertResponse:
+		return []string{r.GetAlertId()}
+	case *pb.CreateAlertBundleResponse:
+		return []string{r.GetAlertId()}
+	case *pb.ModifyAlertResponse:
+		return []string{r.GetAlertId()}
+	case *pb.UpdateAlertBundleResponse:
+		return []string{r.GetAlertId()}
+	case *pb.ImportAlertsResponse:
+		alertIds := []string{}
+		for _, change := range r.GetChanges() {
+			if change.GetOperation() != "unchanged" {
+				alertIds = append(alertIds, change.GetAlertId())
+			}
+		}
+		return alertIds
+	case *pb.DeleteAlertsResponse:
+		return r.GetAlertId()
+	case *pb.ReceiveAlertsResponse:
+		alertIds := []string{}
+		for _, alert := range r.GetAlerts() {
+			if alert.GetAlertCreated() {
+				alertIds = append(alertIds, alert.GetAlertId())
+			}
+		}
+		return alertIds
+	}
+
+	return nil
+}
+
+// getAuditCreatedRuleIds returns the ids of the rules a request added to the alerts it did not
+// create, the rules of the alerts it created are audited along with their bundles.
+func getAuditCreatedRuleIds(resp interface{}) []string {
+	switch r := resp.(type) {
+	case *pb.ReceiveAlertsResponse:
+		created := make(map[string]bool)
+		for _, alert := range r.GetAlerts() {
+			if alert.GetAlertCreated() {
+				created[alert.GetAlertId()] = true
+			}
+		}
+		ruleIds := []string{}
+		for _, alert := range r.GetAlerts() {
+			if alert.GetRuleCreated() && !created[alert.GetAlertId()] {
+				ruleIds = append(ruleIds, alert.GetRuleId())
+			}
+		}
+		return ruleIds
+	}
+
+	return nil
+}
+
+// bundleResourceTypes are the resources an alert bundle changes along with the alert.
+var bundleResourceTypes = []string{models.TableResourceFilter, models.TablePolicy, models.TableAction, models.TableRule}
+
+// changesAlertBundles reports whether a request changes the resource filters, policies,
+// actions and rules of the alerts it changes.
+func changesAlertBundles(req interface{}) bool {
+	switch req.(type) {
+	case *pb.CreateAlertBundleRequest, *pb.UpdateAlertBundleRequest, *pb.ImportAlertsRequest, *pb.ReceiveAlertsRequest:
+		return true
+	}
+	return false
+}
+
+// getImportAlertIds returns the ids of the alerts an import updates, the alerts of its document
+// watching its resources already. The request names them only by name.
+func getImportAlertIds(ctx context.Context, req *pb.ImportAlertsRequest) ([]string, error) {
+	document, err := models.ParseAlertDocument([]byte(req.GetDocument()))
+	if err != nil {
+		return nil, err
+	}
+
+	alertNames := []string{}
+	for _, definition := range document.Alerts {
+		alertNames = append(alertNames, definition.AlertName)
+	}
+	return rs.DescribeAlertIds(ctx, req.GetResourceSearch(), alertNames)
+}
+
+// getBundleSnapshots returns the snapshots of the bundles of the alerts with alertIds besides
+// the alerts, keyed by resource type and resource id.
+func getBundleSnapshots(ctx context.Context, alertIds []string) (map[string]map[string]string, error) {
+	bundleIds, err := rs.GetAlertBundleIds(ctx, alertIds)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make(map[string]map[string]string)
+	for _, resourceType := range bundleResourceTypes {
+		snapshots[resourceType], err = rs.GetAuditSnapshots(ctx, resourceType, bundleIds[resourceType])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return snapshots, nil
+}
+
+// getChangedIds returns the sorted ids of the resources whose snapshots differ.
+func getChangedIds(before map[string]string, after map[string]string) []string {
+	changedIds := []string{}
+	for resourceId, snapshot := range before {
+		if after[resourceId] != snapshot {
+			changedIds = append(changedIds, resourceId)
+		}
+	}
+	for resourceId := range after {
+		if _, ok := before[resourceId]; !ok {
+			changedIds = append(changedIds, resourceId)
+		}
+	}
+	sort.Strings(changedIds)
+	return changedIds
+}
+
+func newAudit(ctx context.Context, operation string, resourceType string, resourceId string, before string, after string) *models.Audit {
+	diff, err := jsonutil.Diff(before, after)
+	if err != nil {
+		logger.Error(ctx, "Diff %s[%s] of [%s] failed, [%+v].", resourceType, resourceId, operation, err)
+	}
+	content, _ := json.Marshal(diff)
+
+	return models.NewAudit(ctxutil.GetActor(ctx), operation, resourceType, resourceId, ctxutil.GetRequestId(ctx), before, after, string(content))
+}
+
+// Auditor records who changed which resources for every mutating request handled successfully,
+// with the resources before and after the change. Requests changing alert bundles record the
+// resource filters, policies, actions and rules they change as well. Failing to write the audit
+// does not fail the request, the change is already committed.
+func (s *Server) Auditor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resourceType, resourceIds, ok := getAuditRequest(req)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	method := strings.Split(info.FullMethod, "/")
+	operation := method[len(method)-1]
+
+	//the alerts an import updates are audited as updated rather than created
+	if importReq, ok := req.(*pb.ImportAlertsRequest); ok {
+		var err error
+		resourceIds, err = getImportAlertIds(ctx, importReq)
+		if err != nil {
+			logger.Error(ctx, "Get %s ids before [%s] failed, [%+v].", resourceType, operation, err)
+		}
+	}
+
+	resourceIds = stringutil.SimplifyStringList(resourceIds)
+	before, err := rs.GetAuditSnapshots(ctx, resourceType, resourceIds)
+	if err != nil {
+		logger.Error(ctx, "Get %s snapshots before [%s] failed, [%+v].", resourceType, operation, err)
+	}
+
+	bundles := changesAlertBundles(req)
+	var bundlesBefore map[string]map[string]string
+	if bundles {
+		bundlesBefore, err = getBundleSnapshots(ctx, resourceIds)
+		if err != nil {
+			logger.Error(ctx, "Get bundle snapshots before [%s] failed, [%+v].", operation, err)
+		}
+	}
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	changedIds := stringutil.SimplifyStringList(getAuditResponseIds(resp))
+	after, err := rs.GetAuditSnapshots(ctx, resourceType, changedIds)
+	if err != nil {
+		logger.Error(ctx, "Get %s snapshots after [%s] failed, [%+v].", resourceType, operation, err)
+	}
+
+	var audits []*models.Audit
+	for _, resourceId := range changedIds {
+		audits = append(audits, newAudit(ctx, operation, resourceType, resourceId, before[resourceId], after[resourceId]))
+	}
+
+	if bundles {
+		bundlesAfter, err := getBundleSnapshots(ctx, changedIds)
+		if err != nil {
+			logger.Error(ctx, "Get bundle snapshots after [%s] failed, [%+v].", operation, err)
+		}
+		for _, bundleType := range bundleResourceTypes {
+			for _, resourceId := range getChangedIds(bundlesBefore[bundleType], bundlesAfter[bundleType]) {
+				audits = append(audits, newAudit(ctx, operation, bundleType, resourceId, bundlesBefore[bundleType][resourceId], bundlesAfter[bundleType][resourceId]))
+			}
+		}
+	}
+
+	ruleIds := stringutil.SimplifyStringList(getAuditCreatedRuleIds(resp))
+	if len(ruleIds) != 0 {
+		rules, err := rs.GetAuditSnapshots(ctx, models.TableRule, ruleIds)
+		if err != nil {
+			logger.Error(ctx, "Get %s snapshots after [%s] failed, [%+v].", models.TableRule, operation, err)
+		}
+		for _, ruleId := range ruleIds {
+			audits = append(audits, newAudit(ctx, operation, models.TableRule, ruleId, "", rules[ruleId]))
+		}
+	}
+
+	err = rs.CreateAudits(ctx, audits)
+	if err != nil {
+		logger.Error(ctx, "Write audit of [%s] %s%v by [%s] failed, [%+v].", operation, resourceType, changedIds, ctxutil.GetActor(ctx), err)
+	}
+
+	return resp, nil
+}
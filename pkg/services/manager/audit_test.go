@@ -0,0 +1,69 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/pb"
+)
+
+func TestGetChangedIds(t *testing.T) {
+	before := map[string]string{
+		"rl-1": `{"thresholds":"80"}`,
+		"rl-2": `{"thresholds":"90"}`,
+		"rl-3": `{"thresholds":"95"}`,
+	}
+	after := map[string]string{
+		"rl-1": `{"thresholds":"80"}`,
+		"rl-2": `{"thresholds":"70"}`,
+		"rl-4": `{"thresholds":"60"}`,
+	}
+
+	// rl-2 is modified, rl-3 removed and rl-4 added
+	require.Equal(t, []string{"rl-2", "rl-3", "rl-4"}, getChangedIds(before, after))
+	require.Equal(t, []string{"rl-1", "rl-2", "rl-3"}, getChangedIds(before, nil))
+	require.Equal(t, []string{}, getChangedIds(nil, nil))
+}
+
+func TestGetAuditReceiveAlertsIds(t *testing.T) {
+	resp := &pb.ReceiveAlertsResponse{
+		Alerts: []*pb.ReceivedAlert{
+			{AlertId: "al-1", RuleId: "rl-1", AlertCreated: true},
+			{AlertId: "al-1", RuleId: "rl-2", RuleCreated: true},
+			{AlertId: "al-2", RuleId: "rl-3", RuleCreated: true},
+			{AlertId: "al-3", RuleId: "rl-4"},
+		},
+	}
+
+	// rules of the alerts created are audited along with their bundles
+	require.Equal(t, []string{"al-1"}, getAuditResponseIds(resp))
+	require.Equal(t, []string{"rl-3"}, getAuditCreatedRuleIds(resp))
+}
+
+func TestAuditImportAlerts(t *testing.T) {
+	req := &pb.ImportAlertsRequest{Document: "alerts:\n- alert_name: cpu\n"}
+
+	// the alerts an import changes are resolved by name, their bundles are audited as well
+	resourceType, alertIds, ok := getAuditRequest(req)
+	require.True(t, ok)
+	require.Equal(t, "alert", resourceType)
+	require.Empty(t, alertIds)
+	require.True(t, changesAlertBundles(req))
+
+	_, _, ok = getAuditRequest(&pb.ImportAlertsRequest{DryRun: true})
+	require.False(t, ok)
+
+	resp := &pb.ImportAlertsResponse{
+		Changes: []*pb.AlertDocumentChange{
+			{AlertName: "cpu", AlertId: "al-1", Operation: "update"},
+			{AlertName: "memory", AlertId: "al-2", Operation: "unchanged"},
+			{AlertName: "disk", AlertId: "al-3", Operation: "create"},
+		},
+	}
+	require.Equal(t, []string{"al-1", "al-3"}, getAuditResponseIds(resp))
+}
@@ -0,0 +1,335 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	"kubesphere.io/alert/pkg/auth"
+	"kubesphere.io/alert/pkg/config"
+	"kubesphere.io/alert/pkg/gerr"
+	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/notification"
+	"kubesphere.io/alert/pkg/pb"
+	rs "kubesphere.io/alert/pkg/services/manager/resource_control"
+	"kubesphere.io/alert/pkg/util/ctxutil"
+	"kubesphere.io/alert/pkg/util/stringutil"
+)
+
+type Authorizer struct {
+	authenticator auth.Authenticator
+	policy        *auth.Policy
+}
+
+// NewAuthorizer authenticates callers by the bearer token verified with the configured key,
+// if any, or by their client certificate, and grants them the workspaces and namespaces of
+// the configured policy. The api gateway and the rest client connect with the manager
+// certificate on behalf of their callers, requests through them need a bearer token.
+func NewAuthorizer(cfg config.AuthConfig, tlsCfg config.TlsConfig) (*Authorizer, error) {
+	authenticators := auth.Authenticators{}
+	if cfg.TokenKey != "" {
+		tokenAuthenticator, err := auth.NewTokenAuthenticator(cfg.TokenKey)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, tokenAuthenticator)
+	}
+
+	proxies := stringutil.SimplifyStringList(strings.Split(cfg.Proxies, ","))
+	if tlsCfg.Enable {
+		name, err := auth.CertCommonName(tlsCfg.CertFile, tlsCfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, name)
+	}
+	authenticators = append(authenticators, auth.CertAuthenticator{Proxies: proxies})
+
+	policy, err := auth.LoadPolicy(cfg.PolicyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Authorizer{
+		authenticator: authenticators,
+		policy:        policy,
+	}, nil
+}
+
+// Authorize identifies the caller of every request, who becomes the actor of its audit logs,
+// and denies callers other than admins the requests reaching beyond their tenant.
+func (a *Authorizer) Authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	name, err := a.authenticator.Authenticate(ctx)
+	if err != nil {
+		return nil, gerr.NewWithDetail(ctx, gerr.Unauthenticated, err, gerr.ErrorAuthFailure)
+	}
+
+	identity := a.policy.Identity(name)
+	ctx = auth.NewContext(ctx, identity)
+	ctx = ctxutil.SetActor(ctx, identity.Name)
+
+	if !identity.Admin {
+		allowed, err := authorize(ctx, identity, req)
+		if err != nil {
+			return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorInternalError)
+		}
+		if !allowed {
+			logger.Warn(ctx, "Request [%s] of [%s] denied.", info.FullMethod, identity.Name)
+			return nil, gerr.New(ctx, gerr.PermissionDenied, gerr.ErrorPermissionDenied)
+		}
+	}
+
+	return handler(ctx, req)
+}
+
+// authorize reports whether a caller other than an admin may make a request. Callers reach
+// the alerts watching the resources of their workspaces and namespaces, along with the
+// resource filters, policies, rules, actions and histories of those alerts, and read the
+// resource types and metrics shared by every tenant. Policies no alert uses yet belong to the
+// caller creating them. Lists of alerts, resource filters, policies, rules, actions, histories
+// and comments name the ids of the resources of the caller, listing them all is for admins.
+func authorize(ctx context.Context, identity *auth.Identity, req interface{}) (bool, error) {
+	switch r := req.(type) {
+	case *pb.DescribeResourceTypesRequest, *pb.DescribeMetricsRequest:
+		return true, nil
+	case *pb.CreatePolicyRequest:
+		r.Creator = identity.Name
+		return true, nil
+
+	case *pb.DescribeAlertsRequest:
+		return allowsListed(ctx, identity, models.TableAlert, r.AlertId)
+	case *pb.DescribeResourceFiltersRequest:
+		return allowsListed(ctx, identity, models.TableResourceFilter, r.RsFilterId)
+	case *pb.DescribePoliciesRequest:
+		return allowsListed(ctx, identity, models.TablePolicy, r.PolicyId)
+	case *pb.DescribeRulesRequest:
+		return allowsListed(ctx, identity, models.TableRule, r.RuleId)
+	case *pb.DescribeActionsRequest:
+		if len(r.ActionId) != 0 {
+			return allowsListed(ctx, identity, models.TableAction, r.ActionId)
+		}
+		return allowsListed(ctx, identity, models.TablePolicy, r.PolicyId)
+	case *pb.DescribeHistoriesRequest:
+		if len(r.HistoryId) != 0 {
+			return allowsListed(ctx, identity, models.TableHistory, r.HistoryId)
+		}
+		return allowsListed(ctx, identity, models.TableAlert, r.AlertId)
+	case *pb.DescribeCommentsRequest:
+		return allowsListed(ctx, identity, models.TableHistory, r.HistoryId)
+
+	case *pb.DescribeAlertsWithResourceRequest:
+		return identity.AllowsJson("", r.ResourceSearch), nil
+	case *pb.DescribeAlertDetailsRequest:
+		return identity.AllowsJson("", r.ResourceSearch), nil
+	case *pb.DescribeAlertStatusRequest:
+		return identity.AllowsJson("", r.ResourceSearch), nil
+	case *pb.DescribeHistoryDetailRequest:
+		return identity.AllowsJson("", r.ResourceSearch), nil
+
+	case *pb.CreateResourceFilterRequest:
+		rsTypeName, err := rs.GetResourceTypeName(ctx, r.RsTypeId)
+		if err != nil {
+			return false, err
+		}
+		return allowsScopes(identity, []rs.ResourceScope{{RsTypeName: rsTypeName, RsFilterParam: r.RsFilterParam}}), nil
+	case *pb.ModifyResourceFilterRequest:
+		scopes, err := rs.GetResourceScopes(ctx, models.TableResourceFilter, []string{r.RsFilterId})
+		if err != nil || len(scopes) == 0 || !allowsScopes(identity, scopes) {
+			return false, err
+		}
+		//the filter has to stay in the tenant
+		if r.RsTypeId != "" {
+			scopes[0].RsTypeName, err = rs.GetResourceTypeName(ctx, r.RsTypeId)
+			if err != nil {
+				return false, err
+			}
+		}
+		if r.RsFilterParam != "" {
+			scopes[0].RsFilterParam = r.RsFilterParam
+		}
+		return allowsScopes(identity, scopes), nil
+	case *pb.DeleteResourceFiltersRequest:
+		return allowsResources(ctx, identity, models.TableResourceFilter, r.RsFilterId)
+
+	case *pb.CreateAlertRequest:
+		return allowsAll(
+			func() (bool, error) { return allowsReference(ctx, identity, models.TableResourceFilter, r.RsFilterId) },
+			func() (bool, error) { return allowsPolicies(ctx, identity, []string{r.PolicyId}) },
+		)
+	case *pb.CreateAlertBundleRequest:
+		if r.RsFilter == nil {
+			//rejected by the validation
+			return true, nil
+		}
+		rsTypeName, err := rs.GetResourceTypeName(ctx, r.RsFilter.RsTypeId)
+		if err != nil {
+			return false, err
+		}
+		return identity.AllowsJson("", r.ResourceSearch) &&
+			allowsScopes(identity, []rs.ResourceScope{{RsTypeName: rsTypeName, RsFilterParam: r.RsFilter.RsFilterParam}}), nil
+	case *pb.UpdateAlertBundleRequest:
+		if r.RsFilter == nil {
+			//rejected by the validation
+			return true, nil
+		}
+		return allowsAll(
+			func() (bool, error) { return allowsResources(ctx, identity, models.TableAlert, []string{r.AlertId}) },
+			func() (bool, error) {
+				rsTypeName, err := rs.GetResourceTypeName(ctx, r.RsFilter.RsTypeId)
+				if err != nil {
+					return false, err
+				}
+				return identity.AllowsJson("", r.ResourceSearch) &&
+					allowsScopes(identity, []rs.ResourceScope{{RsTypeName: rsTypeName, RsFilterParam: r.RsFilter.RsFilterParam}}), nil
+			},
+		)
+	case *pb.ExportAlertsRequest:
+		return identity.AllowsJson("", r.ResourceSearch), nil
+	case *pb.ImportAlertsRequest:
+		document, err := models.ParseAlertDocument([]byte(r.Document))
+		if err != nil {
+			// rejected by the handler
+			return true, nil
+		}
+		scopes := []rs.ResourceScope{}
+		for _, alert := range document.Alerts {
+			scopes = append(scopes, rs.ResourceScope{RsTypeName: alert.ResourceFilter.RsTypeName, RsFilterParam: alert.ResourceFilter.RsFilterParam})
+		}
+		return identity.AllowsJson("", r.ResourceSearch) && allowsScopes(identity, scopes), nil
+	case *pb.ModifyAlertRequest:
+		return allowsAll(
+			func() (bool, error) { return allowsResources(ctx, identity, models.TableAlert, []string{r.AlertId}) },
+			func() (bool, error) {
+				if r.RsFilterId == "" {
+					return true, nil
+				}
+				return allowsReference(ctx, identity, models.TableResourceFilter, r.RsFilterId)
+			},
+			func() (bool, error) {
+				if r.PolicyId == "" {
+					return true, nil
+				}
+				return allowsPolicies(ctx, identity, []string{r.PolicyId})
+			},
+		)
+	case *pb.DeleteAlertsRequest:
+		return allowsResources(ctx, identity, models.TableAlert, r.AlertId)
+
+	case *pb.ModifyPolicyRequest:
+		//the policy stays with its creator
+		r.Creator = ""
+		return allowsPolicies(ctx, identity, []string{r.PolicyId})
+	case *pb.DeletePoliciesRequest:
+		return allowsPolicies(ctx, identity, r.PolicyId)
+	case *pb.CreateRuleRequest:
+		return allowsPolicies(ctx, identity, []string{r.PolicyId})
+	case *pb.ModifyRuleRequest:
+		return allowsResources(ctx, identity, models.TableRule, []string{r.RuleId})
+	case *pb.DeleteRulesRequest:
+		return allowsResources(ctx, identity, models.TableRule, r.RuleId)
+	case *pb.CreateActionRequest:
+		return allowsPolicies(ctx, identity, []string{r.PolicyId})
+	case *pb.ModifyActionRequest:
+		return allowsAll(
+			func() (bool, error) { return allowsResources(ctx, identity, models.TableAction, []string{r.ActionId}) },
+			func() (bool, error) {
+				if r.PolicyId == "" {
+					return true, nil
+				}
+				return allowsPolicies(ctx, identity, []string{r.PolicyId})
+			},
+		)
+	case *pb.DeleteActionsRequest:
+		return allowsResources(ctx, identity, models.TableAction, r.ActionId)
+
+	case *pb.CreateCommentRequest:
+		return allowsReference(ctx, identity, models.TableHistory, r.HistoryId)
+
+	case *pb.ReceiveAlertsRequest:
+		var receivedAlerts []notification.AlertmanagerAlert
+		err := json.Unmarshal([]byte(r.Alerts), &receivedAlerts)
+		if err != nil {
+			// rejected by the handler
+			return true, nil
+		}
+		scopes := []rs.ResourceScope{}
+		for _, receivedAlert := range receivedAlerts {
+			scopes = append(scopes, rs.ResourceScope{RsTypeName: models.RsTypeNameExternal, RsFilterParam: models.ExternalRsFilterParam(receivedAlert.Labels[models.ExternalNamespaceLabel])})
+		}
+		return allowsScopes(identity, scopes), nil
+	}
+
+	return false, nil
+}
+
+func allowsScopes(identity *auth.Identity, scopes []rs.ResourceScope) bool {
+	for _, scope := range scopes {
+		if scope.RsTypeName == "" || !identity.AllowsJson(scope.RsTypeName, scope.RsFilterParam) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowsResources reports whether the identity may access every resource with resourceIds,
+// resources that do not exist are left to the handlers.
+func allowsResources(ctx context.Context, identity *auth.Identity, resourceType string, resourceIds []string) (bool, error) {
+	scopes, err := rs.GetResourceScopes(ctx, resourceType, resourceIds)
+	if err != nil {
+		return false, err
+	}
+	return allowsScopes(identity, scopes), nil
+}
+
+// allowsPolicies is allowsResources for policies, the policies no alert uses are allowed to the
+// identity having created them.
+func allowsPolicies(ctx context.Context, identity *auth.Identity, policyIds []string) (bool, error) {
+	creators, err := rs.GetUnusedPolicyCreators(ctx, policyIds)
+	if err != nil {
+		return false, err
+	}
+	for _, creator := range creators {
+		if creator != identity.Name {
+			return false, nil
+		}
+	}
+	return allowsResources(ctx, identity, models.TablePolicy, policyIds)
+}
+
+// allowsListed is allowsResources for lists, which have to name the resources listed.
+func allowsListed(ctx context.Context, identity *auth.Identity, resourceType string, resourceIds []string) (bool, error) {
+	resourceIds = stringutil.SimplifyStringList(append([]string{}, resourceIds...))
+	if len(resourceIds) == 0 {
+		return false, nil
+	}
+	if resourceType == models.TablePolicy {
+		return allowsPolicies(ctx, identity, resourceIds)
+	}
+	return allowsResources(ctx, identity, resourceType, resourceIds)
+}
+
+// allowsReference is allowsResources for a resource a request refers to, which has to exist.
+func allowsReference(ctx context.Context, identity *auth.Identity, resourceType string, resourceId string) (bool, error) {
+	scopes, err := rs.GetResourceScopes(ctx, resourceType, []string{resourceId})
+	if err != nil || len(scopes) == 0 {
+		return false, err
+	}
+	return allowsScopes(identity, scopes), nil
+}
+
+func allowsAll(checks ...func() (bool, error)) (bool, error) {
+	for _, check := range checks {
+		allowed, err := check()
+		if err != nil || !allowed {
+			return false, err
+		}
+	}
+	return true, nil
+}
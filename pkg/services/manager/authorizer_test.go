@@ -0,0 +1,76 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"kubesphere.io/alert/pkg/auth"
+	"kubesphere.io/alert/pkg/pb"
+)
+
+// newGatewayContext is the context of a request the api gateway proxies, over mutual TLS
+// with the manager certificate.
+func newGatewayContext() context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "alert-manager"}}
+	state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestAuthorizeGatewayRequestWithoutToken(t *testing.T) {
+	a := &Authorizer{
+		authenticator: auth.Authenticators{auth.CertAuthenticator{Proxies: []string{"alert-manager"}}},
+		policy:        &auth.Policy{Admins: []string{"alert-manager"}},
+	}
+
+	handled := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled = true
+		return nil, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/kubesphere.alert.AlertManager/DescribeResourceTypes"}
+	_, err := a.Authorize(newGatewayContext(), &pb.DescribeResourceTypesRequest{}, info, handler)
+	require.Error(t, err)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	require.False(t, handled)
+}
+
+func TestAuthorizeTenantLists(t *testing.T) {
+	identity := &auth.Identity{Name: "tenant1", Namespaces: []string{"ns1"}}
+
+	// lists of every resource are for admins
+	for _, req := range []interface{}{
+		&pb.DescribeAlertsRequest{},
+		&pb.DescribeResourceFiltersRequest{RsFilterId: []string{" "}},
+		&pb.DescribePoliciesRequest{},
+		&pb.DescribeRulesRequest{},
+		&pb.DescribeActionsRequest{},
+		&pb.DescribeHistoriesRequest{},
+		&pb.DescribeCommentsRequest{HistoryId: []string{""}},
+	} {
+		allowed, err := authorize(context.Background(), identity, req)
+		require.NoError(t, err)
+		require.False(t, allowed, "%T", req)
+	}
+
+	// policies belong to the callers creating them until alerts use them
+	req := &pb.CreatePolicyRequest{Creator: "tenant2"}
+	allowed, err := authorize(context.Background(), identity, req)
+	require.NoError(t, err)
+	require.True(t, allowed)
+	require.Equal(t, "tenant1", req.Creator)
+}
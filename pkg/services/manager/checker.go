@@ -14,6 +14,11 @@ import (
 
 func (s *Server) Checker(ctx context.Context, req interface{}) error {
 	switch r := req.(type) {
+	case *pb.ModifyExecutorRequest:
+		return manager.NewChecker(ctx, r).
+			Required("executor_id", "status").
+			StringChosen("status", []string{ExecutorStatusRunning, ExecutorStatusCordoned, ExecutorStatusEvicting}).
+			Exec()
 	case *pb.CreateResourceTypeRequest:
 		return manager.NewChecker(ctx, r).
 			Required(models.RtColName, models.RtColParam).
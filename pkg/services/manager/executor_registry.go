@@ -0,0 +1,206 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+
+	"kubesphere.io/alert/pkg/global"
+	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/pb"
+	"kubesphere.io/alert/pkg/util/jsonutil"
+	"kubesphere.io/alert/pkg/util/pbutil"
+	"kubesphere.io/alert/pkg/util/stringutil"
+)
+
+const (
+	ExecutorStatusRunning  = "running"
+	ExecutorStatusCordoned = "cordoned"
+	ExecutorStatusEvicting = "evicting"
+)
+
+// ExecutorInfo is the service register information to etcd
+type ExecutorInfo struct {
+	Name          string
+	TaskCount     int
+	BootTime      time.Time
+	HeartbeatTime time.Time
+	Status        string `json:"-"`
+}
+
+type ExecutorRegistry struct {
+}
+
+func NewExecutorRegistry() *ExecutorRegistry {
+	return &ExecutorRegistry{}
+}
+
+func (er *ExecutorRegistry) getCordonedExecutors(ctx context.Context) (map[string]bool, error) {
+	e := global.GetInstance().GetEtcd()
+	resp, err := e.Get(ctx, "alert-executor-status/", clientv3.WithPrefix())
+	if err != nil {
+		logger.Error(ctx, "ExecutorRegistry get executor status from etcd failed: %+v", err)
+		return nil, err
+	}
+
+	cordoned := make(map[string]bool)
+	for _, kv := range resp.Kvs {
+		name := strings.TrimPrefix(string(kv.Key), "alert-executor-status/")
+		cordoned[name] = string(kv.Value) == ExecutorStatusCordoned
+	}
+
+	return cordoned, nil
+}
+
+// GetExecutors returns the executors currently alive in etcd.
+func (er *ExecutorRegistry) GetExecutors(ctx context.Context) ([]*ExecutorInfo, error) {
+	e := global.GetInstance().GetEtcd()
+	resp, err := e.Get(ctx, "alert-executors/", clientv3.WithPrefix())
+	if err != nil {
+		logger.Error(ctx, "ExecutorRegistry get executors from etcd failed: %+v", err)
+		return nil, err
+	}
+
+	cordoned, err := er.getCordonedExecutors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var executors []*ExecutorInfo
+	for _, kv := range resp.Kvs {
+		var info ExecutorInfo
+		err := jsonutil.Decode(kv.Value, &info)
+		if err != nil {
+			logger.Error(ctx, "ExecutorRegistry decode executor [%s] [%s] failed: %+v", string(kv.Key), string(kv.Value), err)
+			continue
+		}
+
+		info.Status = ExecutorStatusRunning
+		if cordoned[info.Name] {
+			info.Status = ExecutorStatusCordoned
+		}
+		executors = append(executors, &info)
+	}
+
+	return executors, nil
+}
+
+func (er *ExecutorRegistry) GetExecutor(ctx context.Context, name string) (*ExecutorInfo, error) {
+	executors, err := er.GetExecutors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, executor := range executors {
+		if executor.Name == name {
+			return executor, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Cordon stops the executor from taking new alerts, running alerts are kept.
+func (er *ExecutorRegistry) Cordon(ctx context.Context, name string) error {
+	e := global.GetInstance().GetEtcd()
+	_, err := e.Put(ctx, "alert-executor-status/"+name, ExecutorStatusCordoned)
+	if err != nil {
+		logger.Error(ctx, "ExecutorRegistry cordon executor [%s] failed: %+v", name, err)
+		return err
+	}
+
+	return nil
+}
+
+func (er *ExecutorRegistry) Uncordon(ctx context.Context, name string) error {
+	e := global.GetInstance().GetEtcd()
+	_, err := e.Delete(ctx, "alert-executor-status/"+name)
+	if err != nil {
+		logger.Error(ctx, "ExecutorRegistry uncordon executor [%s] failed: %+v", name, err)
+		return err
+	}
+
+	return nil
+}
+
+// Evict kicks the executor out of etcd, the executor exits on its next
+// heartbeat and the watcher migrates its alerts to the other executors.
+func (er *ExecutorRegistry) Evict(ctx context.Context, name string) error {
+	e := global.GetInstance().GetEtcd()
+	_, err := e.Delete(ctx, "alert-executors/"+name)
+	if err != nil {
+		logger.Error(ctx, "ExecutorRegistry evict executor [%s] failed: %+v", name, err)
+		return err
+	}
+
+	_, err = e.Delete(ctx, "alert-executor-status/"+name)
+	if err != nil {
+		logger.Error(ctx, "ExecutorRegistry clear executor status [%s] failed: %+v", name, err)
+		return err
+	}
+
+	return nil
+}
+
+func filterExecutors(executors []*ExecutorInfo, req *pb.DescribeExecutorsRequest) []*ExecutorInfo {
+	executorIds := stringutil.SimplifyStringList(req.ExecutorId)
+	executorNames := stringutil.SimplifyStringList(req.ExecutorName)
+	status := stringutil.SimplifyStringList(req.Status)
+
+	var filtered []*ExecutorInfo
+	for _, executor := range executors {
+		if len(executorIds) != 0 && !stringutil.StringIn(executor.Name, executorIds) {
+			continue
+		}
+		if len(executorNames) != 0 && !stringutil.StringIn(executor.Name, executorNames) {
+			continue
+		}
+		if len(status) != 0 && !stringutil.StringIn(executor.Status, status) {
+			continue
+		}
+		if req.SearchWord != "" && !strings.Contains(executor.Name, req.SearchWord) {
+			continue
+		}
+		filtered = append(filtered, executor)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		var less bool
+		switch req.SortKey {
+		case "task_count":
+			less = filtered[i].TaskCount < filtered[j].TaskCount
+		case "create_time":
+			less = filtered[i].BootTime.Before(filtered[j].BootTime)
+		case "update_time":
+			less = filtered[i].HeartbeatTime.Before(filtered[j].HeartbeatTime)
+		default:
+			less = filtered[i].Name < filtered[j].Name
+		}
+		if req.Reverse {
+			return !less
+		}
+		return less
+	})
+
+	return filtered
+}
+
+func pageExecutors(executors []*pb.Executor, req *pb.DescribeExecutorsRequest) []*pb.Executor {
+	offset := int(pbutil.GetOffsetFromRequest(req))
+	limit := int(pbutil.GetLimitFromRequest(req))
+
+	if offset >= len(executors) {
+		return nil
+	}
+	if offset+limit > len(executors) {
+		return executors[offset:]
+	}
+	return executors[offset : offset+limit]
+}
@@ -0,0 +1,522 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	nf "kubesphere.io/alert/pkg/client/notification"
+	"kubesphere.io/alert/pkg/client/webhook"
+	"kubesphere.io/alert/pkg/config"
+	"kubesphere.io/alert/pkg/gerr"
+	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/notification"
+	"kubesphere.io/alert/pkg/pb"
+	rs "kubesphere.io/alert/pkg/services/manager/resource_control"
+)
+
+// Events of the alerts received, besides the triggered and resumed histories.
+const (
+	externalEventFiring   = "firing"
+	externalEventCleared  = "cleared"
+	externalEventDisabled = "disabled"
+)
+
+// externalExpireInterval is how often firing external alerts are checked for the end time
+// their sender set.
+const externalExpireInterval = time.Minute
+
+const externalAlertTimeFormat = "2006-01-02 15:04:05.99999"
+
+// externalStatusRetries is how many times the status of an external alert is read and written
+// back when other replicas of the manager change it in the meantime.
+const externalStatusRetries = 3
+
+func getExternalDescription(annotations map[string]string) string {
+	for _, key := range []string{"description", "summary", "message"} {
+		if annotations[key] != "" {
+			return annotations[key]
+		}
+	}
+	return ""
+}
+
+// externalNotification is a notification of an external alert decided on while receiving alerts,
+// it is sent once receiving is done so that slow receivers do not hold up receiving.
+type externalNotification struct {
+	alertId      string
+	ruleId       string
+	resourceName string
+	// forwarded is the alert forwarded to the webhook, firing or resolved
+	forwarded       notification.AlertmanagerAlert
+	webhookUrl      string
+	nfAddressListId string
+	title           string
+	body            string
+	// content is the content of the sent history, resolved alerts write no sent history
+	content string
+}
+
+// ReceiveAlerts records the alerts external systems post in the format Alertmanager receives
+// alerts. Alerts map onto the external alert of their alertname and namespace labels, the rule of
+// their severity label and the resource of their other labels. They write the triggered and
+// resumed histories and are notified of by the policy and action of the alert, as the alerts
+// executors evaluate.
+//
+// Every replica of the manager receives alerts and expires them, the status of an alert is
+// written back only if no other replica changed it since it was read, and read again otherwise.
+func (s *Server) ReceiveAlerts(ctx context.Context, req *pb.ReceiveAlertsRequest) (*pb.ReceiveAlertsResponse, error) {
+	receivedAlerts, err := parseReceivedAlerts(ctx, req.GetAlerts())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	res := &pb.ReceiveAlertsResponse{}
+	var notifications []*externalNotification
+
+	// alerts are created on the first alert received and their status is read and written back
+	s.receiveMutex.Lock()
+	for i := range receivedAlerts {
+		var receivedAlert *pb.ReceivedAlert
+		var n *externalNotification
+		receiveErr := retryExternalStatus(func() error {
+			var err error
+			receivedAlert, n, err = s.receiveAlert(ctx, &receivedAlerts[i], now)
+			return err
+		})
+		if receiveErr == rs.ErrAlertStatusChanged {
+			receiveErr = gerr.NewWithDetail(ctx, gerr.Internal, receiveErr, gerr.ErrorUpdateResourceFailed, receivedAlerts[i].Labels["alertname"])
+		}
+		if receiveErr != nil {
+			err = receiveErr
+			break
+		}
+		res.Alerts = append(res.Alerts, receivedAlert)
+		if n != nil {
+			notifications = append(notifications, n)
+		}
+	}
+	s.receiveMutex.Unlock()
+
+	// notifications decided on before a failure count against the policies already, so they are
+	// sent all the same
+	s.sendExternalNotifications(ctx, notifications)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug(ctx, "Receive Alerts [%d] successfully.", len(res.Alerts))
+	return res, nil
+}
+
+func (s *Server) receiveAlert(ctx context.Context, received *notification.AlertmanagerAlert, now time.Time) (*pb.ReceivedAlert, *externalNotification, error) {
+	labels := received.Labels
+	severity, _ := models.ParseSeverity(labels["severity"])
+
+	alert, err := rs.GetExternalAlert(ctx, labels["alertname"], labels[models.ExternalNamespaceLabel], severity, getExternalDescription(received.Annotations))
+	if err != nil {
+		return nil, nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorCreateResourcesFailed)
+	}
+
+	resourceName := models.ExternalResourceName(labels, notification.AlertmanagerFingerprint(labels))
+	receivedAlert := &pb.ReceivedAlert{
+		AlertId:      alert.AlertId,
+		AlertName:    alert.AlertName,
+		RuleId:       alert.RuleId,
+		ResourceName: resourceName,
+		AlertCreated: alert.AlertCreated,
+		RuleCreated:  alert.RuleCreated,
+	}
+	if alert.Disabled {
+		receivedAlert.Event = externalEventDisabled
+		return receivedAlert, nil, nil
+	}
+
+	alertStatus := rs.StatusAlert{}
+	json.Unmarshal([]byte(alert.AlertStatus), &alertStatus)
+	if alertStatus.ResourceStatus == nil {
+		alertStatus.ResourceStatus = make(map[string]rs.StatusResource)
+	}
+
+	content, _ := json.Marshal(received)
+	histories := []*models.History{}
+	var n *externalNotification
+
+	ruleResourceKey := alert.RuleId + " " + resourceName
+	status, firing := alertStatus.ResourceStatus[ruleResourceKey]
+	if !received.EndsAt.IsZero() && !received.EndsAt.After(now) {
+		if !firing {
+			receivedAlert.Event = externalEventCleared
+			return receivedAlert, nil, nil
+		}
+
+		n = newResolvedExternalNotification(alert.TriggerAction, &status, forwardExternalAlert(alert, received, notification.AlertStatusResolved))
+		delete(alertStatus.ResourceStatus, ruleResourceKey)
+		histories = append(histories, models.NewHistory("", "resumed", string(content), "", alert.AlertId, alert.RuleId, resourceName))
+		receivedAlert.Event = "resumed"
+	} else {
+		policy := models.ParsePolicyConfig(alert.PolicyConfig)[alert.Severity]
+		if !firing {
+			status = rs.StatusResource{
+				CurrentLevel:       alert.Severity,
+				NextResendInterval: policy.RepeatIntervalInitvalue,
+				NextSendableTime:   now,
+				FiringTime:         getExternalStartsAt(received, now),
+			}
+			histories = append(histories, models.NewHistory("", "triggered", string(content), "", alert.AlertId, alert.RuleId, resourceName))
+			receivedAlert.Event = "triggered"
+		} else {
+			receivedAlert.Event = externalEventFiring
+		}
+
+		forwarded := forwardExternalAlert(alert, received, notification.AlertStatusFiring)
+		status.PositiveCount = status.PositiveCount + 1
+		status.Labels = forwarded.Labels
+		status.EndsAt = received.EndsAt
+		pushExternalAlert(&status, received, now)
+
+		n = newExternalNotification(ctx, alert, &status, policy, resourceName, received, forwarded, string(content), now)
+		alertStatus.ResourceStatus[ruleResourceKey] = status
+	}
+
+	alertStatus.UpdateTime = now
+	alertStatusBytes, err := json.Marshal(alertStatus)
+	if err != nil {
+		return nil, nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorUpdateResourceFailed, alert.AlertId)
+	}
+	err = rs.UpdateExternalAlertStatus(ctx, alert.AlertId, alert.AlertStatus, string(alertStatusBytes), histories)
+	if err == rs.ErrAlertStatusChanged {
+		return nil, nil, err
+	}
+	if err != nil {
+		return nil, nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorUpdateResourceFailed, alert.AlertId)
+	}
+
+	return receivedAlert, n, nil
+}
+
+// retryExternalStatus runs update, which reads the status of an external alert and writes it
+// back, again while another replica of the manager changed the status in the meantime.
+func retryExternalStatus(update func() error) error {
+	var err error
+	for i := 0; i < externalStatusRetries; i++ {
+		err = update()
+		if err != rs.ErrAlertStatusChanged {
+			return err
+		}
+	}
+	return err
+}
+
+// getExternalStartsAt returns the time an alert received started firing, alerts without start
+// time start as they are received.
+func getExternalStartsAt(received *notification.AlertmanagerAlert, now time.Time) time.Time {
+	if received.StartsAt.IsZero() {
+		return now
+	}
+	return received.StartsAt
+}
+
+// pushExternalAlert aggregates an alert received into the alerts not notified of yet.
+func pushExternalAlert(status *rs.StatusResource, received *notification.AlertmanagerAlert, now time.Time) {
+	aggregatedAlerts := status.AggregatedAlerts
+
+	aggregatedAlerts.CumulatedCount = aggregatedAlerts.CumulatedCount + 1
+	if aggregatedAlerts.FirstAlertTime == "" {
+		aggregatedAlerts.FirstAlertTime = getExternalStartsAt(received, now).Local().Format(externalAlertTimeFormat)
+	}
+	aggregatedAlerts.LastAlertTime = now.Local().Format(externalAlertTimeFormat)
+
+	status.AggregatedAlerts = aggregatedAlerts
+}
+
+// newExternalNotification decides on notifying of a firing external alert by the notification
+// address list or the webhook of the action of the alert, when the policy allows it at the
+// moment. The notification counts against the policy as it is decided on.
+func newExternalNotification(ctx context.Context, alert *rs.ExternalAlert, status *rs.StatusResource, policy models.RepeatPolicy, resourceName string, received *notification.AlertmanagerAlert, forwarded notification.AlertmanagerAlert, content string, now time.Time) *externalNotification {
+	if !nf.CheckTimeAvailable(alert.AvailableStartTime, alert.AvailableEndTime) {
+		status.NextSendableTime = now
+		return nil
+	}
+
+	if !policy.Sendable(status.RepeatStatus(), now) {
+		return nil
+	}
+
+	actionConfig, err := models.ParseActionConfig(alert.TriggerAction)
+	if err != nil {
+		logger.Error(ctx, "Parse Action config of External Alert [%s] error: %v", alert.AlertId, err)
+		actionConfig = &models.ActionConfig{}
+	}
+	if alert.NfAddressListId == "" && actionConfig.Type == "" {
+		// nowhere to notify until the action is configured
+		return nil
+	}
+
+	n := &externalNotification{
+		alertId:      alert.AlertId,
+		ruleId:       alert.RuleId,
+		resourceName: resourceName,
+		forwarded:    forwarded,
+		content:      content,
+	}
+	if actionConfig.Type == models.ActionTypeAlertmanagerWebhook {
+		n.webhookUrl = actionConfig.Url
+	}
+	if alert.NfAddressListId != "" {
+		n.nfAddressListId = alert.NfAddressListId
+		n.title, n.body = formatExternalNotification(alert, resourceName, status, received)
+	}
+
+	status.SetRepeatStatus(policy.Repeat(status.RepeatStatus()))
+	return n
+}
+
+// newResolvedExternalNotification forwards a resolved alert to the webhook of the action when
+// the webhook got the firing alert.
+func newResolvedExternalNotification(triggerAction string, status *rs.StatusResource, forwarded notification.AlertmanagerAlert) *externalNotification {
+	actionConfig, err := models.ParseActionConfig(triggerAction)
+	if err != nil || actionConfig.Type != models.ActionTypeAlertmanagerWebhook || !status.WebhookSent {
+		return nil
+	}
+	return &externalNotification{
+		webhookUrl: actionConfig.Url,
+		forwarded:  forwarded,
+	}
+}
+
+// sendExternalNotifications sends the notifications decided on while receiving alerts. Firing
+// alerts write the sent histories, and the alerts sent successfully are marked in their status.
+func (s *Server) sendExternalNotifications(ctx context.Context, notifications []*externalNotification) {
+	for _, n := range notifications {
+		webhookSent := n.webhookUrl != "" && sendExternalWebhook(ctx, n.webhookUrl, n.forwarded)
+		if n.forwarded.Status == notification.AlertStatusResolved {
+			continue
+		}
+
+		sentSuccess := n.webhookUrl == "" || webhookSent
+		notificationId := ""
+		if n.nfAddressListId != "" {
+			nfCtx, cancel := context.WithTimeout(ctx, time.Duration(config.GetInstance().App.NotificationTimeout)*time.Millisecond)
+			nfSuccess, id := nf.SendNotification(nfCtx, "other", fmt.Sprintf(`["%s"]`, n.nfAddressListId), n.title, n.body)
+			cancel()
+			sentSuccess = sentSuccess && nfSuccess
+			notificationId = id
+		}
+
+		history := models.NewHistory("", "sent_success", n.content, notificationId, n.alertId, n.ruleId, n.resourceName)
+		if !sentSuccess {
+			logger.Error(ctx, "Notify External Alert [%s] of [%s] failed", n.alertId, n.resourceName)
+			history = models.NewHistory("", "sent_failed", n.content, "", n.alertId, n.ruleId, n.resourceName)
+		}
+		s.markExternalNotificationSent(ctx, n, sentSuccess, webhookSent, history)
+	}
+}
+
+// markExternalNotificationSent writes the sent history of a notification along with the status
+// of its alert, which was read again as alerts were received in the meantime. Alerts sent
+// successfully are aggregated anew, and the webhook having got the firing alert gets the
+// resolved alert as well.
+func (s *Server) markExternalNotificationSent(ctx context.Context, n *externalNotification, sentSuccess bool, webhookSent bool, history *models.History) {
+	s.receiveMutex.Lock()
+	defer s.receiveMutex.Unlock()
+
+	err := retryExternalStatus(func() error {
+		return markExternalNotificationStatus(ctx, n, sentSuccess, webhookSent, history)
+	})
+	if err != nil {
+		logger.Error(ctx, "Update status of External Alert [%s] failed, [%+v]", n.alertId, err)
+	}
+}
+
+func markExternalNotificationStatus(ctx context.Context, n *externalNotification, sentSuccess bool, webhookSent bool, history *models.History) error {
+	statuses, err := rs.GetExternalAlertStatuses(ctx, []string{n.alertId})
+	if err != nil {
+		return err
+	}
+	if len(statuses) == 0 {
+		return fmt.Errorf("external alert [%s] not found", n.alertId)
+	}
+
+	alertStatus := rs.StatusAlert{}
+	json.Unmarshal([]byte(statuses[0].AlertStatus), &alertStatus)
+	ruleResourceKey := n.ruleId + " " + n.resourceName
+	if status, ok := alertStatus.ResourceStatus[ruleResourceKey]; ok {
+		if sentSuccess {
+			status.AggregatedAlerts = rs.AggregatedAlert{}
+		}
+		status.WebhookSent = status.WebhookSent || webhookSent
+		alertStatus.ResourceStatus[ruleResourceKey] = status
+	}
+
+	alertStatusBytes, err := json.Marshal(alertStatus)
+	if err != nil {
+		return err
+	}
+	return rs.UpdateExternalAlertStatus(ctx, n.alertId, statuses[0].AlertStatus, string(alertStatusBytes), []*models.History{history})
+}
+
+// watchExternalAlerts periodically resolves the firing external alerts whose end time passed.
+// Every replica of the manager watches them, an alert is resolved by the one writing its status
+// first.
+func (s *Server) watchExternalAlerts() {
+	ticker := time.NewTicker(externalExpireInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.expireExternalAlerts(context.Background(), time.Now())
+		}
+	}
+}
+
+// expireExternalAlerts resolves the firing external alerts whose end time passed without the
+// alerts being received again, as Alertmanager resolves the alerts their sender stopped sending.
+func (s *Server) expireExternalAlerts(ctx context.Context, now time.Time) {
+	var notifications []*externalNotification
+
+	s.receiveMutex.Lock()
+	statuses, err := rs.GetExternalAlertStatuses(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, "Get statuses of External Alerts failed, [%+v]", err)
+	}
+	for _, externalStatus := range statuses {
+		alertStatus := rs.StatusAlert{}
+		json.Unmarshal([]byte(externalStatus.AlertStatus), &alertStatus)
+
+		histories, expired := expireExternalStatus(externalStatus, &alertStatus, now)
+		if len(histories) == 0 {
+			continue
+		}
+
+		alertStatus.UpdateTime = now
+		alertStatusBytes, err := json.Marshal(alertStatus)
+		if err != nil {
+			logger.Error(ctx, "Marshal status of External Alert [%s] failed, [%+v]", externalStatus.AlertId, err)
+			continue
+		}
+		//another replica expired or received the alert meanwhile, the next check sees its status
+		err = rs.UpdateExternalAlertStatus(ctx, externalStatus.AlertId, externalStatus.AlertStatus, string(alertStatusBytes), histories)
+		if err == rs.ErrAlertStatusChanged {
+			logger.Debug(ctx, "Status of External Alert [%s] changed while expiring it.", externalStatus.AlertId)
+			continue
+		}
+		if err != nil {
+			logger.Error(ctx, "Expire External Alert [%s] failed, [%+v]", externalStatus.AlertId, err)
+			continue
+		}
+		logger.Info(ctx, "Expired External Alert [%s] of [%d] resources.", externalStatus.AlertId, len(histories))
+		notifications = append(notifications, expired...)
+	}
+	s.receiveMutex.Unlock()
+
+	s.sendExternalNotifications(ctx, notifications)
+}
+
+// expireExternalStatus removes the resources whose end time passed from the status of an
+// external alert, and returns their resumed histories and resolved notifications.
+func expireExternalStatus(externalStatus *rs.ExternalAlertStatus, alertStatus *rs.StatusAlert, now time.Time) ([]*models.History, []*externalNotification) {
+	var ruleResourceKeys []string
+	for ruleResourceKey, status := range alertStatus.ResourceStatus {
+		if !status.EndsAt.IsZero() && !status.EndsAt.After(now) {
+			ruleResourceKeys = append(ruleResourceKeys, ruleResourceKey)
+		}
+	}
+	sort.Strings(ruleResourceKeys)
+
+	var histories []*models.History
+	var notifications []*externalNotification
+	for _, ruleResourceKey := range ruleResourceKeys {
+		status := alertStatus.ResourceStatus[ruleResourceKey]
+		delete(alertStatus.ResourceStatus, ruleResourceKey)
+
+		forwarded := notification.AlertmanagerAlert{
+			Status:      notification.AlertStatusResolved,
+			Labels:      status.Labels,
+			StartsAt:    status.FiringTime,
+			EndsAt:      status.EndsAt,
+			Fingerprint: notification.AlertmanagerFingerprint(status.Labels),
+		}
+		content, _ := json.Marshal(forwarded)
+
+		keys := strings.SplitN(ruleResourceKey, " ", 2)
+		histories = append(histories, models.NewHistory("", "resumed", string(content), "", externalStatus.AlertId, keys[0], keys[len(keys)-1]))
+		if n := newResolvedExternalNotification(externalStatus.TriggerAction, &status, forwarded); n != nil {
+			notifications = append(notifications, n)
+		}
+	}
+
+	return histories, notifications
+}
+
+func formatExternalNotification(alert *rs.ExternalAlert, resourceName string, status *rs.StatusResource, received *notification.AlertmanagerAlert) (string, string) {
+	title := received.Annotations["summary"]
+	if title == "" {
+		title = fmt.Sprintf("Alert %s is firing on %s", alert.AlertName, resourceName)
+	}
+
+	aggregatedAlerts := status.AggregatedAlerts
+	content := fmt.Sprintf("Alert: %s\nSeverity: %s\nResource: %s\nReceived: %d times from %s to %s\n",
+		alert.AlertName, alert.Severity, resourceName, aggregatedAlerts.CumulatedCount, aggregatedAlerts.FirstAlertTime, aggregatedAlerts.LastAlertTime)
+	if description := getExternalDescription(received.Annotations); description != "" && description != title {
+		content += description + "\n"
+	}
+	if received.GeneratorURL != "" {
+		content += received.GeneratorURL + "\n"
+	}
+
+	return title, content
+}
+
+// forwardExternalAlert labels an alert received with the alert and rule it maps onto, as it is
+// forwarded to webhooks with status.
+func forwardExternalAlert(alert *rs.ExternalAlert, received *notification.AlertmanagerAlert, status string) notification.AlertmanagerAlert {
+	labels := make(map[string]string)
+	for k, v := range received.Labels {
+		labels[k] = v
+	}
+	labels["alert_id"] = alert.AlertId
+	labels["rule_id"] = alert.RuleId
+	labels["rule_name"] = alert.RuleName
+
+	forwarded := *received
+	forwarded.Status = status
+	forwarded.Labels = labels
+	forwarded.Fingerprint = notification.AlertmanagerFingerprint(labels)
+	return forwarded
+}
+
+// sendExternalWebhook sends an alert forwarded to the Alertmanager webhook receiver url.
+func sendExternalWebhook(ctx context.Context, url string, forwarded notification.AlertmanagerAlert) bool {
+	alertId := forwarded.Labels["alert_id"]
+	message := &notification.AlertmanagerMessage{
+		Version:           notification.AlertmanagerWebhookVersion,
+		GroupKey:          fmt.Sprintf(`{}:{alert_id="%s"}`, alertId),
+		Status:            forwarded.Status,
+		Receiver:          forwarded.Labels["alertname"],
+		GroupLabels:       map[string]string{"alert_id": alertId},
+		CommonLabels:      forwarded.Labels,
+		CommonAnnotations: forwarded.Annotations,
+		Alerts:            []notification.AlertmanagerAlert{forwarded},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(config.GetInstance().App.NotificationTimeout)*time.Millisecond)
+	defer cancel()
+
+	err := webhook.SendAlertmanagerMessage(ctx, url, message)
+	if err != nil {
+		logger.Error(ctx, "Send %s webhook of External Alert [%s] failed: %v", forwarded.Status, alertId, err)
+		return false
+	}
+	return true
+}
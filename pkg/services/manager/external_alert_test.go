@@ -0,0 +1,81 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/notification"
+	rs "kubesphere.io/alert/pkg/services/manager/resource_control"
+)
+
+const testWebhookAction = `{"type":"alertmanager_webhook","url":"http://receiver:9093"}`
+
+func TestForwardExternalAlert(t *testing.T) {
+	alert := &rs.ExternalAlert{AlertId: "al-1", AlertName: "HighLatency", RuleId: "rl-1", RuleName: "HighLatency-major"}
+	received := &notification.AlertmanagerAlert{Labels: map[string]string{"alertname": "HighLatency", "instance": "node1"}}
+
+	forwarded := forwardExternalAlert(alert, received, notification.AlertStatusFiring)
+	require.Equal(t, notification.AlertStatusFiring, forwarded.Status)
+	require.Equal(t, map[string]string{
+		"alertname": "HighLatency",
+		"instance":  "node1",
+		"alert_id":  "al-1",
+		"rule_id":   "rl-1",
+		"rule_name": "HighLatency-major",
+	}, forwarded.Labels)
+	require.Equal(t, notification.AlertmanagerFingerprint(forwarded.Labels), forwarded.Fingerprint)
+	// the alert received is left as it is
+	require.Len(t, received.Labels, 2)
+}
+
+func TestNewResolvedExternalNotification(t *testing.T) {
+	forwarded := notification.AlertmanagerAlert{Status: notification.AlertStatusResolved}
+
+	n := newResolvedExternalNotification(testWebhookAction, &rs.StatusResource{WebhookSent: true}, forwarded)
+	require.NotNil(t, n)
+	require.Equal(t, "http://receiver:9093", n.webhookUrl)
+
+	// webhooks which did not get the firing alert do not get the resolved alert
+	require.Nil(t, newResolvedExternalNotification(testWebhookAction, &rs.StatusResource{CumulatedSendCount: 1}, forwarded))
+	require.Nil(t, newResolvedExternalNotification("", &rs.StatusResource{WebhookSent: true}, forwarded))
+}
+
+func TestExpireExternalStatus(t *testing.T) {
+	now := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	labels := map[string]string{"alertname": "HighLatency", "alert_id": "al-1", "instance": "node1"}
+	alertStatus := &rs.StatusAlert{
+		ResourceStatus: map[string]rs.StatusResource{
+			"rl-1 instance=node1": {CurrentLevel: "major", Labels: labels, FiringTime: now.Add(-time.Hour), EndsAt: now.Add(-time.Minute), WebhookSent: true},
+			"rl-1 instance=node2": {CurrentLevel: "major", EndsAt: now},
+			"rl-1 instance=node3": {CurrentLevel: "major", EndsAt: now.Add(time.Minute)},
+			"rl-1 instance=node4": {CurrentLevel: "major"},
+		},
+	}
+
+	histories, notifications := expireExternalStatus(&rs.ExternalAlertStatus{AlertId: "al-1", TriggerAction: testWebhookAction}, alertStatus, now)
+
+	// alerts resolve once their end time passes, alerts without end time stay firing
+	require.Len(t, alertStatus.ResourceStatus, 2)
+	require.Contains(t, alertStatus.ResourceStatus, "rl-1 instance=node3")
+	require.Contains(t, alertStatus.ResourceStatus, "rl-1 instance=node4")
+
+	require.Len(t, histories, 2)
+	require.Equal(t, "resumed", histories[0].Event)
+	require.Equal(t, "rl-1", histories[0].RuleId)
+	require.Equal(t, "instance=node1", histories[0].ResourceName)
+	require.Equal(t, "instance=node2", histories[1].ResourceName)
+
+	// only the webhook which got the firing alert gets the resolved alert
+	require.Len(t, notifications, 1)
+	forwarded := notifications[0].forwarded
+	require.Equal(t, notification.AlertStatusResolved, forwarded.Status)
+	require.Equal(t, labels, forwarded.Labels)
+	require.Equal(t, now.Add(-time.Hour), forwarded.StartsAt)
+	require.Equal(t, now.Add(-time.Minute), forwarded.EndsAt)
+}
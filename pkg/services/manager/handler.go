@@ -8,6 +8,7 @@ import (
 	"kubesphere.io/alert/pkg/models"
 	. "kubesphere.io/alert/pkg/pb"
 	rs "kubesphere.io/alert/pkg/services/manager/resource_control"
+	"kubesphere.io/alert/pkg/util/pbutil"
 	"kubesphere.io/alert/pkg/util/stringutil"
 )
 
@@ -29,16 +30,25 @@ func (s *Server) createAlert(ctx context.Context, req *CreateAlertRequest) (stri
 	logger.Debug(ctx, "Manager create Alert[%s][%s] in DB successfully.", alert.AlertId, req.GetAlertName())
 
 	// Enqueue alert after create tasks.
-	err = s.alertQueue.Enqueue(alert.AlertId)
+	err = s.enqueueAlert(ctx, alert.AlertId)
 	if err != nil {
-		logger.Error(ctx, "Manager push alert[%s] into etcd failed, [%+v].", alert.AlertId, err)
 		return alert.AlertId, err
 	}
-	logger.Debug(ctx, "Manager push alert[%s] into etcd successfully.", alert.AlertId)
 
 	return alert.AlertId, nil
 }
 
+func (s *Server) enqueueAlert(ctx context.Context, alertId string) error {
+	err := s.alertQueue.Enqueue(alertId)
+	if err != nil {
+		logger.Error(ctx, "Manager push alert[%s] into etcd failed, [%+v].", alertId, err)
+		return err
+	}
+	logger.Debug(ctx, "Manager push alert[%s] into etcd successfully.", alertId)
+
+	return nil
+}
+
 func (s *Server) operateAlert(ctx context.Context, req *ModifyAlertRequest, alertId string, operation string) (string, error) {
 	alertId, err := rs.UpdateAlert(ctx, req, alertId, operation)
 	if err != nil {
@@ -47,7 +57,7 @@ func (s *Server) operateAlert(ctx context.Context, req *ModifyAlertRequest, aler
 	}
 
 	// Broadcast alert operation after update DB.
-	err = s.alertBroadcast.Broadcast(alertId, operation, 10)
+	err = s.alertBroadcast.Broadcast(ctx, alertId, operation, 10)
 	if err != nil {
 		logger.Error(ctx, "Manager broadast alert %s[%s] into etcd failed, [%+v].", operation, alertId, err)
 		return "", err
@@ -59,20 +69,122 @@ func (s *Server) operateAlert(ctx context.Context, req *ModifyAlertRequest, aler
 
 //0.Executor
 //********************************************************************************************************
-func (s *Server) CreateExecutor(context.Context, *CreateExecutorRequest) (*CreateExecutorResponse, error) {
-	return &CreateExecutorResponse{}, nil
+func (s *Server) CreateExecutor(ctx context.Context, req *CreateExecutorRequest) (*CreateExecutorResponse, error) {
+	// Executors register themselves in etcd on boot, they can not be created from API.
+	logger.Error(ctx, "Create Executor[%s] is not supported, executors register themselves.", req.GetExecutorName())
+	return nil, gerr.New(ctx, gerr.Unimplemented, gerr.ErrorUnsupportedOperation, "create executor")
 }
 
-func (s *Server) DescribeExecutors(context.Context, *DescribeExecutorsRequest) (*DescribeExecutorsResponse, error) {
-	return &DescribeExecutorsResponse{}, nil
+func (s *Server) DescribeExecutors(ctx context.Context, req *DescribeExecutorsRequest) (*DescribeExecutorsResponse, error) {
+	executors, err := s.executorRegistry.GetExecutors(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to Describe Executors, [%+v], [%+v].", req, err)
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorDescribeResourcesFailed)
+	}
+
+	executors = filterExecutors(executors, req)
+
+	executorIds := []string{}
+	for _, executor := range executors {
+		executorIds = append(executorIds, executor.Name)
+	}
+
+	alertIds, err := rs.GetAlertIdsByExecutorIds(ctx, executorIds)
+	if err != nil {
+		logger.Error(ctx, "Failed to Describe Executors alerts, [%+v], [%+v].", req, err)
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorDescribeResourcesFailed)
+	}
+
+	var exPbSet []*Executor
+	for _, executor := range executors {
+		exPbSet = append(exPbSet, &Executor{
+			ExecutorId:   executor.Name,
+			ExecutorName: executor.Name,
+			Status:       executor.Status,
+			CreateTime:   pbutil.ToProtoTimestamp(executor.BootTime),
+			UpdateTime:   pbutil.ToProtoTimestamp(executor.HeartbeatTime),
+			TaskCount:    uint32(executor.TaskCount),
+			AlertId:      alertIds[executor.Name],
+		})
+	}
+
+	res := &DescribeExecutorsResponse{
+		Total:       uint32(len(exPbSet)),
+		ExecutorSet: pageExecutors(exPbSet, req),
+	}
+
+	logger.Debug(ctx, "Describe Executors successfully, Executors=[%+v].", res)
+	return res, nil
 }
 
-func (s *Server) ModifyExecutor(context.Context, *ModifyExecutorRequest) (*ModifyExecutorResponse, error) {
-	return &ModifyExecutorResponse{}, nil
+func (s *Server) ModifyExecutor(ctx context.Context, req *ModifyExecutorRequest) (*ModifyExecutorResponse, error) {
+	err := ValidateModifyExecutorParams(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	executorId := req.GetExecutorId()
+	executor, err := s.executorRegistry.GetExecutor(ctx, executorId)
+	if err != nil {
+		logger.Error(ctx, "Failed to Modify Executor[%s], [%+v].", executorId, err)
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorUpdateResourceFailed, executorId)
+	}
+	if executor == nil {
+		logger.Error(ctx, "Failed to Modify Executor[%s], executor is not alive.", executorId)
+		return nil, gerr.New(ctx, gerr.NotFound, gerr.ErrorResourceNotFound, executorId)
+	}
+
+	switch req.GetStatus() {
+	case ExecutorStatusRunning:
+		err = s.executorRegistry.Uncordon(ctx, executorId)
+	case ExecutorStatusCordoned:
+		err = s.executorRegistry.Cordon(ctx, executorId)
+	case ExecutorStatusEvicting:
+		err = s.executorRegistry.Evict(ctx, executorId)
+	}
+	if err != nil {
+		logger.Error(ctx, "Failed to Modify Executor[%s] to [%s], [%+v].", executorId, req.GetStatus(), err)
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorUpdateResourceFailed, executorId)
+	}
+
+	logger.Debug(ctx, "Modify Executor[%s] to [%s] successfully.", executorId, req.GetStatus())
+	return &ModifyExecutorResponse{
+		ExecutorId: executorId,
+	}, nil
 }
 
-func (s *Server) DeleteExecutors(context.Context, *DeleteExecutorsRequest) (*DeleteExecutorsResponse, error) {
-	return &DeleteExecutorsResponse{}, nil
+func (s *Server) DeleteExecutors(ctx context.Context, req *DeleteExecutorsRequest) (*DeleteExecutorsResponse, error) {
+	executorIds := stringutil.SimplifyStringList(req.ExecutorId)
+	executors, err := s.executorRegistry.GetExecutors(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to Delete Executors[%+v], [%+v].", executorIds, err)
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorDescribeResourcesFailed)
+	}
+	alive := make(map[string]bool)
+	for _, executor := range executors {
+		alive[executor.Name] = true
+	}
+	for _, executorId := range executorIds {
+		if !alive[executorId] {
+			logger.Error(ctx, "Failed to Delete Executor[%s], executor is not alive.", executorId)
+			return nil, gerr.New(ctx, gerr.NotFound, gerr.ErrorResourceNotFound, executorId)
+		}
+	}
+
+	executorIdsSuccess := []string{}
+	for _, executorId := range executorIds {
+		err := s.executorRegistry.Evict(ctx, executorId)
+		if err != nil {
+			logger.Error(ctx, "Failed to Delete Executor[%s], [%+v].", executorId, err)
+		} else {
+			executorIdsSuccess = append(executorIdsSuccess, executorId)
+		}
+	}
+
+	logger.Debug(ctx, "Delete Executors[%+v] successfully.", executorIdsSuccess)
+	return &DeleteExecutorsResponse{
+		ExecutorId: executorIdsSuccess,
+	}, nil
 }
 
 //1.ResourceType
@@ -371,6 +483,7 @@ func (s *Server) CreateRule(ctx context.Context, req *CreateRuleRequest) (*Creat
 		req.GetInhibit(),
 		req.GetPolicyId(),
 		req.GetMetricId(),
+		req.GetEvaluationInterval(),
 	)
 
 	err = rs.CreateRule(ctx, rule)
@@ -482,6 +595,17 @@ func (s *Server) DeleteAlerts(ctx context.Context, req *DeleteAlertsRequest) (*D
 	alertIds := stringutil.SimplifyStringList(req.AlertId)
 	alertIdsSuccess := []string{}
 	for _, alertId := range alertIds {
+		// external alerts have no executor to delete them
+		deleted, err := rs.DeleteExternalAlert(ctx, alertId)
+		if err != nil {
+			logger.Error(ctx, "Failed to Delete Alert[%s], [%+v].", alertId, err)
+			continue
+		}
+		if deleted {
+			alertIdsSuccess = append(alertIdsSuccess, alertId)
+			continue
+		}
+
 		alertIdSuccess, err := s.operateAlert(ctx, nil, alertId, "deleting")
 		if err != nil {
 			logger.Error(ctx, "Failed to Delete Alert[%s], [%+v].", alertId, err)
@@ -553,10 +677,19 @@ func (s *Server) CreateComment(ctx context.Context, req *CreateCommentRequest) (
 	}
 	logger.Debug(ctx, "Create Comment[%s] in DB successfully.", comment.CommentId)
 
+	// External alerts have no executor to record the comment.
+	if alert.RunningStatus == models.RunningStatusExternal {
+		err = rs.CreateHistory(ctx, models.NewHistory("", "commented", req.GetHistoryId(), "", alert.AlertId, "", ""))
+		if err != nil {
+			return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorCreateResourcesFailed)
+		}
+		return &CreateCommentResponse{CommentId: comment.CommentId}, nil
+	}
+
 	// Broadcast alert comment after update DB.
 	alertId := alert.AlertId
 	operation := "commenting " + req.GetHistoryId()
-	err = s.alertBroadcast.Broadcast(alertId, operation, 10)
+	err = s.alertBroadcast.Broadcast(ctx, alertId, operation, 10)
 	if err != nil {
 		logger.Error(ctx, "Manager broadast alert %s[%s] into etcd failed, [%+v].", operation, alertId, err)
 		return nil, gerr.NewWithDetail(ctx, gerr.Internal, nil, gerr.ErrorCreateResourcesFailed)
@@ -659,3 +792,26 @@ func (s *Server) DeleteActions(ctx context.Context, req *DeleteActionsRequest) (
 		ActionId: actionIds,
 	}, nil
 }
+
+//10.Audit
+//********************************************************************************************************
+func (s *Server) DescribeAuditLogs(ctx context.Context, req *DescribeAuditLogsRequest) (*DescribeAuditLogsResponse, error) {
+	err := ValidateDescribeAuditLogsParams(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ads, adCnt, err := rs.DescribeAuditLogs(ctx, req)
+	if err != nil {
+		logger.Error(ctx, "Failed to Describe Audit Logs, [%+v], [%+v].", req, err)
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorDescribeResourcesFailed)
+	}
+	adPbSet := models.ParseAdSet2PbSet(ads)
+	res := &DescribeAuditLogsResponse{
+		Total:    uint32(adCnt),
+		AuditSet: adPbSet,
+	}
+
+	logger.Debug(ctx, "Describe Audit Logs successfully, Audit Logs=[%+v].", res)
+	return res, nil
+}
@@ -3,6 +3,7 @@ package manager
 import (
 	"context"
 
+	nf "kubesphere.io/alert/pkg/client/notification"
 	"kubesphere.io/alert/pkg/gerr"
 	"kubesphere.io/alert/pkg/logger"
 	"kubesphere.io/alert/pkg/models"
@@ -77,3 +78,555 @@ func (s *Server) DescribeHistoryDetail(ctx context.Context, req *DescribeHistory
 	logger.Debug(ctx, "Describe History Detail successfully, Histories=[%+v].", res)
 	return res, nil
 }
+
+//2.Alert Bundle
+//********************************************************************************************************
+// checkAlertBundleResourceType checks the resource filter of an alert bundle is of the type of
+// resources searched.
+func checkAlertBundleResourceType(ctx context.Context, resourceSearch string, rsFilter *CreateResourceFilterRequest) error {
+	resourceMap, _ := checkResourceSearch(ctx, resourceSearch, rsFilter.GetRsFilterParam())
+
+	rsTypeId := rsFilter.GetRsTypeId()
+	rsTypeName, err := rs.GetResourceTypeName(ctx, rsTypeId)
+	if err != nil {
+		return gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorDescribeResourcesFailed)
+	}
+	if rsTypeName == "" {
+		return gerr.New(ctx, gerr.NotFound, gerr.ErrorResourceNotFound, rsTypeId)
+	}
+	if rsTypeName != resourceMap["rs_type_name"] {
+		return gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorUnsupportedParameterValue, "rs_type_id", rsTypeId)
+	}
+
+	return nil
+}
+
+func newAlertBundle(rsFilter *CreateResourceFilterRequest, policy *CreatePolicyRequest, action *CreateActionRequest, rules []*CreateRuleRequest, alert *CreateAlertRequest) *rs.AlertBundle {
+	bundle := &rs.AlertBundle{
+		RsFilter: models.NewResourceFilter(
+			rsFilter.GetRsFilterName(),
+			rsFilter.GetRsFilterParam(),
+			rsFilter.GetRsTypeId(),
+		),
+		Policy: models.NewPolicy(
+			policy.GetPolicyName(),
+			policy.GetPolicyDescription(),
+			policy.GetPolicyConfig(),
+			policy.GetCreator(),
+			policy.GetAvailableStartTime(),
+			policy.GetAvailableEndTime(),
+			rsFilter.GetRsTypeId(),
+		),
+		Alert: models.NewAlert(
+			alert.GetAlertName(),
+			alert.GetDisabled(),
+			"adding",
+			"",
+			"",
+			"",
+		),
+	}
+	bundle.Action = models.NewAction(
+		action.GetActionName(),
+		action.GetTriggerStatus(),
+		action.GetTriggerAction(),
+		bundle.Policy.PolicyId,
+		action.GetNfAddressListId(),
+	)
+	for _, rule := range rules {
+		bundle.Rules = append(bundle.Rules, models.NewRule(
+			rule.GetRuleName(),
+			rule.GetDisabled(),
+			rule.GetMonitorPeriods(),
+			rule.GetSeverity(),
+			rule.GetMetricsType(),
+			rule.GetConditionType(),
+			rule.GetThresholds(),
+			rule.GetUnit(),
+			rule.GetConsecutiveCount(),
+			rule.GetInhibit(),
+			bundle.Policy.PolicyId,
+			rule.GetMetricId(),
+			rule.GetEvaluationInterval(),
+		))
+	}
+
+	return bundle
+}
+
+func getAlertBundleRuleIds(bundle *rs.AlertBundle) []string {
+	ruleIds := []string{}
+	for _, rule := range bundle.Rules {
+		ruleIds = append(ruleIds, rule.RuleId)
+	}
+	return ruleIds
+}
+
+func (s *Server) CreateAlertBundle(ctx context.Context, req *CreateAlertBundleRequest) (*CreateAlertBundleResponse, error) {
+	err := ValidateCreateAlertBundleParams(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkAlertBundleResourceType(ctx, req.GetResourceSearch(), req.GetRsFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := newAlertBundle(req.GetRsFilter(), req.GetPolicy(), req.GetAction(), req.GetRules(), req.GetAlert())
+
+	err = rs.CreateAlertBundle(ctx, req.GetResourceSearch(), bundle)
+	if err == rs.ErrAlertNameExists {
+		return nil, gerr.New(ctx, gerr.AlreadyExists, gerr.ErrorResourceAlreadyExists, bundle.Alert.AlertName)
+	}
+	if err != nil {
+		logger.Error(ctx, "Failed to Create Alert Bundle, [%+v], [%+v].", req, err)
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorCreateResourcesFailed)
+	}
+	logger.Debug(ctx, "Create Alert Bundle[%s][%s] in DB successfully.", bundle.Alert.AlertId, bundle.Alert.AlertName)
+
+	// Enqueue alert only after all of it is committed.
+	err = s.enqueueAlert(ctx, bundle.Alert.AlertId)
+	if err != nil {
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorCreateResourcesFailed)
+	}
+
+	return &CreateAlertBundleResponse{
+		AlertId:    bundle.Alert.AlertId,
+		RsFilterId: bundle.RsFilter.RsFilterId,
+		PolicyId:   bundle.Policy.PolicyId,
+		ActionId:   bundle.Action.ActionId,
+		RuleId:     getAlertBundleRuleIds(bundle),
+	}, nil
+}
+
+func (s *Server) UpdateAlertBundle(ctx context.Context, req *UpdateAlertBundleRequest) (*UpdateAlertBundleResponse, error) {
+	err := ValidateUpdateAlertBundleParams(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkAlertBundleResourceType(ctx, req.GetResourceSearch(), req.GetRsFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []*CreateRuleRequest{}
+	for _, rule := range req.GetRules() {
+		rules = append(rules, rule.GetRule())
+	}
+	bundle := newAlertBundle(req.GetRsFilter(), req.GetPolicy(), req.GetAction(), rules, req.GetAlert())
+	// rules without an id are added
+	for i, rule := range req.GetRules() {
+		bundle.Rules[i].RuleId = rule.GetRuleId()
+	}
+
+	alertId := req.GetAlertId()
+	err = rs.UpdateAlertBundle(ctx, req.GetResourceSearch(), alertId, bundle)
+	switch err {
+	case nil:
+	case rs.ErrAlertNotFound:
+		return nil, gerr.New(ctx, gerr.NotFound, gerr.ErrorResourceNotFound, alertId)
+	case rs.ErrRuleNotFound:
+		return nil, gerr.New(ctx, gerr.NotFound, gerr.ErrorResourceNotFound, "rule_id")
+	case rs.ErrAlertNameExists:
+		return nil, gerr.New(ctx, gerr.AlreadyExists, gerr.ErrorResourceAlreadyExists, bundle.Alert.AlertName)
+	default:
+		logger.Error(ctx, "Failed to Update Alert Bundle, [%+v], [%+v].", req, err)
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorUpdateResourceFailed, alertId)
+	}
+	logger.Debug(ctx, "Update Alert Bundle[%s][%s] in DB successfully.", alertId, bundle.Alert.AlertName)
+
+	// Broadcast once after all of it is committed, the runner reloads the whole alert.
+	err = s.alertBroadcast.Broadcast(ctx, alertId, "updating", 10)
+	if err != nil {
+		logger.Error(ctx, "Manager broadast alert updating[%s] into etcd failed, [%+v].", alertId, err)
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorUpdateResourceFailed, alertId)
+	}
+	logger.Debug(ctx, "Manager broadast alert updating[%s] into etcd successfully.", alertId)
+
+	return &UpdateAlertBundleResponse{
+		AlertId:    alertId,
+		RsFilterId: bundle.RsFilter.RsFilterId,
+		PolicyId:   bundle.Policy.PolicyId,
+		ActionId:   bundle.Action.ActionId,
+		RuleId:     getAlertBundleRuleIds(bundle),
+	}, nil
+}
+
+//3.Alert Document
+//********************************************************************************************************
+// newAlertDefinition describes an alert bundle in an alert document, metricNames maps the ids of
+// the metrics of rsTypeName to their names and addressListNames those of the address lists.
+func newAlertDefinition(bundle *rs.AlertBundle, rsTypeName string, metricNames map[string]string, addressListNames map[string]string) models.AlertDefinition {
+	definition := models.AlertDefinition{
+		AlertName: bundle.Alert.AlertName,
+		Disabled:  bundle.Alert.Disabled,
+		ResourceFilter: models.ResourceFilterDefinition{
+			RsFilterName:  bundle.RsFilter.RsFilterName,
+			RsFilterParam: bundle.RsFilter.RsFilterParam,
+			RsTypeName:    rsTypeName,
+		},
+		Policy: models.PolicyDefinition{
+			PolicyName:         bundle.Policy.PolicyName,
+			PolicyDescription:  bundle.Policy.PolicyDescription,
+			PolicyConfig:       bundle.Policy.PolicyConfig,
+			Creator:            bundle.Policy.Creator,
+			AvailableStartTime: bundle.Policy.AvailableStartTime,
+			AvailableEndTime:   bundle.Policy.AvailableEndTime,
+		},
+		Action: models.ActionDefinition{
+			ActionName:        bundle.Action.ActionName,
+			TriggerStatus:     bundle.Action.TriggerStatus,
+			TriggerAction:     bundle.Action.TriggerAction,
+			NfAddressListName: addressListNames[bundle.Action.NfAddressListId],
+		},
+	}
+	for _, rule := range bundle.Rules {
+		definition.Rules = append(definition.Rules, models.RuleDefinition{
+			RuleName:           rule.RuleName,
+			Disabled:           rule.Disabled,
+			MetricName:         metricNames[rule.MetricId],
+			MonitorPeriods:     rule.MonitorPeriods,
+			EvaluationInterval: rule.EvaluationInterval,
+			Severity:           rule.Severity,
+			MetricsType:        rule.MetricsType,
+			ConditionType:      rule.ConditionType,
+			Thresholds:         rule.Thresholds,
+			Unit:               rule.Unit,
+			ConsecutiveCount:   rule.ConsecutiveCount,
+			Inhibit:            rule.Inhibit,
+		})
+	}
+
+	return definition
+}
+
+// newAlertDefinitionRequest describes an alert of an alert document as the request creating it,
+// metricIds maps the names of the metrics of the resource type rsTypeId to their ids and
+// addressListIds those of the address lists.
+func newAlertDefinitionRequest(ctx context.Context, resourceSearch string, definition *models.AlertDefinition, rsTypeName string, rsTypeId string, metricIds map[string]string, addressListIds map[string]string) (*CreateAlertBundleRequest, error) {
+	if definition.ResourceFilter.RsTypeName != rsTypeName {
+		return nil, gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorUnsupportedParameterValue, "rs_type_name", definition.ResourceFilter.RsTypeName)
+	}
+
+	addressListName := definition.Action.NfAddressListName
+	addressListId, ok := addressListIds[addressListName]
+	if addressListName != "" && (!ok || addressListId == "") {
+		return nil, gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorUnsupportedParameterValue, "nf_address_list_name", addressListName)
+	}
+
+	req := &CreateAlertBundleRequest{
+		ResourceSearch: resourceSearch,
+		RsFilter: &CreateResourceFilterRequest{
+			RsFilterName:  definition.ResourceFilter.RsFilterName,
+			RsFilterParam: definition.ResourceFilter.RsFilterParam,
+			RsTypeId:      rsTypeId,
+		},
+		Policy: &CreatePolicyRequest{
+			PolicyName:         definition.Policy.PolicyName,
+			PolicyDescription:  definition.Policy.PolicyDescription,
+			PolicyConfig:       definition.Policy.PolicyConfig,
+			Creator:            definition.Policy.Creator,
+			AvailableStartTime: definition.Policy.AvailableStartTime,
+			AvailableEndTime:   definition.Policy.AvailableEndTime,
+			RsTypeId:           rsTypeId,
+		},
+		Action: &CreateActionRequest{
+			ActionName:      definition.Action.ActionName,
+			TriggerStatus:   definition.Action.TriggerStatus,
+			TriggerAction:   definition.Action.TriggerAction,
+			NfAddressListId: addressListId,
+		},
+		Alert: &CreateAlertRequest{
+			AlertName: definition.AlertName,
+			Disabled:  definition.Disabled,
+		},
+	}
+	for _, rule := range definition.Rules {
+		metricId, ok := metricIds[rule.MetricName]
+		if !ok {
+			return nil, gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorUnsupportedParameterValue, "metric_name", rule.MetricName)
+		}
+		req.Rules = append(req.Rules, &CreateRuleRequest{
+			RuleName:           rule.RuleName,
+			Disabled:           rule.Disabled,
+			MonitorPeriods:     rule.MonitorPeriods,
+			Severity:           rule.Severity,
+			MetricsType:        rule.MetricsType,
+			ConditionType:      rule.ConditionType,
+			Thresholds:         rule.Thresholds,
+			Unit:               rule.Unit,
+			ConsecutiveCount:   rule.ConsecutiveCount,
+			Inhibit:            rule.Inhibit,
+			MetricId:           metricId,
+			EvaluationInterval: rule.EvaluationInterval,
+		})
+	}
+
+	return req, nil
+}
+
+// describeAlertDocumentMetrics returns the id of the resource type searched and the names of its
+// metrics by id.
+func describeAlertDocumentMetrics(ctx context.Context, rsTypeName string) (string, map[string]string, error) {
+	rsTypeId, err := rs.GetResourceTypeId(ctx, rsTypeName)
+	if err != nil {
+		return "", nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorDescribeResourcesFailed)
+	}
+	if rsTypeId == "" {
+		return "", nil, gerr.New(ctx, gerr.NotFound, gerr.ErrorResourceNotFound, rsTypeName)
+	}
+
+	metricNames, err := rs.DescribeMetricNames(ctx, rsTypeId)
+	if err != nil {
+		return "", nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorDescribeResourcesFailed)
+	}
+
+	return rsTypeId, metricNames, nil
+}
+
+// describeAddressListNames returns the names of the notification address lists of bundles by id.
+func describeAddressListNames(ctx context.Context, bundles []*rs.AlertBundle) (map[string]string, error) {
+	addressListIds := []string{}
+	seen := map[string]bool{}
+	for _, bundle := range bundles {
+		addressListId := bundle.Action.NfAddressListId
+		if addressListId != "" && !seen[addressListId] {
+			seen[addressListId] = true
+			addressListIds = append(addressListIds, addressListId)
+		}
+	}
+	if len(addressListIds) == 0 {
+		return map[string]string{}, nil
+	}
+
+	addressListNames, err := nf.DescribeAddressListNames(ctx, addressListIds, nil)
+	if err != nil {
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorDescribeResourcesFailed)
+	}
+	for _, addressListId := range addressListIds {
+		if _, ok := addressListNames[addressListId]; !ok {
+			logger.Warn(ctx, "Address list [%s] of alert document not found in notification.", addressListId)
+		}
+	}
+
+	return addressListNames, nil
+}
+
+// describeAddressListIds returns the ids of the notification address lists document refers to by
+// name. Names shared by several address lists map to no id, as they can not tell them apart.
+func describeAddressListIds(ctx context.Context, document *models.AlertDocument) (map[string]string, error) {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, definition := range document.Alerts {
+		name := definition.Action.NfAddressListName
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return map[string]string{}, nil
+	}
+
+	addressListNames, err := nf.DescribeAddressListNames(ctx, nil, names)
+	if err != nil {
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorDescribeResourcesFailed)
+	}
+
+	return getAddressListIds(addressListNames), nil
+}
+
+func getAddressListIds(addressListNames map[string]string) map[string]string {
+	addressListIds := map[string]string{}
+	for addressListId, name := range addressListNames {
+		if _, ok := addressListIds[name]; ok {
+			addressListIds[name] = ""
+			continue
+		}
+		addressListIds[name] = addressListId
+	}
+	return addressListIds
+}
+
+func (s *Server) ExportAlerts(ctx context.Context, req *ExportAlertsRequest) (*ExportAlertsResponse, error) {
+	err := ValidateExportAlertsParams(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceMap, _ := parseResourceSearch(ctx, req.GetResourceSearch())
+	rsTypeName := resourceMap["rs_type_name"]
+	_, metricNames, err := describeAlertDocumentMetrics(ctx, rsTypeName)
+	if err != nil {
+		return nil, err
+	}
+
+	bundles, err := rs.DescribeAlertBundles(ctx, req.GetResourceSearch(), req.GetAlertName())
+	if err != nil {
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorDescribeResourcesFailed)
+	}
+	addressListNames, err := describeAddressListNames(ctx, bundles)
+	if err != nil {
+		return nil, err
+	}
+
+	document := &models.AlertDocument{
+		Version: models.AlertDocumentVersion,
+		Alerts:  []models.AlertDefinition{},
+	}
+	for _, bundle := range bundles {
+		document.Alerts = append(document.Alerts, newAlertDefinition(bundle, rsTypeName, metricNames, addressListNames))
+	}
+
+	data, err := models.MarshalAlertDocument(document, req.GetFormat())
+	if err != nil {
+		logger.Error(ctx, "Failed to Marshal Alert Document, [%+v].", err)
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorInternalError)
+	}
+
+	return &ExportAlertsResponse{
+		Document: string(data),
+	}, nil
+}
+
+func (s *Server) ImportAlerts(ctx context.Context, req *ImportAlertsRequest) (*ImportAlertsResponse, error) {
+	err := ValidateImportAlertsParams(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := models.ParseAlertDocument([]byte(req.GetDocument()))
+	if err != nil {
+		logger.Error(ctx, "Failed to Parse Alert Document, [%+v].", err)
+		return nil, gerr.NewWithDetail(ctx, gerr.InvalidArgument, err, gerr.ErrorValidateFailed)
+	}
+
+	resourceSearch := req.GetResourceSearch()
+	resourceMap, _ := parseResourceSearch(ctx, resourceSearch)
+	rsTypeName := resourceMap["rs_type_name"]
+	rsTypeId, metricNames, err := describeAlertDocumentMetrics(ctx, rsTypeName)
+	if err != nil {
+		return nil, err
+	}
+	metricIds := map[string]string{}
+	for metricId, metricName := range metricNames {
+		metricIds[metricName] = metricId
+	}
+
+	alertNames := []string{}
+	for _, definition := range document.Alerts {
+		alertNames = append(alertNames, definition.AlertName)
+	}
+	bundles, err := rs.DescribeAlertBundles(ctx, resourceSearch, alertNames)
+	if err != nil {
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorDescribeResourcesFailed)
+	}
+	existing := map[string]*rs.AlertBundle{}
+	for _, bundle := range bundles {
+		existing[bundle.Alert.AlertName] = bundle
+	}
+	addressListNames, err := describeAddressListNames(ctx, bundles)
+	if err != nil {
+		return nil, err
+	}
+	addressListIds, err := describeAddressListIds(ctx, document)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*AlertDocumentChange
+	var created, updated []*rs.AlertBundle
+	var createdChanges, updatedChanges []*AlertDocumentChange
+	for i := range document.Alerts {
+		definition := &document.Alerts[i]
+		bundleReq, err := newAlertDefinitionRequest(ctx, resourceSearch, definition, rsTypeName, rsTypeId, metricIds, addressListIds)
+		if err != nil {
+			return nil, err
+		}
+		err = ValidateCreateAlertBundleParams(ctx, bundleReq)
+		if err != nil {
+			return nil, err
+		}
+
+		bundle := newAlertBundle(bundleReq.GetRsFilter(), bundleReq.GetPolicy(), bundleReq.GetAction(), bundleReq.GetRules(), bundleReq.GetAlert())
+		change := &AlertDocumentChange{
+			AlertName: definition.AlertName,
+		}
+		changes = append(changes, change)
+
+		old, ok := existing[definition.AlertName]
+		if !ok {
+			change.Operation = "create"
+			created = append(created, bundle)
+			createdChanges = append(createdChanges, change)
+			continue
+		}
+
+		change.AlertId = old.Alert.AlertId
+		oldDefinition := newAlertDefinition(old, rsTypeName, metricNames, addressListNames)
+		change.Diff = models.DiffAlertDefinitions(&oldDefinition, definition)
+		if len(change.Diff) == 0 {
+			change.Operation = "unchanged"
+			continue
+		}
+
+		// rules are matched by name, to keep the status of the rules kept
+		change.Operation = "update"
+		ruleIds := map[string]string{}
+		for _, rule := range old.Rules {
+			ruleIds[rule.RuleName] = rule.RuleId
+		}
+		for _, rule := range bundle.Rules {
+			rule.RuleId = ruleIds[rule.RuleName]
+		}
+		bundle.Alert.AlertId = old.Alert.AlertId
+		updated = append(updated, bundle)
+		updatedChanges = append(updatedChanges, change)
+	}
+
+	if req.GetDryRun() || len(created)+len(updated) == 0 {
+		return &ImportAlertsResponse{
+			Changes: changes,
+			DryRun:  req.GetDryRun(),
+		}, nil
+	}
+
+	err = rs.ApplyAlertBundles(ctx, resourceSearch, created, updated)
+	switch err {
+	case nil:
+	case rs.ErrAlertNotFound, rs.ErrRuleNotFound:
+		return nil, gerr.New(ctx, gerr.NotFound, gerr.ErrorResourceNotFound, "alert")
+	case rs.ErrAlertNameExists:
+		return nil, gerr.New(ctx, gerr.AlreadyExists, gerr.ErrorResourceAlreadyExists, "alert_name")
+	default:
+		logger.Error(ctx, "Failed to Import Alerts, [%+v], [%+v].", req, err)
+		return nil, gerr.NewWithDetail(ctx, gerr.Internal, err, gerr.ErrorCreateResourcesFailed)
+	}
+	logger.Debug(ctx, "Import Alerts [%d] created [%d] updated in DB successfully.", len(created), len(updated))
+
+	// the changes are committed, alerts the executors are not told of are reported along with
+	// the others rather than failing the import
+	for i, bundle := range created {
+		createdChanges[i].AlertId = bundle.Alert.AlertId
+		err = s.enqueueAlert(ctx, bundle.Alert.AlertId)
+		if err != nil {
+			createdChanges[i].Error = err.Error()
+		}
+	}
+
+	for i, bundle := range updated {
+		alertId := bundle.Alert.AlertId
+		err = s.alertBroadcast.Broadcast(ctx, alertId, "updating", 10)
+		if err != nil {
+			logger.Error(ctx, "Manager broadast alert updating[%s] into etcd failed, [%+v].", alertId, err)
+			updatedChanges[i].Error = err.Error()
+		}
+	}
+
+	return &ImportAlertsResponse{
+		Changes: changes,
+		DryRun:  false,
+	}, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/models"
+	rs "kubesphere.io/alert/pkg/services/manager/resource_control"
+)
+
+func TestGetAddressListIds(t *testing.T) {
+	addressListNames := map[string]string{
+		"nl-1": "ops",
+		"nl-2": "dev",
+		"nl-3": "dev",
+	}
+
+	// names shared by several address lists map to no id
+	require.Equal(t, map[string]string{"ops": "nl-1", "dev": ""}, getAddressListIds(addressListNames))
+}
+
+func TestAlertDefinitionAddressList(t *testing.T) {
+	bundle := &rs.AlertBundle{
+		RsFilter: &models.ResourceFilter{},
+		Policy:   &models.Policy{},
+		Action:   &models.Action{ActionName: "notify", NfAddressListId: "nl-1"},
+		Alert:    &models.Alert{AlertName: "pod-cpu"},
+	}
+
+	// the address list is exported by name and imported by the id it has in the cluster
+	definition := newAlertDefinition(bundle, "pod", nil, map[string]string{"nl-1": "ops"})
+	require.Equal(t, "ops", definition.Action.NfAddressListName)
+
+	req, err := newAlertDefinitionRequest(context.Background(), "", &definition, "pod", "rst-1", nil, map[string]string{"ops": "nl-9"})
+	require.NoError(t, err)
+	require.Equal(t, "nl-9", req.Action.NfAddressListId)
+
+	_, err = newAlertDefinitionRequest(context.Background(), "", &definition, "pod", "rst-1", nil, map[string]string{"ops": ""})
+	require.Error(t, err)
+	_, err = newAlertDefinitionRequest(context.Background(), "", &definition, "pod", "rst-1", nil, map[string]string{})
+	require.Error(t, err)
+
+	definition.Action.NfAddressListName = ""
+	req, err = newAlertDefinitionRequest(context.Background(), "", &definition, "pod", "rst-1", nil, map[string]string{})
+	require.NoError(t, err)
+	require.Equal(t, "", req.Action.NfAddressListId)
+}
@@ -98,7 +98,7 @@ func UpdateAlert(ctx context.Context, req *pb.ModifyAlertRequest, alertId string
 			attributes[models.AlColRsFilterId] = req.RsFilterId
 		}
 
-		attributes[models.AlColRunningStatus] = operation
+		attributes[models.AlColRunningStatus] = updatingRunningStatus()
 		attributes[models.AlColUpdateTime] = time.Now()
 	case "deleting":
 		attributes[models.AlColId] = alertId
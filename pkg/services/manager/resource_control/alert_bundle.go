@@ -0,0 +1,291 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package resource_control
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	aldb "kubesphere.io/alert/pkg/db"
+	"kubesphere.io/alert/pkg/global"
+	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/pb"
+)
+
+var (
+	// ErrAlertNameExists is returned naming an alert after another alert watching the same
+	// resources.
+	ErrAlertNameExists = errors.New("alert name already exists")
+	// ErrAlertNotFound is returned updating an alert not watching the resources searched.
+	ErrAlertNotFound = errors.New("alert not found")
+	// ErrRuleNotFound is returned updating a rule the alert does not have.
+	ErrRuleNotFound = errors.New("rule not found")
+)
+
+// AlertBundle is an alert along with the resource filter, policy, action and rules it uses,
+// which are created and changed together.
+type AlertBundle struct {
+	RsFilter *models.ResourceFilter
+	Policy   *models.Policy
+	Action   *models.Action
+	Rules    []*models.Rule
+	Alert    *models.Alert
+}
+
+// countAlerts counts the alerts DescribeAlertsWithResource finds for req.
+func countAlerts(db *gorm.DB, req *pb.DescribeAlertsWithResourceRequest) (uint64, error) {
+	dbChain := aldb.GetChain(db.Table("alert t1").
+		Joins("left join resource_filter t2 on t1.rs_filter_id=t2.rs_filter_id").
+		Joins("left join resource_type t3 on t2.rs_type_id=t3.rs_type_id"))
+
+	dbChain, _ = buildDB4DescribeAlertsWithResource(dbChain, req)
+
+	var count uint64
+	err := dbChain.Count(&count).Error
+	return count, err
+}
+
+// CreateAlertBundle inserts the alert and everything it uses in one transaction, the alert
+// name has to be unique among the alerts watching resourceSearch.
+func CreateAlertBundle(ctx context.Context, resourceSearch string, bundle *AlertBundle) error {
+	return createAlertBundle(ctx, global.GetInstance().GetDB(), resourceSearch, bundle)
+}
+
+func createAlertBundle(ctx context.Context, db *gorm.DB, resourceSearch string, bundle *AlertBundle) error {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	err := insertAlertBundle(tx, resourceSearch, bundle)
+	if err != nil {
+		tx.Rollback()
+		if err != ErrAlertNameExists {
+			logger.Error(ctx, "Insert Alert bundle [%s] failed, [%+v]", bundle.Alert.AlertName, err)
+		}
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func insertAlertBundle(tx *gorm.DB, resourceSearch string, bundle *AlertBundle) error {
+	count, err := countAlerts(tx, &pb.DescribeAlertsWithResourceRequest{
+		ResourceSearch: resourceSearch,
+		AlertName:      []string{bundle.Alert.AlertName},
+	})
+	if err != nil {
+		return err
+	}
+	if count != 0 {
+		return ErrAlertNameExists
+	}
+
+	return createAlertBundleRecords(tx, bundle)
+}
+
+// createAlertBundleRecords inserts the records of the bundle, linking them by their ids.
+func createAlertBundleRecords(tx *gorm.DB, bundle *AlertBundle) error {
+	bundle.Policy.RsTypeId = bundle.RsFilter.RsTypeId
+	bundle.Action.PolicyId = bundle.Policy.PolicyId
+	bundle.Alert.PolicyId = bundle.Policy.PolicyId
+	bundle.Alert.RsFilterId = bundle.RsFilter.RsFilterId
+
+	records := []interface{}{bundle.RsFilter, bundle.Policy, bundle.Action}
+	for _, rule := range bundle.Rules {
+		rule.PolicyId = bundle.Policy.PolicyId
+		records = append(records, rule)
+	}
+	records = append(records, bundle.Alert)
+
+	for _, record := range records {
+		err := tx.Create(record).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateAlertBundle replaces the resource filter, policy, action and rules of the alert alertId
+// watching resourceSearch in one transaction. Rules with an id are updated, they have to be rules
+// of the alert, rules without are added and the rules of the alert left out are removed. The ids
+// of the bundle are set to the ones of the alert.
+func UpdateAlertBundle(ctx context.Context, resourceSearch string, alertId string, bundle *AlertBundle) error {
+	return updateAlertBundle(ctx, global.GetInstance().GetDB(), resourceSearch, alertId, bundle)
+}
+
+func updateAlertBundle(ctx context.Context, db *gorm.DB, resourceSearch string, alertId string, bundle *AlertBundle) error {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	err := replaceAlertBundle(tx, resourceSearch, alertId, bundle)
+	if err != nil {
+		tx.Rollback()
+		if err != ErrAlertNotFound && err != ErrRuleNotFound && err != ErrAlertNameExists {
+			logger.Error(ctx, "Update Alert bundle [%s] failed, [%+v]", alertId, err)
+		}
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func replaceAlertBundle(tx *gorm.DB, resourceSearch string, alertId string, bundle *AlertBundle) error {
+	count, err := countAlerts(tx, &pb.DescribeAlertsWithResourceRequest{
+		ResourceSearch: resourceSearch,
+		AlertId:        []string{alertId},
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrAlertNotFound
+	}
+
+	var alert models.Alert
+	err = tx.Table(models.TableAlert).Where(models.AlColId+" = ?", alertId).First(&alert).Error
+	if err != nil {
+		return err
+	}
+
+	if bundle.Alert.AlertName != alert.AlertName {
+		count, err := countAlerts(tx, &pb.DescribeAlertsWithResourceRequest{
+			ResourceSearch: resourceSearch,
+			AlertName:      []string{bundle.Alert.AlertName},
+		})
+		if err != nil {
+			return err
+		}
+		if count != 0 {
+			return ErrAlertNameExists
+		}
+	}
+
+	now := time.Now()
+
+	bundle.RsFilter.RsFilterId = alert.RsFilterId
+	err = tx.Table(models.TableResourceFilter).Where(models.RfColId+" = ?", alert.RsFilterId).Updates(map[string]interface{}{
+		models.RfColName:       bundle.RsFilter.RsFilterName,
+		models.RfColParam:      bundle.RsFilter.RsFilterParam,
+		models.RfColTypeId:     bundle.RsFilter.RsTypeId,
+		models.RfColUpdateTime: now,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	bundle.Policy.PolicyId = alert.PolicyId
+	bundle.Policy.RsTypeId = bundle.RsFilter.RsTypeId
+	err = tx.Table(models.TablePolicy).Where(models.PlColId+" = ?", alert.PolicyId).Updates(map[string]interface{}{
+		models.PlColName:               bundle.Policy.PolicyName,
+		models.PlColDescription:        bundle.Policy.PolicyDescription,
+		models.PlColConfig:             bundle.Policy.PolicyConfig,
+		models.PlColCreator:            bundle.Policy.Creator,
+		models.PlColAvailableStartTime: bundle.Policy.AvailableStartTime,
+		models.PlColAvailableEndTime:   bundle.Policy.AvailableEndTime,
+		models.PlColTypeId:             bundle.Policy.RsTypeId,
+		models.PlColUpdateTime:         now,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	var actions []*models.Action
+	err = tx.Table(models.TableAction).Where(models.AcColPolicyId+" = ?", alert.PolicyId).Find(&actions).Error
+	if err != nil {
+		return err
+	}
+	bundle.Action.PolicyId = alert.PolicyId
+	if len(actions) == 0 {
+		err = tx.Create(bundle.Action).Error
+	} else {
+		bundle.Action.ActionId = actions[0].ActionId
+		err = tx.Table(models.TableAction).Where(models.AcColId+" = ?", bundle.Action.ActionId).Updates(map[string]interface{}{
+			models.AcColName:            bundle.Action.ActionName,
+			models.AcColTriggerStatus:   bundle.Action.TriggerStatus,
+			models.AcColTriggerAction:   bundle.Action.TriggerAction,
+			models.AcColNfAddressListId: bundle.Action.NfAddressListId,
+			models.AcColUpdateTime:      now,
+		}).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	var rules []*models.Rule
+	err = tx.Table(models.TableRule).Where(models.RlColPolicyId+" = ?", alert.PolicyId).Find(&rules).Error
+	if err != nil {
+		return err
+	}
+	removedRuleIds := map[string]bool{}
+	for _, rule := range rules {
+		removedRuleIds[rule.RuleId] = true
+	}
+
+	for _, rule := range bundle.Rules {
+		rule.PolicyId = alert.PolicyId
+		if rule.RuleId == "" {
+			rule.RuleId = models.NewRuleId()
+			err = tx.Create(rule).Error
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !removedRuleIds[rule.RuleId] {
+			return ErrRuleNotFound
+		}
+		delete(removedRuleIds, rule.RuleId)
+
+		err = tx.Table(models.TableRule).Where(models.RlColId+" = ?", rule.RuleId).Updates(map[string]interface{}{
+			models.RlColName:               rule.RuleName,
+			models.RlColDisabled:           rule.Disabled,
+			models.RlColMonitorPeriods:     rule.MonitorPeriods,
+			models.RlColSeverity:           rule.Severity,
+			models.RlColMetricsType:        rule.MetricsType,
+			models.RlColConditionType:      rule.ConditionType,
+			models.RlColThresholds:         rule.Thresholds,
+			models.RlColUnit:               rule.Unit,
+			models.RlColConsecutiveCount:   rule.ConsecutiveCount,
+			models.RlColInhibit:            rule.Inhibit,
+			models.RlColMetricId:           rule.MetricId,
+			models.RlColEvaluationInterval: rule.EvaluationInterval,
+			models.RlColUpdateTime:         now,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(removedRuleIds) != 0 {
+		ruleIds := []string{}
+		for ruleId := range removedRuleIds {
+			ruleIds = append(ruleIds, ruleId)
+		}
+		err = tx.Where(models.RlColId+" in (?)", ruleIds).Delete(models.Rule{}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	bundle.Alert.AlertId = alert.AlertId
+	bundle.Alert.PolicyId = alert.PolicyId
+	bundle.Alert.RsFilterId = alert.RsFilterId
+	return tx.Table(models.TableAlert).Where(models.AlColId+" = ?", alertId).Updates(map[string]interface{}{
+		models.AlColName:          bundle.Alert.AlertName,
+		models.AlColDisabled:      bundle.Alert.Disabled,
+		models.AlColRunningStatus: updatingRunningStatus(),
+		models.AlColUpdateTime:    now,
+	}).Error
+}
@@ -0,0 +1,265 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package resource_control
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/db/migrations"
+	"kubesphere.io/alert/pkg/internal/testutil"
+	"kubesphere.io/alert/pkg/models"
+)
+
+const rsTypeIdNamespace = "rst-pX1mLzBoJ3mA"
+
+func openTestDB(t *testing.T) *gorm.DB {
+	db := testutil.OpenDB(t)
+	require.NoError(t, migrations.NewMigrator(db, "sqlite3").Up())
+	return db
+}
+
+func newTestAlertBundle(alertName string, nsName string, ruleCount int) *AlertBundle {
+	bundle := &AlertBundle{
+		RsFilter: models.NewResourceFilter("filter", `{"ns_name":"`+nsName+`"}`, rsTypeIdNamespace),
+		Policy:   models.NewPolicy("policy", "", "{}", "admin", "00:00:00", "23:59:59", ""),
+		Alert:    models.NewAlert(alertName, false, "adding", "", "", ""),
+	}
+	bundle.Action = models.NewAction("action", "", "", bundle.Policy.PolicyId, "")
+	for i := 0; i < ruleCount; i++ {
+		bundle.Rules = append(bundle.Rules, models.NewRule("rule", false, 1, "minor", "", "", "", "", 1, false, "", "mt-0WMZpN7MgjMY", 0))
+	}
+	return bundle
+}
+
+func TestCreateAlertBundle(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	bundle := newTestAlertBundle("alert-1", "ns1", 2)
+	require.NoError(t, createAlertBundle(ctx, db, `{"rs_type_name":"namespace","ns_name":"ns1"}`, bundle))
+
+	require.Equal(t, 1, testutil.CountRows(t, db, models.TableResourceFilter))
+	require.Equal(t, 1, testutil.CountRows(t, db, models.TablePolicy))
+	require.Equal(t, 1, testutil.CountRows(t, db, models.TableAction))
+	require.Equal(t, 2, testutil.CountRows(t, db, models.TableRule))
+	require.Equal(t, 1, testutil.CountRows(t, db, models.TableAlert))
+
+	alert := models.Alert{}
+	require.NoError(t, db.Table(models.TableAlert).Where("alert_id = ?", bundle.Alert.AlertId).First(&alert).Error)
+	require.Equal(t, bundle.Policy.PolicyId, alert.PolicyId)
+	require.Equal(t, bundle.RsFilter.RsFilterId, alert.RsFilterId)
+	require.Equal(t, "adding", alert.RunningStatus)
+
+	policy := models.Policy{}
+	require.NoError(t, db.Table(models.TablePolicy).Where("policy_id = ?", bundle.Policy.PolicyId).First(&policy).Error)
+	require.Equal(t, rsTypeIdNamespace, policy.RsTypeId)
+}
+
+func TestCreateAlertBundleNameExists(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	require.NoError(t, createAlertBundle(ctx, db, `{"rs_type_name":"namespace","ns_name":"ns1"}`, newTestAlertBundle("alert-1", "ns1", 1)))
+
+	err := createAlertBundle(ctx, db, `{"rs_type_name":"namespace","ns_name":"ns1"}`, newTestAlertBundle("alert-1", "ns1", 1))
+	require.Equal(t, ErrAlertNameExists, err)
+	require.Equal(t, 1, testutil.CountRows(t, db, models.TableAlert))
+	require.Equal(t, 1, testutil.CountRows(t, db, models.TablePolicy))
+
+	// names only have to be unique among the alerts of the same resources
+	require.NoError(t, createAlertBundle(ctx, db, `{"rs_type_name":"namespace","ns_name":"ns2"}`, newTestAlertBundle("alert-1", "ns2", 1)))
+	require.Equal(t, 2, testutil.CountRows(t, db, models.TableAlert))
+}
+
+func TestCreateAlertBundleRollback(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	bundle := newTestAlertBundle("alert-1", "ns1", 2)
+	bundle.Rules[1].RuleId = bundle.Rules[0].RuleId
+	require.Error(t, createAlertBundle(ctx, db, `{"rs_type_name":"namespace","ns_name":"ns1"}`, bundle))
+
+	require.Equal(t, 0, testutil.CountRows(t, db, models.TableResourceFilter))
+	require.Equal(t, 0, testutil.CountRows(t, db, models.TablePolicy))
+	require.Equal(t, 0, testutil.CountRows(t, db, models.TableAction))
+	require.Equal(t, 0, testutil.CountRows(t, db, models.TableRule))
+	require.Equal(t, 0, testutil.CountRows(t, db, models.TableAlert))
+}
+
+func TestUpdateAlertBundle(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+	resourceSearch := `{"rs_type_name":"namespace","ns_name":"ns1"}`
+
+	created := newTestAlertBundle("alert-1", "ns1", 2)
+	require.NoError(t, createAlertBundle(ctx, db, resourceSearch, created))
+	alertId := created.Alert.AlertId
+
+	// keep the first rule with a new threshold, remove the second and add one
+	bundle := newTestAlertBundle("alert-1", "ns1", 2)
+	bundle.Policy.PolicyName = "policy-2"
+	bundle.Rules[0].RuleId = created.Rules[0].RuleId
+	bundle.Rules[0].Thresholds = "90"
+	bundle.Rules[1].RuleId = ""
+	require.NoError(t, updateAlertBundle(ctx, db, resourceSearch, alertId, bundle))
+
+	require.Equal(t, created.Policy.PolicyId, bundle.Policy.PolicyId)
+	require.Equal(t, created.Action.ActionId, bundle.Action.ActionId)
+	require.Equal(t, created.RsFilter.RsFilterId, bundle.RsFilter.RsFilterId)
+	require.Equal(t, 1, testutil.CountRows(t, db, models.TablePolicy))
+	require.Equal(t, 1, testutil.CountRows(t, db, models.TableAction))
+
+	var rules []*models.Rule
+	require.NoError(t, db.Table(models.TableRule).Order("rule_id").Find(&rules).Error)
+	require.Len(t, rules, 2)
+	ruleIds := []string{rules[0].RuleId, rules[1].RuleId}
+	require.Contains(t, ruleIds, created.Rules[0].RuleId)
+	require.NotContains(t, ruleIds, created.Rules[1].RuleId)
+	require.Contains(t, ruleIds, bundle.Rules[1].RuleId)
+
+	rule := models.Rule{}
+	require.NoError(t, db.Table(models.TableRule).Where("rule_id = ?", created.Rules[0].RuleId).First(&rule).Error)
+	require.Equal(t, "90", rule.Thresholds)
+
+	policy := models.Policy{}
+	require.NoError(t, db.Table(models.TablePolicy).Where("policy_id = ?", created.Policy.PolicyId).First(&policy).Error)
+	require.Equal(t, "policy-2", policy.PolicyName)
+
+	alert := models.Alert{}
+	require.NoError(t, db.Table(models.TableAlert).Where("alert_id = ?", alertId).First(&alert).Error)
+	require.Equal(t, "updating", alert.RunningStatus)
+}
+
+func TestUpdateAlertBundleRollback(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+	resourceSearch := `{"rs_type_name":"namespace","ns_name":"ns1"}`
+
+	created := newTestAlertBundle("alert-1", "ns1", 1)
+	require.NoError(t, createAlertBundle(ctx, db, resourceSearch, created))
+	require.NoError(t, createAlertBundle(ctx, db, resourceSearch, newTestAlertBundle("alert-2", "ns1", 1)))
+	alertId := created.Alert.AlertId
+
+	bundle := newTestAlertBundle("alert-1", "ns1", 1)
+	bundle.Policy.PolicyName = "policy-2"
+	require.Equal(t, ErrRuleNotFound, updateAlertBundle(ctx, db, resourceSearch, alertId, bundle))
+
+	bundle = newTestAlertBundle("alert-2", "ns1", 1)
+	bundle.Rules[0].RuleId = ""
+	require.Equal(t, ErrAlertNameExists, updateAlertBundle(ctx, db, resourceSearch, alertId, bundle))
+
+	bundle = newTestAlertBundle("alert-1", "ns2", 1)
+	bundle.Rules[0].RuleId = ""
+	require.Equal(t, ErrAlertNotFound, updateAlertBundle(ctx, db, `{"rs_type_name":"namespace","ns_name":"ns2"}`, alertId, bundle))
+
+	policy := models.Policy{}
+	require.NoError(t, db.Table(models.TablePolicy).Where("policy_id = ?", created.Policy.PolicyId).First(&policy).Error)
+	require.Equal(t, "policy", policy.PolicyName)
+	require.Equal(t, 2, testutil.CountRows(t, db, models.TableRule))
+}
+
+func TestDescribeAlertBundles(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+	resourceSearch := `{"rs_type_name":"namespace","ns_name":"ns1"}`
+
+	require.NoError(t, createAlertBundle(ctx, db, resourceSearch, newTestAlertBundle("alert-2", "ns1", 2)))
+	require.NoError(t, createAlertBundle(ctx, db, resourceSearch, newTestAlertBundle("alert-1", "ns1", 1)))
+	require.NoError(t, createAlertBundle(ctx, db, `{"rs_type_name":"namespace","ns_name":"ns2"}`, newTestAlertBundle("alert-3", "ns2", 1)))
+
+	bundles, err := describeAlertBundles(db, resourceSearch, nil)
+	require.NoError(t, err)
+	require.Len(t, bundles, 2)
+	require.Equal(t, "alert-1", bundles[0].Alert.AlertName)
+	require.Equal(t, "alert-2", bundles[1].Alert.AlertName)
+	require.Equal(t, "filter", bundles[1].RsFilter.RsFilterName)
+	require.Equal(t, "policy", bundles[1].Policy.PolicyName)
+	require.Equal(t, "action", bundles[1].Action.ActionName)
+	require.Len(t, bundles[1].Rules, 2)
+
+	bundles, err = describeAlertBundles(db, resourceSearch, []string{"alert-2", "alert-3"})
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+	require.Equal(t, "alert-2", bundles[0].Alert.AlertName)
+}
+
+func TestDescribeAlertIds(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+	resourceSearch := `{"rs_type_name":"namespace","ns_name":"ns1"}`
+
+	existing := newTestAlertBundle("alert-1", "ns1", 1)
+	require.NoError(t, createAlertBundle(ctx, db, resourceSearch, existing))
+	require.NoError(t, createAlertBundle(ctx, db, `{"rs_type_name":"namespace","ns_name":"ns2"}`, newTestAlertBundle("alert-2", "ns2", 1)))
+
+	// an import of alert-1 and alert-2 into ns1 updates alert-1 and creates alert-2
+	alertIds, err := describeAlertIds(db, resourceSearch, []string{"alert-1", "alert-2"})
+	require.NoError(t, err)
+	require.Equal(t, []string{existing.Alert.AlertId}, alertIds)
+
+	alertIds, err = describeAlertIds(db, resourceSearch, nil)
+	require.NoError(t, err)
+	require.Empty(t, alertIds)
+}
+
+func TestApplyAlertBundles(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+	resourceSearch := `{"rs_type_name":"namespace","ns_name":"ns1"}`
+
+	existing := newTestAlertBundle("alert-1", "ns1", 1)
+	require.NoError(t, createAlertBundle(ctx, db, resourceSearch, existing))
+
+	updated := newTestAlertBundle("alert-1", "ns1", 1)
+	updated.Alert.AlertId = existing.Alert.AlertId
+	updated.Rules[0].RuleId = existing.Rules[0].RuleId
+	updated.Rules[0].Thresholds = "90"
+
+	// the whole import is rolled back when one alert fails
+	require.Equal(t, ErrAlertNameExists, applyAlertBundles(ctx, db, resourceSearch,
+		[]*AlertBundle{newTestAlertBundle("alert-2", "ns1", 1), newTestAlertBundle("alert-2", "ns1", 1)},
+		[]*AlertBundle{updated}))
+	require.Equal(t, 1, testutil.CountRows(t, db, models.TableAlert))
+
+	require.NoError(t, applyAlertBundles(ctx, db, resourceSearch, []*AlertBundle{newTestAlertBundle("alert-2", "ns1", 1)}, []*AlertBundle{updated}))
+	require.Equal(t, 2, testutil.CountRows(t, db, models.TableAlert))
+
+	rule := models.Rule{}
+	require.NoError(t, db.Table(models.TableRule).Where("rule_id = ?", existing.Rules[0].RuleId).First(&rule).Error)
+	require.Equal(t, "90", rule.Thresholds)
+}
+
+func TestGetAlertBundleIds(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	bundle := newTestAlertBundle("alert-1", "ns1", 2)
+	require.NoError(t, createAlertBundle(ctx, db, `{"rs_type_name":"namespace","ns_name":"ns1"}`, bundle))
+	require.NoError(t, createAlertBundle(ctx, db, `{"rs_type_name":"namespace","ns_name":"ns1"}`, newTestAlertBundle("alert-2", "ns1", 1)))
+
+	bundleIds, err := getAlertBundleIds(ctx, db, []string{bundle.Alert.AlertId})
+	require.NoError(t, err)
+	require.Equal(t, []string{bundle.RsFilter.RsFilterId}, bundleIds[models.TableResourceFilter])
+	require.Equal(t, []string{bundle.Policy.PolicyId}, bundleIds[models.TablePolicy])
+	require.Equal(t, []string{bundle.Action.ActionId}, bundleIds[models.TableAction])
+	require.ElementsMatch(t, []string{bundle.Rules[0].RuleId, bundle.Rules[1].RuleId}, bundleIds[models.TableRule])
+
+	bundleIds, err = getAlertBundleIds(ctx, db, []string{"al-missing"})
+	require.NoError(t, err)
+	require.Empty(t, bundleIds)
+}
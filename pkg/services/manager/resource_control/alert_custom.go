@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/jinzhu/gorm"
+
 	"kubesphere.io/alert/pkg/constants"
 	aldb "kubesphere.io/alert/pkg/db"
 	"kubesphere.io/alert/pkg/global"
@@ -32,8 +35,19 @@ func getLimit(limit uint32) uint32 {
 	return limit
 }
 
+var columnRegexp = regexp.MustCompile(`^([a-z][a-z0-9]*\.)?[a-z_][a-z0-9_]*$`)
+
+// isColumn reports whether a sort key names a column, sort keys are spliced into order clauses.
+func isColumn(sortKey string) bool {
+	return columnRegexp.MatchString(sortKey)
+}
+
 func DescribeAlertsWithResource(ctx context.Context, req *pb.DescribeAlertsWithResourceRequest) ([]*models.Alert, uint64, error) {
-	dbChain := aldb.GetChain(global.GetInstance().GetDB().Table("alert t1").
+	return describeAlertsWithResource(ctx, global.GetInstance().GetDB(), req)
+}
+
+func describeAlertsWithResource(ctx context.Context, db *gorm.DB, req *pb.DescribeAlertsWithResourceRequest) ([]*models.Alert, uint64, error) {
+	dbChain := aldb.GetChain(db.Table("alert t1").
 		Select("t1.alert_id,t1.alert_name,t1.policy_id").
 		Joins("left join resource_filter t2 on t1.rs_filter_id=t2.rs_filter_id").
 		Joins("left join resource_type t3 on t2.rs_type_id=t3.rs_type_id"))
@@ -82,32 +96,36 @@ func buildDB4DescribeAlertsWithResource(dbChain *aldb.Chain, req *pb.DescribeAle
 				break
 			case "workspace":
 				if resourceMap["ws_name"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t2.rs_filter_param, "$.ws_name") in (?)`, resourceMap["ws_name"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t2.rs_filter_param", "ws_name")+" in (?)", resourceMap["ws_name"])
 				}
 			case "namespace":
 				if resourceMap["ns_name"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t2.rs_filter_param, "$.ns_name") in (?)`, resourceMap["ns_name"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t2.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 				}
 			case "workload":
 				if resourceMap["ns_name"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t2.rs_filter_param, "$.ns_name") in (?)`, resourceMap["ns_name"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t2.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 				}
 			case "pod":
 				if resourceMap["ns_name"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t2.rs_filter_param, "$.ns_name") in (?)`, resourceMap["ns_name"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t2.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 				}
 				if resourceMap["node_id"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t2.rs_filter_param, "$.node_id") in (?)`, resourceMap["node_id"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t2.rs_filter_param", "node_id")+" in (?)", resourceMap["node_id"])
 				}
 			case "container":
 				if resourceMap["ns_name"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t2.rs_filter_param, "$.ns_name") in (?)`, resourceMap["ns_name"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t2.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 				}
 				if resourceMap["node_id"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t2.rs_filter_param, "$.node_id") in (?)`, resourceMap["node_id"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t2.rs_filter_param", "node_id")+" in (?)", resourceMap["node_id"])
 				}
 				if resourceMap["pod_name"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t2.rs_filter_param, "$.pod_name") in (?)`, resourceMap["pod_name"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t2.rs_filter_param", "pod_name")+" in (?)", resourceMap["pod_name"])
+				}
+			case "external":
+				if resourceMap["ns_name"] != "" {
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t2.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 				}
 			}
 		} else {
@@ -187,7 +205,7 @@ func getMostRecentAlertTimeByAlertId(alertId string) string {
 		Select("t2.create_time").
 		Joins("left join history t2 on t2.alert_id=t1.alert_id"))
 
-	dbChain.DB = dbChain.DB.Where(`t1.alert_id in (?) and t2.event in ("triggered", "sent_success", "sent_failed")`, alertId)
+	dbChain.DB = dbChain.DB.Where(`t1.alert_id in (?) and t2.event in ('triggered', 'sent_success', 'sent_failed')`, alertId)
 
 	var mis []*MessageInfo
 
@@ -212,8 +230,19 @@ func getMostRecentAlertTimeByAlertId(alertId string) string {
 }
 
 type StatusAlert struct {
-	ResourceStatus map[string]StatusResource `json:resource_status`
-	UpdateTime     time.Time
+	ResourceStatus   map[string]StatusResource   `json:resource_status`
+	EvaluationStatus map[string]StatusEvaluation `json:"evaluation_status"`
+	UpdateTime       time.Time
+}
+
+type StatusEvaluation struct {
+	State               string    `json:"state"`
+	Error               string    `json:"error"`
+	LastSuccessTime     time.Time `json:"last_success_time"`
+	LastErrorTime       time.Time `json:"last_error_time"`
+	ConsecutiveFailures uint32    `json:"consecutive_failures"`
+	LastDuration        uint32    `json:"last_duration"`
+	UpdateTime          time.Time `json:"update_time"`
 }
 
 type StatusResource struct {
@@ -223,6 +252,28 @@ type StatusResource struct {
 	NextResendInterval uint32          `json:next_resend_interval`
 	NextSendableTime   time.Time       `json:next_sendable_time`
 	AggregatedAlerts   AggregatedAlert `json:aggregated_alerts`
+	FiringTime         time.Time       `json:"firing_time"`
+	WebhookSent        bool            `json:"webhook_sent"`
+	// the labels forwarded to webhooks, and the time the external alert resolves by, if any
+	Labels map[string]string `json:"labels,omitempty"`
+	EndsAt time.Time         `json:"ends_at,omitempty"`
+}
+
+// RepeatStatus returns how often the notifications of the resource were sent, as the repeat
+// policies read it.
+func (s *StatusResource) RepeatStatus() models.RepeatStatus {
+	return models.RepeatStatus{
+		CumulatedSendCount: s.CumulatedSendCount,
+		NextResendInterval: s.NextResendInterval,
+		NextSendableTime:   s.NextSendableTime,
+	}
+}
+
+// SetRepeatStatus sets how often the notifications of the resource were sent.
+func (s *StatusResource) SetRepeatStatus(repeatStatus models.RepeatStatus) {
+	s.CumulatedSendCount = repeatStatus.CumulatedSendCount
+	s.NextResendInterval = repeatStatus.NextResendInterval
+	s.NextSendableTime = repeatStatus.NextSendableTime
 }
 
 type AggregatedAlert struct {
@@ -315,32 +366,36 @@ func buildDB4DescAlertDetails(dbChain *aldb.Chain, req *pb.DescribeAlertDetailsR
 				break
 			case "workspace":
 				if resourceMap["ws_name"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t3.rs_filter_param, "$.ws_name") in (?)`, resourceMap["ws_name"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t3.rs_filter_param", "ws_name")+" in (?)", resourceMap["ws_name"])
 				}
 			case "namespace":
 				if resourceMap["ns_name"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t3.rs_filter_param, "$.ns_name") in (?)`, resourceMap["ns_name"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t3.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 				}
 			case "workload":
 				if resourceMap["ns_name"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t3.rs_filter_param, "$.ns_name") in (?)`, resourceMap["ns_name"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t3.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 				}
 			case "pod":
 				if resourceMap["ns_name"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t3.rs_filter_param, "$.ns_name") in (?)`, resourceMap["ns_name"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t3.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 				}
 				if resourceMap["node_id"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t3.rs_filter_param, "$.node_id") in (?)`, resourceMap["node_id"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t3.rs_filter_param", "node_id")+" in (?)", resourceMap["node_id"])
 				}
 			case "container":
 				if resourceMap["ns_name"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t3.rs_filter_param, "$.ns_name") in (?)`, resourceMap["ns_name"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t3.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 				}
 				if resourceMap["node_id"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t3.rs_filter_param, "$.node_id") in (?)`, resourceMap["node_id"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t3.rs_filter_param", "node_id")+" in (?)", resourceMap["node_id"])
 				}
 				if resourceMap["pod_name"] != "" {
-					dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t3.rs_filter_param, "$.pod_name") in (?)`, resourceMap["pod_name"])
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t3.rs_filter_param", "pod_name")+" in (?)", resourceMap["pod_name"])
+				}
+			case "external":
+				if resourceMap["ns_name"] != "" {
+					dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t3.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 				}
 			}
 		} else {
@@ -380,7 +435,7 @@ func buildDB4DescAlertDetails(dbChain *aldb.Chain, req *pb.DescribeAlertDetailsR
 	var reverseStr string = constants.DESC
 	orderByStr := sortKeyStr + " " + reverseStr
 
-	if req.SortKey != "" {
+	if isColumn(req.SortKey) {
 		sortKeyStr = req.SortKey
 		if req.Reverse {
 			reverseStr = constants.DESC
@@ -428,32 +483,36 @@ func DescribeAlertStatus(ctx context.Context, req *pb.DescribeAlertStatusRequest
 		break
 	case "workspace":
 		if resourceMap["ws_name"] != "" {
-			dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t4.rs_filter_param, "$.ws_name") in (?)`, resourceMap["ws_name"])
+			dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t4.rs_filter_param", "ws_name")+" in (?)", resourceMap["ws_name"])
 		}
 	case "namespace":
 		if resourceMap["ns_name"] != "" {
-			dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t4.rs_filter_param, "$.ns_name") in (?)`, resourceMap["ns_name"])
+			dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t4.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 		}
 	case "workload":
 		if resourceMap["ns_name"] != "" {
-			dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t4.rs_filter_param, "$.ns_name") in (?)`, resourceMap["ns_name"])
+			dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t4.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 		}
 	case "pod":
 		if resourceMap["ns_name"] != "" {
-			dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t4.rs_filter_param, "$.ns_name") in (?)`, resourceMap["ns_name"])
+			dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t4.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 		}
 		if resourceMap["node_id"] != "" {
-			dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t4.rs_filter_param, "$.node_id") in (?)`, resourceMap["node_id"])
+			dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t4.rs_filter_param", "node_id")+" in (?)", resourceMap["node_id"])
 		}
 	case "container":
 		if resourceMap["ns_name"] != "" {
-			dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t4.rs_filter_param, "$.ns_name") in (?)`, resourceMap["ns_name"])
+			dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t4.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 		}
 		if resourceMap["node_id"] != "" {
-			dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t4.rs_filter_param, "$.node_id") in (?)`, resourceMap["node_id"])
+			dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t4.rs_filter_param", "node_id")+" in (?)", resourceMap["node_id"])
 		}
 		if resourceMap["pod_name"] != "" {
-			dbChain.DB = dbChain.DB.Where(`JSON_EXTRACT(t4.rs_filter_param, "$.pod_name") in (?)`, resourceMap["pod_name"])
+			dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t4.rs_filter_param", "pod_name")+" in (?)", resourceMap["pod_name"])
+		}
+	case "external":
+		if resourceMap["ns_name"] != "" {
+			dbChain.DB = dbChain.DB.Where(aldb.JsonExtract("t4.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 		}
 	}
 
@@ -493,7 +552,7 @@ func DescribeAlertStatus(ctx context.Context, req *pb.DescribeAlertStatusRequest
 	var reverseStr string = constants.DESC
 	orderByStr := sortKeyStr + " " + reverseStr
 
-	if req.SortKey != "" {
+	if isColumn(req.SortKey) {
 		sortKeyStr = req.SortKey
 		if req.Reverse {
 			reverseStr = constants.DESC
@@ -532,7 +591,7 @@ func DescribeAlertStatus(ctx context.Context, req *pb.DescribeAlertStatusRequest
 		if err == nil {
 			als_resource := *als
 			for k, v := range alertStatus.ResourceStatus {
-				alertid_resourcename := strings.Split(k, " ")
+				alertid_resourcename := strings.SplitN(k, " ", 2)
 				if alertid_resourcename[0] == als.RuleId {
 					resourceStatus := models.ResourceStatus{}
 					resourceStatus.ResourceName = alertid_resourcename[1]
@@ -545,6 +604,16 @@ func DescribeAlertStatus(ctx context.Context, req *pb.DescribeAlertStatusRequest
 					als_resource.Resources = append(als_resource.Resources, resourceStatus)
 				}
 			}
+			if v, ok := alertStatus.EvaluationStatus[als.RuleId]; ok {
+				als_resource.Evaluation = models.EvaluationStatus{
+					State:               v.State,
+					Error:               v.Error,
+					LastSuccessTime:     v.LastSuccessTime,
+					LastErrorTime:       v.LastErrorTime,
+					ConsecutiveFailures: v.ConsecutiveFailures,
+					LastDuration:        v.LastDuration,
+				}
+			}
 			als_resources = append(als_resources, als_resource)
 			count_resources = count_resources + 1
 		} else {
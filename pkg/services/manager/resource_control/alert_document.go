@@ -0,0 +1,198 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package resource_control
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+
+	aldb "kubesphere.io/alert/pkg/db"
+	"kubesphere.io/alert/pkg/global"
+	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/pb"
+)
+
+// GetResourceTypeId returns the id of the resource type rsTypeName, empty if there is none.
+func GetResourceTypeId(ctx context.Context, rsTypeName string) (string, error) {
+	var rsTypes []*models.ResourceType
+	err := global.GetInstance().GetDB().
+		Table(models.TableResourceType).
+		Where(models.RtColName+" = ?", rsTypeName).
+		Find(&rsTypes).Error
+	if err != nil {
+		logger.Error(ctx, "Get Resource type [%s] failed, [%+v]", rsTypeName, err)
+		return "", err
+	}
+
+	if len(rsTypes) == 0 {
+		return "", nil
+	}
+	return rsTypes[0].RsTypeId, nil
+}
+
+// DescribeMetricNames maps the ids of the metrics of the resource type rsTypeId to their names.
+func DescribeMetricNames(ctx context.Context, rsTypeId string) (map[string]string, error) {
+	var metrics []*models.Metric
+	err := global.GetInstance().GetDB().
+		Table(models.TableMetric).
+		Where(models.MtColTypeId+" = ?", rsTypeId).
+		Find(&metrics).Error
+	if err != nil {
+		logger.Error(ctx, "Describe Metrics of Resource type [%s] failed, [%+v]", rsTypeId, err)
+		return nil, err
+	}
+
+	metricNames := map[string]string{}
+	for _, metric := range metrics {
+		metricNames[metric.MetricId] = metric.MetricName
+	}
+	return metricNames, nil
+}
+
+// DescribeAlertBundles describes the alerts watching resourceSearch named alertNames, all of them
+// if empty, along with the resource filter, policy, action and rules they use. Alerts are ordered
+// by name and rules by rule name.
+func DescribeAlertBundles(ctx context.Context, resourceSearch string, alertNames []string) ([]*AlertBundle, error) {
+	bundles, err := describeAlertBundles(global.GetInstance().GetDB(), resourceSearch, alertNames)
+	if err != nil {
+		logger.Error(ctx, "Describe Alert bundles [%s] failed, [%+v]", resourceSearch, err)
+		return nil, err
+	}
+	return bundles, nil
+}
+
+func describeAlertBundles(db *gorm.DB, resourceSearch string, alertNames []string) ([]*AlertBundle, error) {
+	alerts, err := describeNamedAlerts(db, resourceSearch, alertNames)
+	if err != nil {
+		return nil, err
+	}
+
+	bundles := []*AlertBundle{}
+	for _, alert := range alerts {
+		bundle := &AlertBundle{
+			RsFilter: &models.ResourceFilter{},
+			Policy:   &models.Policy{},
+			Action:   &models.Action{},
+			Alert:    alert,
+		}
+
+		err = db.Table(models.TableResourceFilter).Where(models.RfColId+" = ?", alert.RsFilterId).First(bundle.RsFilter).Error
+		if err != nil {
+			return nil, err
+		}
+
+		err = db.Table(models.TablePolicy).Where(models.PlColId+" = ?", alert.PolicyId).First(bundle.Policy).Error
+		if err != nil {
+			return nil, err
+		}
+
+		// an alert uses the first action of its policy
+		var actions []*models.Action
+		err = db.Table(models.TableAction).Where(models.AcColPolicyId+" = ?", alert.PolicyId).Find(&actions).Error
+		if err != nil {
+			return nil, err
+		}
+		if len(actions) != 0 {
+			bundle.Action = actions[0]
+		}
+
+		err = db.Table(models.TableRule).Where(models.RlColPolicyId+" = ?", alert.PolicyId).Order(models.RlColName).Find(&bundle.Rules).Error
+		if err != nil {
+			return nil, err
+		}
+
+		bundles = append(bundles, bundle)
+	}
+
+	return bundles, nil
+}
+
+// DescribeAlertIds returns the ids of the alerts watching resourceSearch named alertNames, the
+// ones without such an alert are left out.
+func DescribeAlertIds(ctx context.Context, resourceSearch string, alertNames []string) ([]string, error) {
+	alertIds, err := describeAlertIds(global.GetInstance().GetDB(), resourceSearch, alertNames)
+	if err != nil {
+		logger.Error(ctx, "Describe Alert ids [%s] %v failed, [%+v]", resourceSearch, alertNames, err)
+		return nil, err
+	}
+	return alertIds, nil
+}
+
+func describeAlertIds(db *gorm.DB, resourceSearch string, alertNames []string) ([]string, error) {
+	alertIds := []string{}
+	//no names would describe all the alerts
+	if len(alertNames) == 0 {
+		return alertIds, nil
+	}
+
+	alerts, err := describeNamedAlerts(db, resourceSearch, alertNames)
+	if err != nil {
+		return nil, err
+	}
+	for _, alert := range alerts {
+		alertIds = append(alertIds, alert.AlertId)
+	}
+	return alertIds, nil
+}
+
+// describeNamedAlerts describes the alerts watching resourceSearch named alertNames, all of them
+// if empty, ordered by name.
+func describeNamedAlerts(db *gorm.DB, resourceSearch string, alertNames []string) ([]*models.Alert, error) {
+	dbChain := aldb.GetChain(db.Table("alert t1").
+		Select("t1.*").
+		Joins("left join resource_filter t2 on t1.rs_filter_id=t2.rs_filter_id").
+		Joins("left join resource_type t3 on t2.rs_type_id=t3.rs_type_id"))
+
+	dbChain, _ = buildDB4DescribeAlertsWithResource(dbChain, &pb.DescribeAlertsWithResourceRequest{
+		ResourceSearch: resourceSearch,
+		AlertName:      alertNames,
+	})
+
+	var alerts []*models.Alert
+	err := dbChain.Order("t1.alert_name").Scan(&alerts).Error
+	if err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// ApplyAlertBundles creates the alerts created and replaces the alerts updated, as
+// UpdateAlertBundle does for the alert of their alert id, all of them in one transaction.
+func ApplyAlertBundles(ctx context.Context, resourceSearch string, created []*AlertBundle, updated []*AlertBundle) error {
+	return applyAlertBundles(ctx, global.GetInstance().GetDB(), resourceSearch, created, updated)
+}
+
+func applyAlertBundles(ctx context.Context, db *gorm.DB, resourceSearch string, created []*AlertBundle, updated []*AlertBundle) error {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	for _, bundle := range created {
+		err := insertAlertBundle(tx, resourceSearch, bundle)
+		if err != nil {
+			tx.Rollback()
+			if err != ErrAlertNameExists {
+				logger.Error(ctx, "Insert Alert bundle [%s] failed, [%+v]", bundle.Alert.AlertName, err)
+			}
+			return err
+		}
+	}
+
+	for _, bundle := range updated {
+		err := replaceAlertBundle(tx, resourceSearch, bundle.Alert.AlertId, bundle)
+		if err != nil {
+			tx.Rollback()
+			if err != ErrAlertNotFound && err != ErrRuleNotFound && err != ErrAlertNameExists {
+				logger.Error(ctx, "Update Alert bundle [%s] failed, [%+v]", bundle.Alert.AlertId, err)
+			}
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,186 @@
+package resource_control
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/jinzhu/gorm"
+
+	aldb "kubesphere.io/alert/pkg/db"
+	"kubesphere.io/alert/pkg/global"
+	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/pb"
+	"kubesphere.io/alert/pkg/util/pbutil"
+	"kubesphere.io/alert/pkg/util/stringutil"
+)
+
+func CreateAudits(ctx context.Context, audits []*models.Audit) error {
+	db := global.GetInstance().GetDB()
+	tx := db.Begin()
+	for _, audit := range audits {
+		err := tx.Create(audit).Error
+		if err != nil {
+			tx.Rollback()
+			logger.Error(ctx, "Insert Audit failed, [%+v]", err)
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+func DescribeAuditLogs(ctx context.Context, req *pb.DescribeAuditLogsRequest) ([]*models.Audit, uint64, error) {
+	req.AuditId = stringutil.SimplifyStringList(req.AuditId)
+	req.Actor = stringutil.SimplifyStringList(req.Actor)
+	req.Operation = stringutil.SimplifyStringList(req.Operation)
+	req.ResourceType = stringutil.SimplifyStringList(req.ResourceType)
+	req.ResourceId = stringutil.SimplifyStringList(req.ResourceId)
+	req.RequestId = stringutil.SimplifyStringList(req.RequestId)
+
+	offset := pbutil.GetOffsetFromRequest(req)
+	limit := pbutil.GetLimitFromRequest(req)
+
+	var ads []*models.Audit
+	var count uint64
+
+	chain := func() *aldb.Chain {
+		c := aldb.GetChain(global.GetInstance().GetDB().Table(models.TableAudit))
+		if req.StartTime != nil {
+			c.DB = c.Where(models.AdColCreateTime+" >= ?", pbutil.FromProtoTimestamp(req.StartTime))
+		}
+		if req.EndTime != nil {
+			c.DB = c.Where(models.AdColCreateTime+" <= ?", pbutil.FromProtoTimestamp(req.EndTime))
+		}
+		return c
+	}
+
+	if err := chain().
+		AddQueryOrderDir(req, models.AdColCreateTime).
+		BuildFilterConditions(req, models.TableAudit).
+		Offset(offset).
+		Limit(limit).
+		Find(&ads).Error; err != nil {
+		logger.Error(ctx, "Describe Audit Logs failed: %+v", err)
+		return nil, 0, err
+	}
+
+	if err := chain().
+		BuildFilterConditions(req, models.TableAudit).
+		Count(&count).Error; err != nil {
+		logger.Error(ctx, "Describe Audit Logs count failed: %+v", err)
+		return nil, 0, err
+	}
+
+	return ads, count, nil
+}
+
+// GetAuditSnapshots returns the json of the resources with resourceIds keyed by resource id,
+// resources that do not exist are left out.
+func GetAuditSnapshots(ctx context.Context, resourceType string, resourceIds []string) (map[string]string, error) {
+	snapshots := make(map[string]string)
+	if len(resourceIds) == 0 {
+		return snapshots, nil
+	}
+
+	var resources interface{}
+	var idColumn string
+	switch resourceType {
+	case models.TableResourceType:
+		resources, idColumn = &[]*models.ResourceType{}, models.RtColId
+	case models.TableResourceFilter:
+		resources, idColumn = &[]*models.ResourceFilter{}, models.RfColId
+	case models.TableMetric:
+		resources, idColumn = &[]*models.Metric{}, models.MtColId
+	case models.TablePolicy:
+		resources, idColumn = &[]*models.Policy{}, models.PlColId
+	case models.TableRule:
+		resources, idColumn = &[]*models.Rule{}, models.RlColId
+	case models.TableAction:
+		resources, idColumn = &[]*models.Action{}, models.AcColId
+	case models.TableAlert:
+		resources, idColumn = &[]*models.Alert{}, models.AlColId
+	default:
+		return nil, fmt.Errorf("unsupported audit resource type [%s]", resourceType)
+	}
+
+	db := global.GetInstance().GetDB()
+	err := db.Table(resourceType).Where(idColumn+" in (?)", resourceIds).Find(resources).Error
+	if err != nil {
+		logger.Error(ctx, "Get Audit snapshots of %s%v failed, [%+v]", resourceType, resourceIds, err)
+		return nil, err
+	}
+
+	rows := reflect.ValueOf(resources).Elem()
+	for i := 0; i < rows.Len(); i++ {
+		content, err := json.Marshal(rows.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]interface{})
+		err = json.Unmarshal(content, &row)
+		if err != nil {
+			return nil, err
+		}
+		//alert_status is the runtime state written by executors, not a configuration change.
+		if resourceType == models.TableAlert {
+			delete(row, models.AlColAlertStatus)
+			content, _ = json.Marshal(row)
+		}
+
+		resourceId, _ := row[idColumn].(string)
+		snapshots[resourceId] = string(content)
+	}
+
+	return snapshots, nil
+}
+
+// GetAlertBundleIds returns the ids of the resource filters, policies, actions and rules of the
+// alerts with alertIds keyed by resource type.
+func GetAlertBundleIds(ctx context.Context, alertIds []string) (map[string][]string, error) {
+	return getAlertBundleIds(ctx, global.GetInstance().GetDB(), alertIds)
+}
+
+func getAlertBundleIds(ctx context.Context, db *gorm.DB, alertIds []string) (map[string][]string, error) {
+	bundleIds := make(map[string][]string)
+	if len(alertIds) == 0 {
+		return bundleIds, nil
+	}
+
+	var alerts []*models.Alert
+	err := db.Table(models.TableAlert).Where(models.AlColId+" in (?)", alertIds).Find(&alerts).Error
+	if err != nil {
+		logger.Error(ctx, "Get Alert Bundle ids of %v failed, [%+v]", alertIds, err)
+		return nil, err
+	}
+	if len(alerts) == 0 {
+		return bundleIds, nil
+	}
+
+	var policyIds []string
+	for _, alert := range alerts {
+		bundleIds[models.TableResourceFilter] = append(bundleIds[models.TableResourceFilter], alert.RsFilterId)
+		policyIds = append(policyIds, alert.PolicyId)
+	}
+	bundleIds[models.TablePolicy] = policyIds
+
+	var actionIds []string
+	err = db.Table(models.TableAction).Where(models.AcColPolicyId+" in (?)", policyIds).Pluck(models.AcColId, &actionIds).Error
+	if err != nil {
+		logger.Error(ctx, "Get Alert Bundle actions of %v failed, [%+v]", alertIds, err)
+		return nil, err
+	}
+	bundleIds[models.TableAction] = actionIds
+
+	var ruleIds []string
+	err = db.Table(models.TableRule).Where(models.RlColPolicyId+" in (?)", policyIds).Pluck(models.RlColId, &ruleIds).Error
+	if err != nil {
+		logger.Error(ctx, "Get Alert Bundle rules of %v failed, [%+v]", alertIds, err)
+		return nil, err
+	}
+	bundleIds[models.TableRule] = ruleIds
+
+	return bundleIds, nil
+}
@@ -0,0 +1,30 @@
+package resource_control
+
+import (
+	"context"
+
+	"kubesphere.io/alert/pkg/global"
+	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/models"
+)
+
+func GetAlertIdsByExecutorIds(ctx context.Context, executorIds []string) (map[string][]string, error) {
+	var als []*models.Alert
+
+	err := global.GetInstance().GetDB().Table(models.TableAlert).
+		Select(models.AlColId+", "+models.AlColExecutorId).
+		Where(models.AlColExecutorId+" in (?)", executorIds).
+		Order(models.AlColId + " asc").
+		Find(&als).Error
+	if err != nil {
+		logger.Error(ctx, "GetAlertIdsByExecutorIds failed: %+v", err)
+		return nil, err
+	}
+
+	alertIds := make(map[string][]string)
+	for _, al := range als {
+		alertIds[al.ExecutorId] = append(alertIds[al.ExecutorId], al.AlertId)
+	}
+
+	return alertIds, nil
+}
@@ -0,0 +1,351 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package resource_control
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"kubesphere.io/alert/pkg/global"
+	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/util/ctxutil"
+)
+
+// ErrAlertStatusChanged is returned saving the status of an external alert which another
+// replica of the manager changed since the status was read.
+var ErrAlertStatusChanged = errors.New("alert status changed")
+
+// ExternalAlert is the alert the alerts an external system posts under a name map onto, along
+// with the rule of their severity and the policy and action notifying of them.
+type ExternalAlert struct {
+	AlertId            string
+	AlertName          string
+	Disabled           bool
+	AlertStatus        string
+	RuleId             string
+	RuleName           string
+	Severity           string
+	PolicyConfig       string
+	AvailableStartTime string
+	AvailableEndTime   string
+	NfAddressListId    string
+	TriggerAction      string
+
+	// the alert, or the rule of the severity, was created on getting the alert
+	AlertCreated bool
+	RuleCreated  bool
+}
+
+// updatingRunningStatus is the running status of an alert being updated. External alerts have
+// no executor to run the update and stay external.
+func updatingRunningStatus() interface{} {
+	return gorm.Expr("CASE WHEN running_status = ? THEN running_status ELSE ? END", models.RunningStatusExternal, "updating")
+}
+
+// GetExternalAlert returns the external alert named alertName in the namespace nsName, with its
+// rule of severity. The first alert received creates the alert along with a resource filter,
+// a policy of the default config described by description, and an action without notification
+// address list until one is configured. Rules of severities received later are added. Replicas of
+// the manager receiving the first alert at once may both create the alert, the alerts received
+// map onto the one created first.
+func GetExternalAlert(ctx context.Context, alertName string, nsName string, severity string, description string) (*ExternalAlert, error) {
+	return getExternalAlert(ctx, global.GetInstance().GetDB(), alertName, nsName, severity, description)
+}
+
+func getExternalAlert(ctx context.Context, db *gorm.DB, alertName string, nsName string, severity string, description string) (*ExternalAlert, error) {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	alert, err := findExternalAlert(ctx, tx, alertName, nsName, severity, description)
+	if err != nil {
+		tx.Rollback()
+		logger.Error(ctx, "Get External Alert [%s] failed, [%+v]", alertName, err)
+		return nil, err
+	}
+
+	return alert, tx.Commit().Error
+}
+
+type externalAlertRow struct {
+	AlertId       string
+	Disabled      bool
+	AlertStatus   string
+	PolicyId      string
+	RsFilterParam string
+}
+
+func getExternalIds(tx *gorm.DB) (string, string, error) {
+	var rsType models.ResourceType
+	err := tx.Table(models.TableResourceType).Where(models.RtColName+" = ?", models.RsTypeNameExternal).First(&rsType).Error
+	if err != nil {
+		return "", "", err
+	}
+
+	var metric models.Metric
+	err = tx.Table(models.TableMetric).
+		Where(models.MtColName+" = ? and "+models.MtColTypeId+" = ?", models.MetricNameExternal, rsType.RsTypeId).
+		First(&metric).Error
+	if err != nil {
+		return "", "", err
+	}
+
+	return rsType.RsTypeId, metric.MetricId, nil
+}
+
+func newExternalRule(alertName string, severity string, policyId string, metricId string) *models.Rule {
+	return models.NewRule(fmt.Sprintf("%s-%s", alertName, severity), false, 1, severity, "", ">", "0", "", 1, false, policyId, metricId, 0)
+}
+
+func findExternalAlert(ctx context.Context, tx *gorm.DB, alertName string, nsName string, severity string, description string) (*ExternalAlert, error) {
+	rsTypeId, metricId, err := getExternalIds(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []externalAlertRow
+	err = tx.Table("alert t1").
+		Select("t1.alert_id,t1.disabled,t1.alert_status,t1.policy_id,t2.rs_filter_param").
+		Joins("left join resource_filter t2 on t1.rs_filter_id=t2.rs_filter_id").
+		Where("t2.rs_type_id = ? and t1.alert_name = ? and t1.running_status <> ?", rsTypeId, alertName, "deleting").
+		Order("t1.create_time").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var row *externalAlertRow
+	for i := range rows {
+		rsFilterParam := map[string]string{}
+		json.Unmarshal([]byte(rows[i].RsFilterParam), &rsFilterParam)
+		if rsFilterParam["ns_name"] == nsName {
+			row = &rows[i]
+			break
+		}
+	}
+
+	if row == nil {
+		bundle := &AlertBundle{
+			RsFilter: models.NewResourceFilter(alertName, models.ExternalRsFilterParam(nsName), rsTypeId),
+			Policy:   models.NewPolicy(alertName, description, "", ctxutil.GetActor(ctx), "00:00:00", "23:59:59", rsTypeId),
+			Action:   models.NewAction(alertName, "", "", "", ""),
+			Rules:    []*models.Rule{newExternalRule(alertName, severity, "", metricId)},
+			Alert:    models.NewAlert(alertName, false, models.RunningStatusExternal, "", "", ""),
+		}
+		err = createAlertBundleRecords(tx, bundle)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info(ctx, "Created External Alert [%s] [%s].", alertName, bundle.Alert.AlertId)
+
+		return &ExternalAlert{
+			AlertId:            bundle.Alert.AlertId,
+			AlertName:          alertName,
+			RuleId:             bundle.Rules[0].RuleId,
+			RuleName:           bundle.Rules[0].RuleName,
+			Severity:           severity,
+			AvailableStartTime: bundle.Policy.AvailableStartTime,
+			AvailableEndTime:   bundle.Policy.AvailableEndTime,
+			AlertCreated:       true,
+		}, nil
+	}
+
+	alert := &ExternalAlert{
+		AlertId:     row.AlertId,
+		AlertName:   alertName,
+		Disabled:    row.Disabled,
+		AlertStatus: row.AlertStatus,
+		Severity:    severity,
+	}
+
+	var policy models.Policy
+	err = tx.Table(models.TablePolicy).Where(models.PlColId+" = ?", row.PolicyId).First(&policy).Error
+	if err != nil {
+		return nil, err
+	}
+	alert.PolicyConfig = policy.PolicyConfig
+	alert.AvailableStartTime = policy.AvailableStartTime
+	alert.AvailableEndTime = policy.AvailableEndTime
+
+	var actions []models.Action
+	err = tx.Table(models.TableAction).Where(models.AcColPolicyId+" = ?", row.PolicyId).Order(models.AcColCreateTime).Find(&actions).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(actions) != 0 {
+		alert.NfAddressListId = actions[0].NfAddressListId
+		alert.TriggerAction = actions[0].TriggerAction
+	}
+
+	var rules []models.Rule
+	err = tx.Table(models.TableRule).
+		Where(models.RlColPolicyId+" = ? and "+models.RlColMetricId+" = ? and "+models.RlColSeverity+" = ?", row.PolicyId, metricId, severity).
+		Order(models.RlColCreateTime).
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) != 0 {
+		alert.RuleId = rules[0].RuleId
+		alert.RuleName = rules[0].RuleName
+		return alert, nil
+	}
+
+	rule := newExternalRule(alertName, severity, row.PolicyId, metricId)
+	err = tx.Create(rule).Error
+	if err != nil {
+		return nil, err
+	}
+	alert.RuleId = rule.RuleId
+	alert.RuleName = rule.RuleName
+	alert.RuleCreated = true
+
+	return alert, nil
+}
+
+// UpdateExternalAlertStatus saves the status of the external alert alertId along with the
+// histories of the alerts received, when the status is still oldStatus as it was read. Replicas
+// of the manager receive alerts side by side, the status changed by another one in the meantime
+// is left as it is and ErrAlertStatusChanged returned, to read the status again.
+func UpdateExternalAlertStatus(ctx context.Context, alertId string, oldStatus string, alertStatus string, histories []*models.History) error {
+	return updateExternalAlertStatus(ctx, global.GetInstance().GetDB(), alertId, oldStatus, alertStatus, histories)
+}
+
+func updateExternalAlertStatus(ctx context.Context, db *gorm.DB, alertId string, oldStatus string, alertStatus string, histories []*models.History) error {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	res := tx.Table(models.TableAlert).
+		Where(models.AlColId+" = ? and "+models.AlColAlertStatus+" = ?", alertId, oldStatus).
+		Updates(map[string]interface{}{
+			models.AlColAlertStatus: alertStatus,
+			models.AlColUpdateTime:  time.Now(),
+		})
+	if res.Error != nil {
+		tx.Rollback()
+		logger.Error(ctx, "Update External Alert [%s] status failed, [%+v]", alertId, res.Error)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrAlertStatusChanged
+	}
+
+	for _, history := range histories {
+		err := tx.Create(history).Error
+		if err != nil {
+			tx.Rollback()
+			logger.Error(ctx, "Insert History of External Alert [%s] failed, [%+v]", alertId, err)
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// DeleteExternalAlert deletes the alert alertId along with its resource filter, policy, action
+// and rules if it is an external alert, which no executor runs to delete it. It reports whether
+// alertId was an external alert.
+func DeleteExternalAlert(ctx context.Context, alertId string) (bool, error) {
+	return deleteExternalAlert(ctx, global.GetInstance().GetDB(), alertId)
+}
+
+func deleteExternalAlert(ctx context.Context, db *gorm.DB, alertId string) (bool, error) {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	var alerts []models.Alert
+	err := tx.Table(models.TableAlert).
+		Where(models.AlColId+" = ? and "+models.AlColRunningStatus+" = ?", alertId, models.RunningStatusExternal).
+		Find(&alerts).Error
+	if err != nil || len(alerts) == 0 {
+		tx.Rollback()
+		return false, err
+	}
+	alert := alerts[0]
+
+	deletions := []struct {
+		table  string
+		column string
+		value  string
+	}{
+		{models.TableResourceFilter, models.RfColId, alert.RsFilterId},
+		{models.TableRule, models.RlColPolicyId, alert.PolicyId},
+		{models.TableAction, models.AcColPolicyId, alert.PolicyId},
+		{models.TablePolicy, models.PlColId, alert.PolicyId},
+		{models.TableAlert, models.AlColId, alert.AlertId},
+	}
+	for _, deletion := range deletions {
+		err = tx.Exec("DELETE FROM "+deletion.table+" WHERE "+deletion.column+" = ?", deletion.value).Error
+		if err != nil {
+			tx.Rollback()
+			logger.Error(ctx, "Delete %s of External Alert [%s] failed, [%+v]", deletion.table, alertId, err)
+			return false, err
+		}
+	}
+
+	logger.Info(ctx, "Deleted External Alert [%s] [%s].", alert.AlertName, alertId)
+	return true, tx.Commit().Error
+}
+
+// ExternalAlertStatus is the status of an external alert along with the trigger action of its
+// action.
+type ExternalAlertStatus struct {
+	AlertId       string
+	AlertStatus   string
+	TriggerAction string
+}
+
+// GetExternalAlertStatuses returns the statuses of the external alerts with alertIds, or of all
+// the external alerts without alertIds.
+func GetExternalAlertStatuses(ctx context.Context, alertIds []string) ([]*ExternalAlertStatus, error) {
+	return getExternalAlertStatuses(ctx, global.GetInstance().GetDB(), alertIds)
+}
+
+func getExternalAlertStatuses(ctx context.Context, db *gorm.DB, alertIds []string) ([]*ExternalAlertStatus, error) {
+	query := db.Table(models.TableAlert).Where(models.AlColRunningStatus+" = ?", models.RunningStatusExternal)
+	if len(alertIds) != 0 {
+		query = query.Where(models.AlColId+" in (?)", alertIds)
+	}
+
+	var alerts []models.Alert
+	err := query.Find(&alerts).Error
+	if err != nil {
+		logger.Error(ctx, "Get External Alert statuses of %v failed, [%+v]", alertIds, err)
+		return nil, err
+	}
+
+	var statuses []*ExternalAlertStatus
+	for _, alert := range alerts {
+		var actions []models.Action
+		err = db.Table(models.TableAction).Where(models.AcColPolicyId+" = ?", alert.PolicyId).Order(models.AcColCreateTime).Find(&actions).Error
+		if err != nil {
+			logger.Error(ctx, "Get Action of External Alert [%s] failed, [%+v]", alert.AlertId, err)
+			return nil, err
+		}
+
+		status := &ExternalAlertStatus{
+			AlertId:     alert.AlertId,
+			AlertStatus: alert.AlertStatus,
+		}
+		if len(actions) != 0 {
+			status.TriggerAction = actions[0].TriggerAction
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package resource_control
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/internal/testutil"
+	"kubesphere.io/alert/pkg/models"
+)
+
+const (
+	rsTypeIdExternal = "rst-Xq7WzE0tNmLk"
+	metricIdExternal = "mt-Xq7WzE0tAlRt"
+)
+
+func TestGetExternalAlert(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	created, err := getExternalAlert(ctx, db, "HighLatency", "ns1", "major", "")
+	require.NoError(t, err)
+	require.Equal(t, "HighLatency-major", created.RuleName)
+	require.Equal(t, "major", created.Severity)
+	require.Equal(t, "00:00:00", created.AvailableStartTime)
+	require.Equal(t, "23:59:59", created.AvailableEndTime)
+	require.True(t, created.AlertCreated)
+	require.False(t, created.RuleCreated)
+
+	alert := models.Alert{}
+	require.NoError(t, db.Table(models.TableAlert).Where("alert_id = ?", created.AlertId).First(&alert).Error)
+	require.Equal(t, models.RunningStatusExternal, alert.RunningStatus)
+
+	rsFilter := models.ResourceFilter{}
+	require.NoError(t, db.Table(models.TableResourceFilter).Where("rs_filter_id = ?", alert.RsFilterId).First(&rsFilter).Error)
+	require.Equal(t, `{"ns_name":"ns1"}`, rsFilter.RsFilterParam)
+
+	// alerts received later reuse the alert and the rule of their severity
+	received, err := getExternalAlert(ctx, db, "HighLatency", "ns1", "major", "")
+	require.NoError(t, err)
+	require.Equal(t, created.AlertId, received.AlertId)
+	require.Equal(t, created.RuleId, received.RuleId)
+	require.False(t, received.AlertCreated)
+	require.False(t, received.RuleCreated)
+	require.Equal(t, 1, testutil.CountRows(t, db, models.TableAlert))
+	require.Equal(t, 1, testutil.CountRows(t, db, models.TableRule))
+
+	received, err = getExternalAlert(ctx, db, "HighLatency", "ns1", "critical", "")
+	require.NoError(t, err)
+	require.Equal(t, created.AlertId, received.AlertId)
+	require.NotEqual(t, created.RuleId, received.RuleId)
+	require.Equal(t, "HighLatency-critical", received.RuleName)
+	require.False(t, received.AlertCreated)
+	require.True(t, received.RuleCreated)
+	require.Equal(t, 2, testutil.CountRows(t, db, models.TableRule))
+
+	// alerts of the same name in other namespaces are alerts of their own
+	received, err = getExternalAlert(ctx, db, "HighLatency", "ns2", "major", "")
+	require.NoError(t, err)
+	require.NotEqual(t, created.AlertId, received.AlertId)
+	received, err = getExternalAlert(ctx, db, "HighLatency", "", "major", "")
+	require.NoError(t, err)
+	require.NotEqual(t, created.AlertId, received.AlertId)
+	require.Equal(t, 3, testutil.CountRows(t, db, models.TableAlert))
+}
+
+func TestUpdateExternalAlertStatus(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	received, err := getExternalAlert(ctx, db, "HighLatency", "ns1", "major", "")
+	require.NoError(t, err)
+
+	histories := []*models.History{
+		models.NewHistory(received.AlertName, "triggered", "{}", "", received.AlertId, received.RuleId, "instance=node1"),
+		models.NewHistory(received.AlertName, "sent_success", "{}", "nf-1", received.AlertId, received.RuleId, "instance=node1"),
+	}
+	require.NoError(t, updateExternalAlertStatus(ctx, db, received.AlertId, "", `{"status":"firing"}`, histories))
+
+	alert := models.Alert{}
+	require.NoError(t, db.Table(models.TableAlert).Where("alert_id = ?", received.AlertId).First(&alert).Error)
+	require.Equal(t, `{"status":"firing"}`, alert.AlertStatus)
+	require.Equal(t, 2, testutil.CountRows(t, db, models.TableHistory))
+
+	received, err = getExternalAlert(ctx, db, "HighLatency", "ns1", "major", "")
+	require.NoError(t, err)
+	require.Equal(t, `{"status":"firing"}`, received.AlertStatus)
+
+	// the status changed since it was read by another replica is kept, along with its histories
+	require.Equal(t, ErrAlertStatusChanged, updateExternalAlertStatus(ctx, db, received.AlertId, "", `{"status":"resolved"}`, histories[:1]))
+	require.NoError(t, db.Table(models.TableAlert).Where("alert_id = ?", received.AlertId).First(&alert).Error)
+	require.Equal(t, `{"status":"firing"}`, alert.AlertStatus)
+	require.Equal(t, 2, testutil.CountRows(t, db, models.TableHistory))
+}
+
+func TestUpdateExternalAlertBundle(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	received, err := getExternalAlert(ctx, db, "HighLatency", "ns1", "major", "")
+	require.NoError(t, err)
+
+	bundle := &AlertBundle{
+		RsFilter: models.NewResourceFilter("HighLatency", `{"ns_name":"ns1"}`, rsTypeIdExternal),
+		Policy:   models.NewPolicy("HighLatency", "", "{}", "admin", "08:00:00", "20:00:00", rsTypeIdExternal),
+		Alert:    models.NewAlert("HighLatency", false, "adding", "", "", ""),
+	}
+	bundle.Action = models.NewAction("HighLatency", "", "", bundle.Policy.PolicyId, "nl-1")
+	rule := *models.NewRule("HighLatency-major", false, 1, "major", "", ">", "0", "", 1, false, "", metricIdExternal, 0)
+	rule.RuleId = received.RuleId
+	bundle.Rules = []*models.Rule{&rule}
+
+	// external alerts have no executor and stay external when updated
+	require.NoError(t, updateAlertBundle(ctx, db, `{"rs_type_name":"external","ns_name":"ns1"}`, received.AlertId, bundle))
+
+	alert := models.Alert{}
+	require.NoError(t, db.Table(models.TableAlert).Where("alert_id = ?", received.AlertId).First(&alert).Error)
+	require.Equal(t, models.RunningStatusExternal, alert.RunningStatus)
+
+	received, err = getExternalAlert(ctx, db, "HighLatency", "ns1", "major", "")
+	require.NoError(t, err)
+	require.Equal(t, "08:00:00", received.AvailableStartTime)
+	require.Equal(t, "nl-1", received.NfAddressListId)
+}
+
+func TestGetExternalAlertStatuses(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	first, err := getExternalAlert(ctx, db, "HighLatency", "ns1", "major", "")
+	require.NoError(t, err)
+	second, err := getExternalAlert(ctx, db, "HighLatency", "ns2", "major", "")
+	require.NoError(t, err)
+	require.NoError(t, updateExternalAlertStatus(ctx, db, first.AlertId, "", `{"status":"firing"}`, nil))
+
+	alert := models.Alert{}
+	require.NoError(t, db.Table(models.TableAlert).Where("alert_id = ?", first.AlertId).First(&alert).Error)
+	triggerAction := `{"type":"alertmanager_webhook","url":"http://receiver:9093"}`
+	require.NoError(t, db.Table(models.TableAction).Where("policy_id = ?", alert.PolicyId).Update("trigger_action", triggerAction).Error)
+
+	// alerts executors evaluate are not external alerts
+	require.NoError(t, db.Create(models.NewAlert("pod-cpu", false, "running", "", "", "")).Error)
+
+	statuses, err := getExternalAlertStatuses(ctx, db, nil)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+
+	statuses, err = getExternalAlertStatuses(ctx, db, []string{first.AlertId})
+	require.NoError(t, err)
+	require.Equal(t, []*ExternalAlertStatus{{AlertId: first.AlertId, AlertStatus: `{"status":"firing"}`, TriggerAction: triggerAction}}, statuses)
+
+	statuses, err = getExternalAlertStatuses(ctx, db, []string{second.AlertId})
+	require.NoError(t, err)
+	require.Equal(t, []*ExternalAlertStatus{{AlertId: second.AlertId}}, statuses)
+}
+
+func TestDeleteExternalAlert(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	received, err := getExternalAlert(ctx, db, "HighLatency", "ns1", "major", "")
+	require.NoError(t, err)
+	bundle := newTestAlertBundle("pod-cpu", "ns1", 1)
+	require.NoError(t, createAlertBundle(ctx, db, `{"rs_type_name":"namespace","ns_name":"ns1"}`, bundle))
+
+	// alerts executors run are deleted by their executors
+	deleted, err := deleteExternalAlert(ctx, db, bundle.Alert.AlertId)
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	deleted, err = deleteExternalAlert(ctx, db, received.AlertId)
+	require.NoError(t, err)
+	require.True(t, deleted)
+	for _, table := range []string{models.TableAlert, models.TableResourceFilter, models.TablePolicy, models.TableAction, models.TableRule} {
+		require.Equal(t, 1, testutil.CountRows(t, db, table), table)
+	}
+
+	// alerts received later create the alert anew
+	created, err := getExternalAlert(ctx, db, "HighLatency", "ns1", "major", "")
+	require.NoError(t, err)
+	require.True(t, created.AlertCreated)
+}
+
+func TestGetExternalAlertDeleting(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	received, err := getExternalAlert(ctx, db, "HighLatency", "ns1", "major", "")
+	require.NoError(t, err)
+	require.NoError(t, db.Table(models.TableAlert).Where("alert_id = ?", received.AlertId).Update("running_status", "deleting").Error)
+
+	// alerts being deleted do not receive alerts any more
+	created, err := getExternalAlert(ctx, db, "HighLatency", "ns1", "major", "")
+	require.NoError(t, err)
+	require.NotEqual(t, received.AlertId, created.AlertId)
+	require.True(t, created.AlertCreated)
+}
@@ -44,3 +44,13 @@ func DescribeHistories(ctx context.Context, req *pb.DescribeHistoriesRequest) ([
 
 	return hss, count, nil
 }
+
+func CreateHistory(ctx context.Context, history *models.History) error {
+	db := global.GetInstance().GetDB()
+	err := db.Create(history).Error
+	if err != nil {
+		logger.Error(ctx, "Insert History failed, [%+v]", err)
+		return err
+	}
+	return nil
+}
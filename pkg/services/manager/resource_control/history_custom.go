@@ -9,6 +9,7 @@ import (
 	"time"
 
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/jinzhu/gorm"
 
 	nf "kubesphere.io/alert/pkg/client/notification"
 	"kubesphere.io/alert/pkg/constants"
@@ -72,19 +73,11 @@ type DescribeHistoryDetailResponse struct {
 	HistorydetailSet []*HistoryDetail `json:"historydetail_set"`
 }
 
-func parseInValues(values []string) string {
-	output := ""
-
-	for _, value := range values {
-		output = output + fmt.Sprintf(`,'%s'`, value)
-	}
-
-	output = strings.TrimPrefix(output, ",")
-
-	return output
+func DescribeHistoryDetail(ctx context.Context, req *pb.DescribeHistoryDetailRequest) ([]*models.HistoryDetail, uint64, error) {
+	return describeHistoryDetail(ctx, global.GetInstance().GetDB(), req)
 }
 
-func DescribeHistoryDetail(ctx context.Context, req *pb.DescribeHistoryDetailRequest) ([]*models.HistoryDetail, uint64, error) {
+func describeHistoryDetail(ctx context.Context, db *gorm.DB, req *pb.DescribeHistoryDetailRequest) ([]*models.HistoryDetail, uint64, error) {
 	resourceMap := map[string]string{}
 	err := json.Unmarshal([]byte(req.ResourceSearch), &resourceMap)
 	if err != nil {
@@ -110,7 +103,7 @@ func DescribeHistoryDetail(ctx context.Context, req *pb.DescribeHistoryDetailReq
 			AlertName:      []string{alertName},
 		}
 
-		alerts, count, _ := DescribeAlertsWithResource(ctx, reqAlerts)
+		alerts, count, _ := describeAlertsWithResource(ctx, db, reqAlerts)
 
 		if count == 1 {
 			alertIds = append(alertIds, alerts[0].AlertId)
@@ -122,7 +115,7 @@ func DescribeHistoryDetail(ctx context.Context, req *pb.DescribeHistoryDetailReq
 		return nil, 0, errors.New("alert_name not found")
 	}
 
-	dbChain := aldb.GetChain(global.GetInstance().GetDB().Table("history t1").
+	dbChain := aldb.GetChain(db.Table("history t1").
 		Select("t1.history_id,t1.history_name,t1.event,t1.notification_id,t1.rule_id,t1.resource_name,t2.rule_name,t2.severity,t6.rs_type_name,t4.rs_filter_name,t5.metric_name,t2.condition_type,t2.thresholds,t2.unit,t3.alert_name,t4.rs_filter_param,t1.create_time,t1.update_time").
 		Joins("left join rule t2 on t2.rule_id=t1.rule_id").
 		Joins("left join alert t3 on t3.alert_id=t1.alert_id").
@@ -130,11 +123,14 @@ func DescribeHistoryDetail(ctx context.Context, req *pb.DescribeHistoryDetailReq
 		Joins("left join metric t5 on t5.metric_id=t2.metric_id").
 		Joins("left join resource_type t6 on t6.rs_type_id=t4.rs_type_id"))
 
-	whereResult := ""
-	whereTriggered := ""
+	conditions := []string{}
+	args := []interface{}{}
+	where := func(condition string, arg interface{}) {
+		conditions = append(conditions, condition)
+		args = append(args, arg)
+	}
 
-	whereResult = whereResult + fmt.Sprintf(`t6.rs_type_name in ("%s") and `, resourceMap["rs_type_name"])
-	whereTriggered = whereTriggered + fmt.Sprintf(`t6.rs_type_name in ("%s") and `, resourceMap["rs_type_name"])
+	where(`t6.rs_type_name in (?)`, resourceMap["rs_type_name"])
 
 	switch resourceMap["rs_type_name"] {
 	case "cluster":
@@ -143,92 +139,82 @@ func DescribeHistoryDetail(ctx context.Context, req *pb.DescribeHistoryDetailReq
 		break
 	case "workspace":
 		if resourceMap["ws_name"] != "" {
-			whereResult = whereResult + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.ws_name") in ("%s") and `, resourceMap["ws_name"])
-			whereTriggered = whereTriggered + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.ws_name") in ("%s") and `, resourceMap["ws_name"])
+			where(aldb.JsonExtract("t4.rs_filter_param", "ws_name")+" in (?)", resourceMap["ws_name"])
 		}
 	case "namespace":
 		if resourceMap["ns_name"] != "" {
-			whereResult = whereResult + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.ns_name") in ("%s") and `, resourceMap["ns_name"])
-			whereTriggered = whereTriggered + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.ns_name") in ("%s") and `, resourceMap["ns_name"])
+			where(aldb.JsonExtract("t4.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 		}
 	case "workload":
 		if resourceMap["ns_name"] != "" {
-			whereResult = whereResult + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.ns_name") in ("%s") and `, resourceMap["ns_name"])
-			whereTriggered = whereTriggered + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.ns_name") in ("%s") and `, resourceMap["ns_name"])
+			where(aldb.JsonExtract("t4.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 		}
 	case "pod":
 		if resourceMap["ns_name"] != "" {
-			whereResult = whereResult + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.ns_name") in ("%s") and `, resourceMap["ns_name"])
-			whereTriggered = whereTriggered + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.ns_name") in ("%s") and `, resourceMap["ns_name"])
+			where(aldb.JsonExtract("t4.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 		}
 		if resourceMap["node_id"] != "" {
-			whereResult = whereResult + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.node_id") in ("%s") and `, resourceMap["node_id"])
-			whereTriggered = whereTriggered + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.node_id") in ("%s") and `, resourceMap["node_id"])
+			where(aldb.JsonExtract("t4.rs_filter_param", "node_id")+" in (?)", resourceMap["node_id"])
 		}
 	case "container":
 		if resourceMap["ns_name"] != "" {
-			whereResult = whereResult + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.ns_name") in ("%s") and `, resourceMap["ns_name"])
-			whereTriggered = whereTriggered + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.ns_name") in ("%s") and `, resourceMap["ns_name"])
+			where(aldb.JsonExtract("t4.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 		}
 		if resourceMap["node_id"] != "" {
-			whereResult = whereResult + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.node_id") in ("%s") and `, resourceMap["node_id"])
-			whereTriggered = whereTriggered + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.node_id") in ("%s") and `, resourceMap["node_id"])
+			where(aldb.JsonExtract("t4.rs_filter_param", "node_id")+" in (?)", resourceMap["node_id"])
 		}
 		if resourceMap["pod_name"] != "" {
-			whereResult = whereResult + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.pod_name") in ("%s") and `, resourceMap["pod_name"])
-			whereTriggered = whereTriggered + fmt.Sprintf(`JSON_EXTRACT(t4.rs_filter_param, "$.pod_name") in ("%s") and `, resourceMap["pod_name"])
+			where(aldb.JsonExtract("t4.rs_filter_param", "pod_name")+" in (?)", resourceMap["pod_name"])
+		}
+	case "external":
+		if resourceMap["ns_name"] != "" {
+			where(aldb.JsonExtract("t4.rs_filter_param", "ns_name")+" in (?)", resourceMap["ns_name"])
 		}
 	}
 
 	if len(historyId) != 0 {
-		whereResult = whereResult + fmt.Sprintf("t1.history_id in (%s) and ", parseInValues(historyId))
-		whereTriggered = whereTriggered + fmt.Sprintf("t1.history_id in (%s) and ", parseInValues(historyId))
+		where("t1.history_id in (?)", historyId)
 	}
 	if len(historyName) != 0 {
-		whereResult = whereResult + fmt.Sprintf("t1.history_name in (%s) and ", parseInValues(historyName))
-		whereTriggered = whereTriggered + fmt.Sprintf("t1.history_name in (%s) and ", parseInValues(historyName))
+		where("t1.history_name in (?)", historyName)
 	}
 	if len(alertIds) != 0 {
-		whereResult = whereResult + fmt.Sprintf("t3.alert_id in (%s) and ", parseInValues(alertIds))
-		whereTriggered = whereTriggered + fmt.Sprintf("t3.alert_id in (%s) and ", parseInValues(alertIds))
+		where("t3.alert_id in (?)", alertIds)
 	}
 	if len(ruleName) != 0 {
-		whereResult = whereResult + fmt.Sprintf("t2.rule_name in (%s) and ", parseInValues(ruleName))
-		whereTriggered = whereTriggered + fmt.Sprintf("t2.rule_name in (%s) and ", parseInValues(ruleName))
-	}
-	if len(event) != 0 {
-		whereResult = whereResult + fmt.Sprintf("t1.event in (%s) and ", parseInValues(event))
+		where("t2.rule_name in (?)", ruleName)
 	}
-	whereTriggered = whereTriggered + fmt.Sprintf("t1.event = 'triggered' and ")
 	if len(ruleId) != 0 {
-		whereResult = whereResult + fmt.Sprintf("t1.rule_id in (%s) and ", parseInValues(ruleId))
-		whereTriggered = whereTriggered + fmt.Sprintf("t1.rule_id in (%s) and ", parseInValues(ruleId))
+		where("t1.rule_id in (?)", ruleId)
 	}
 	if len(resourceName) != 0 {
-		whereResult = whereResult + fmt.Sprintf("t1.resource_name in (%s) and ", parseInValues(resourceName))
-		whereTriggered = whereTriggered + fmt.Sprintf("t1.resource_name in (%s) and ", parseInValues(resourceName))
+		where("t1.resource_name in (?)", resourceName)
 	}
 	//Step2: get SearchWord
 	if req.SearchWord != "" {
-		whereResult = whereResult + fmt.Sprintf("t1.resource_name LIKE '%%%s%%' and ", req.SearchWord)
-		whereTriggered = whereTriggered + fmt.Sprintf("t1.resource_name LIKE '%%%s%%' and ", req.SearchWord)
+		where("t1.resource_name LIKE ?", "%"+req.SearchWord+"%")
 	}
 
-	whereResult = strings.TrimSuffix(whereResult, " and ")
-	whereTriggered = strings.TrimSuffix(whereTriggered, " and ")
+	//the most recent triggered history matches every condition but the requested events
+	whereTriggered := strings.Join(append(conditions, "t1.event = 'triggered'"), " and ")
+	argsTriggered := args
+	if len(event) != 0 {
+		where("t1.event in (?)", event)
+	}
+	whereResult := strings.Join(conditions, " and ")
 
 	//Step3: get Recent
 	if req.Recent {
-		dbChain.DB = dbChain.DB.Where(fmt.Sprintf(`%s and t1.create_time >= (select max(t1.create_time) from history t1 left join rule t2 on t2.rule_id=t1.rule_id left join alert t3 on t3.alert_id=t1.alert_id left join resource_filter t4 on t4.rs_filter_id=t3.rs_filter_id left join metric t5 on t5.metric_id=t2.metric_id left join resource_type t6 on t6.rs_type_id=t4.rs_type_id where %s)`, whereResult, whereTriggered))
+		dbChain.DB = dbChain.DB.Where(fmt.Sprintf(`%s and t1.create_time >= (select max(t1.create_time) from history t1 left join rule t2 on t2.rule_id=t1.rule_id left join alert t3 on t3.alert_id=t1.alert_id left join resource_filter t4 on t4.rs_filter_id=t3.rs_filter_id left join metric t5 on t5.metric_id=t2.metric_id left join resource_type t6 on t6.rs_type_id=t4.rs_type_id where %s)`, whereResult, whereTriggered), append(args, argsTriggered...)...)
 	} else {
-		dbChain.DB = dbChain.DB.Where(whereResult)
+		dbChain.DB = dbChain.DB.Where(whereResult, args...)
 	}
 	//Step4: get OrderByStr
 	var sortKeyStr string = "t1.create_time"
 	var reverseStr string = constants.ASC
 	orderByStr := sortKeyStr + " " + reverseStr
 
-	if req.SortKey != "" {
+	if isColumn(req.SortKey) {
 		sortKeyStr = req.SortKey
 		if req.Reverse {
 			reverseStr = constants.DESC
@@ -262,7 +248,7 @@ func DescribeHistoryDetail(ctx context.Context, req *pb.DescribeHistoryDetailReq
 	}
 
 	if len(notificationIds) > 0 {
-		notificationStatusMap := nf.GetNotificationStatus(notificationIds)
+		notificationStatusMap := nf.GetNotificationStatus(ctx, notificationIds)
 
 		if notificationStatusMap != nil {
 			for _, hsd := range hsds {
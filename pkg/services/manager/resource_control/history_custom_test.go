@@ -0,0 +1,77 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package resource_control
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/internal/testutil"
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/pb"
+)
+
+const hostileHistoryId = "hs-'); DROP TABLE history; --"
+
+func TestDescribeHistoryDetail(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	resourceSearch := `{"rs_type_name":"namespace","ns_name":"ns1"}`
+	bundle := newTestAlertBundle(`alert's "1"`, "ns1", 1)
+	require.NoError(t, createAlertBundle(ctx, db, resourceSearch, bundle))
+	other := newTestAlertBundle("alert-2", "ns1", 1)
+	require.NoError(t, createAlertBundle(ctx, db, resourceSearch, other))
+
+	now := time.Now().Truncate(time.Second)
+	triggered := models.NewHistory("history", "triggered", "", "", bundle.Alert.AlertId, bundle.Rules[0].RuleId, `pod's "name"`)
+	triggered.HistoryId = hostileHistoryId
+	triggered.CreateTime = now
+	recovered := models.NewHistory("history", "recovered", "", "", bundle.Alert.AlertId, bundle.Rules[0].RuleId, "pod-2")
+	recovered.CreateTime = now.Add(time.Minute)
+	otherTriggered := models.NewHistory("history", "triggered", "", "", other.Alert.AlertId, other.Rules[0].RuleId, "pod-3")
+	otherTriggered.CreateTime = now.Add(2 * time.Minute)
+	for _, history := range []*models.History{triggered, recovered, otherTriggered} {
+		require.NoError(t, db.Create(history).Error)
+	}
+
+	describe := func(req *pb.DescribeHistoryDetailRequest) []string {
+		req.ResourceSearch = resourceSearch
+		hsds, count, err := describeHistoryDetail(ctx, db, req)
+		require.NoError(t, err)
+		require.Equal(t, uint64(len(hsds)), count)
+		historyIds := []string{}
+		for _, hsd := range hsds {
+			historyIds = append(historyIds, hsd.HistoryId)
+		}
+		return historyIds
+	}
+
+	require.Equal(t, []string{hostileHistoryId}, describe(&pb.DescribeHistoryDetailRequest{HistoryId: []string{hostileHistoryId}}))
+	require.Equal(t, []string{hostileHistoryId, recovered.HistoryId}, describe(&pb.DescribeHistoryDetailRequest{AlertName: []string{`alert's "1"`}}))
+	require.Equal(t, []string{hostileHistoryId}, describe(&pb.DescribeHistoryDetailRequest{SearchWord: `'s "`}))
+	require.Equal(t, []string{}, describe(&pb.DescribeHistoryDetailRequest{SearchWord: "x' OR '1'='1"}))
+	require.Equal(t, []string{}, describe(&pb.DescribeHistoryDetailRequest{ResourceName: []string{"pod-2' OR '1'='1"}}))
+
+	// the most recent histories are the ones since the alert was last triggered
+	require.Equal(t, []string{recovered.HistoryId}, describe(&pb.DescribeHistoryDetailRequest{
+		AlertName: []string{`alert's "1"`},
+		Event:     []string{"recovered"},
+		Recent:    true,
+	}))
+
+	hsds, _, err := describeHistoryDetail(ctx, db, &pb.DescribeHistoryDetailRequest{
+		ResourceSearch: `{"rs_type_name":"namespace","ns_name":"ns1' OR '1'='1"}`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(hsds))
+
+	require.Equal(t, 3, testutil.CountRows(t, db, models.TableHistory))
+	require.Equal(t, 2, testutil.CountRows(t, db, models.TableAlert))
+}
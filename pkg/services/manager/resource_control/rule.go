@@ -73,6 +73,7 @@ func ModifyRule(ctx context.Context, req *pb.ModifyRuleRequest) (string, error)
 	}
 	attributes[models.RlColDisabled] = req.Disabled
 	attributes[models.RlColMonitorPeriods] = req.MonitorPeriods
+	attributes[models.RlColEvaluationInterval] = req.EvaluationInterval
 	if req.Severity != "" {
 		attributes[models.RlColSeverity] = req.Severity
 	}
@@ -0,0 +1,123 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package resource_control
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+
+	"kubesphere.io/alert/pkg/global"
+	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/models"
+)
+
+// ResourceScope is the resource type and the filter param of a resource filter, which decide
+// the tenant owning the filter, the alerts watching it and the policies, rules and actions
+// of those alerts.
+type ResourceScope struct {
+	RsTypeName    string `gorm:"column:rs_type_name"`
+	RsFilterParam string `gorm:"column:rs_filter_param"`
+}
+
+// GetResourceScopes returns the scopes of the resources with resourceIds. Resource filters
+// and alerts have one scope each, policies, rules, actions and histories have the scopes of
+// the alerts using them, none if unused.
+func GetResourceScopes(ctx context.Context, resourceType string, resourceIds []string) ([]ResourceScope, error) {
+	var scopes []ResourceScope
+	if len(resourceIds) == 0 {
+		return scopes, nil
+	}
+
+	db := global.GetInstance().GetDB().
+		Table("resource_filter t1").
+		Select("t2.rs_type_name,t1.rs_filter_param").
+		Joins("left join resource_type t2 on t2.rs_type_id=t1.rs_type_id")
+
+	switch resourceType {
+	case models.TableResourceFilter:
+		db = db.Where("t1.rs_filter_id in (?)", resourceIds)
+	case models.TableAlert:
+		db = db.Joins("join alert t3 on t3.rs_filter_id=t1.rs_filter_id").
+			Where("t3.alert_id in (?)", resourceIds)
+	case models.TablePolicy:
+		db = db.Joins("join alert t3 on t3.rs_filter_id=t1.rs_filter_id").
+			Where("t3.policy_id in (?)", resourceIds)
+	case models.TableRule:
+		db = db.Joins("join alert t3 on t3.rs_filter_id=t1.rs_filter_id").
+			Joins("join rule t4 on t4.policy_id=t3.policy_id").
+			Where("t4.rule_id in (?)", resourceIds)
+	case models.TableAction:
+		db = db.Joins("join alert t3 on t3.rs_filter_id=t1.rs_filter_id").
+			Joins("join action t4 on t4.policy_id=t3.policy_id").
+			Where("t4.action_id in (?)", resourceIds)
+	case models.TableHistory:
+		db = db.Joins("join alert t3 on t3.rs_filter_id=t1.rs_filter_id").
+			Joins("join history t4 on t4.alert_id=t3.alert_id").
+			Where("t4.history_id in (?)", resourceIds)
+	default:
+		return nil, fmt.Errorf("unsupported scope resource type [%s]", resourceType)
+	}
+
+	err := db.Scan(&scopes).Error
+	if err != nil {
+		logger.Error(ctx, "Get Resource scopes of %s%v failed, [%+v]", resourceType, resourceIds, err)
+		return nil, err
+	}
+
+	return scopes, nil
+}
+
+// GetUnusedPolicyCreators maps the policies with policyIds no alert uses to their creators, the
+// tenants owning them until alerts use them.
+func GetUnusedPolicyCreators(ctx context.Context, policyIds []string) (map[string]string, error) {
+	creators, err := getUnusedPolicyCreators(global.GetInstance().GetDB(), policyIds)
+	if err != nil {
+		logger.Error(ctx, "Get creators of unused Policies %v failed, [%+v]", policyIds, err)
+		return nil, err
+	}
+	return creators, nil
+}
+
+func getUnusedPolicyCreators(db *gorm.DB, policyIds []string) (map[string]string, error) {
+	creators := make(map[string]string)
+	if len(policyIds) == 0 {
+		return creators, nil
+	}
+
+	var policies []*models.Policy
+	err := db.Table(models.TablePolicy).
+		Where(models.PlColId+" in (?)", policyIds).
+		Where(models.PlColId+" not in (select policy_id from alert where policy_id in (?))", policyIds).
+		Find(&policies).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, policy := range policies {
+		creators[policy.PolicyId] = policy.Creator
+	}
+	return creators, nil
+}
+
+// GetResourceTypeName returns the name of the resource type with rsTypeId, empty if it does
+// not exist.
+func GetResourceTypeName(ctx context.Context, rsTypeId string) (string, error) {
+	var rsTypes []*models.ResourceType
+	err := global.GetInstance().GetDB().
+		Table(models.TableResourceType).
+		Where(models.RtColId+" = ?", rsTypeId).
+		Find(&rsTypes).Error
+	if err != nil {
+		logger.Error(ctx, "Get Resource type [%s] failed, [%+v]", rsTypeId, err)
+		return "", err
+	}
+
+	if len(rsTypes) == 0 {
+		return "", nil
+	}
+	return rsTypes[0].RsTypeName, nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package resource_control
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/models"
+)
+
+func TestGetUnusedPolicyCreators(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	bundle := newTestAlertBundle("alert-1", "ns1", 1)
+	require.NoError(t, createAlertBundle(context.Background(), db, `{"rs_type_name":"namespace","ns_name":"ns1"}`, bundle))
+	unused := models.NewPolicy("policy", "", "{}", "tenant1", "00:00:00", "23:59:59", "")
+	require.NoError(t, db.Create(unused).Error)
+
+	// policies alerts use belong to the tenants of the alerts
+	creators, err := getUnusedPolicyCreators(db, []string{bundle.Policy.PolicyId, unused.PolicyId, "pl-missing"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{unused.PolicyId: "tenant1"}, creators)
+
+	creators, err = getUnusedPolicyCreators(db, nil)
+	require.NoError(t, err)
+	require.Empty(t, creators)
+}
@@ -5,41 +5,82 @@
 package manager
 
 import (
+	"os"
 	"strconv"
+	"sync"
 
 	"google.golang.org/grpc"
 
+	nf "kubesphere.io/alert/pkg/client/notification"
 	"kubesphere.io/alert/pkg/config"
+	"kubesphere.io/alert/pkg/global"
+	"kubesphere.io/alert/pkg/logger"
 	"kubesphere.io/alert/pkg/manager"
+	"kubesphere.io/alert/pkg/monitoring"
 	"kubesphere.io/alert/pkg/pb"
+	"kubesphere.io/alert/pkg/util/tlsutil"
 )
 
 type Server struct {
-	alertQueue     *AlertQueue
-	alertBroadcast *AlertBroadcast
+	alertQueue       *AlertQueue
+	alertBroadcast   *AlertBroadcast
+	executorRegistry *ExecutorRegistry
+	// receiveMutex serializes receiving external alerts within the replica, the replicas of the
+	// manager write their status only if unchanged since it was read
+	receiveMutex sync.Mutex
 }
 
 func Serve() {
 	alertQueue := NewAlertQueue()
 	alertBroadcast := NewAlertBroadcast()
+	executorRegistry := NewExecutorRegistry()
 
 	s := &Server{
-		alertQueue:     alertQueue,
-		alertBroadcast: alertBroadcast,
+		alertQueue:       alertQueue,
+		alertBroadcast:   alertBroadcast,
+		executorRegistry: executorRegistry,
 	}
 
+	g := global.GetInstance()
+	monitoring.AddLivenessCheck("database", g.CheckDB)
+	monitoring.AddLivenessCheck("etcd", g.CheckEtcd)
+	monitoring.AddReadinessCheck("queue", alertQueue.CheckQueue)
+	monitoring.AddReadinessCheck("notification", nf.CheckConnection)
+
 	cfg := config.GetInstance()
 
 	managerHost := cfg.App.Host
 	managerPort, _ := strconv.Atoi(cfg.App.Port)
 
 	go ServeApiGateway()
+	go s.watchExternalAlerts()
 
-	manager.NewGrpcServer(managerHost, managerPort).
+	grpcServer := manager.NewGrpcServer(managerHost, managerPort).
 		ShowErrorCause(cfg.Grpc.ShowErrorCause).
 		WithChecker(s.Checker).
-		Serve(func(server *grpc.Server) {
-			pb.RegisterAlertManagerServer(server, s)
-			pb.RegisterAlertManagerCustomServer(server, s)
-		})
+		WithInterceptor(s.Auditor)
+
+	if cfg.Auth.Enable {
+		authorizer, err := NewAuthorizer(cfg.Auth, cfg.Tls)
+		if err != nil {
+			logger.Critical(nil, "Failed to init authorizer: %+v", err)
+			os.Exit(1)
+		}
+		grpcServer.WithAuthorizer(authorizer.Authorize)
+	}
+
+	var opts []grpc.ServerOption
+	if cfg.Tls.Enable {
+		creds, err := tlsutil.NewServerCredentials(cfg.Tls.CertFile, cfg.Tls.KeyFile, cfg.Tls.CaFile)
+		if err != nil {
+			logger.Critical(nil, "Failed to load server certificates: %+v", err)
+			os.Exit(1)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	grpcServer.Serve(func(server *grpc.Server) {
+		pb.RegisterAlertManagerServer(server, s)
+		pb.RegisterAlertManagerCustomServer(server, s)
+	}, opts...)
 }
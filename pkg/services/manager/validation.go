@@ -6,10 +6,15 @@ package manager
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
+
 	"kubesphere.io/alert/pkg/gerr"
 	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/notification"
 	"kubesphere.io/alert/pkg/pb"
 )
 
@@ -33,6 +38,38 @@ func checkTimeFormat(ctx context.Context, timeStr string) error {
 	}
 }
 
+// MinEvaluationInterval is the shortest rule evaluation interval in seconds,
+// zero means the rule is evaluated every monitor_periods minutes.
+const MinEvaluationInterval = 5
+
+func checkEvaluationInterval(ctx context.Context, interval uint32) error {
+	if interval == 0 || interval >= MinEvaluationInterval {
+		return nil
+	} else {
+		return gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorEvaluationIntervalTooShort, interval, MinEvaluationInterval)
+	}
+}
+
+func checkTriggerAction(ctx context.Context, triggerAction string) error {
+	_, err := models.ParseActionConfig(triggerAction)
+	if err == nil {
+		return nil
+	} else {
+		return gerr.NewWithDetail(ctx, gerr.InvalidArgument, err, gerr.ErrorUnsupportedParameterValue, "trigger_action", triggerAction)
+	}
+}
+
+func ValidateModifyExecutorParams(ctx context.Context, req *pb.ModifyExecutorRequest) error {
+	executorId := req.GetExecutorId()
+	err := checkStringLen(ctx, executorId, 255)
+	if err != nil {
+		logger.Error(ctx, "Failed to validate ExecutorId [%s]: %+v", executorId, err)
+		return err
+	}
+
+	return nil
+}
+
 func ValidateCreateResourceTypeParams(ctx context.Context, req *pb.CreateResourceTypeRequest) error {
 	rsTypeName := req.GetRsTypeName()
 	err := checkStringLen(ctx, rsTypeName, 50)
@@ -290,6 +327,13 @@ func ValidateCreateRuleParams(ctx context.Context, req *pb.CreateRuleRequest) er
 		return err
 	}
 
+	evaluationInterval := req.GetEvaluationInterval()
+	err = checkEvaluationInterval(ctx, evaluationInterval)
+	if err != nil {
+		logger.Error(ctx, "Failed to validate EvaluationInterval [%d]: %+v", evaluationInterval, err)
+		return err
+	}
+
 	return nil
 }
 
@@ -343,6 +387,13 @@ func ValidateModifyRuleParams(ctx context.Context, req *pb.ModifyRuleRequest) er
 		return err
 	}
 
+	evaluationInterval := req.GetEvaluationInterval()
+	err = checkEvaluationInterval(ctx, evaluationInterval)
+	if err != nil {
+		logger.Error(ctx, "Failed to validate EvaluationInterval [%d]: %+v", evaluationInterval, err)
+		return err
+	}
+
 	return nil
 }
 
@@ -450,6 +501,12 @@ func ValidateCreateActionParams(ctx context.Context, req *pb.CreateActionRequest
 		return err
 	}
 
+	err = checkTriggerAction(ctx, triggerAction)
+	if err != nil {
+		logger.Error(ctx, "Failed to validate TriggerAction [%s]: %+v", triggerAction, err)
+		return err
+	}
+
 	policyId := req.GetPolicyId()
 	err = checkStringLen(ctx, policyId, 50)
 	if err != nil {
@@ -496,6 +553,12 @@ func ValidateModifyActionParams(ctx context.Context, req *pb.ModifyActionRequest
 		return err
 	}
 
+	err = checkTriggerAction(ctx, triggerAction)
+	if err != nil {
+		logger.Error(ctx, "Failed to validate TriggerAction [%s]: %+v", triggerAction, err)
+		return err
+	}
+
 	policyId := req.GetPolicyId()
 	err = checkStringLen(ctx, policyId, 50)
 	if err != nil {
@@ -512,3 +575,206 @@ func ValidateModifyActionParams(ctx context.Context, req *pb.ModifyActionRequest
 
 	return nil
 }
+
+// checkResourceSearch parses the resource search an alert is created under and checks the
+// resource filter param stays inside of it, eg. in the namespace of the url.
+func parseResourceSearch(ctx context.Context, resourceSearch string) (map[string]string, error) {
+	resourceMap := map[string]string{}
+	err := json.Unmarshal([]byte(resourceSearch), &resourceMap)
+	if err != nil {
+		logger.Error(ctx, "Failed to validate ResourceSearch [%s]: %+v", resourceSearch, err)
+		return nil, gerr.NewWithDetail(ctx, gerr.InvalidArgument, err, gerr.ErrorUnsupportedParameterValue, "resource_search", resourceSearch)
+	}
+
+	switch resourceMap["rs_type_name"] {
+	case "cluster", "node", "workspace", "namespace", "workload", "pod", "container", "external":
+	default:
+		return nil, gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorUnsupportedParameterValue, "resource_search", resourceSearch)
+	}
+
+	return resourceMap, nil
+}
+
+func checkResourceSearch(ctx context.Context, resourceSearch string, rsFilterParam string) (map[string]string, error) {
+	resourceMap, err := parseResourceSearch(ctx, resourceSearch)
+	if err != nil {
+		return nil, err
+	}
+
+	rsFilterURI := map[string]string{}
+	err = json.Unmarshal([]byte(rsFilterParam), &rsFilterURI)
+	if err != nil {
+		logger.Error(ctx, "Failed to validate RsFilterParam [%s]: %+v", rsFilterParam, err)
+		return nil, gerr.NewWithDetail(ctx, gerr.InvalidArgument, err, gerr.ErrorUnsupportedParameterValue, "rs_filter_param", rsFilterParam)
+	}
+
+	uriCorrect := true
+	switch resourceMap["rs_type_name"] {
+	case "workspace":
+		uriCorrect = resourceMap["ws_name"] == rsFilterURI["ws_name"]
+	case "namespace", "workload", "pod", "container", "external":
+		uriCorrect = resourceMap["ns_name"] == rsFilterURI["ns_name"]
+	}
+
+	if !uriCorrect {
+		logger.Error(ctx, "Failed to validate RsFilterParam [%s] under [%s]", rsFilterParam, resourceSearch)
+		return nil, gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorUnsupportedParameterValue, "rs_filter_param", rsFilterParam)
+	}
+
+	return resourceMap, nil
+}
+
+// validateAlertBundle validates the parts of an alert bundle, rules of an alert update are
+// validated as the rules they are created from.
+func validateAlertBundle(ctx context.Context, resourceSearch string, rsFilter *pb.CreateResourceFilterRequest, policy *pb.CreatePolicyRequest, action *pb.CreateActionRequest, rules []*pb.CreateRuleRequest, alert *pb.CreateAlertRequest) error {
+	if rsFilter == nil {
+		return gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorMissingParameter, "rs_filter")
+	}
+	if policy == nil {
+		return gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorMissingParameter, "policy")
+	}
+	if action == nil {
+		return gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorMissingParameter, "action")
+	}
+	if len(rules) == 0 {
+		return gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorMissingParameter, "rules")
+	}
+	if alert.GetAlertName() == "" {
+		return gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorMissingParameter, "alert_name")
+	}
+
+	_, err := checkResourceSearch(ctx, resourceSearch, rsFilter.GetRsFilterParam())
+	if err != nil {
+		return err
+	}
+
+	err = ValidateCreateResourceFilterParams(ctx, rsFilter)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateCreatePolicyParams(ctx, policy)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateCreateActionParams(ctx, action)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if rule == nil {
+			return gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorMissingParameter, "rule")
+		}
+		err = ValidateCreateRuleParams(ctx, rule)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ValidateCreateAlertParams(ctx, alert)
+}
+
+func ValidateCreateAlertBundleParams(ctx context.Context, req *pb.CreateAlertBundleRequest) error {
+	return validateAlertBundle(ctx, req.GetResourceSearch(), req.GetRsFilter(), req.GetPolicy(), req.GetAction(), req.GetRules(), req.GetAlert())
+}
+
+func ValidateUpdateAlertBundleParams(ctx context.Context, req *pb.UpdateAlertBundleRequest) error {
+	alertId := req.GetAlertId()
+	if alertId == "" {
+		return gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorMissingParameter, "alert_id")
+	}
+	err := checkStringLen(ctx, alertId, 50)
+	if err != nil {
+		logger.Error(ctx, "Failed to validate AlertId [%s]: %+v", alertId, err)
+		return err
+	}
+
+	rules := []*pb.CreateRuleRequest{}
+	for _, rule := range req.GetRules() {
+		err = checkStringLen(ctx, rule.GetRuleId(), 50)
+		if err != nil {
+			logger.Error(ctx, "Failed to validate RuleId [%s]: %+v", rule.GetRuleId(), err)
+			return err
+		}
+		rules = append(rules, rule.GetRule())
+	}
+
+	return validateAlertBundle(ctx, req.GetResourceSearch(), req.GetRsFilter(), req.GetPolicy(), req.GetAction(), rules, req.GetAlert())
+}
+
+func ValidateExportAlertsParams(ctx context.Context, req *pb.ExportAlertsRequest) error {
+	_, err := parseResourceSearch(ctx, req.GetResourceSearch())
+	if err != nil {
+		return err
+	}
+
+	switch req.GetFormat() {
+	case "", models.AlertDocumentFormatYaml, models.AlertDocumentFormatJson:
+	default:
+		return gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorUnsupportedParameterValue, "format", req.GetFormat())
+	}
+
+	return nil
+}
+
+func ValidateImportAlertsParams(ctx context.Context, req *pb.ImportAlertsRequest) error {
+	_, err := parseResourceSearch(ctx, req.GetResourceSearch())
+	if err != nil {
+		return err
+	}
+
+	if req.GetDocument() == "" {
+		return gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorMissingParameter, "document")
+	}
+
+	return nil
+}
+
+func ValidateDescribeAuditLogsParams(ctx context.Context, req *pb.DescribeAuditLogsRequest) error {
+	if req.GetStartTime() != nil {
+		_, err := ptypes.Timestamp(req.GetStartTime())
+		if err != nil {
+			logger.Error(ctx, "Failed to validate StartTime [%+v]: %+v", req.GetStartTime(), err)
+			return gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorIllegalTimeFormat, req.GetStartTime().String())
+		}
+	}
+
+	if req.GetEndTime() != nil {
+		_, err := ptypes.Timestamp(req.GetEndTime())
+		if err != nil {
+			logger.Error(ctx, "Failed to validate EndTime [%+v]: %+v", req.GetEndTime(), err)
+			return gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorIllegalTimeFormat, req.GetEndTime().String())
+		}
+	}
+
+	return nil
+}
+
+// parseReceivedAlerts reads the alerts external systems post, every alert needs an alertname
+// label and a severity label of a supported value, if any.
+func parseReceivedAlerts(ctx context.Context, alerts string) ([]notification.AlertmanagerAlert, error) {
+	if alerts == "" {
+		return nil, gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorMissingParameter, "alerts")
+	}
+
+	var receivedAlerts []notification.AlertmanagerAlert
+	err := json.Unmarshal([]byte(alerts), &receivedAlerts)
+	if err != nil {
+		logger.Error(ctx, "Failed to Parse Received Alerts, [%+v].", err)
+		return nil, gerr.NewWithDetail(ctx, gerr.InvalidArgument, err, gerr.ErrorValidateFailed)
+	}
+
+	for _, receivedAlert := range receivedAlerts {
+		if receivedAlert.Labels["alertname"] == "" {
+			return nil, gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorMissingParameter, "labels.alertname")
+		}
+		severity := receivedAlert.Labels["severity"]
+		if _, ok := models.ParseSeverity(severity); !ok {
+			return nil, gerr.New(ctx, gerr.InvalidArgument, gerr.ErrorUnsupportedParameterValue, "labels.severity", severity)
+		}
+	}
+
+	return receivedAlerts, nil
+}
@@ -6,6 +6,7 @@ package watcher
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	lib "openpitrix.io/libqueue"
@@ -14,6 +15,7 @@ import (
 	"kubesphere.io/alert/pkg/config"
 	"kubesphere.io/alert/pkg/constants"
 	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/monitoring"
 )
 
 type AlertQueue struct {
@@ -43,8 +45,16 @@ func NewAlertQueue() *AlertQueue {
 	}
 }
 
+func (aq *AlertQueue) CheckQueue(ctx context.Context) error {
+	if aq.alertQueue == nil {
+		return errors.New("AlertQueue not initialized")
+	}
+
+	return monitoring.CheckQueue(ctx)
+}
+
 func (aq *AlertQueue) WriteBackAlert(alertId string) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	ctx, cancel := context.WithTimeout(logger.WithField(nil, logger.AlertIdField, alertId), time.Second)
 	defer cancel()
 	aq.createAlert(ctx, alertId)
 }
@@ -14,6 +14,7 @@ import (
 
 	"kubesphere.io/alert/pkg/global"
 	"kubesphere.io/alert/pkg/logger"
+	"kubesphere.io/alert/pkg/monitoring"
 	rs "kubesphere.io/alert/pkg/services/watcher/resource_control"
 	"kubesphere.io/alert/pkg/util/jsonutil"
 )
@@ -163,10 +164,19 @@ func (ew *ExecutorWatcher) migrateAlerts(executorId string) {
 
 	for _, alert := range alertsToMigrate {
 		ew.alertQueue.WriteBackAlert(alert.AlertId)
+		monitoring.MigrationsTotal.WithLabelValues("executor_deleted").Inc()
 	}
 }
 
+func (ew *ExecutorWatcher) registerHealthChecks() {
+	g := global.GetInstance()
+	monitoring.AddLivenessCheck("database", g.CheckDB)
+	monitoring.AddLivenessCheck("etcd", g.CheckEtcd)
+	monitoring.AddReadinessCheck("queue", ew.alertQueue.CheckQueue)
+}
+
 func (ew *ExecutorWatcher) Serve() {
+	ew.registerHealthChecks()
 	ew.initExecutors()
 
 	go ew.healthChecker.HealthCheck()
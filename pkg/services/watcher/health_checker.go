@@ -1,6 +1,7 @@
 package watcher
 
 import (
+	"kubesphere.io/alert/pkg/monitoring"
 	rs "kubesphere.io/alert/pkg/services/watcher/resource_control"
 	"time"
 )
@@ -55,6 +56,7 @@ func (hc *HealthChecker) processTimeoutRunningAlerts() {
 		err := rs.UpdateAlertByAlertId(alert.AlertId, "running", "migrating")
 		if err == nil && executorCount > 0 {
 			hc.executorWatcher.alertQueue.WriteBackAlert(alert.AlertId)
+			monitoring.MigrationsTotal.WithLabelValues("running_timeout").Inc()
 		}
 	}
 }
@@ -71,6 +73,7 @@ func (hc *HealthChecker) processTimeoutAddingAlerts() {
 		err := rs.UpdateAlertByAlertId(alert.AlertId, "adding", "adding")
 		if err == nil && executorCount > 0 {
 			hc.executorWatcher.alertQueue.WriteBackAlert(alert.AlertId)
+			monitoring.MigrationsTotal.WithLabelValues("adding_timeout").Inc()
 		}
 	}
 }
@@ -87,6 +90,7 @@ func (hc *HealthChecker) processTimeoutUpdatingAlerts() {
 		err := rs.UpdateAlertByAlertId(alert.AlertId, "updating", "adding")
 		if err == nil && executorCount > 0 {
 			hc.executorWatcher.alertQueue.WriteBackAlert(alert.AlertId)
+			monitoring.MigrationsTotal.WithLabelValues("updating_timeout").Inc()
 		}
 	}
 }
@@ -103,6 +107,7 @@ func (hc *HealthChecker) processTimeoutMigratingAlerts() {
 		err := rs.UpdateAlertByAlertId(alert.AlertId, "migrating", "migrating")
 		if err == nil && executorCount > 0 {
 			hc.executorWatcher.alertQueue.WriteBackAlert(alert.AlertId)
+			monitoring.MigrationsTotal.WithLabelValues("migrating_timeout").Inc()
 		}
 	}
 }
@@ -1,9 +1,10 @@
 package resource_control
 
 import (
-	"fmt"
 	"time"
 
+	"github.com/jinzhu/gorm"
+
 	"kubesphere.io/alert/pkg/global"
 	"kubesphere.io/alert/pkg/logger"
 	"kubesphere.io/alert/pkg/models"
@@ -64,56 +65,49 @@ func UpdateAlertByAlertId(alertId string, oldStatus string, newStatus string) er
 }
 
 func DeleteAlerts(alertIds []string) error {
-	db := global.GetInstance().GetDB()
-	tx := db.Begin()
+	return deleteAlerts(global.GetInstance().GetDB(), alertIds)
+}
 
-	//1. Prepare where collect
-	where := ""
-	for i, alertId := range alertIds {
-		if i == 0 {
-			where += fmt.Sprintf("'%s'", alertId)
-		} else {
-			where += fmt.Sprintf(",'%s'", alertId)
-		}
+func deleteAlerts(db *gorm.DB, alertIds []string) error {
+	if len(alertIds) == 0 {
+		return nil
 	}
 
-	//2. Delete ResourceFilters
-	sql := fmt.Sprintf("DELETE rf FROM resource_filter rf, alert al WHERE rf.rs_filter_id=al.rs_filter_id AND al.alert_id IN (%s)", where)
-	err := tx.Exec(sql)
+	tx := db.Begin()
+
+	//1. Delete ResourceFilters
+	err := tx.Exec("DELETE FROM resource_filter WHERE rs_filter_id IN (SELECT rs_filter_id FROM alert WHERE alert_id IN (?))", alertIds)
 	if err.Error != nil {
 		tx.Rollback()
 		logger.Error(nil, "DeleteAlertByAlertId Delete ResourceFilter failed, [%+v]\n", err.Error)
 		return err.Error
 	}
 
-	//3. Delete Rules
-	sql = fmt.Sprintf("DELETE rl FROM rule rl, alert al WHERE rl.policy_id=al.policy_id AND al.alert_id IN (%s)", where)
-	err = tx.Exec(sql)
+	//2. Delete Rules
+	err = tx.Exec("DELETE FROM rule WHERE policy_id IN (SELECT policy_id FROM alert WHERE alert_id IN (?))", alertIds)
 	if err.Error != nil {
 		tx.Rollback()
 		logger.Error(nil, "DeleteAlertByAlertId Delete Rules failed, [%+v]\n", err.Error)
 		return err.Error
 	}
 
-	//4. Delete Actions
-	sql = fmt.Sprintf("DELETE ac FROM action ac, alert al WHERE ac.policy_id=al.policy_id AND al.alert_id IN (%s)", where)
-	err = tx.Exec(sql)
+	//3. Delete Actions
+	err = tx.Exec("DELETE FROM action WHERE policy_id IN (SELECT policy_id FROM alert WHERE alert_id IN (?))", alertIds)
 	if err.Error != nil {
 		tx.Rollback()
 		logger.Error(nil, "DeleteAlertByAlertId Delete Action failed, [%+v]\n", err.Error)
 		return err.Error
 	}
 
-	//5. Delete Policies
-	sql = fmt.Sprintf("DELETE pl FROM policy pl, alert al WHERE pl.policy_id=al.policy_id AND al.alert_id IN (%s)", where)
-	err = tx.Exec(sql)
+	//4. Delete Policies
+	err = tx.Exec("DELETE FROM policy WHERE policy_id IN (SELECT policy_id FROM alert WHERE alert_id IN (?))", alertIds)
 	if err.Error != nil {
 		tx.Rollback()
 		logger.Error(nil, "DeleteAlertByAlertId Delete Policy failed, [%+v]\n", err.Error)
 		return err.Error
 	}
 
-	//6. Delete Alerts
+	//5. Delete Alerts
 	var alert models.Alert
 	err = tx.Model(&alert).Where("alert_id in (?)", alertIds).Delete(models.Alert{})
 	if err.Error != nil {
@@ -0,0 +1,35 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package resource_control
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"kubesphere.io/alert/pkg/internal/testutil"
+	"kubesphere.io/alert/pkg/models"
+)
+
+func TestDeleteAlerts(t *testing.T) {
+	db := testutil.OpenAlertDB(t)
+	defer db.Close()
+
+	hostileIds := []string{"al-') OR 1=1; --", `al-" OR "1"="1`}
+	testutil.CreateAlert(t, db, hostileIds[0], "1")
+	testutil.CreateAlert(t, db, hostileIds[1], "2")
+	testutil.CreateAlert(t, db, "al-3", "3")
+
+	require.NoError(t, deleteAlerts(db, nil))
+	require.NoError(t, deleteAlerts(db, hostileIds))
+
+	for _, table := range []string{"alert", "resource_filter", "policy", "rule", "action"} {
+		require.Equal(t, 1, testutil.CountRows(t, db, table), table)
+	}
+
+	var alert models.Alert
+	require.NoError(t, db.First(&alert, "alert_id = ?", "al-3").Error)
+	require.Equal(t, "pl-3", alert.PolicyId)
+}
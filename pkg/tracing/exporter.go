@@ -0,0 +1,179 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package tracing
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"kubesphere.io/alert/pkg/config"
+	"kubesphere.io/alert/pkg/logger"
+)
+
+const (
+	ExporterFile = "file"
+)
+
+// Exporter receives finished spans in the OpenTelemetry span data layout.
+type Exporter interface {
+	ExportSpan(span *SpanData)
+}
+
+type KeyValue struct {
+	Key   string   `json:"key"`
+	Value AnyValue `json:"value"`
+}
+
+type AnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type SpanStatus struct {
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// SpanData follows the field names of the OTLP/JSON span, so that exported
+// files can be loaded by OpenTelemetry tooling.
+type SpanData struct {
+	TraceId           string     `json:"traceId"`
+	SpanId            string     `json:"spanId"`
+	ParentSpanId      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              string     `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []KeyValue `json:"attributes"`
+	Status            SpanStatus `json:"status"`
+	Resource          []KeyValue `json:"resource"`
+}
+
+// FileExporter appends one json encoded span per line to a local file.
+type FileExporter struct {
+	sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func NewFileExporter(path string) (*FileExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileExporter{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+func (fe *FileExporter) ExportSpan(span *SpanData) {
+	fe.Lock()
+	err := fe.encoder.Encode(span)
+	fe.Unlock()
+	if err != nil {
+		logger.Error(nil, "FileExporter export span [%s] failed: %+v", span.Name, err)
+	}
+}
+
+type tracer struct {
+	exporter    Exporter
+	serviceName string
+	sampleRate  float64
+	random      *rand.Rand
+	randomMutex sync.Mutex
+}
+
+var globalTracer atomic.Value
+
+func getTracer() *tracer {
+	t, _ := globalTracer.Load().(*tracer)
+	return t
+}
+
+// Init enables tracing for the service as configured, spans are no-ops otherwise.
+func Init(serviceName string) error {
+	cfg := config.GetInstance()
+	if !cfg.Tracing.Enable {
+		return nil
+	}
+
+	var exporter Exporter
+	switch cfg.Tracing.Exporter {
+	case ExporterFile:
+		fe, err := NewFileExporter(cfg.Tracing.File)
+		if err != nil {
+			return err
+		}
+		exporter = fe
+	default:
+		return fmt.Errorf("unsupported tracing exporter [%s]", cfg.Tracing.Exporter)
+	}
+
+	SetExporter(serviceName, exporter, cfg.Tracing.SampleRate)
+	logger.Info(nil, "Tracing enabled, exporter [%s] sample rate [%v]", cfg.Tracing.Exporter, cfg.Tracing.SampleRate)
+
+	return nil
+}
+
+// SetExporter enables tracing with exporter, a nil exporter disables tracing.
+func SetExporter(serviceName string, exporter Exporter, sampleRate float64) {
+	if exporter == nil {
+		globalTracer.Store((*tracer)(nil))
+		return
+	}
+
+	globalTracer.Store(&tracer{
+		exporter:    exporter,
+		serviceName: serviceName,
+		sampleRate:  sampleRate,
+		random:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	})
+}
+
+func (t *tracer) sample() bool {
+	if t.sampleRate >= 1 {
+		return true
+	}
+
+	t.randomMutex.Lock()
+	sampled := t.random.Float64() < t.sampleRate
+	t.randomMutex.Unlock()
+
+	return sampled
+}
+
+func (t *tracer) export(s *Span) {
+	s.Lock()
+	data := &SpanData{
+		TraceId:           s.spanContext.TraceId,
+		SpanId:            s.spanContext.SpanId,
+		ParentSpanId:      s.parentSpanId,
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.startTime.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.endTime.UnixNano(), 10),
+		Attributes:        []KeyValue{},
+		Status:            SpanStatus{Code: s.statusCode, Message: s.statusMsg},
+		Resource:          []KeyValue{{Key: "service.name", Value: AnyValue{StringValue: t.serviceName}}},
+	}
+	for k, v := range s.attributes {
+		data.Attributes = append(data.Attributes, KeyValue{Key: k, Value: AnyValue{StringValue: v}})
+	}
+	s.Unlock()
+
+	sort.Slice(data.Attributes, func(i, j int) bool {
+		return data.Attributes[i].Key < data.Attributes[j].Key
+	})
+
+	t.exporter.ExportSpan(data)
+}
@@ -0,0 +1,155 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// W3C trace context header, also used as gRPC metadata key
+	TraceparentKey = "traceparent"
+
+	traceparentVersion = "00"
+)
+
+// Traceparent formats sc as a W3C traceparent value.
+func (sc SpanContext) Traceparent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+
+	return fmt.Sprintf("%s-%s-%s-%s", traceparentVersion, sc.TraceId, sc.SpanId, flags)
+}
+
+func isHex(s string) bool {
+	_, err := hex.DecodeString(s)
+	return err == nil && strings.ToLower(s) == s
+}
+
+// ParseTraceparent parses a W3C traceparent value.
+func ParseTraceparent(traceparent string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) != 4 || parts[0] != traceparentVersion {
+		return SpanContext{}, false
+	}
+
+	traceId, spanId, flags := parts[1], parts[2], parts[3]
+	if len(traceId) != 32 || len(spanId) != 16 || len(flags) != 2 {
+		return SpanContext{}, false
+	}
+	if !isHex(traceId) || !isHex(spanId) || !isHex(flags) {
+		return SpanContext{}, false
+	}
+	if traceId == strings.Repeat("0", 32) || spanId == strings.Repeat("0", 16) {
+		return SpanContext{}, false
+	}
+
+	flagBytes, _ := hex.DecodeString(flags)
+
+	return SpanContext{
+		TraceId: traceId,
+		SpanId:  spanId,
+		Sampled: flagBytes[0]&0x01 == 0x01,
+	}, true
+}
+
+// Inject returns the traceparent of the current span of ctx, or "" when there is none.
+func Inject(ctx context.Context) string {
+	return SpanContextFromContext(ctx).Traceparent()
+}
+
+// Extract returns a copy of ctx with the span of traceparent as remote parent.
+func Extract(ctx context.Context, traceparent string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sc, ok := ParseTraceparent(traceparent)
+	if !ok {
+		return ctx
+	}
+
+	return ContextWithSpanContext(ctx, sc)
+}
+
+func InjectHTTP(ctx context.Context, header http.Header) {
+	traceparent := Inject(ctx)
+	if traceparent != "" {
+		header.Set(TraceparentKey, traceparent)
+	}
+}
+
+func ExtractHTTP(ctx context.Context, header http.Header) context.Context {
+	return Extract(ctx, header.Get(TraceparentKey))
+}
+
+// UnaryServerInterceptor starts a server span for each call, continuing the
+// trace of the caller when its metadata carries a traceparent.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if getTracer() == nil {
+			return handler(ctx, req)
+		}
+
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(TraceparentKey); len(values) > 0 {
+				ctx = Extract(ctx, values[0])
+			}
+		}
+
+		ctx, span := StartSpanWithKind(ctx, info.FullMethod, SpanKindServer)
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+
+		span.SetAttribute("rpc.grpc.status_code", status.Code(err).String())
+		span.SetError(err)
+
+		return resp, err
+	}
+}
+
+// UnaryClientInterceptor starts a client span for each call and sends its
+// traceparent in the outgoing metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if getTracer() == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		ctx, span := StartSpanWithKind(ctx, method, SpanKindClient)
+		defer span.End()
+
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if ok {
+			md = md.Copy()
+		} else {
+			md = metadata.MD{}
+		}
+		md.Set(TraceparentKey, Inject(ctx))
+		ctx = metadata.NewOutgoingContext(ctx, md)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		span.SetAttribute("rpc.grpc.status_code", status.Code(err).String())
+		span.SetError(err)
+
+		return err
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+const (
+	SpanKindInternal = "SPAN_KIND_INTERNAL"
+	SpanKindServer   = "SPAN_KIND_SERVER"
+	SpanKindClient   = "SPAN_KIND_CLIENT"
+
+	StatusCodeUnset = "STATUS_CODE_UNSET"
+	StatusCodeOk    = "STATUS_CODE_OK"
+	StatusCodeError = "STATUS_CODE_ERROR"
+)
+
+// SpanContext identifies a span across process boundaries, ids are lowercase
+// hex as in the W3C trace context.
+type SpanContext struct {
+	TraceId string
+	SpanId  string
+	Sampled bool
+}
+
+func (sc SpanContext) IsValid() bool {
+	return len(sc.TraceId) == 32 && len(sc.SpanId) == 16
+}
+
+type spanContextKey struct{}
+
+type forceSamplingKey struct{}
+
+// Span records one operation of a trace, a nil Span is valid and records nothing.
+type Span struct {
+	sync.Mutex
+	spanContext  SpanContext
+	parentSpanId string
+	name         string
+	kind         string
+	startTime    time.Time
+	endTime      time.Time
+	attributes   map[string]string
+	statusCode   string
+	statusMsg    string
+	ended        bool
+}
+
+func newId(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// SpanContextFromContext returns the span context of the current span of ctx,
+// or of the remote parent extracted into ctx.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if ctx == nil {
+		return SpanContext{}
+	}
+	sc, _ := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc
+}
+
+// ContextWithSpanContext returns a copy of ctx with sc as parent of the next span.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !sc.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// WithForcedSampling returns a copy of ctx whose spans are recorded regardless
+// of the sample rate, it is used to trace a single alert verbosely.
+func WithForcedSampling(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, forceSamplingKey{}, true)
+}
+
+func isForcedSampling(ctx context.Context) bool {
+	forced, _ := ctx.Value(forceSamplingKey{}).(bool)
+	return forced
+}
+
+// StartSpan starts a span as child of the span in ctx, or a new trace when
+// there is none. The returned context carries the new span.
+func StartSpan(ctx context.Context, name string) (context.Context, *Span) {
+	return StartSpanWithKind(ctx, name, SpanKindInternal)
+}
+
+func StartSpanWithKind(ctx context.Context, name string, kind string) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	t := getTracer()
+	if t == nil {
+		return ctx, nil
+	}
+
+	parent := SpanContextFromContext(ctx)
+
+	span := &Span{
+		name:       name,
+		kind:       kind,
+		startTime:  time.Now(),
+		attributes: make(map[string]string),
+		statusCode: StatusCodeUnset,
+	}
+
+	if parent.IsValid() {
+		span.spanContext = SpanContext{
+			TraceId: parent.TraceId,
+			SpanId:  newId(8),
+			Sampled: parent.Sampled || isForcedSampling(ctx),
+		}
+		span.parentSpanId = parent.SpanId
+	} else {
+		span.spanContext = SpanContext{
+			TraceId: newId(16),
+			SpanId:  newId(8),
+			Sampled: t.sample() || isForcedSampling(ctx),
+		}
+	}
+
+	return context.WithValue(ctx, spanContextKey{}, span.spanContext), span
+}
+
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.spanContext
+}
+
+func (s *Span) SetAttribute(key string, value string) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	s.attributes[key] = value
+	s.Unlock()
+}
+
+// SetError marks the span as failed with err, a nil err marks it as ok.
+func (s *Span) SetError(err error) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	if err != nil {
+		s.statusCode = StatusCodeError
+		s.statusMsg = err.Error()
+	} else {
+		s.statusCode = StatusCodeOk
+		s.statusMsg = ""
+	}
+	s.Unlock()
+}
+
+// End finishes the span and hands it to the exporter when it is sampled.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	if s.ended {
+		s.Unlock()
+		return
+	}
+	s.ended = true
+	s.endTime = time.Now()
+	s.Unlock()
+
+	if !s.spanContext.Sampled {
+		return
+	}
+
+	t := getTracer()
+	if t != nil {
+		t.export(s)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	sc, ok := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	require.True(t, ok)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceId)
+	require.Equal(t, "00f067aa0ba902b7", sc.SpanId)
+	require.True(t, sc.Sampled)
+	require.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", sc.Traceparent())
+
+	invalid := []string{
+		"",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6-00f067aa0ba902b7-01",
+	}
+	for _, traceparent := range invalid {
+		_, ok := ParseTraceparent(traceparent)
+		require.False(t, ok, traceparent)
+	}
+}
+
+func TestSpanFileExporter(t *testing.T) {
+	f, err := ioutil.TempFile("", "alert-trace")
+	require.NoError(t, err)
+	f.Close()
+	defer os.Remove(f.Name())
+
+	exporter, err := NewFileExporter(f.Name())
+	require.NoError(t, err)
+	SetExporter("alert-test", exporter, 1)
+	defer SetExporter("", nil, 0)
+
+	ctx := Extract(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx, parent := StartSpan(ctx, "parent")
+	_, child := StartSpan(ctx, "child")
+	child.SetAttribute("alert_id", "al-1")
+	child.End()
+	parent.End()
+
+	file, err := os.Open(f.Name())
+	require.NoError(t, err)
+	defer file.Close()
+
+	spans := []SpanData{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		span := SpanData{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &span))
+		spans = append(spans, span)
+	}
+
+	require.Len(t, spans, 2)
+	require.Equal(t, "child", spans[0].Name)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].TraceId)
+	require.Equal(t, parent.SpanContext().SpanId, spans[0].ParentSpanId)
+	require.Equal(t, []KeyValue{{Key: "alert_id", Value: AnyValue{StringValue: "al-1"}}}, spans[0].Attributes)
+	require.Equal(t, "parent", spans[1].Name)
+	require.Equal(t, "00f067aa0ba902b7", spans[1].ParentSpanId)
+}
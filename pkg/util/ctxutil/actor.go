@@ -0,0 +1,30 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package ctxutil
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// GetActor returns who issued the request, as set by the caller in the x-actor metadata.
+func GetActor(ctx context.Context) string {
+	actor := GetValueFromContext(ctx, actorKey)
+	if len(actor) == 0 {
+		return ""
+	}
+	return actor[0]
+}
+
+func SetActor(ctx context.Context, actor string) context.Context {
+	ctx = context.WithValue(ctx, actorKey, []string{actor})
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	md[actorKey] = []string{actor}
+	return metadata.NewOutgoingContext(ctx, md)
+}
@@ -8,11 +8,14 @@ import (
 	"context"
 
 	"google.golang.org/grpc/metadata"
+
+	"kubesphere.io/alert/pkg/constants"
 )
 
 const (
 	messageIdKey = "x-message-id"
 	requestIdKey = "x-request-id"
+	actorKey     = constants.ActorHeader
 )
 
 type getMetadataFromContext func(ctx context.Context) (md metadata.MD, ok bool)
@@ -48,3 +48,15 @@ func TestGetRequestId(t *testing.T) {
 
 	require.Equal(t, requestId, GetRequestId(ctx))
 }
+
+func TestGetActor(t *testing.T) {
+	ctx := context.TODO()
+	require.Equal(t, "", GetActor(ctx))
+
+	ctx = SetActor(ctx, "admin")
+	require.Equal(t, "admin", GetActor(ctx))
+
+	ctx = SetRequestId(ctx, "abcdef")
+	require.Equal(t, "admin", GetActor(ctx))
+	require.Equal(t, "abcdef", GetRequestId(ctx))
+}
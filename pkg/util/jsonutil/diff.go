@@ -0,0 +1,54 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package jsonutil
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+type DiffValue struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+func decodeObject(s string) (map[string]interface{}, error) {
+	object := make(map[string]interface{})
+	if s == "" {
+		return object, nil
+	}
+	err := json.Unmarshal([]byte(s), &object)
+	if err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// Diff compares the top level keys of two json objects and returns the changed ones,
+// an empty string stands for a missing object, eg. before a create or after a delete.
+func Diff(before string, after string) (map[string]DiffValue, error) {
+	beforeObject, err := decodeObject(before)
+	if err != nil {
+		return nil, err
+	}
+	afterObject, err := decodeObject(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := make(map[string]DiffValue)
+	for k, v := range beforeObject {
+		if !reflect.DeepEqual(v, afterObject[k]) {
+			diff[k] = DiffValue{Before: v, After: afterObject[k]}
+		}
+	}
+	for k, v := range afterObject {
+		if _, ok := beforeObject[k]; !ok {
+			diff[k] = DiffValue{Before: nil, After: v}
+		}
+	}
+
+	return diff, nil
+}
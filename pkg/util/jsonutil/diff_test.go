@@ -0,0 +1,39 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package jsonutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	diff, err := Diff(`{"rule_id":"rl-1","severity":"minor","thresholds":"80"}`, `{"rule_id":"rl-1","severity":"major","thresholds":"80"}`)
+	require.NoError(t, err)
+	require.Equal(t, map[string]DiffValue{
+		"severity": {Before: "minor", After: "major"},
+	}, diff)
+
+	diff, err = Diff("", `{"rule_id":"rl-1","disabled":false}`)
+	require.NoError(t, err)
+	require.Equal(t, map[string]DiffValue{
+		"rule_id":  {Before: nil, After: "rl-1"},
+		"disabled": {Before: nil, After: false},
+	}, diff)
+
+	diff, err = Diff(`{"rule_id":"rl-1"}`, "")
+	require.NoError(t, err)
+	require.Equal(t, map[string]DiffValue{
+		"rule_id": {Before: "rl-1", After: nil},
+	}, diff)
+
+	diff, err = Diff(`{"rule_id":"rl-1"}`, `{"rule_id":"rl-1"}`)
+	require.NoError(t, err)
+	require.Empty(t, diff)
+
+	_, err = Diff(`{"rule_id":`, "")
+	require.Error(t, err)
+}
@@ -0,0 +1,191 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package tlsutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+
+	"kubesphere.io/alert/pkg/logger"
+)
+
+// Reloader loads a certificate with its key and a CA bundle from files, and loads them
+// again once the files change so rotated certificates take effect without a restart.
+// Every file is optional.
+type Reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+
+	mu       sync.Mutex
+	modTimes []time.Time
+	cert     *tls.Certificate
+	pool     *x509.CertPool
+}
+
+func NewReloader(certFile, keyFile, caFile string) (*Reloader, error) {
+	r := &Reloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// reload loads the files again if any of them changed since they were last loaded.
+func (r *Reloader) reload() error {
+	modTimes := []time.Time{}
+	for _, file := range []string{r.certFile, r.keyFile, r.caFile} {
+		if file == "" {
+			modTimes = append(modTimes, time.Time{})
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+
+	if r.modTimes != nil {
+		changed := false
+		for i := range modTimes {
+			changed = changed || !modTimes[i].Equal(r.modTimes[i])
+		}
+		if !changed {
+			return nil
+		}
+	}
+
+	var cert *tls.Certificate
+	if r.certFile != "" || r.keyFile != "" {
+		c, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			return errors.Wrap(err, "load certificate")
+		}
+		cert = &c
+	}
+
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		content, err := ioutil.ReadFile(r.caFile)
+		if err != nil {
+			return err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(content) {
+			return errors.Errorf("no certificate found in [%s]", r.caFile)
+		}
+	}
+
+	r.cert, r.pool, r.modTimes = cert, pool, modTimes
+	return nil
+}
+
+func (r *Reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// a certificate half way through its rotation fails to load, the previous one stays
+	// in use until the rotation completes
+	if err := r.reload(); err != nil {
+		logger.Error(nil, "Failed to reload certificates, keep using the loaded ones: %+v", err)
+	}
+	return r.cert, r.pool
+}
+
+// ServerConfig returns the config of a server presenting the certificate, client
+// certificates are required and verified by the CA bundle if there is one.
+func (r *Reloader) ServerConfig() *tls.Config {
+	cert, pool := r.current()
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cert != nil {
+		config.Certificates = []tls.Certificate{*cert}
+	}
+	if pool != nil {
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config
+}
+
+// ClientConfig returns the config of a client verifying the server certificate by the CA
+// bundle, or by the system roots if there is none, and presenting the certificate if any.
+func (r *Reloader) ClientConfig(serverName string) *tls.Config {
+	cert, pool := r.current()
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		RootCAs:    pool,
+	}
+	if cert != nil {
+		config.Certificates = []tls.Certificate{*cert}
+	}
+	return config
+}
+
+// reloadCredentials are grpc transport credentials taking the tls config of every
+// handshake from a Reloader.
+type reloadCredentials struct {
+	reloader   *Reloader
+	serverName string
+}
+
+func NewServerCredentials(certFile, keyFile, caFile string) (credentials.TransportCredentials, error) {
+	r, err := NewReloader(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	if r.cert == nil {
+		return nil, errors.New("server certificate required")
+	}
+	return &reloadCredentials{reloader: r}, nil
+}
+
+// NewClientCredentials returns the credentials of a client, serverName is the name expected
+// in the server certificate, the dialed host if empty.
+func NewClientCredentials(certFile, keyFile, caFile, serverName string) (credentials.TransportCredentials, error) {
+	r, err := NewReloader(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	return &reloadCredentials{reloader: r, serverName: serverName}, nil
+}
+
+func (c *reloadCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(c.reloader.ClientConfig(c.serverName)).ClientHandshake(ctx, authority, conn)
+}
+
+func (c *reloadCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(c.reloader.ServerConfig()).ServerHandshake(conn)
+}
+
+func (c *reloadCredentials) Info() credentials.ProtocolInfo {
+	return credentials.NewTLS(&tls.Config{}).Info()
+}
+
+func (c *reloadCredentials) Clone() credentials.TransportCredentials {
+	clone := *c
+	return &clone
+}
+
+func (c *reloadCredentials) OverrideServerName(serverName string) error {
+	c.serverName = serverName
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCert(t *testing.T, commonName string, serial int64, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCert{cert: cert, key: key}
+}
+
+func (c *testCert) write(t *testing.T, certFile, keyFile string) {
+	keyDer, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0600))
+	if keyFile != "" {
+		require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	}
+}
+
+// handshake connects a client to a server on a loopback port and returns the state seen by each.
+func handshake(t *testing.T, clientConfig, serverConfig *tls.Config) (tls.ConnectionState, tls.ConnectionState, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	type result struct {
+		state tls.ConnectionState
+		err   error
+	}
+	serverResult := make(chan result, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			serverResult <- result{err: err}
+			return
+		}
+		defer conn.Close()
+		server := tls.Server(conn, serverConfig)
+		err = server.Handshake()
+		serverResult <- result{server.ConnectionState(), err}
+	}()
+
+	conn, err := net.Dial("tcp", lis.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := tls.Client(conn, clientConfig)
+	err = client.Handshake()
+	// the server may reject the client after the client has finished its handshake
+	r := <-serverResult
+	if err == nil {
+		err = r.err
+	}
+	return client.ConnectionState(), r.state, err
+}
+
+func TestReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsutil")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	ca := newTestCert(t, "ca", 1, nil)
+	ca.write(t, path("ca.crt"), "")
+	newTestCert(t, "manager", 2, ca).write(t, path("server.crt"), path("server.key"))
+	newTestCert(t, "client", 3, ca).write(t, path("client.crt"), path("client.key"))
+
+	server, err := NewReloader(path("server.crt"), path("server.key"), path("ca.crt"))
+	require.NoError(t, err)
+	client, err := NewReloader(path("client.crt"), path("client.key"), path("ca.crt"))
+	require.NoError(t, err)
+
+	clientState, serverState, err := handshake(t, client.ClientConfig("localhost"), server.ServerConfig())
+	require.NoError(t, err)
+	require.Equal(t, "manager", clientState.PeerCertificates[0].Subject.CommonName)
+	require.Equal(t, "client", serverState.VerifiedChains[0][0].Subject.CommonName)
+
+	// clients without a certificate are rejected
+	anonymous, err := NewReloader("", "", path("ca.crt"))
+	require.NoError(t, err)
+	_, _, err = handshake(t, anonymous.ClientConfig("localhost"), server.ServerConfig())
+	require.Error(t, err)
+
+	// servers are verified by name
+	_, _, err = handshake(t, client.ClientConfig("notification"), server.ServerConfig())
+	require.Error(t, err)
+
+	// a rotated certificate is served once written
+	newTestCert(t, "manager", 4, ca).write(t, path("server.crt"), path("server.key"))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path("server.crt"), later, later))
+	require.NoError(t, os.Chtimes(path("server.key"), later, later))
+
+	clientState, _, err = handshake(t, client.ClientConfig("localhost"), server.ServerConfig())
+	require.NoError(t, err)
+	require.Equal(t, int64(4), clientState.PeerCertificates[0].SerialNumber.Int64())
+
+	// a certificate half way through its rotation is not loaded
+	require.NoError(t, ioutil.WriteFile(path("server.key"), []byte("garbage"), 0600))
+	later = later.Add(time.Minute)
+	require.NoError(t, os.Chtimes(path("server.key"), later, later))
+
+	clientState, _, err = handshake(t, client.ClientConfig("localhost"), server.ServerConfig())
+	require.NoError(t, err)
+	require.Equal(t, int64(4), clientState.PeerCertificates[0].SerialNumber.Int64())
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 The KubeSphere Authors. All rights reserved.
+// Use of this source code is governed by a Apache license
+// that can be found in the LICENSE file.
+
+// promrule-import converts the alerting rules of a PrometheusRule object or a Prometheus rule file
+// into an alert document, to import with POST /api/v1/{resources}/alert_document of the client
+// service, eg. POST /api/v1/namespaces/{ns_name}/pods/alert_document for --rs-type=pod. Rules that
+// cannot be expressed are reported on stderr.
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"kubesphere.io/alert/pkg/models"
+	"kubesphere.io/alert/pkg/promrule"
+)
+
+var (
+	input         string
+	output        string
+	format        string
+	rsTypeName    string
+	rsFilterParam string
+	strict        bool
+)
+
+func init() {
+	flag.StringVar(&input, "input", "", "--input=./rules.yaml, stdin if empty")
+	flag.StringVar(&output, "output", "", "--output=./alerts.yaml, stdout if empty")
+	flag.StringVar(&format, "format", models.AlertDocumentFormatYaml, "--format=yaml|json")
+	flag.StringVar(&rsTypeName, "rs-type", "", "--rs-type=pod, resource type of the metrics")
+	flag.StringVar(&rsFilterParam, "rs-filter-param", "", `--rs-filter-param={"ns_name":"default"}`)
+	flag.BoolVar(&strict, "strict", false, "--strict, exit with 1 if a rule cannot be expressed")
+}
+
+func main() {
+	flag.Parse()
+
+	if rsTypeName == "" {
+		log.Fatal("--rs-type is required")
+	}
+
+	var data []byte
+	var err error
+	if input == "" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(input)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	promRule, err := promrule.ParsePrometheusRule(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	result := promrule.Convert(promRule, promrule.Options{
+		RsTypeName:    rsTypeName,
+		RsFilterParam: rsFilterParam,
+	})
+
+	document, err := models.MarshalAlertDocument(result.Document, format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(document)
+	} else {
+		err = ioutil.WriteFile(output, document, 0644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("converted %d alerts using metrics %v", len(result.Document.Alerts), result.MetricNames)
+	for _, rule := range result.Unsupported {
+		log.Printf("skipped alert [%s] of group [%s]: %s", rule.Alert, rule.Group, rule.Reason)
+	}
+	if strict && len(result.Unsupported) != 0 {
+		os.Exit(1)
+	}
+}